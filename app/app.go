@@ -50,6 +50,9 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/evidence"
 	evidencekeeper "github.com/cosmos/cosmos-sdk/x/evidence/keeper"
 	evidencetypes "github.com/cosmos/cosmos-sdk/x/evidence/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	feegrantkeeper "github.com/cosmos/cosmos-sdk/x/feegrant/keeper"
+	feegrantmodule "github.com/cosmos/cosmos-sdk/x/feegrant/module"
 	"github.com/cosmos/cosmos-sdk/x/genutil"
 	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
 	"github.com/cosmos/cosmos-sdk/x/gov"
@@ -180,6 +183,7 @@ var (
 		emissionsModule.AppModuleBasic{},
 		groupmodule.AppModuleBasic{},
 		authzmodule.AppModuleBasic{},
+		feegrantmodule.AppModuleBasic{},
 	)
 
 	// module account permissions
@@ -238,6 +242,7 @@ type App struct {
 	EmissionsKeeper    emissionsModuleKeeper.Keeper
 	GroupKeeper        groupkeeper.Keeper
 	AuthzKeeper        authzkeeper.Keeper
+	FeeGrantKeeper     feegrantkeeper.Keeper
 }
 
 // New returns a reference to an initialized ZetaApp.
@@ -275,6 +280,7 @@ func New(
 		fungibleModuleTypes.StoreKey,
 		emissionsModuleTypes.StoreKey,
 		authzkeeper.StoreKey,
+		feegrant.StoreKey,
 	)
 	tkeys := sdk.NewTransientStoreKeys(paramstypes.TStoreKey, evmtypes.TransientKey, feemarkettypes.TransientKey)
 	memKeys := sdk.NewMemoryStoreKeys()
@@ -346,6 +352,9 @@ func New(
 		app.MsgServiceRouter(),
 		app.AccountKeeper)
 
+	// the fee grants let the observers pay the fees of the txs of their hotkey from their operator account
+	app.FeeGrantKeeper = feegrantkeeper.NewKeeper(appCodec, keys[feegrant.StoreKey], app.AccountKeeper)
+
 	app.EmissionsKeeper = *emissionsModuleKeeper.NewKeeper(
 		appCodec,
 		keys[emissionsModuleTypes.StoreKey],
@@ -468,6 +477,7 @@ func New(
 		fungibleModule.NewAppModule(appCodec, app.FungibleKeeper, app.AccountKeeper, app.BankKeeper),
 		emissionsModule.NewAppModule(appCodec, app.EmissionsKeeper, app.AccountKeeper),
 		authzmodule.NewAppModule(appCodec, app.AuthzKeeper, app.AccountKeeper, app.BankKeeper, app.interfaceRegistry),
+		feegrantmodule.NewAppModule(appCodec, app.AccountKeeper, app.BankKeeper, app.FeeGrantKeeper, app.interfaceRegistry),
 	)
 
 	// During begin block slashing happens after distr.BeginBlocker so that
@@ -496,6 +506,7 @@ func New(
 		fungibleModuleTypes.ModuleName,
 		emissionsModuleTypes.ModuleName,
 		authz.ModuleName,
+		feegrant.ModuleName,
 	)
 	app.mm.SetOrderEndBlockers(
 		banktypes.ModuleName,
@@ -518,6 +529,7 @@ func New(
 		fungibleModuleTypes.ModuleName,
 		emissionsModuleTypes.ModuleName,
 		authz.ModuleName,
+		feegrant.ModuleName,
 	)
 
 	// NOTE: The genutils module must occur after staking so that pools are
@@ -546,6 +558,7 @@ func New(
 		fungibleModuleTypes.ModuleName,
 		emissionsModuleTypes.ModuleName,
 		authz.ModuleName,
+		feegrant.ModuleName,
 	)
 
 	app.mm.RegisterInvariants(&app.CrisisKeeper)
@@ -568,6 +581,7 @@ func New(
 		BankKeeper:      app.BankKeeper,
 		EvmKeeper:       app.EvmKeeper,
 		FeeMarketKeeper: app.FeeMarketKeeper,
+		FeegrantKeeper:  app.FeeGrantKeeper,
 		SignModeHandler: encodingConfig.TxConfig.SignModeHandler(),
 		SigGasConsumer:  evmante.DefaultSigVerificationGasConsumer,
 		MaxTxGasWanted:  maxGasWanted,
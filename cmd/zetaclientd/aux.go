@@ -1,6 +1,9 @@
 package main
 
 import (
+	"fmt"
+	"time"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/rs/zerolog"
@@ -29,11 +32,43 @@ func CreateZetaBridge(cfg *config.Config) (*zetaclient.ZetaCoreBridge, error) {
 	}
 
 	k := zetaclient.NewKeysWithKeybase(kb, granterAddreess, cfg.AuthzHotkey)
+	k.SetHotkeyPasswordFile(cfg.HotkeyPasswordFile)
 
 	bridge, err := zetaclient.NewZetaCoreBridge(k, chainIP, cfg.AuthzHotkey, cfg.ChainID)
 	if err != nil {
 		return nil, err
 	}
+	if err := bridge.AddBackupEndpoints(cfg.ZetaCoreBackupURLs); err != nil {
+		return nil, err
+	}
+	bridge.SetGRPCLimits(cfg.GRPCMaxRecvMsgSize, cfg.GRPCMaxSendMsgSize, cfg.GRPCCompression)
+	fees, err := cfg.GetTxFees()
+	if err != nil {
+		return nil, err
+	}
+	bridge.SetTxFees(fees)
+	if cfg.FeeGrant {
+		bridge.SetFeeGranter(granterAddreess)
+	}
+	bridge.SetPostRateLimit(cfg.PostRateLimit, cfg.PostRateBurst)
+	bridge.SetBackpressure(cfg.MaxInFlightPosts, cfg.MaxOutboxBacklog)
+	if cfg.SimulateTxs {
+		bridge.EnableTxSimulation()
+	}
+	// #nosec G701 always in range
+	bridge.SetBroadcastMode(cfg.BroadcastMode, time.Duration(cfg.InclusionTimeoutSec)*time.Second)
+	if cfg.VoteBatchSize > 1 {
+		// #nosec G701 always in range
+		bridge.EnableVoteBatching(cfg.VoteBatchSize, time.Duration(cfg.VoteBatchWindowMs)*time.Millisecond)
+	}
+	bridge.SetVoteRetry(
+		zetaclient.BackoffFromPolicy(cfg.InboundVoteRetry, zetaclient.BroadcastBackoff),
+		zetaclient.BackoffFromPolicy(cfg.OutboundVoteRetry, zetaclient.ConfirmationBackoff),
+	)
+	if cfg.OutboundVoteBatchSize > 1 {
+		// #nosec G701 always in range
+		bridge.EnableConfirmationBatching(cfg.OutboundVoteBatchSize, time.Duration(cfg.OutboundVoteBatchWindowMs)*time.Millisecond)
+	}
 
 	return bridge, nil
 }
@@ -50,9 +85,13 @@ func CreateSignerMap(
 		if evmConfig.Chain.IsZetaChain() {
 			continue
 		}
+		// tron txs cannot be broadcast through the evm json-rpc; tron is observed for inbound txs only
+		if common.IsTronChain(evmConfig.Chain.ChainId) {
+			continue
+		}
 		mpiAddress := ethcommon.HexToAddress(evmConfig.CoreParams.ConnectorContractAddress)
 		erc20CustodyAddress := ethcommon.HexToAddress(evmConfig.CoreParams.Erc20CustodyContractAddress)
-		signer, err := zetaclient.NewEVMSigner(evmConfig.Chain, evmConfig.Endpoint, tss, config.GetConnectorABI(), config.GetERC20CustodyABI(), mpiAddress, erc20CustodyAddress, logger, ts)
+		signer, err := zetaclient.NewEVMSigner(evmConfig.Chain, evmConfig.Endpoint, evmConfig.GetRPCClientConfig(evmConfig.Endpoint), tss, cfg.GetChainConnectorABI(evmConfig.Chain.ChainId), cfg.GetChainERC20CustodyABI(evmConfig.Chain.ChainId), mpiAddress, erc20CustodyAddress, logger, ts)
 		if err != nil {
 			logger.Error().Err(err).Msgf("NewEVMSigner error for chain %s", evmConfig.Chain.String())
 			continue
@@ -85,7 +124,7 @@ func CreateChainClientMap(
 	clientMap := make(map[common.Chain]zetaclient.ChainClient)
 	// EVM clients
 	for _, evmConfig := range cfg.GetAllEVMConfigs() {
-		if evmConfig.Chain.IsZetaChain() {
+		if evmConfig.Chain.IsZetaChain() && !cfg.IsZEVMObserved() {
 			continue
 		}
 		co, err := zetaclient.NewEVMChainClient(bridge, tss, dbpath, metrics, logger, cfg, *evmConfig, ts)
@@ -98,7 +137,7 @@ func CreateChainClientMap(
 	// BTC client
 	btcChain, btcConfig, enabled := cfg.GetBTCConfig()
 	if enabled {
-		co, err := zetaclient.NewBitcoinClient(btcChain, bridge, tss, dbpath, metrics, logger, btcConfig, ts)
+		co, err := zetaclient.NewBitcoinClient(btcChain, bridge, tss, dbpath, metrics, logger, cfg, btcConfig, ts)
 		if err != nil {
 			logger.Error().Err(err).Msgf("NewBitcoinClient error for chain %s", btcChain.String())
 
@@ -109,3 +148,57 @@ func CreateChainClientMap(
 
 	return clientMap, nil
 }
+
+// NewChainFactory returns the factory used to create the chain client and signer of a chain enabled in zetacore at runtime
+// The chain must have an entry in the local config as the rpc endpoints are not stored in zetacore
+func NewChainFactory(
+	bridge *zetaclient.ZetaCoreBridge,
+	tss zetaclient.TSSSigner,
+	dbpath string,
+	metrics *metrics.Metrics,
+	logger zerolog.Logger,
+	cfg *config.Config,
+	ts *zetaclient.TelemetryServer,
+) zetaclient.ChainFactory {
+	return func(chain common.Chain) (zetaclient.ChainClient, zetaclient.ChainSigner, error) {
+		if common.IsBitcoinChain(chain.ChainId) {
+			btcChain, btcConfig, enabled := cfg.GetBTCConfig()
+			if !enabled || btcChain.ChainId != chain.ChainId {
+				return nil, nil, fmt.Errorf("no local config for chain %s", chain.String())
+			}
+			signer, err := zetaclient.NewBTCSigner(btcConfig, tss, logger, ts)
+			if err != nil {
+				return nil, nil, err
+			}
+			client, err := zetaclient.NewBitcoinClient(btcChain, bridge, tss, dbpath, metrics, logger, cfg, btcConfig, ts)
+			if err != nil {
+				return nil, nil, err
+			}
+			return client, signer, nil
+		}
+
+		evmConfig, found := cfg.GetEVMConfig(chain.ChainId)
+		if !found || evmConfig.Endpoint == "" {
+			return nil, nil, fmt.Errorf("no local config for chain %s", chain.String())
+		}
+		// tron and zEVM are observed only; zEVM cctxs are created by zetacore itself
+		if common.IsTronChain(chain.ChainId) || chain.IsZetaChain() {
+			client, err := zetaclient.NewEVMChainClient(bridge, tss, dbpath, metrics, logger, cfg, evmConfig, ts)
+			if err != nil {
+				return nil, nil, err
+			}
+			return client, nil, nil
+		}
+		mpiAddress := ethcommon.HexToAddress(evmConfig.CoreParams.ConnectorContractAddress)
+		erc20CustodyAddress := ethcommon.HexToAddress(evmConfig.CoreParams.Erc20CustodyContractAddress)
+		signer, err := zetaclient.NewEVMSigner(evmConfig.Chain, evmConfig.Endpoint, evmConfig.GetRPCClientConfig(evmConfig.Endpoint), tss, cfg.GetChainConnectorABI(evmConfig.Chain.ChainId), cfg.GetChainERC20CustodyABI(evmConfig.Chain.ChainId), mpiAddress, erc20CustodyAddress, logger, ts)
+		if err != nil {
+			return nil, nil, err
+		}
+		client, err := zetaclient.NewEVMChainClient(bridge, tss, dbpath, metrics, logger, cfg, evmConfig, ts)
+		if err != nil {
+			return nil, nil, err
+		}
+		return client, signer, nil
+	}
+}
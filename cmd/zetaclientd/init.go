@@ -4,6 +4,7 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
 	"github.com/zeta-chain/zetacore/zetaclient/config"
+	metrics2 "github.com/zeta-chain/zetacore/zetaclient/metrics"
 )
 
 var InitCmd = &cobra.Command{
@@ -32,6 +33,12 @@ type initArguments struct {
 	TssPath             string
 	TestTssKeysign      bool
 	KeyringBackend      string
+	HotkeyPasswordFile  string
+	FeeGrant            bool
+	ObserverDBPath      string
+	ConnectorABIPath    string
+	ERC20CustodyABIPath string
+	MetricsPort         uint16
 }
 
 func init() {
@@ -53,7 +60,13 @@ func init() {
 	InitCmd.Flags().Uint64Var(&initArgs.configUpdateTicker, "config-update-ticker", 5, "config update ticker (default: 0 means no ticker)")
 	InitCmd.Flags().StringVar(&initArgs.TssPath, "tss-path", "~/.tss", "path to tss location")
 	InitCmd.Flags().BoolVar(&initArgs.TestTssKeysign, "test-tss", false, "set to to true to run a check for TSS keysign on startup")
-	InitCmd.Flags().StringVar(&initArgs.KeyringBackend, "keyring-backend", string(config.KeyringBackendTest), "keyring backend to use (test, file)")
+	InitCmd.Flags().StringVar(&initArgs.KeyringBackend, "keyring-backend", string(config.KeyringBackendTest), "keyring backend to use (test, file, os)")
+	InitCmd.Flags().StringVar(&initArgs.HotkeyPasswordFile, "hotkey-password-file", "", "file holding the hotkey password with the file keyring backend (default: read from HOTKEY_PASSWORD)")
+	InitCmd.Flags().BoolVar(&initArgs.FeeGrant, "fee-grant", false, "set to true to have the fees of the hotkey paid by the operator through a fee allowance")
+	InitCmd.Flags().StringVar(&initArgs.ObserverDBPath, "observer-db-path", "~/.zetaclient/chainobserver", "path to the data directory of the chain observers")
+	InitCmd.Flags().StringVar(&initArgs.ConnectorABIPath, "connector-abi", "", "file path or url of the connector contract abi (default: abi of the compiled-in bindings)")
+	InitCmd.Flags().Uint16Var(&initArgs.MetricsPort, "metrics-port", metrics2.DefaultPort, "port of the prometheus /metrics endpoint")
+	InitCmd.Flags().StringVar(&initArgs.ERC20CustodyABIPath, "erc20-custody-abi", "", "file path or url of the erc20 custody contract abi (default: abi of the compiled-in bindings)")
 }
 
 func Initialize(_ *cobra.Command, _ []string) error {
@@ -89,6 +102,12 @@ func Initialize(_ *cobra.Command, _ []string) error {
 	configData.P2PDiagnosticTicker = initArgs.p2pDiagnosticTicker
 	configData.ConfigUpdateTicker = initArgs.configUpdateTicker
 	configData.KeyringBackend = config.KeyringBackend(initArgs.KeyringBackend)
+	configData.HotkeyPasswordFile = initArgs.HotkeyPasswordFile
+	configData.FeeGrant = initArgs.FeeGrant
+	configData.ObserverDBPath = initArgs.ObserverDBPath
+	configData.ConnectorABIPath = initArgs.ConnectorABIPath
+	configData.ERC20CustodyABIPath = initArgs.ERC20CustodyABIPath
+	configData.MetricsPort = initArgs.MetricsPort
 
 	//Save config file
 	return config.Save(&configData, rootArgs.zetaCoreHome)
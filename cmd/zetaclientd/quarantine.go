@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var QuarantineCmd = &cobra.Command{
+	Use:   "quarantine",
+	Short: "Inspect and process again the inbound events quarantined because they could not be decoded",
+}
+
+var QuarantineListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the quarantined inbound events of a chain",
+	RunE:  quarantineList,
+}
+
+var QuarantineReprocessCmd = &cobra.Command{
+	Use:   "reprocess",
+	Short: "Decode quarantined inbound events again and post their votes",
+	RunE:  quarantineReprocess,
+}
+
+var quarantineArgs = quarantineArguments{}
+
+type quarantineArguments struct {
+	chain string
+	key   string
+	all   bool
+}
+
+func init() {
+	RootCmd.AddCommand(QuarantineCmd)
+	QuarantineCmd.AddCommand(QuarantineListCmd)
+	QuarantineCmd.AddCommand(QuarantineReprocessCmd)
+	QuarantineCmd.PersistentFlags().StringVar(&quarantineArgs.chain, "chain", "", "chain name or chain id, e.g. eth_mainnet or 1")
+	QuarantineReprocessCmd.Flags().StringVar(&quarantineArgs.key, "key", "", "key of the quarantined event, i.e. <tx hash>-<log index>, or <tx hash>-gas-<index> for the gas deposits")
+	QuarantineReprocessCmd.Flags().BoolVar(&quarantineArgs.all, "all", false, "process all quarantined events of the chain again")
+}
+
+func quarantineList(_ *cobra.Command, _ []string) error {
+	ob, err := newCmdEVMChainClient(quarantineArgs.chain, "quarantine")
+	if err != nil {
+		return err
+	}
+	defer ob.Stop()
+
+	events, err := ob.GetQuarantinedEvents()
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		fmt.Printf("%s\tblock %d\t%s contract %s\tquarantined %s\n\ttopics %s\n\tdata %s\n\terror: %s\n",
+			event.Key, event.BlockNumber, event.Kind, event.Address, event.UpdatedAt.UTC().Format("2006-01-02 15:04:05"),
+			event.Topics, event.Data, event.Error)
+		if event.Value != "" {
+			fmt.Printf("\tvalue %s\n", event.Value)
+		}
+	}
+	fmt.Printf("%d quarantined events\n", len(events))
+	return nil
+}
+
+func quarantineReprocess(_ *cobra.Command, _ []string) error {
+	if quarantineArgs.key == "" && !quarantineArgs.all {
+		return errors.New("either --key or --all is required")
+	}
+	ob, err := newCmdEVMChainClient(quarantineArgs.chain, "quarantine")
+	if err != nil {
+		return err
+	}
+	defer ob.Stop()
+
+	keys := []string{quarantineArgs.key}
+	if quarantineArgs.all {
+		events, err := ob.GetQuarantinedEvents()
+		if err != nil {
+			return err
+		}
+		keys = keys[:0]
+		for _, event := range events {
+			keys = append(keys, event.Key)
+		}
+	}
+	quarantineLogger := log.Logger.With().Str("module", "quarantine").Logger()
+	failed := 0
+	for _, key := range keys {
+		zetaHash, err := ob.ReprocessQuarantinedEvent(key)
+		if err != nil {
+			failed++
+			quarantineLogger.Error().Err(err).Msgf("quarantined event %s could not be processed", key)
+			continue
+		}
+		quarantineLogger.Info().Msgf("quarantined event %s processed: zeta tx %s", key, zetaHash)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d quarantined events could not be processed", failed, len(keys))
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	mc "github.com/zeta-chain/zetacore/zetaclient"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var QueryCmd = &cobra.Command{
+	Use:   "query",
+	Short: "Query the state of the cctxs, outbound queues and keygen on zetacore",
+}
+
+var QueryCctxCmd = &cobra.Command{
+	Use:   "cctx [hash]",
+	Short: "Show the cctx of a send hash, or the cctxs of an inbound tx hash",
+	Args:  cobra.ExactArgs(1),
+	RunE:  queryCctx,
+}
+
+var QueryPendingCmd = &cobra.Command{
+	Use:   "pending",
+	Short: "Show the pending nonces, cctxs and outbound trackers of a chain",
+	RunE:  queryPending,
+}
+
+var QueryLastHeightsCmd = &cobra.Command{
+	Use:   "last-heights",
+	Short: "Show the last block heights of the external chains observed by zetacore",
+	RunE:  queryLastHeights,
+}
+
+var QueryKeygenCmd = &cobra.Command{
+	Use:   "keygen",
+	Short: "Show the keygen status, the current TSS and the TSS history",
+	RunE:  queryKeygen,
+}
+
+var queryArgs = queryArguments{}
+
+type queryArguments struct {
+	chain string
+}
+
+func init() {
+	RootCmd.AddCommand(QueryCmd)
+	QueryCmd.AddCommand(QueryCctxCmd)
+	QueryCmd.AddCommand(QueryPendingCmd)
+	QueryCmd.AddCommand(QueryLastHeightsCmd)
+	QueryCmd.AddCommand(QueryKeygenCmd)
+	QueryPendingCmd.Flags().StringVar(&queryArgs.chain, "chain", "", "chain name or chain id, e.g. eth_mainnet or 1")
+}
+
+func queryCctx(_ *cobra.Command, args []string) error {
+	bridge, err := newCmdQueryBridge()
+	if err != nil {
+		return err
+	}
+	defer bridge.Stop()
+
+	cctx, err := bridge.GetCctxByHash(args[0])
+	if err == nil {
+		return printJSON(cctx)
+	}
+	if status.Code(err) != codes.NotFound {
+		return err
+	}
+	// not a send hash, look up the cctxs of the inbound tx
+	cctxs, err := bridge.GetCctxByInboundHash(args[0])
+	if err != nil {
+		return err
+	}
+	return printJSON(cctxs)
+}
+
+func queryPending(_ *cobra.Command, _ []string) error {
+	chain, err := parseChainArg(queryArgs.chain)
+	if err != nil {
+		return err
+	}
+	bridge, err := newCmdQueryBridge()
+	if err != nil {
+		return err
+	}
+	defer bridge.Stop()
+
+	pending, err := bridge.GetPendingOutbounds(*chain)
+	if err != nil {
+		return err
+	}
+	return printJSON(pending)
+}
+
+func queryLastHeights(_ *cobra.Command, _ []string) error {
+	bridge, err := newCmdQueryBridge()
+	if err != nil {
+		return err
+	}
+	defer bridge.Stop()
+
+	heights, err := bridge.GetLastBlockHeight()
+	if err != nil {
+		return err
+	}
+	return printJSON(heights)
+}
+
+func queryKeygen(_ *cobra.Command, _ []string) error {
+	bridge, err := newCmdQueryBridge()
+	if err != nil {
+		return err
+	}
+	defer bridge.Stop()
+
+	state, err := bridge.GetKeygenState()
+	if err != nil {
+		return err
+	}
+	return printJSON(state)
+}
+
+// newCmdQueryBridge creates a bridge to the zetacore node of the config for queries only; it holds no key
+func newCmdQueryBridge() (*mc.ZetaCoreBridge, error) {
+	err := setHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	SetupConfigForTest()
+
+	cfg, err := config.Load(rootArgs.zetaCoreHome)
+	if err != nil {
+		return nil, err
+	}
+	bridge, err := mc.NewZetaCoreBridge(&mc.Keys{}, cfg.ZetaCoreURL, cfg.AuthzHotkey, cfg.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	bridge.SetGRPCLimits(cfg.GRPCMaxRecvMsgSize, cfg.GRPCMaxSendMsgSize, cfg.GRPCCompression)
+	return bridge, nil
+}
+
+func printJSON(v interface{}) error {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
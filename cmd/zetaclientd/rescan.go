@@ -0,0 +1,167 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/btcsuite/btcutil"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/zeta-chain/zetacore/common"
+	mc "github.com/zeta-chain/zetacore/zetaclient"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
+	metrics2 "github.com/zeta-chain/zetacore/zetaclient/metrics"
+)
+
+var RescanCmd = &cobra.Command{
+	Use:   "rescan",
+	Short: "Observe the inbound txs of a block range again and post the ones zetacore has not seen",
+	RunE:  rescan,
+}
+
+var rescanArgs = rescanArguments{}
+
+type rescanArguments struct {
+	chain     string
+	fromBlock int64
+	toBlock   int64
+}
+
+func init() {
+	RootCmd.AddCommand(RescanCmd)
+	RescanCmd.Flags().StringVar(&rescanArgs.chain, "chain", "", "chain name or chain id, e.g. eth_mainnet or 1")
+	RescanCmd.Flags().Int64Var(&rescanArgs.fromBlock, "from", 0, "first block to rescan")
+	RescanCmd.Flags().Int64Var(&rescanArgs.toBlock, "to", 0, "last block to rescan")
+}
+
+func rescan(_ *cobra.Command, _ []string) error {
+	ob, err := newCmdEVMChainClient(rescanArgs.chain, "rescan")
+	if err != nil {
+		return err
+	}
+	defer ob.Stop()
+
+	rescanLogger := log.Logger.With().Str("module", "rescan").Logger()
+	rescanLogger.Info().Msgf("rescanning chain %s from block %d to block %d", rescanArgs.chain, rescanArgs.fromBlock, rescanArgs.toBlock)
+	err = ob.Rescan(rescanArgs.fromBlock, rescanArgs.toBlock)
+	if err != nil {
+		return err
+	}
+	rescanLogger.Info().Msg("rescan completed")
+	return nil
+}
+
+// newCmdEVMChainClient creates the chain client of an evm chain, given by name or chain id, for a command run next
+// to the zetaclient of the node: it shares its config, keys and observer db, whose last scanned block it never writes
+func newCmdEVMChainClient(chainArg string, command string) (*mc.EVMChainClient, error) {
+	err := setHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	SetupConfigForTest()
+
+	chain, err := parseChainArg(chainArg)
+	if err != nil {
+		return nil, err
+	}
+	if !common.IsEVMChain(chain.ChainId) {
+		return nil, fmt.Errorf("%s is only supported for evm chains, got %s", command, chain.Name())
+	}
+
+	cfg, err := config.Load(rootArgs.zetaCoreHome)
+	if err != nil {
+		return nil, err
+	}
+	log.Logger = InitLogger(cfg)
+	masterLogger := log.Logger
+
+	zetaBridge, err := CreateZetaBridge(cfg)
+	if err != nil {
+		return nil, err
+	}
+	zetaBridge.WaitForCoreToCreateBlocks()
+	zetaBridge.SetAccountNumber(common.ZetaClientGranteeKey)
+	CreateAuthzSigner(zetaBridge.GetKeys().GetOperatorAddress().String(), zetaBridge.GetKeys().GetAddress())
+	err = zetaBridge.UpdateConfigFromCore(cfg, true)
+	if err != nil {
+		return nil, err
+	}
+
+	evmConfig, found := cfg.GetEVMConfig(chain.ChainId)
+	if !found {
+		return nil, fmt.Errorf("no config found for chain %s", chain.Name())
+	}
+	tssAddress, err := zetaBridge.GetEthTssAddress()
+	if err != nil {
+		return nil, err
+	}
+	metrics, err := metrics2.NewMetrics(cfg.MetricsPort)
+	if err != nil {
+		return nil, err
+	}
+
+	dbpath := cfg.ObserverDBPath
+	if dbpath == "" {
+		userDir, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		dbpath = filepath.Join(userDir, ".zetaclient/chainobserver")
+	}
+
+	ob, err := mc.NewEVMChainClient(zetaBridge, tssAddressSigner{evmAddress: ethcommon.HexToAddress(tssAddress)}, dbpath, metrics, masterLogger, cfg, evmConfig, mc.NewTelemetryServer())
+	if err != nil {
+		return nil, err
+	}
+	ob.KeepLastScannedBlock()
+	return ob, nil
+}
+
+// parseChainArg parses a chain given by name or chain id
+func parseChainArg(arg string) (*common.Chain, error) {
+	if chainID, err := strconv.ParseInt(arg, 10, 64); err == nil {
+		if chain := common.GetChainFromChainID(chainID); chain != nil {
+			return chain, nil
+		}
+		return nil, fmt.Errorf("invalid chain id %d", chainID)
+	}
+	if chain := common.GetChainFromChainName(common.ParseChainName(arg)); chain != nil {
+		return chain, nil
+	}
+	return nil, fmt.Errorf("invalid chain %q", arg)
+}
+
+var _ mc.TSSSigner = tssAddressSigner{}
+
+// tssAddressSigner only knows the TSS address; it is enough to observe inbound txs but cannot sign
+type tssAddressSigner struct {
+	evmAddress ethcommon.Address
+}
+
+func (s tssAddressSigner) Pubkey() []byte {
+	return nil
+}
+
+func (s tssAddressSigner) Sign(_ []byte, _ uint64, _ uint64, _ *common.Chain, _ string) ([65]byte, error) {
+	return [65]byte{}, errors.New("tssAddressSigner cannot sign")
+}
+
+func (s tssAddressSigner) EVMAddress() ethcommon.Address {
+	return s.evmAddress
+}
+
+func (s tssAddressSigner) BTCAddress() string {
+	return ""
+}
+
+func (s tssAddressSigner) BTCAddressWitnessPubkeyHash() *btcutil.AddressWitnessPubKeyHash {
+	return nil
+}
+
+func (s tssAddressSigner) PubKeyCompressedBytes() []byte {
+	return nil
+}
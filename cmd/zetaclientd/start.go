@@ -89,11 +89,28 @@ func start(_ *cobra.Command, _ []string) error {
 		zetaBridge.UpdateChainID(cfg.ChainID)
 	}
 
+	// CheckCoreCompatibility: zetacore must register the msgs zetaclient broadcasts, so that a zetaclient left behind by
+	// an upgrade of zetacore stops here rather than broadcasting txs zetacore rejects
+	compatibility, err := zetaBridge.CheckCoreCompatibility()
+	if err != nil {
+		startLogger.Error().Err(err).Msg("CheckCoreCompatibility error")
+		return err
+	}
+	startLogger.Info().Msgf("zetacore version %s, zetaclient version %s", compatibility.CoreVersion, compatibility.ClientVersion)
+
 	// CreateAuthzSigner : which is used to sign all authz messages . All votes broadcast to zetacore are wrapped in authz exec .
 	// This is to ensure that the user does not need to keep their operator key online , and can use a cold key to sign votes
 	CreateAuthzSigner(zetaBridge.GetKeys().GetOperatorAddress().String(), zetaBridge.GetKeys().GetAddress())
 	startLogger.Debug().Msgf("CreateAuthzSigner is ready")
 
+	// CheckGrants: the hotkey must be separate from the operator key and granted the msgs broadcast by zetaclient, so
+	// that the key kept on this host cannot move the funds staked by the operator
+	err = zetaBridge.CheckGrants(cfg.FeeGrant)
+	if err != nil {
+		startLogger.Error().Err(err).Msg("CheckGrants error")
+		return err
+	}
+
 	// Initialize core parameters from zetacore
 	err = zetaBridge.UpdateConfigFromCore(cfg, true)
 	if err != nil {
@@ -102,6 +119,9 @@ func start(_ *cobra.Command, _ []string) error {
 	}
 	startLogger.Info().Msgf("Config is updated from ZetaCore %s", cfg.String())
 
+	// CoreEvents: the events of zetacore are pushed over the websocket, so that the clients react to them rather than wait for their next poll
+	coreEvents := zetaBridge.EnableCoreEvents()
+
 	// ConfigUpdater: A polling goroutine checks and updates core parameters at every height. Zetacore stores core parameters for all clients
 	go zetaBridge.ConfigUpdater(cfg)
 
@@ -144,7 +164,7 @@ func start(_ *cobra.Command, _ []string) error {
 		}
 	}()
 
-	metrics, err := metrics2.NewMetrics()
+	metrics, err := metrics2.NewMetrics(cfg.MetricsPort)
 	if err != nil {
 		log.Error().Err(err).Msg("NewMetrics")
 		return err
@@ -220,12 +240,26 @@ func start(_ *cobra.Command, _ []string) error {
 		return err
 	}
 
-	userDir, err := os.UserHomeDir()
-	if err != nil {
-		log.Error().Err(err).Msg("os.UserHomeDir")
+	// use the default data directory if it is not set in config
+	dbpath := cfg.ObserverDBPath
+	if dbpath == "" {
+		userDir, err := os.UserHomeDir()
+		if err != nil {
+			log.Error().Err(err).Msg("os.UserHomeDir")
+			return err
+		}
+		dbpath = filepath.Join(userDir, ".zetaclient/chainobserver")
+	}
+	startLogger.Info().Msgf("chain observer data directory: %s", dbpath)
+
+	// the txs broadcast to zetacore are written ahead to the outbox and replayed until included
+	if err := zetaBridge.EnableOutbox(dbpath); err != nil {
+		startLogger.Error().Err(err).Msg("EnableOutbox")
 		return err
 	}
-	dbpath := filepath.Join(userDir, ".zetaclient/chainobserver")
+
+	// the votes broadcast are followed until their ballot finalized, so that the votes which stop counting are reported
+	zetaBridge.EnableVoteTracking()
 
 	// CreateChainClientMap : This creates a map of all chain clients . Each chain client is responsible for listening to events on the chain and processing them
 	chainClientMap, err := CreateChainClientMap(zetaBridge, tss, dbpath, metrics, masterLogger, cfg, telemetryServer)
@@ -233,12 +267,15 @@ func start(_ *cobra.Command, _ []string) error {
 		startLogger.Err(err).Msg("CreateSignerMap")
 		return err
 	}
-	for _, v := range chainClientMap {
-		v.Start()
-	}
-
-	// CreateCoreObserver : Core observer wraps the zetacore bridge and adds the client and signer maps to it . This is the high level object used for CCTX interactions
-	mo1 := mc.NewCoreObserver(zetaBridge, signerMap, chainClientMap, metrics, masterLogger, cfg, telemetryServer)
+	// ChainClientSupervisor : starts the chain clients, restarts their goroutines if they crash and reports their status to telemetry
+	// It also starts and stops chain clients when chains are enabled or disabled in zetacore at runtime
+	chainFactory := NewChainFactory(zetaBridge, tss, dbpath, metrics, masterLogger, cfg, telemetryServer)
+	supervisor := mc.NewChainClientSupervisor(chainClientMap, signerMap, chainFactory, cfg, telemetryServer, masterLogger)
+	supervisor.Start()
+
+	// CreateCoreObserver : Core observer wraps the zetacore bridge and adds the chain clients and signers to it . This is the high level object used for CCTX interactions
+	mo1 := mc.NewCoreObserver(zetaBridge, supervisor, metrics, masterLogger, cfg, telemetryServer)
+	mo1.SubscribeCoreEvents(coreEvents)
 	mo1.MonitorCore()
 
 	zetaSupplyChecker, err := mc.NewZetaSupplyChecker(cfg, zetaBridge, masterLogger)
@@ -256,9 +293,7 @@ func start(_ *cobra.Command, _ []string) error {
 	startLogger.Info().Msgf("stop signal received: %s", sig)
 
 	// stop zetacore observer
-	for _, client := range chainClientMap {
-		client.Stop()
-	}
+	supervisor.Stop()
 	zetaBridge.Stop()
 
 	return nil
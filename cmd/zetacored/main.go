@@ -9,6 +9,9 @@ import (
 	svrcmd "github.com/cosmos/cosmos-sdk/server/cmd"
 	"github.com/zeta-chain/zetacore/app"
 	cmdcfg "github.com/zeta-chain/zetacore/cmd/zetacored/config"
+
+	// the grpc server decompresses the gzip requests of the observers with GRPCCompression set
+	_ "google.golang.org/grpc/encoding/gzip"
 )
 
 func main() {
@@ -12,6 +12,7 @@ import (
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	authz "github.com/cosmos/cosmos-sdk/x/authz"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
 	"github.com/cosmos/cosmos-sdk/x/genutil"
 	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
 	v1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
@@ -74,6 +75,7 @@ func AddObserverAccountsCmd() *cobra.Command {
 			}
 			var observerMapper []*types.ObserverMapper
 			var grantAuthorizations []authz.GrantAuthorization
+			var feeAllowances []feegrant.Grant
 			var nodeAccounts []*types.NodeAccount
 			var keygenPubKeys []string
 			observersForChain := map[int64][]string{}
@@ -114,6 +116,9 @@ func AddObserverAccountsCmd() *cobra.Command {
 					panic("ZetaClientGranteeAddress or ObserverAddress is empty")
 				}
 				grantAuthorizations = append(grantAuthorizations, generateGrants(info)...)
+				if info.ZetaClientFeeAllowance != "" {
+					feeAllowances = append(feeAllowances, generateFeeAllowance(info))
+				}
 				for _, chain := range supportedChains {
 					observersForChain[chain.ChainId] = append(observersForChain[chain.ChainId], info.ObserverAddress)
 				}
@@ -221,6 +226,14 @@ func AddObserverAccountsCmd() *cobra.Command {
 			}
 			appState[types.ModuleName] = zetaObserverStateBz
 			appState[authz.ModuleName] = authZStateBz
+			if len(feeAllowances) > 0 {
+				// Add the fee allowances of the hotkeys to feegrant genesis state
+				feeGrantStateBz, err := cdc.MarshalJSON(feegrant.NewGenesisState(feeAllowances))
+				if err != nil {
+					return fmt.Errorf("failed to marshal fee allowances into Genesis File: %w", err)
+				}
+				appState[feegrant.ModuleName] = feeGrantStateBz
+			}
 			appState[crosschaintypes.ModuleName] = zetaCrossChainStateBz
 			modifiedAppState, err := AddGenesisAccount(clientCtx, balances, appState)
 			if err != nil {
@@ -295,6 +308,24 @@ func addZetaClientGrants(grants []authz.GrantAuthorization, info ObserverInfoRea
 	return grants
 }
 
+// generateFeeAllowance returns the allowance of the fees of the zetaclient hotkey paid by the observer, so that the
+// hotkey needs no funds of its own
+func generateFeeAllowance(info ObserverInfoReader) feegrant.Grant {
+	feeAllowance, ok := sdk.NewIntFromString(info.ZetaClientFeeAllowance)
+	if !ok {
+		panic("Failed to parse zetaclient fee allowance")
+	}
+	grant, err := feegrant.NewGrant(
+		sdk.MustAccAddressFromBech32(info.ObserverAddress),
+		sdk.MustAccAddressFromBech32(info.ZetaClientGranteeAddress),
+		&feegrant.BasicAllowance{SpendLimit: sdk.NewCoins(sdk.NewCoin(config.BaseDenom, feeAllowance))},
+	)
+	if err != nil {
+		panic(err)
+	}
+	return grant
+}
+
 func addGovGrants(grants []authz.GrantAuthorization, info ObserverInfoReader) []authz.GrantAuthorization {
 
 	txTypes := []string{sdk.MsgTypeURL(&v1beta1.MsgVote{}),
@@ -17,6 +17,7 @@ type ObserverInfoReader struct {
 	SpendMaxTokens            string   `json:"SpendMaxTokens,omitempty"`
 	GovGranteeAddress         string   `json:"GovGranteeAddress,omitempty"`
 	ZetaClientGranteePubKey   string   `json:"ZetaClientGranteePubKey,omitempty"`
+	ZetaClientFeeAllowance    string   `json:"ZetaClientFeeAllowance,omitempty"`
 }
 
 func (o ObserverInfoReader) String() string {
@@ -18,6 +18,9 @@ var (
 
 const ETHAddressLen = 42
 
+// ZetaBech32Prefix is the bech32 prefix of the account addresses of the zeta chain
+const ZetaBech32Prefix = "zeta"
+
 // NewAddress create a new Address. Supports Ethereum, BSC, Polygon
 func NewAddress(address string, chain Chain) (Address, error) {
 
@@ -46,3 +49,52 @@ func (addr Address) IsEmpty() bool {
 func (addr Address) String() string {
 	return string(addr)
 }
+
+// ValidateAddress checks that address is an address of the chain before anything is sent to it: a 20 bytes hex
+// address on the EVM chains, matching its EIP-55 checksum if mixed case, or a base58 address on tron; a hex or bech32
+// account address on the zeta chain; an address of the network on bitcoin
+func ValidateAddress(chain Chain, address string) error {
+	switch {
+	case chain.IsZetaChain():
+		if strings.HasPrefix(address, ZetaBech32Prefix+"1") {
+			accAddress, err := cosmos.GetFromBech32(address, ZetaBech32Prefix)
+			if err != nil {
+				return fmt.Errorf("invalid bech32 address %s: %w", address, err)
+			}
+			if len(accAddress) != eth.AddressLength {
+				return fmt.Errorf("invalid bech32 address %s: %d bytes", address, len(accAddress))
+			}
+			return nil
+		}
+		return validateHexAddress(address)
+	case IsTronChain(chain.ChainId) && strings.HasPrefix(address, "T"):
+		_, err := TronAddressToEVM(address)
+		return err
+	case IsEVMChain(chain.ChainId):
+		return validateHexAddress(address)
+	case IsBitcoinChain(chain.ChainId):
+		_, err := chain.EncodeAddress([]byte(address))
+		return err
+	}
+	return fmt.Errorf("chain (%d) not supported", chain.ChainId)
+}
+
+// validateHexAddress checks that address is a non-zero 20 bytes hex address, and that a mixed case address matches its
+// EIP-55 checksum
+func validateHexAddress(address string) error {
+	if !eth.IsHexAddress(address) {
+		return fmt.Errorf("invalid hex address %s", address)
+	}
+	addr := eth.HexToAddress(address)
+	if addr == (eth.Address{}) {
+		return fmt.Errorf("zero address %s", address)
+	}
+	digits := address
+	if strings.HasPrefix(digits, "0x") || strings.HasPrefix(digits, "0X") {
+		digits = digits[2:]
+	}
+	if digits != strings.ToLower(digits) && digits != strings.ToUpper(digits) && "0x"+digits != addr.Hex() {
+		return fmt.Errorf("invalid EIP-55 checksum of address %s", address)
+	}
+	return nil
+}
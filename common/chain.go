@@ -102,7 +102,11 @@ func IsEVMChain(chainID int64) bool {
 		chainID == 1337 || // eth privnet
 		chainID == 1 || // eth mainnet
 		chainID == 56 || // bsc mainnet
-		chainID == 137 // polygon mainnet
+		chainID == 137 || // polygon mainnet
+		IsTronChain(chainID) || // tron is evm compatible
+		IsArbitrumChain(chainID) ||
+		IsOPStackChain(chainID) ||
+		IsCustomEVMChain(chainID)
 }
 
 func IsHeaderSupportedEvmChain(chainID int64) bool {
@@ -113,6 +117,20 @@ func IsHeaderSupportedEvmChain(chainID int64) bool {
 		chainID == 56 // bsc mainnet
 }
 
+// IsArbitrumChain returns true for arbitrum rollups, whose blocks are final once their batch is finalized on L1
+func IsArbitrumChain(chainID int64) bool {
+	return chainID == 42161 || // arbitrum one
+		chainID == 421613 // arbitrum goerli
+}
+
+// IsOPStackChain returns true for OP-stack rollups (optimism, base), whose blocks start with L1 deposit txs
+func IsOPStackChain(chainID int64) bool {
+	return chainID == 10 || // optimism
+		chainID == 420 || // optimism goerli
+		chainID == 8453 || // base
+		chainID == 84531 // base goerli
+}
+
 func (chain Chain) IsKlaytnChain() bool {
 	return chain.ChainId == 1001
 }
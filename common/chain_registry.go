@@ -0,0 +1,50 @@
+package common
+
+import (
+	"fmt"
+	"sync"
+)
+
+// custom evm chains are defined in the zetaclient config instead of being built in, keyed by chain id
+var (
+	customEVMChainsLock sync.RWMutex
+	customEVMChains     = map[int64]string{}
+)
+
+// RegisterCustomEVMChain registers an evm chain defined by configuration so that it is handled like the built-in evm chains
+func RegisterCustomEVMChain(chainID int64, name string) error {
+	if name == "" {
+		return fmt.Errorf("custom chain %d has no name", chainID)
+	}
+	if _, found := ChainName_value[name]; found {
+		return fmt.Errorf("custom chain name %s is already used by a built-in chain", name)
+	}
+	if !IsCustomEVMChain(chainID) && (IsEVMChain(chainID) || IsBitcoinChain(chainID) || chainID == ZetaChain().ChainId) {
+		return fmt.Errorf("chain %d is a built-in chain", chainID)
+	}
+	customEVMChainsLock.Lock()
+	defer customEVMChainsLock.Unlock()
+	customEVMChains[chainID] = name
+	return nil
+}
+
+// IsCustomEVMChain returns true if the chain is an evm chain defined by configuration
+func IsCustomEVMChain(chainID int64) bool {
+	customEVMChainsLock.RLock()
+	defer customEVMChainsLock.RUnlock()
+	_, found := customEVMChains[chainID]
+	return found
+}
+
+// Name returns the name of the chain, including the configured name of a custom evm chain
+func (chain Chain) Name() string {
+	if chain.ChainName != ChainName_empty {
+		return chain.ChainName.String()
+	}
+	customEVMChainsLock.RLock()
+	defer customEVMChainsLock.RUnlock()
+	if name, found := customEVMChains[chain.ChainId]; found {
+		return name
+	}
+	return fmt.Sprintf("chain_%d", chain.ChainId)
+}
@@ -0,0 +1,28 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterCustomEVMChain(t *testing.T) {
+	chainID := int64(123456789)
+	require.False(t, IsEVMChain(chainID))
+	require.Equal(t, "chain_123456789", Chain{ChainId: chainID}.Name())
+
+	require.NoError(t, RegisterCustomEVMChain(chainID, "custom_mainnet"))
+	require.True(t, IsCustomEVMChain(chainID))
+	require.True(t, IsEVMChain(chainID))
+	require.Equal(t, "custom_mainnet", Chain{ChainId: chainID}.Name())
+
+	// registering again, e.g. when the config is reloaded, is allowed
+	require.NoError(t, RegisterCustomEVMChain(chainID, "custom_mainnet"))
+
+	// built-in chains and chain names cannot be overridden
+	require.Error(t, RegisterCustomEVMChain(EthChain().ChainId, "custom_eth"))
+	require.Error(t, RegisterCustomEVMChain(BtcMainnetChain().ChainId, "custom_btc"))
+	require.Error(t, RegisterCustomEVMChain(987654321, ChainName_eth_mainnet.String()))
+	require.Error(t, RegisterCustomEVMChain(987654321, ""))
+	require.Equal(t, ChainName_eth_mainnet.String(), EthChain().Name())
+}
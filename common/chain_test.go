@@ -53,3 +53,32 @@ func TestChain_EncodeAddress(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateAddress(t *testing.T) {
+	eth := Chain{ChainName: ChainName_eth_mainnet, ChainId: 1}
+	btc := Chain{ChainName: ChainName_btc_mainnet, ChainId: 8332}
+	tron := Chain{ChainName: ChainName_tron_mainnet, ChainId: 728126428}
+
+	// EVM chains take 20 bytes hex addresses, checksummed if mixed case
+	require.NoError(t, ValidateAddress(eth, "0x90f2b1ae50e6018230e90a33f98c7844a0ab635a"))
+	require.NoError(t, ValidateAddress(eth, "0x90F2B1AE50E6018230E90A33F98C7844A0AB635A"))
+	require.NoError(t, ValidateAddress(eth, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"))
+	require.Error(t, ValidateAddress(eth, "0x5AAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"))
+	require.Error(t, ValidateAddress(eth, "0x90f2b1ae50e6018230e90a33f98c7844a0ab63"))
+	require.Error(t, ValidateAddress(eth, "0x0000000000000000000000000000000000000000"))
+	require.Error(t, ValidateAddress(eth, "bc1qk0cc73p8m7hswn8y2q080xa4e5pxapnqgp7h9c"))
+
+	// tron takes base58 addresses as well
+	require.NoError(t, ValidateAddress(tron, "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"))
+	require.Error(t, ValidateAddress(tron, "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6u"))
+
+	// bitcoin takes the addresses of its network
+	require.NoError(t, ValidateAddress(btc, "bc1qk0cc73p8m7hswn8y2q080xa4e5pxapnqgp7h9c"))
+	require.Error(t, ValidateAddress(btc, "tb1qsa222mn2rhdq9cruxkz8p2teutvxuextx3ees2"))
+	require.Error(t, ValidateAddress(btc, "0x90f2b1ae50e6018230e90a33f98c7844a0ab635a"))
+
+	// the zeta chain takes hex and bech32 account addresses
+	require.NoError(t, ValidateAddress(ZetaChain(), "0x90f2b1ae50e6018230e90a33f98c7844a0ab635a"))
+	require.NoError(t, ValidateAddress(ZetaChain(), "zeta15ruj2tc76pnj9xtw64utktee7cc7w6vzaes73z"))
+	require.Error(t, ValidateAddress(ZetaChain(), "zeta15ruj2tc76pnj9xtw64utktee7cc7w6vzaes73y"))
+}
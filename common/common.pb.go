@@ -104,8 +104,16 @@ const (
 	ChainName_btc_testnet     ChainName = 12
 	//  LocalNet
 	//  zeta_localnet = 13;
-	ChainName_goerli_localnet ChainName = 14
-	ChainName_btc_regtest     ChainName = 15
+	ChainName_goerli_localnet  ChainName = 14
+	ChainName_btc_regtest      ChainName = 15
+	ChainName_tron_mainnet     ChainName = 16
+	ChainName_tron_testnet     ChainName = 17
+	ChainName_arbitrum_mainnet ChainName = 18
+	ChainName_arbitrum_testnet ChainName = 19
+	ChainName_optimism_mainnet ChainName = 20
+	ChainName_optimism_testnet ChainName = 21
+	ChainName_base_mainnet     ChainName = 22
+	ChainName_base_testnet     ChainName = 23
 )
 
 var ChainName_name = map[int32]string{
@@ -124,24 +132,40 @@ var ChainName_name = map[int32]string{
 	12: "btc_testnet",
 	14: "goerli_localnet",
 	15: "btc_regtest",
+	16: "tron_mainnet",
+	17: "tron_testnet",
+	18: "arbitrum_mainnet",
+	19: "arbitrum_testnet",
+	20: "optimism_mainnet",
+	21: "optimism_testnet",
+	22: "base_mainnet",
+	23: "base_testnet",
 }
 
 var ChainName_value = map[string]int32{
-	"empty":           0,
-	"eth_mainnet":     1,
-	"zeta_mainnet":    2,
-	"btc_mainnet":     3,
-	"polygon_mainnet": 4,
-	"bsc_mainnet":     5,
-	"goerli_testnet":  6,
-	"mumbai_testnet":  7,
-	"ganache_testnet": 8,
-	"baobab_testnet":  9,
-	"bsc_testnet":     10,
-	"zeta_testnet":    11,
-	"btc_testnet":     12,
-	"goerli_localnet": 14,
-	"btc_regtest":     15,
+	"empty":            0,
+	"eth_mainnet":      1,
+	"zeta_mainnet":     2,
+	"btc_mainnet":      3,
+	"polygon_mainnet":  4,
+	"bsc_mainnet":      5,
+	"goerli_testnet":   6,
+	"mumbai_testnet":   7,
+	"ganache_testnet":  8,
+	"baobab_testnet":   9,
+	"bsc_testnet":      10,
+	"zeta_testnet":     11,
+	"btc_testnet":      12,
+	"goerli_localnet":  14,
+	"btc_regtest":      15,
+	"tron_mainnet":     16,
+	"tron_testnet":     17,
+	"arbitrum_mainnet": 18,
+	"arbitrum_testnet": 19,
+	"optimism_mainnet": 20,
+	"optimism_testnet": 21,
+	"base_mainnet":     22,
+	"base_testnet":     23,
 }
 
 func (x ChainName) String() string {
@@ -520,50 +544,53 @@ func init() {
 func init() { proto.RegisterFile("common/common.proto", fileDescriptor_8f954d82c0b891f6) }
 
 var fileDescriptor_8f954d82c0b891f6 = []byte{
-	// 682 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x94, 0xcd, 0x6a, 0xdb, 0x4a,
-	0x14, 0x80, 0x25, 0xff, 0xeb, 0xd8, 0xb1, 0x75, 0x27, 0x97, 0x7b, 0x73, 0xc3, 0x45, 0x0e, 0xa6,
-	0xa5, 0x69, 0xa0, 0x4e, 0xe2, 0xe2, 0xfe, 0xd0, 0x45, 0xc1, 0xee, 0x4f, 0x4a, 0xa1, 0x04, 0x39,
-	0xab, 0x6c, 0xcc, 0x48, 0x3a, 0x95, 0x44, 0x2c, 0x8d, 0x91, 0xc7, 0x05, 0x77, 0xd7, 0x37, 0xe8,
-	0x2b, 0x14, 0x0a, 0xed, 0xa3, 0x64, 0x99, 0x65, 0x57, 0xa1, 0x38, 0x6f, 0xd1, 0x55, 0x99, 0xd1,
-	0x8c, 0x9c, 0xae, 0x74, 0xe6, 0x3b, 0xdf, 0x39, 0x67, 0x46, 0x1a, 0x04, 0xdb, 0x3e, 0x4b, 0x12,
-	0x96, 0x1e, 0xe6, 0x8f, 0xfe, 0x3c, 0x63, 0x9c, 0x91, 0x5a, 0xbe, 0xda, 0xfd, 0x5f, 0x25, 0xbd,
-	0x98, 0xfb, 0x2c, 0x2e, 0x9e, 0xb9, 0xb5, 0xeb, 0xa8, 0x2c, 0xf2, 0x08, 0x33, 0x5c, 0x26, 0x45,
-	0xa0, 0xf2, 0x7f, 0x87, 0x2c, 0x64, 0x32, 0x3c, 0x14, 0x51, 0x4e, 0x7b, 0x11, 0x58, 0xa7, 0x4b,
-	0xef, 0x2d, 0xae, 0x26, 0xc8, 0xc9, 0x10, 0xac, 0x05, 0xfa, 0xf3, 0xc1, 0xf0, 0xd1, 0xc5, 0xf1,
-	0x8e, 0xb9, 0x67, 0xee, 0x5b, 0xa3, 0x7f, 0xd7, 0xd7, 0x5d, 0x6b, 0xa2, 0xe1, 0xaf, 0xeb, 0x6e,
-	0x2d, 0xd7, 0xdd, 0x8d, 0x49, 0xee, 0x40, 0x1d, 0x83, 0xc1, 0x70, 0x78, 0xfc, 0x74, 0xa7, 0x24,
-	0x8b, 0xe0, 0x96, 0xa7, 0x53, 0xbd, 0x33, 0xa8, 0x8e, 0x23, 0x1a, 0xa7, 0xe4, 0x08, 0xc0, 0x17,
-	0xc1, 0x34, 0xa5, 0x09, 0xca, 0x31, 0xed, 0xc1, 0x5f, 0x7d, 0x75, 0x62, 0xa9, 0xbc, 0xa3, 0x09,
-	0xba, 0x96, 0xaf, 0x43, 0xf2, 0x1f, 0x34, 0xf2, 0x8a, 0x38, 0x90, 0x13, 0xca, 0x6e, 0x5d, 0xae,
-	0xdf, 0x04, 0xbd, 0x6f, 0x26, 0x34, 0x47, 0x33, 0xe6, 0x5f, 0x9c, 0x20, 0x0d, 0x30, 0x23, 0xff,
-	0x40, 0x2d, 0xc2, 0x38, 0x8c, 0xb8, 0x6c, 0x5c, 0x76, 0xd5, 0x8a, 0x10, 0xa8, 0x44, 0x74, 0x11,
-	0xc9, 0xf2, 0x96, 0x2b, 0x63, 0xd2, 0x85, 0xe6, 0x9c, 0x66, 0x98, 0xf2, 0xa9, 0x4c, 0x95, 0x65,
-	0x0a, 0x72, 0x74, 0x22, 0x84, 0xdb, 0x73, 0x2b, 0x7f, 0xcc, 0x25, 0x47, 0x62, 0x8e, 0x98, 0xb8,
-	0x53, 0xdd, 0x33, 0xf7, 0x9b, 0x03, 0xa2, 0x0f, 0x90, 0xef, 0xe3, 0x05, 0xe5, 0x74, 0x54, 0xb9,
-	0xbc, 0xee, 0x1a, 0xae, 0xf2, 0x7a, 0x11, 0xc0, 0x26, 0x47, 0xee, 0x43, 0x47, 0x7f, 0x9f, 0xa9,
-	0x6a, 0x24, 0x36, 0xdc, 0x3a, 0x31, 0xdc, 0xb6, 0x4e, 0xa8, 0x23, 0xdd, 0x83, 0xb6, 0xfa, 0xd2,
-	0xda, 0x2c, 0x29, 0x73, 0x4b, 0xf1, 0x5c, 0x1c, 0xd5, 0xa0, 0x12, 0x50, 0x4e, 0x7b, 0x9f, 0x4c,
-	0xa8, 0x9e, 0x66, 0x8c, 0xbd, 0x27, 0x4f, 0xa0, 0x68, 0x36, 0x9d, 0x0b, 0x22, 0x87, 0x34, 0x07,
-	0x9d, 0x7e, 0x71, 0x39, 0xa4, 0x28, 0x7a, 0x69, 0x92, 0x57, 0x0e, 0x41, 0x37, 0x57, 0x85, 0x25,
-	0x59, 0xd8, 0xee, 0xeb, 0x4b, 0xa7, 0xeb, 0x5a, 0x0a, 0xc8, 0xf5, 0xa8, 0x0e, 0x55, 0xa9, 0x1f,
-	0x3c, 0x83, 0x2d, 0x17, 0x7d, 0x8c, 0x3f, 0xe0, 0x84, 0x53, 0xbe, 0x5c, 0x90, 0x26, 0xd4, 0xc7,
-	0x19, 0x52, 0x8e, 0x81, 0x6d, 0x88, 0xc5, 0x64, 0xe9, 0xfb, 0xb8, 0x58, 0xd8, 0x26, 0x01, 0xa8,
-	0xbd, 0xa2, 0xf1, 0x0c, 0x03, 0xbb, 0xb4, 0x5b, 0xf9, 0xfe, 0xd5, 0x31, 0x0f, 0x1e, 0x43, 0x63,
-	0xcc, 0xe2, 0xf4, 0x6c, 0x35, 0x47, 0xd2, 0x80, 0xca, 0x39, 0x72, 0x6a, 0x1b, 0xa4, 0x0e, 0xe5,
-	0xd7, 0x54, 0x14, 0x58, 0x50, 0x7d, 0xe9, 0x8e, 0x07, 0x47, 0x76, 0x49, 0xb0, 0x71, 0x12, 0xd8,
-	0x65, 0x55, 0xf8, 0xa5, 0x04, 0x56, 0x71, 0x83, 0x84, 0x87, 0xc9, 0x9c, 0xaf, 0x6c, 0x83, 0x74,
-	0xa0, 0x89, 0x3c, 0x9a, 0x26, 0x34, 0x4e, 0x53, 0xe4, 0xb6, 0x49, 0x6c, 0x68, 0x7d, 0x44, 0x4e,
-	0x0b, 0x52, 0x12, 0x8a, 0xc7, 0xfd, 0x02, 0x94, 0xc9, 0x36, 0x74, 0xe6, 0x6c, 0xb6, 0x0a, 0x59,
-	0x5a, 0xc0, 0x8a, 0xb4, 0x16, 0x1b, 0xab, 0x4a, 0x08, 0xb4, 0x43, 0x86, 0xd9, 0x2c, 0x9e, 0x72,
-	0x5c, 0x70, 0xc1, 0x6a, 0x82, 0x25, 0xcb, 0xc4, 0xa3, 0x1b, 0x56, 0x17, 0xdd, 0x42, 0x9a, 0x52,
-	0x3f, 0xc2, 0x02, 0x36, 0x84, 0xe8, 0x51, 0xe6, 0x51, 0xaf, 0x60, 0x96, 0x9e, 0xa0, 0x01, 0x14,
-	0x5b, 0xd5, 0xa4, 0xa9, 0xb7, 0xaa, 0x41, 0x4b, 0x36, 0xcf, 0x37, 0x31, 0x63, 0x3e, 0x9d, 0x09,
-	0xd8, 0xd6, 0x56, 0x86, 0xa1, 0x10, 0xed, 0x4e, 0xfe, 0x8e, 0x46, 0xcf, 0x2f, 0xd7, 0x8e, 0x79,
-	0xb5, 0x76, 0xcc, 0x9f, 0x6b, 0xc7, 0xfc, 0x7c, 0xe3, 0x18, 0x57, 0x37, 0x8e, 0xf1, 0xe3, 0xc6,
-	0x31, 0xce, 0xef, 0x86, 0x31, 0x8f, 0x96, 0x9e, 0xb8, 0xc9, 0x87, 0x62, 0xe2, 0x03, 0x79, 0xd9,
-	0x65, 0xe8, 0xb3, 0x0c, 0xd5, 0x4f, 0xc9, 0xab, 0xc9, 0x3f, 0xc7, 0xc3, 0xdf, 0x01, 0x00, 0x00,
-	0xff, 0xff, 0x61, 0x31, 0x96, 0x1f, 0xac, 0x04, 0x00, 0x00,
+	// 731 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x94, 0xcd, 0x6e, 0xdb, 0x46,
+	0x10, 0x80, 0x49, 0xfd, 0x73, 0x24, 0x4b, 0xf4, 0xda, 0xb5, 0x5d, 0xa3, 0xa0, 0x0c, 0xa1, 0x45,
+	0x5d, 0x03, 0x95, 0x6d, 0x15, 0xea, 0x0f, 0x7a, 0x28, 0x20, 0xb5, 0x8d, 0x83, 0x00, 0x81, 0x41,
+	0xf9, 0xe4, 0x8b, 0xb0, 0x24, 0x27, 0x24, 0x61, 0x91, 0x4b, 0x90, 0xab, 0x00, 0xca, 0x2d, 0x6f,
+	0x90, 0x87, 0x08, 0x90, 0xbc, 0x46, 0x6e, 0x3e, 0xfa, 0x98, 0x93, 0x11, 0xc8, 0x6f, 0x91, 0x53,
+	0xb0, 0x4b, 0x2e, 0x65, 0x9f, 0x38, 0xfb, 0xcd, 0x37, 0x33, 0xbb, 0xe0, 0x92, 0xb0, 0xe3, 0xb2,
+	0x28, 0x62, 0xf1, 0x69, 0xfe, 0x18, 0x26, 0x29, 0xe3, 0x8c, 0x34, 0xf2, 0xd5, 0xe1, 0x0f, 0x45,
+	0xd2, 0x09, 0xb9, 0xcb, 0xc2, 0xf2, 0x99, 0x5b, 0x87, 0x56, 0x91, 0x45, 0x1e, 0x60, 0x8a, 0xcb,
+	0xa8, 0x0c, 0x8a, 0xfc, 0xae, 0xcf, 0x7c, 0x26, 0xc3, 0x53, 0x11, 0xe5, 0x74, 0x10, 0x80, 0x71,
+	0xb9, 0x74, 0x5e, 0xe0, 0x6a, 0x86, 0x9c, 0x8c, 0xc1, 0xc8, 0xd0, 0x4d, 0x46, 0xe3, 0xdf, 0x6f,
+	0xce, 0x0f, 0xf4, 0x23, 0xfd, 0xd8, 0x98, 0xec, 0xaf, 0xef, 0xfb, 0xc6, 0x4c, 0xc1, 0xaf, 0xf7,
+	0xfd, 0x46, 0xae, 0xdb, 0x1b, 0x93, 0xfc, 0x08, 0x4d, 0xf4, 0x46, 0xe3, 0xf1, 0xf9, 0x5f, 0x07,
+	0x15, 0x59, 0x04, 0x8f, 0x3c, 0x95, 0x1a, 0x5c, 0x41, 0x7d, 0x1a, 0xd0, 0x30, 0x26, 0x67, 0x00,
+	0xae, 0x08, 0xe6, 0x31, 0x8d, 0x50, 0x8e, 0xe9, 0x8e, 0xb6, 0x87, 0xc5, 0x89, 0xa5, 0xf2, 0x92,
+	0x46, 0x68, 0x1b, 0xae, 0x0a, 0xc9, 0xf7, 0xd0, 0xca, 0x2b, 0x42, 0x4f, 0x4e, 0xa8, 0xda, 0x4d,
+	0xb9, 0x7e, 0xee, 0x0d, 0x3e, 0xe8, 0xd0, 0x9e, 0x2c, 0x98, 0x7b, 0x73, 0x81, 0xd4, 0xc3, 0x94,
+	0xec, 0x41, 0x23, 0xc0, 0xd0, 0x0f, 0xb8, 0x6c, 0x5c, 0xb5, 0x8b, 0x15, 0x21, 0x50, 0x0b, 0x68,
+	0x16, 0xc8, 0xf2, 0x8e, 0x2d, 0x63, 0xd2, 0x87, 0x76, 0x42, 0x53, 0x8c, 0xf9, 0x5c, 0xa6, 0xaa,
+	0x32, 0x05, 0x39, 0xba, 0x10, 0xc2, 0xe3, 0xb9, 0xb5, 0x27, 0x73, 0xc9, 0x99, 0x98, 0x23, 0x26,
+	0x1e, 0xd4, 0x8f, 0xf4, 0xe3, 0xf6, 0x88, 0xa8, 0x03, 0xe4, 0xfb, 0xf8, 0x97, 0x72, 0x3a, 0xa9,
+	0xdd, 0xde, 0xf7, 0x35, 0xbb, 0xf0, 0x06, 0x01, 0xc0, 0x26, 0x47, 0x7e, 0x81, 0x9e, 0x7a, 0x3f,
+	0xf3, 0xa2, 0x91, 0xd8, 0x70, 0xe7, 0x42, 0xb3, 0xbb, 0x2a, 0x51, 0x1c, 0xe9, 0x67, 0xe8, 0x16,
+	0x6f, 0x5a, 0x99, 0x95, 0xc2, 0xdc, 0x2a, 0x78, 0x2e, 0x4e, 0x1a, 0x50, 0xf3, 0x28, 0xa7, 0x83,
+	0xb7, 0x3a, 0xd4, 0x2f, 0x53, 0xc6, 0x5e, 0x91, 0x3f, 0xa1, 0x6c, 0x36, 0x4f, 0x04, 0x91, 0x43,
+	0xda, 0xa3, 0xde, 0xb0, 0xbc, 0x1c, 0x52, 0x14, 0xbd, 0x14, 0xc9, 0x2b, 0xc7, 0xa0, 0x9a, 0x17,
+	0x85, 0x15, 0x59, 0xd8, 0x1d, 0xaa, 0x4b, 0xa7, 0xea, 0x3a, 0x05, 0x90, 0xeb, 0x49, 0x13, 0xea,
+	0x52, 0x3f, 0xf9, 0x1b, 0xb6, 0x6c, 0x74, 0x31, 0x7c, 0x8d, 0x33, 0x4e, 0xf9, 0x32, 0x23, 0x6d,
+	0x68, 0x4e, 0x53, 0xa4, 0x1c, 0x3d, 0x53, 0x13, 0x8b, 0xd9, 0xd2, 0x75, 0x31, 0xcb, 0x4c, 0x9d,
+	0x00, 0x34, 0xfe, 0xa7, 0xe1, 0x02, 0x3d, 0xb3, 0x72, 0x58, 0xfb, 0xf8, 0xde, 0xd2, 0x4f, 0xfe,
+	0x80, 0xd6, 0x94, 0x85, 0xf1, 0xd5, 0x2a, 0x41, 0xd2, 0x82, 0xda, 0x35, 0x72, 0x6a, 0x6a, 0xa4,
+	0x09, 0xd5, 0x67, 0x54, 0x14, 0x18, 0x50, 0xff, 0xcf, 0x9e, 0x8e, 0xce, 0xcc, 0x8a, 0x60, 0xd3,
+	0xc8, 0x33, 0xab, 0x45, 0xe1, 0xa7, 0x2a, 0x18, 0xe5, 0x0d, 0x12, 0x1e, 0x46, 0x09, 0x5f, 0x99,
+	0x1a, 0xe9, 0x41, 0x1b, 0x79, 0x30, 0x8f, 0x68, 0x18, 0xc7, 0xc8, 0x4d, 0x9d, 0x98, 0xd0, 0x79,
+	0x83, 0x9c, 0x96, 0xa4, 0x22, 0x14, 0x87, 0xbb, 0x25, 0xa8, 0x92, 0x1d, 0xe8, 0x25, 0x6c, 0xb1,
+	0xf2, 0x59, 0x5c, 0xc2, 0x9a, 0xb4, 0xb2, 0x8d, 0x55, 0x27, 0x04, 0xba, 0x3e, 0xc3, 0x74, 0x11,
+	0xce, 0x39, 0x66, 0x5c, 0xb0, 0x86, 0x60, 0xd1, 0x32, 0x72, 0xe8, 0x86, 0x35, 0x45, 0x37, 0x9f,
+	0xc6, 0xd4, 0x0d, 0xb0, 0x84, 0x2d, 0x21, 0x3a, 0x94, 0x39, 0xd4, 0x29, 0x99, 0xa1, 0x26, 0x28,
+	0x00, 0xe5, 0x56, 0x15, 0x69, 0xab, 0xad, 0x2a, 0xd0, 0x91, 0xcd, 0xf3, 0x4d, 0x2c, 0x98, 0x4b,
+	0x17, 0x02, 0x76, 0x95, 0x95, 0xa2, 0x2f, 0x44, 0xb3, 0x27, 0x1a, 0xf1, 0xf4, 0xd1, 0x69, 0xcc,
+	0x92, 0xa8, 0x4e, 0xdb, 0x64, 0x17, 0x4c, 0x9a, 0x3a, 0x21, 0x4f, 0x97, 0x51, 0xe9, 0x91, 0x27,
+	0x54, 0xb9, 0x3b, 0x82, 0xb2, 0x84, 0x87, 0x51, 0x98, 0x6d, 0xdc, 0xdd, 0x27, 0x54, 0xb9, 0xdf,
+	0x89, 0x49, 0x0e, 0xcd, 0xb0, 0xf4, 0xf6, 0x4a, 0xa2, 0x9c, 0xfd, 0xfc, 0x1d, 0x4e, 0xfe, 0xb9,
+	0xfe, 0xc9, 0x0f, 0x79, 0xb0, 0x74, 0xc4, 0x17, 0x75, 0x2a, 0x4e, 0xfe, 0xab, 0xfc, 0xe8, 0x64,
+	0xe8, 0xb2, 0x14, 0x8b, 0x9f, 0xe3, 0xed, 0xda, 0xd2, 0xef, 0xd6, 0x96, 0xfe, 0x65, 0x6d, 0xe9,
+	0xef, 0x1e, 0x2c, 0xed, 0xee, 0xc1, 0xd2, 0x3e, 0x3f, 0x58, 0x9a, 0xd3, 0x90, 0x7f, 0xb6, 0xdf,
+	0xbe, 0x0d, 0x00, 0xc9, 0x31, 0x46, 0x4c, 0x4c, 0x05, 0x00, 0x00,
 }
 
 func (m *PubKeySet) Marshal() (dAtA []byte, err error) {
@@ -46,6 +46,34 @@ func PolygonChain() Chain {
 	}
 }
 
+func TronChain() Chain {
+	return Chain{
+		ChainName: ChainName_tron_mainnet,
+		ChainId:   728126428,
+	}
+}
+
+func ArbitrumChain() Chain {
+	return Chain{
+		ChainName: ChainName_arbitrum_mainnet,
+		ChainId:   42161,
+	}
+}
+
+func OptimismChain() Chain {
+	return Chain{
+		ChainName: ChainName_optimism_mainnet,
+		ChainId:   10,
+	}
+}
+
+func BaseChain() Chain {
+	return Chain{
+		ChainName: ChainName_base_mainnet,
+		ChainId:   8453,
+	}
+}
+
 func DefaultChainsList() []*Chain {
 	chains := []Chain{
 		BtcMainnetChain(),
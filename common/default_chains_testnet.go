@@ -46,6 +46,34 @@ func MumbaiChain() Chain {
 	}
 }
 
+func TronNileChain() Chain {
+	return Chain{
+		ChainName: ChainName_tron_testnet,
+		ChainId:   3448148188,
+	}
+}
+
+func ArbitrumGoerliChain() Chain {
+	return Chain{
+		ChainName: ChainName_arbitrum_testnet,
+		ChainId:   421613,
+	}
+}
+
+func OptimismGoerliChain() Chain {
+	return Chain{
+		ChainName: ChainName_optimism_testnet,
+		ChainId:   420,
+	}
+}
+
+func BaseGoerliChain() Chain {
+	return Chain{
+		ChainName: ChainName_base_testnet,
+		ChainId:   84531,
+	}
+}
+
 func DefaultChainsList() []*Chain {
 	chains := []Chain{
 		BtcTestNetChain(),
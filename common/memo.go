@@ -0,0 +1,83 @@
+package common
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	eth "github.com/ethereum/go-ethereum/common"
+)
+
+// MemoMagic prefixes the versioned deposit memos; memos without it are legacy memos
+var MemoMagic = []byte("ZETA")
+
+const (
+	// MemoVersionLegacy is the memo of the deposits made before versioned memos: the receiver on zEVM followed by the
+	// payload of the call, or only a payload if shorter than an address
+	MemoVersionLegacy uint8 = 0
+
+	// MemoVersion1 is MemoMagic, the version byte, the receiver on zEVM and the payload of the call, if any
+	MemoVersion1 uint8 = 1
+)
+
+// ErrInvalidMemo is returned for the versioned memos that can't be parsed
+var ErrInvalidMemo = errors.New("invalid memo")
+
+// Memo is the memo of a deposit, the calldata of the gas tokens sent to the TSS address or the OP_RETURN of a bitcoin
+// deposit, telling zetacore where to deposit the tokens and what to call
+type Memo struct {
+	Version  uint8
+	Receiver eth.Address
+	Payload  []byte
+
+	// legacy is the legacy memo as is
+	legacy []byte
+}
+
+// ParseMemo parses a deposit memo. Legacy memos are accepted as is, versioned memos are checked strictly: a memo
+// with an unknown version or without receiver is rejected rather than depositing the tokens to the wrong address
+func ParseMemo(memo []byte) (*Memo, error) {
+	if !bytes.HasPrefix(memo, MemoMagic) {
+		parsed := &Memo{Version: MemoVersionLegacy, Payload: memo, legacy: memo}
+		if len(memo) >= eth.AddressLength {
+			parsed.Receiver = eth.BytesToAddress(memo[:eth.AddressLength])
+			parsed.Payload = memo[eth.AddressLength:]
+		}
+		return parsed, nil
+	}
+	memo = memo[len(MemoMagic):]
+	if len(memo) == 0 {
+		return nil, fmt.Errorf("%w: no version", ErrInvalidMemo)
+	}
+	version := memo[0]
+	if version != MemoVersion1 {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidMemo, version)
+	}
+	memo = memo[1:]
+	if len(memo) < eth.AddressLength {
+		return nil, fmt.Errorf("%w: receiver of %d bytes", ErrInvalidMemo, len(memo))
+	}
+	receiver := eth.BytesToAddress(memo[:eth.AddressLength])
+	if receiver == (eth.Address{}) {
+		return nil, fmt.Errorf("%w: zero receiver", ErrInvalidMemo)
+	}
+	return &Memo{Version: version, Receiver: receiver, Payload: memo[eth.AddressLength:]}, nil
+}
+
+// EncodeMemo returns the MemoVersion1 memo of the receiver and payload
+func EncodeMemo(receiver eth.Address, payload []byte) []byte {
+	memo := make([]byte, 0, len(MemoMagic)+1+eth.AddressLength+len(payload))
+	memo = append(memo, MemoMagic...)
+	memo = append(memo, MemoVersion1)
+	memo = append(memo, receiver.Bytes()...)
+	return append(memo, payload...)
+}
+
+// Bytes returns the memo in the legacy layout zetacore parses the message of the inbound votes with: the receiver
+// followed by the payload
+func (m *Memo) Bytes() []byte {
+	if m.Version == MemoVersionLegacy {
+		return m.legacy
+	}
+	return append(m.Receiver.Bytes(), m.Payload...)
+}
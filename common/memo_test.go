@@ -0,0 +1,44 @@
+package common
+
+import (
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMemo(t *testing.T) {
+	receiver := ethcommon.HexToAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	payload := []byte{0xde, 0xad, 0xbe, 0xef}
+
+	// versioned memos are translated to the legacy layout
+	memo, err := ParseMemo(EncodeMemo(receiver, payload))
+	require.NoError(t, err)
+	require.Equal(t, MemoVersion1, memo.Version)
+	require.Equal(t, receiver, memo.Receiver)
+	require.Equal(t, payload, memo.Payload)
+	require.Equal(t, append(receiver.Bytes(), payload...), memo.Bytes())
+
+	// legacy memos are kept as is
+	legacy := append(receiver.Bytes(), payload...)
+	memo, err = ParseMemo(legacy)
+	require.NoError(t, err)
+	require.Equal(t, MemoVersionLegacy, memo.Version)
+	require.Equal(t, receiver, memo.Receiver)
+	require.Equal(t, legacy, memo.Bytes())
+	memo, err = ParseMemo(payload)
+	require.NoError(t, err)
+	require.Equal(t, ethcommon.Address{}, memo.Receiver)
+	require.Equal(t, payload, memo.Bytes())
+
+	// invalid versioned memos
+	for _, invalid := range [][]byte{
+		MemoMagic,
+		append(append([]byte{}, MemoMagic...), 2),
+		append(append([]byte{}, MemoMagic...), MemoVersion1, 0x01),
+		EncodeMemo(ethcommon.Address{}, payload),
+	} {
+		_, err = ParseMemo(invalid)
+		require.ErrorIs(t, err, ErrInvalidMemo)
+	}
+}
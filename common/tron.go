@@ -0,0 +1,44 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcutil/base58"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// TronAddressPrefix is the version byte of base58 encoded tron addresses
+const TronAddressPrefix = 0x41
+
+func IsTronChain(chainID int64) bool {
+	return chainID == 728126428 || // tron mainnet
+		chainID == 3448148188 // tron nile testnet
+}
+
+// TronAddressToEVM converts a base58 (T...) or hex (0x... or 41...) tron address to the 20 bytes address used by the TVM
+func TronAddressToEVM(address string) (ethcommon.Address, error) {
+	if strings.HasPrefix(address, "T") {
+		decoded, version, err := base58.CheckDecode(address)
+		if err != nil {
+			return ethcommon.Address{}, fmt.Errorf("invalid tron address %s: %w", address, err)
+		}
+		if version != TronAddressPrefix || len(decoded) != ethcommon.AddressLength {
+			return ethcommon.Address{}, fmt.Errorf("invalid tron address %s", address)
+		}
+		return ethcommon.BytesToAddress(decoded), nil
+	}
+	hexAddress := strings.TrimPrefix(address, "0x")
+	if len(hexAddress) == 2*(ethcommon.AddressLength+1) && strings.HasPrefix(hexAddress, "41") {
+		hexAddress = hexAddress[2:]
+	}
+	if !ethcommon.IsHexAddress(hexAddress) {
+		return ethcommon.Address{}, fmt.Errorf("invalid tron address %s", address)
+	}
+	return ethcommon.HexToAddress(hexAddress), nil
+}
+
+// EVMAddressToTron returns the base58 encoding of a 20 bytes TVM address
+func EVMAddressToTron(address ethcommon.Address) string {
+	return base58.CheckEncode(address.Bytes(), TronAddressPrefix)
+}
@@ -0,0 +1,31 @@
+package common
+
+import (
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTronAddress(t *testing.T) {
+	// USDT contract on tron mainnet
+	base58Address := "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	evmAddress := ethcommon.HexToAddress("0xa614f803B6FD780986A42c78Ec9c7f77e6DeD13C")
+
+	for _, address := range []string{
+		base58Address,
+		"41a614f803b6fd780986a42c78ec9c7f77e6ded13c",
+		"0xa614f803B6FD780986A42c78Ec9c7f77e6DeD13C",
+	} {
+		converted, err := TronAddressToEVM(address)
+		require.NoError(t, err)
+		require.Equal(t, evmAddress, converted)
+	}
+	require.Equal(t, base58Address, EVMAddressToTron(evmAddress))
+
+	// bad checksum
+	_, err := TronAddressToEVM("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6u")
+	require.Error(t, err)
+	_, err = TronAddressToEVM("0x1234")
+	require.Error(t, err)
+}
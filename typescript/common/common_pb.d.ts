@@ -147,6 +147,46 @@ export declare enum ChainName {
    * @generated from enum value: btc_regtest = 15;
    */
   btc_regtest = 15,
+
+  /**
+   * @generated from enum value: tron_mainnet = 16;
+   */
+  tron_mainnet = 16,
+
+  /**
+   * @generated from enum value: tron_testnet = 17;
+   */
+  tron_testnet = 17,
+
+  /**
+   * @generated from enum value: arbitrum_mainnet = 18;
+   */
+  arbitrum_mainnet = 18,
+
+  /**
+   * @generated from enum value: arbitrum_testnet = 19;
+   */
+  arbitrum_testnet = 19,
+
+  /**
+   * @generated from enum value: optimism_mainnet = 20;
+   */
+  optimism_mainnet = 20,
+
+  /**
+   * @generated from enum value: optimism_testnet = 21;
+   */
+  optimism_testnet = 21,
+
+  /**
+   * @generated from enum value: base_mainnet = 22;
+   */
+  base_mainnet = 22,
+
+  /**
+   * @generated from enum value: base_testnet = 23;
+   */
+  base_testnet = 23,
 }
 
 /**
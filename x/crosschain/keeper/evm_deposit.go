@@ -114,9 +114,9 @@ func parseAddressAndData(message string) (ethcommon.Address, []byte, error) {
 		return ethcommon.Address{}, nil, nil
 	}
 
-	data, err := hex.DecodeString(message)
+	data, err := types.DecodeDepositMessage(message)
 	if err != nil {
-		return ethcommon.Address{}, nil, fmt.Errorf("message should be a hex encoded string: " + err.Error())
+		return ethcommon.Address{}, nil, err
 	}
 
 	if len(data) < 20 {
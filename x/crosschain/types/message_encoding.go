@@ -0,0 +1,65 @@
+package types
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/zeta-chain/zetacore/common"
+)
+
+// The message of an inbound vote is encoded according to where it goes. The messages of the deposits to zEVM, the
+// receiver followed by the calldata, are hex encoded; the messages relayed with ZETA to the onReceive of a contract
+// are base64 encoded
+
+// EncodeDepositMessage hex encodes the message of a deposit to zEVM
+func EncodeDepositMessage(message []byte) string {
+	return hex.EncodeToString(message)
+}
+
+// DecodeDepositMessage decodes the message of a deposit to zEVM
+func DecodeDepositMessage(message string) ([]byte, error) {
+	data, err := hex.DecodeString(message)
+	if err != nil {
+		return nil, fmt.Errorf("message should be a hex encoded string: %w", err)
+	}
+	return data, nil
+}
+
+// EncodeRelayedMessage base64 encodes the message relayed to the onReceive of a contract
+func EncodeRelayedMessage(message []byte) string {
+	if len(message) == 0 {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(message)
+}
+
+// DecodeRelayedMessage decodes the message relayed to the onReceive of a contract
+func DecodeRelayedMessage(message string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(message)
+	if err != nil {
+		return nil, fmt.Errorf("message should be a base64 encoded string: %w", err)
+	}
+	return data, nil
+}
+
+// IsDepositMessage returns true if the message of the vote is the message of a deposit to zEVM, false if it is relayed
+// with ZETA
+func (msg *MsgVoteOnObservedInboundTx) IsDepositMessage() bool {
+	return msg.CoinType != common.CoinType_Zeta && msg.ReceiverChain == common.ZetaChain().ChainId
+}
+
+// ValidateMessageEncoding checks that the message of the vote is encoded as its cctx expects it, so that binary
+// payloads are never relayed corrupted
+func (msg *MsgVoteOnObservedInboundTx) ValidateMessageEncoding() error {
+	if msg.Message == "" {
+		return nil
+	}
+	var err error
+	if msg.IsDepositMessage() {
+		_, err = DecodeDepositMessage(msg.Message)
+	} else {
+		_, err = DecodeRelayedMessage(msg.Message)
+	}
+	return err
+}
@@ -0,0 +1,58 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zeta-chain/zetacore/common"
+	"github.com/zeta-chain/zetacore/x/crosschain/types"
+)
+
+func TestMessageEncoding(t *testing.T) {
+	binary := make([]byte, 256)
+	for i := range binary {
+		binary[i] = byte(i)
+	}
+
+	decoded, err := types.DecodeDepositMessage(types.EncodeDepositMessage(binary))
+	require.NoError(t, err)
+	require.Equal(t, binary, decoded)
+
+	decoded, err = types.DecodeRelayedMessage(types.EncodeRelayedMessage(binary))
+	require.NoError(t, err)
+	require.Equal(t, binary, decoded)
+	require.Equal(t, "", types.EncodeRelayedMessage(nil))
+
+	_, err = types.DecodeDepositMessage("not hex")
+	require.Error(t, err)
+	_, err = types.DecodeRelayedMessage("not base64!")
+	require.Error(t, err)
+}
+
+func TestMsgVoteOnObservedInboundTx_ValidateMessageEncoding(t *testing.T) {
+	binary := []byte{0x00, 0xff, 0x10, 0x80, 0x7f}
+	deposit := types.MsgVoteOnObservedInboundTx{
+		CoinType:      common.CoinType_Gas,
+		ReceiverChain: common.ZetaChain().ChainId,
+	}
+	relay := types.MsgVoteOnObservedInboundTx{
+		CoinType:      common.CoinType_Zeta,
+		ReceiverChain: common.EthChain().ChainId,
+	}
+	require.True(t, deposit.IsDepositMessage())
+	require.False(t, relay.IsDepositMessage())
+
+	// empty messages are valid for both
+	require.NoError(t, deposit.ValidateMessageEncoding())
+	require.NoError(t, relay.ValidateMessageEncoding())
+
+	deposit.Message = types.EncodeDepositMessage(binary)
+	require.NoError(t, deposit.ValidateMessageEncoding())
+	deposit.Message = types.EncodeRelayedMessage(binary)
+	require.Error(t, deposit.ValidateMessageEncoding())
+
+	relay.Message = types.EncodeRelayedMessage(binary)
+	require.NoError(t, relay.ValidateMessageEncoding())
+	relay.Message = "0xdeadbeef"
+	require.Error(t, relay.ValidateMessageEncoding())
+}
@@ -0,0 +1,173 @@
+package zetaclient
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/zeta-chain/zetacore/x/crosschain/types"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
+	metricsPkg "github.com/zeta-chain/zetacore/zetaclient/metrics"
+)
+
+// AddressScreener screens the sender and the receiver of the inbound transfers before they are voted on, so that the
+// operators subject to sanctions compliance can plug in a screening provider, e.g. the OFAC lists or Chainalysis
+type AddressScreener interface {
+	// IsSanctioned returns whether the address of the chain is sanctioned; an error withholds the vote until the
+	// address can be screened
+	IsSanctioned(chainID int64, address string) (bool, error)
+}
+
+// NoopAddressScreener is the default screener, clearing every address
+type NoopAddressScreener struct{}
+
+func (NoopAddressScreener) IsSanctioned(int64, string) (bool, error) {
+	return false, nil
+}
+
+// ListAddressScreener screens the addresses against a list, whatever their chain
+type ListAddressScreener struct {
+	addresses map[string]struct{}
+}
+
+func NewListAddressScreener(addresses []string) *ListAddressScreener {
+	s := &ListAddressScreener{addresses: make(map[string]struct{}, len(addresses))}
+	for _, address := range addresses {
+		s.addresses[normalizeScreenedAddress(address)] = struct{}{}
+	}
+	return s
+}
+
+// LoadListAddressScreener reads the addresses of the screener from a file, one per line; blank lines and the lines
+// starting with # are ignored
+func LoadListAddressScreener(path string) (*ListAddressScreener, error) {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var addresses []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		addresses = append(addresses, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading sanctioned addresses %s: %w", path, err)
+	}
+	return NewListAddressScreener(addresses), nil
+}
+
+func (s *ListAddressScreener) IsSanctioned(_ int64, address string) (bool, error) {
+	_, found := s.addresses[normalizeScreenedAddress(address)]
+	return found, nil
+}
+
+// normalizeScreenedAddress lower cases the hex addresses, which are listed with or without their EIP-55 checksum;
+// other addresses are case sensitive
+func normalizeScreenedAddress(address string) string {
+	address = strings.TrimSpace(address)
+	if strings.HasPrefix(address, "0x") || strings.HasPrefix(address, "0X") {
+		return strings.ToLower(address)
+	}
+	return address
+}
+
+var (
+	screenerLock       sync.RWMutex
+	registeredScreener AddressScreener
+)
+
+// RegisterAddressScreener sets the screener of the observers created afterwards in place of the one of the config,
+// for the builds of zetaclientd wiring in a screening provider
+func RegisterAddressScreener(screener AddressScreener) {
+	screenerLock.Lock()
+	defer screenerLock.Unlock()
+	registeredScreener = screener
+}
+
+// NewAddressScreener returns the registered screener if any, the screener of the SanctionedAddressesPath of the
+// config if set, and the no-op screener otherwise
+func NewAddressScreener(cfg *config.Config) (AddressScreener, error) {
+	screenerLock.RLock()
+	defer screenerLock.RUnlock()
+	if registeredScreener != nil {
+		return registeredScreener, nil
+	}
+	if cfg.SanctionedAddressesPath != "" {
+		return LoadListAddressScreener(cfg.SanctionedAddressesPath)
+	}
+	return NoopAddressScreener{}, nil
+}
+
+// sanctionedInboundAddress returns the first sanctioned address among the sender, the origin and the receiver of the
+// vote, if any, and ErrScreeningFailed if they can't be screened
+func sanctionedInboundAddress(screener AddressScreener, msg *types.MsgVoteOnObservedInboundTx) (string, error) {
+	if screener == nil {
+		return "", nil
+	}
+	addresses := []struct {
+		chainID int64
+		address string
+	}{
+		{msg.SenderChainId, msg.Sender},
+		{msg.SenderChainId, msg.TxOrigin},
+		{msg.ReceiverChain, msg.Receiver},
+	}
+	for _, screened := range addresses {
+		if screened.address == "" {
+			continue
+		}
+		sanctioned, err := screener.IsSanctioned(screened.chainID, screened.address)
+		if err != nil {
+			return "", fmt.Errorf("%w: address %s of tx %s: %s", ErrScreeningFailed, screened.address, msg.InTxHash, err)
+		}
+		if sanctioned {
+			return screened.address, nil
+		}
+	}
+	return "", nil
+}
+
+// screenInbound returns ErrInboundRejected if the sender, the origin or the receiver of the vote is sanctioned, and
+// ErrScreeningFailed if they can't be screened
+func (ob *EVMChainClient) screenInbound(msg *types.MsgVoteOnObservedInboundTx) error {
+	address, err := sanctionedInboundAddress(ob.screener, msg)
+	if err != nil || address == "" {
+		return err
+	}
+	metricsPkg.SanctionedInbounds.WithLabelValues(ob.chain.Name()).Inc()
+	ob.logger.ExternalChainWatcher.Warn().Msgf("screenInbound: address %s of tx %s is sanctioned; not voting", address, msg.InTxHash)
+	ob.webhooks.Publish(WebhookEvent{
+		Type:            WebhookEventAddressSanctioned,
+		ChainID:         ob.chain.ChainId,
+		Chain:           ob.chain.Name(),
+		TxHash:          msg.InTxHash,
+		Sender:          msg.Sender,
+		Receiver:        msg.Receiver,
+		ReceiverChainID: msg.ReceiverChain,
+		CoinType:        msg.CoinType.String(),
+		Asset:           msg.Asset,
+		Amount:          msg.Amount.String(),
+		Message:         fmt.Sprintf("sanctioned address %s", address),
+	})
+	return fmt.Errorf("%w: sanctioned address %s in tx %s", ErrInboundRejected, address, msg.InTxHash)
+}
+
+// screenInbound returns ErrInboundRejected if the sender or the receiver of the vote is sanctioned, and
+// ErrScreeningFailed if they can't be screened
+func (ob *BitcoinChainClient) screenInbound(msg *types.MsgVoteOnObservedInboundTx) error {
+	address, err := sanctionedInboundAddress(ob.screener, msg)
+	if err != nil || address == "" {
+		return err
+	}
+	metricsPkg.SanctionedInbounds.WithLabelValues(ob.chain.ChainName.String()).Inc()
+	ob.logger.WatchInTx.Warn().Msgf("screenInbound: address %s of tx %s is sanctioned; not voting", address, msg.InTxHash)
+	return fmt.Errorf("%w: sanctioned address %s in tx %s", ErrInboundRejected, address, msg.InTxHash)
+}
@@ -0,0 +1,105 @@
+package zetaclient
+
+import (
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/zeta-chain/zetacore/common"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
+)
+
+type failingScreener struct{}
+
+func (failingScreener) IsSanctioned(int64, string) (bool, error) {
+	return false, errors.New("provider unavailable")
+}
+
+func TestLoadListAddressScreener(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sanctioned.txt")
+	content := "# sanctioned addresses\n\n0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed\nbc1qa5wkgaew2dkv56kfvj49j0av5nml45x9ek9hz6\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	screener, err := LoadListAddressScreener(path)
+	require.NoError(t, err)
+
+	// hex addresses are matched whatever their case
+	sanctioned, err := screener.IsSanctioned(1, "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed")
+	require.NoError(t, err)
+	require.True(t, sanctioned)
+	sanctioned, err = screener.IsSanctioned(8332, "bc1qa5wkgaew2dkv56kfvj49j0av5nml45x9ek9hz6")
+	require.NoError(t, err)
+	require.True(t, sanctioned)
+	sanctioned, err = screener.IsSanctioned(1, "0x0000000000000000000000000000000000000001")
+	require.NoError(t, err)
+	require.False(t, sanctioned)
+
+	_, err = LoadListAddressScreener(filepath.Join(t.TempDir(), "missing.txt"))
+	require.Error(t, err)
+}
+
+func TestEVMChainClient_ScreenInbound(t *testing.T) {
+	chain := common.EthChain()
+	cfg := config.NewConfig()
+	cfg.EVMChainConfigs = map[int64]*config.EVMConfig{chain.ChainId: {Chain: chain}}
+	ob := &EVMChainClient{
+		Mu:       &sync.Mutex{},
+		chain:    chain,
+		cfg:      cfg,
+		screener: NewListAddressScreener([]string{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"}),
+		logger:   EVMLog{ExternalChainWatcher: zerolog.Nop()},
+	}
+	clean := "0x0000000000000000000000000000000000000001"
+	sanctioned := "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+	msg, err := InboundEvent{Sender: clean, SenderChain: chain, TxOrigin: clean, Receiver: clean, ReceiverChain: common.ZetaChain(),
+		Amount: big.NewInt(100), InTxHash: "0x01", InBlockHeight: 100, GasLimit: 90_000, CoinType: common.CoinType_Gas}.VoteMessage("zeta1observer")
+	require.NoError(t, err)
+	require.NoError(t, ob.screenInbound(msg))
+
+	// a sanctioned sender, origin or receiver is rejected
+	msg.TxOrigin = sanctioned
+	require.ErrorIs(t, ob.screenInbound(msg), ErrInboundRejected)
+	msg.TxOrigin = clean
+	msg.Receiver = sanctioned
+	require.ErrorIs(t, ob.screenInbound(msg), ErrInboundRejected)
+
+	// the vote is withheld while the addresses can't be screened
+	ob.screener = failingScreener{}
+	require.ErrorIs(t, ob.screenInbound(msg), ErrScreeningFailed)
+
+	// nothing is screened without screener
+	ob.screener = nil
+	require.NoError(t, ob.screenInbound(msg))
+}
+
+func TestBitcoinChainClient_ScreenInbound(t *testing.T) {
+	chain := common.BtcMainnetChain()
+	ob := &BitcoinChainClient{
+		chain:    chain,
+		screener: NewListAddressScreener([]string{"bc1qa5wkgaew2dkv56kfvj49j0av5nml45x9ek9hz6"}),
+		logger:   BTCLog{WatchInTx: zerolog.Nop()},
+	}
+	msg, err := InboundEvent{Sender: "bc1qa5wkgaew2dkv56kfvj49j0av5nml45x9ek9hz6", SenderChain: chain, Receiver: "0x0000000000000000000000000000000000000001",
+		ReceiverChain: common.ZetaChain(), Amount: big.NewInt(100), InTxHash: "01", InBlockHeight: 100, GasLimit: 90_000, CoinType: common.CoinType_Gas}.VoteMessage("zeta1observer")
+	require.NoError(t, err)
+	require.ErrorIs(t, ob.screenInbound(msg), ErrInboundRejected)
+
+	ob.screener = failingScreener{}
+	require.ErrorIs(t, ob.screenInbound(msg), ErrScreeningFailed)
+}
+
+func TestNewAddressScreener(t *testing.T) {
+	screener, err := NewAddressScreener(config.NewConfig())
+	require.NoError(t, err)
+	require.Equal(t, NoopAddressScreener{}, screener)
+
+	RegisterAddressScreener(failingScreener{})
+	defer RegisterAddressScreener(nil)
+	screener, err = NewAddressScreener(config.NewConfig())
+	require.NoError(t, err)
+	require.Equal(t, failingScreener{}, screener)
+}
@@ -0,0 +1,132 @@
+package zetaclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	crosschaintypes "github.com/zeta-chain/zetacore/x/crosschain/types"
+)
+
+var (
+	// ErrHotkeyIsOperator is returned when the hotkey is the operator key, which must stay offline
+	ErrHotkeyIsOperator = errors.New("hotkey is the operator key")
+
+	// ErrMissingGrants is returned when the operator didn't grant the hotkey some of the msgs zetaclient broadcasts
+	ErrMissingGrants = errors.New("hotkey not granted by the operator")
+)
+
+// HotkeyGrants are the grants to the hotkey, checked against the msgs zetaclient broadcasts
+type HotkeyGrants struct {
+	// Missing are the msgs zetaclient broadcasts the operator didn't grant the hotkey
+	Missing []string
+
+	// Extra are the authorizations of the hotkey beyond the msgs zetaclient broadcasts, e.g. to send or stake funds,
+	// which a stolen hotkey could use
+	Extra []string
+}
+
+// CheckGrants checks the hotkey signing the txs of zetaclient is a key separate from the operator, and is granted by
+// the operator every msg zetaclient broadcasts and, with feeGrant, an allowance for the fees of its txs. The
+// authorizations of the hotkey beyond those msgs are reported as they expose the funds of their granter
+func (b *ZetaCoreBridge) CheckGrants(feeGrant bool) error {
+	granter := b.keys.GetOperatorAddress()
+	grantee := b.keys.GetAddress()
+	if granter.Equals(grantee) {
+		return fmt.Errorf("%w: %s, use a separate hotkey granted by the operator", ErrHotkeyIsOperator, grantee.String())
+	}
+
+	grants, err := b.GetGranteeGrants(grantee)
+	if err != nil {
+		return err
+	}
+	hotkeyGrants, err := checkHotkeyGrants(granter, grants, b.encodingCfg.InterfaceRegistry, time.Now())
+	if err != nil {
+		return err
+	}
+	for _, extra := range hotkeyGrants.Extra {
+		b.logger.Warn().Msgf("CheckGrants: hotkey %s is authorized %s, which a stolen hotkey could use", grantee.String(), extra)
+	}
+	if len(hotkeyGrants.Missing) > 0 {
+		return fmt.Errorf("%w %s: %s", ErrMissingGrants, granter.String(), strings.Join(hotkeyGrants.Missing, ", "))
+	}
+
+	if feeGrant {
+		client := feegrant.NewQueryClient(b.conn())
+		_, err := client.Allowance(context.Background(), &feegrant.QueryAllowanceRequest{
+			Granter: granter.String(),
+			Grantee: grantee.String(),
+		})
+		if err != nil {
+			return fmt.Errorf("no fee allowance from operator %s to hotkey %s: %w", granter.String(), grantee.String(), err)
+		}
+	}
+	return nil
+}
+
+// GetGranteeGrants returns the authz grants to the grantee
+func (b *ZetaCoreBridge) GetGranteeGrants(grantee sdk.AccAddress) ([]*authz.GrantAuthorization, error) {
+	client := authz.NewQueryClient(b.conn())
+	var grants []*authz.GrantAuthorization
+	var nextKey []byte
+	for {
+		resp, err := client.GranteeGrants(context.Background(), &authz.QueryGranteeGrantsRequest{
+			Grantee:    grantee.String(),
+			Pagination: &query.PageRequest{Key: nextKey},
+		})
+		if err != nil {
+			return nil, err
+		}
+		grants = append(grants, resp.Grants...)
+		if resp.Pagination == nil || len(resp.Pagination.NextKey) == 0 {
+			return grants, nil
+		}
+		nextKey = resp.Pagination.NextKey
+	}
+}
+
+// checkHotkeyGrants checks the grants to the hotkey unexpired at now against the msgs zetaclient broadcasts on behalf
+// of the granter
+func checkHotkeyGrants(
+	granter sdk.AccAddress,
+	grants []*authz.GrantAuthorization,
+	unpacker codectypes.AnyUnpacker,
+	now time.Time,
+) (HotkeyGrants, error) {
+	required := make(map[string]bool)
+	for _, msgType := range crosschaintypes.GetAllAuthzZetaclientTxTypes() {
+		required[msgType] = false
+	}
+
+	var hotkeyGrants HotkeyGrants
+	for _, grant := range grants {
+		if grant.Expiration != nil && !grant.Expiration.After(now) {
+			continue
+		}
+		var authorization authz.Authorization
+		if err := unpacker.UnpackAny(grant.Authorization, &authorization); err != nil {
+			return HotkeyGrants{}, fmt.Errorf("fail to unpack authorization of granter %s: %w", grant.Granter, err)
+		}
+		msgType := authorization.MsgTypeURL()
+		if _, ok := required[msgType]; ok && grant.Granter == granter.String() {
+			required[msgType] = true
+			continue
+		}
+		hotkeyGrants.Extra = append(hotkeyGrants.Extra, fmt.Sprintf("%s by %s", msgType, grant.Granter))
+	}
+	for msgType, granted := range required {
+		if !granted {
+			hotkeyGrants.Missing = append(hotkeyGrants.Missing, msgType)
+		}
+	}
+	sort.Strings(hotkeyGrants.Missing)
+	return hotkeyGrants, nil
+}
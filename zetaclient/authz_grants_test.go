@@ -0,0 +1,56 @@
+package zetaclient
+
+import (
+	"testing"
+	"time"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/zeta-chain/zetacore/app"
+	crosschaintypes "github.com/zeta-chain/zetacore/x/crosschain/types"
+)
+
+func TestCheckHotkeyGrants(t *testing.T) {
+	registry := app.MakeEncodingConfig().InterfaceRegistry
+	granter := sdk.AccAddress(crypto.AddressHash([]byte("operator")))
+	other := sdk.AccAddress(crypto.AddressHash([]byte("other")))
+	now := time.Now()
+	grant := func(granter sdk.AccAddress, authorization authz.Authorization, expiration *time.Time) *authz.GrantAuthorization {
+		authorizationAny, err := codectypes.NewAnyWithValue(authorization)
+		require.NoError(t, err)
+		return &authz.GrantAuthorization{Granter: granter.String(), Authorization: authorizationAny, Expiration: expiration}
+	}
+
+	var grants []*authz.GrantAuthorization
+	for _, msgType := range crosschaintypes.GetAllAuthzZetaclientTxTypes() {
+		grants = append(grants, grant(granter, authz.NewGenericAuthorization(msgType), nil))
+	}
+	hotkeyGrants, err := checkHotkeyGrants(granter, grants, registry, now)
+	require.NoError(t, err)
+	require.Empty(t, hotkeyGrants.Missing)
+	require.Empty(t, hotkeyGrants.Extra)
+
+	// an expired grant or one by another granter doesn't count, the authorizations to spend funds are reported
+	inboundVote := sdk.MsgTypeURL(&crosschaintypes.MsgVoteOnObservedInboundTx{})
+	expired := now.Add(-time.Hour)
+	for i, g := range grants {
+		var authorization authz.Authorization
+		require.NoError(t, registry.UnpackAny(g.Authorization, &authorization))
+		if authorization.MsgTypeURL() == inboundVote {
+			grants[i] = grant(granter, authz.NewGenericAuthorization(inboundVote), &expired)
+		}
+	}
+	grants = append(grants,
+		grant(other, authz.NewGenericAuthorization(sdk.MsgTypeURL(&crosschaintypes.MsgGasPriceVoter{})), nil),
+		grant(granter, &banktypes.SendAuthorization{SpendLimit: sdk.NewCoins(sdk.NewInt64Coin("azeta", 1))}, nil),
+	)
+	hotkeyGrants, err = checkHotkeyGrants(granter, grants, registry, now)
+	require.NoError(t, err)
+	require.Equal(t, []string{inboundVote}, hotkeyGrants.Missing)
+	require.Len(t, hotkeyGrants.Extra, 2)
+	require.Contains(t, hotkeyGrants.Extra[1], sdk.MsgTypeURL(&banktypes.MsgSend{}))
+}
@@ -0,0 +1,58 @@
+package zetaclient
+
+import (
+	"sync/atomic"
+
+	metricsPkg "github.com/zeta-chain/zetacore/zetaclient/metrics"
+)
+
+const (
+	// DefaultMaxInFlightPosts is the number of txs broadcast to zetacore at once above which the bridge is congested
+	DefaultMaxInFlightPosts = 32
+
+	// DefaultMaxOutboxBacklog is the number of txs of the outbox not included yet above which the bridge is congested
+	DefaultMaxOutboxBacklog = 500
+
+	// BackpressureSlowdown multiplies the interval of the inbound observation of the chains while the bridge is
+	// congested
+	BackpressureSlowdown = 4
+)
+
+// SetBackpressure sets the number of txs broadcast at once and of txs of the outbox not included yet above which the
+// bridge is congested, DefaultMaxInFlightPosts and DefaultMaxOutboxBacklog if zero
+func (b *ZetaCoreBridge) SetBackpressure(maxInFlight int, maxOutboxBacklog int) {
+	if maxInFlight <= 0 {
+		maxInFlight = DefaultMaxInFlightPosts
+	}
+	if maxOutboxBacklog <= 0 {
+		maxOutboxBacklog = DefaultMaxOutboxBacklog
+	}
+	b.maxInFlight = int64(maxInFlight)
+	b.maxOutboxBacklog = int64(maxOutboxBacklog)
+}
+
+// Congested returns true while zetacore is slow to take the txs of the bridge: too many txs are being broadcast at
+// once or too many txs of the outbox are waiting to be included. The chain clients stop scanning new blocks and slow
+// their polling meanwhile, rather than decoding events faster than they are voted on
+func (b *ZetaCoreBridge) Congested() bool {
+	if b.maxInFlight > 0 && atomic.LoadInt64(&b.inFlight) >= b.maxInFlight {
+		return true
+	}
+	if b.outbox != nil && b.maxOutboxBacklog > 0 {
+		backlog, err := b.outbox.Len()
+		if err != nil {
+			b.logger.Error().Err(err).Msg("Congested: error counting outbox entries")
+			return false
+		}
+		return backlog >= b.maxOutboxBacklog
+	}
+	return false
+}
+
+// trackInFlight counts a tx being broadcast until the returned func is called
+func (b *ZetaCoreBridge) trackInFlight() func() {
+	metricsPkg.InFlightPosts.Set(float64(atomic.AddInt64(&b.inFlight, 1)))
+	return func() {
+		metricsPkg.InFlightPosts.Set(float64(atomic.AddInt64(&b.inFlight, -1)))
+	}
+}
@@ -0,0 +1,38 @@
+package zetaclient
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/zeta-chain/zetacore/app"
+	"github.com/zeta-chain/zetacore/common"
+	crosschaintypes "github.com/zeta-chain/zetacore/x/crosschain/types"
+)
+
+func TestZetaCoreBridge_Congested(t *testing.T) {
+	bridge := &ZetaCoreBridge{logger: zerolog.Nop()}
+	bridge.SetBackpressure(2, 2)
+	require.False(t, bridge.Congested())
+
+	// too many txs being broadcast at once
+	done := bridge.trackInFlight()
+	require.False(t, bridge.Congested())
+	doneAgain := bridge.trackInFlight()
+	require.True(t, bridge.Congested())
+	done()
+	doneAgain()
+	require.False(t, bridge.Congested())
+
+	// too many txs of the outbox waiting to be included
+	outbox, err := OpenOutbox(t.TempDir(), app.MakeEncodingConfig().Codec)
+	require.NoError(t, err)
+	bridge.outbox = outbox
+	first, err := outbox.Append(PostGasPriceGasLimit, &crosschaintypes.MsgGasPriceVoter{Creator: "creator", ChainId: 1, Price: 10}, common.ZetaClientGranteeKey)
+	require.NoError(t, err)
+	_, err = outbox.Append(PostGasPriceGasLimit, &crosschaintypes.MsgGasPriceVoter{Creator: "creator", ChainId: 56, Price: 10}, common.ZetaClientGranteeKey)
+	require.NoError(t, err)
+	require.True(t, bridge.Congested())
+	require.NoError(t, outbox.Record(first, "AA", true))
+	require.False(t, bridge.Congested())
+}
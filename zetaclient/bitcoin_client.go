@@ -12,7 +12,6 @@ import (
 	"sync"
 	"sync/atomic"
 
-	cosmosmath "cosmossdk.io/math"
 	"github.com/btcsuite/btcd/btcjson"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/rpcclient"
@@ -63,10 +62,13 @@ type BitcoinChainClient struct {
 	utxos             []btcjson.ListUnspentResult
 	params            observertypes.CoreParams
 
-	db     *gorm.DB
-	stop   chan struct{}
-	logger BTCLog
-	ts     *TelemetryServer
+	db       *gorm.DB
+	stop     chan struct{}
+	stopOnce sync.Once
+	watchers *WatcherGroup
+	logger   BTCLog
+	ts       *TelemetryServer
+	screener AddressScreener
 
 	BlockCache *lru.Cache
 }
@@ -126,6 +128,7 @@ func NewBitcoinClient(
 	dbpath string,
 	metrics *metricsPkg.Metrics,
 	logger zerolog.Logger,
+	cfg *config.Config,
 	btcCfg config.BTCConfig,
 	ts *TelemetryServer,
 ) (*BitcoinChainClient, error) {
@@ -144,6 +147,7 @@ func NewBitcoinClient(
 		WatchUTXOS:    chainLogger.With().Str("module", "WatchUTXOS").Logger(),
 		WatchGasPrice: chainLogger.With().Str("module", "WatchGasPrice").Logger(),
 	}
+	ob.watchers = NewWatcherGroup(chain.ChainName.String(), ob.stop, chainLogger.With().Str("module", "WatcherGroup").Logger())
 
 	ob.zetaClient = bridge
 	ob.Tss = tss
@@ -151,6 +155,12 @@ func NewBitcoinClient(
 	ob.includedTxResults = make(map[string]btcjson.GetTransactionResult)
 	ob.broadcastedTx = make(map[string]string)
 	ob.params = btcCfg.CoreParams
+	screener, err := NewAddressScreener(cfg)
+	if err != nil {
+		ob.logger.ChainLogger.Error().Err(err).Msg("failed to create the address screener")
+		return nil, err
+	}
+	ob.screener = screener
 
 	// initialize the Client
 	ob.logger.ChainLogger.Info().Msgf("Chain %s endpoint %s", ob.chain.String(), btcCfg.RPCHost)
@@ -194,16 +204,41 @@ func NewBitcoinClient(
 
 func (ob *BitcoinChainClient) Start() {
 	ob.logger.ChainLogger.Info().Msgf("BitcoinChainClient is starting")
-	go ob.WatchInTx()
-	go ob.observeOutTx()
-	go ob.WatchUTXOS()
-	go ob.WatchGasPrice()
-	go ob.ExternalChainWatcherForNewInboundTrackerSuggestions()
+	ob.watchers.Go("WatchInTx", ob.WatchInTx)
+	ob.watchers.Go("ObserveOutTx", ob.observeOutTx)
+	ob.watchers.Go("WatchUTXOS", ob.WatchUTXOS)
+	ob.watchers.Go("WatchGasPrice", ob.WatchGasPrice)
+	ob.watchers.Go("InboundTrackerSuggestions", ob.ExternalChainWatcherForNewInboundTrackerSuggestions)
 }
 
+// GetWatcherStatus returns the status of the goroutines of the chain client
+func (ob *BitcoinChainClient) GetWatcherStatus() []clienttypes.WatcherStatus {
+	return ob.watchers.Status()
+}
+
+// Stop notifies all goroutines to stop, waits for them to exit and then flushes state to db
+// It is safe to call Stop() more than once
 func (ob *BitcoinChainClient) Stop() {
+	ob.stopOnce.Do(ob.stopWatchers)
+}
+
+func (ob *BitcoinChainClient) stopWatchers() {
 	ob.logger.ChainLogger.Info().Msgf("ob %s is stopping", ob.chain.String())
 	close(ob.stop) // this notifies all goroutines to stop
+	ob.watchers.Wait()
+
+	// flush last scanned block so that the observer resumes from it after restart
+	if err := ob.db.Save(clienttypes.ToLastBlockSQLType(ob.GetLastBlockHeightScanned())).Error; err != nil {
+		ob.logger.ChainLogger.Error().Err(err).Msg("error writing last scanned block to db")
+	}
+
+	ob.logger.ChainLogger.Info().Msg("closing ob.db")
+	dbInst, err := ob.db.DB()
+	if err != nil {
+		ob.logger.ChainLogger.Info().Msg("error getting database instance")
+	} else if err = dbInst.Close(); err != nil {
+		ob.logger.ChainLogger.Error().Err(err).Msg("error closing database")
+	}
 	ob.logger.ChainLogger.Info().Msgf("%s observer stopped", ob.chain.String())
 }
 
@@ -376,8 +411,25 @@ func (ob *BitcoinChainClient) observeInTx() error {
 		inTxs := FilterAndParseIncomingTx(res.Block.Tx, uint64(res.Block.Height), tssAddress, &ob.logger.WatchInTx)
 
 		for _, inTx := range inTxs {
-			msg := ob.GetInboundVoteMessageFromBtcEvent(inTx)
+			msg, err := ob.GetInboundVoteMessageFromBtcEvent(inTx)
+			if err != nil {
+				ob.logger.WatchInTx.Error().Err(err).Msgf("error building vote on inTx %s", inTx.TxHash)
+				continue
+			}
+			if err := ob.screenInbound(msg); err != nil {
+				// never vote on a deposit that couldn't be screened; the block is scanned again
+				if errors.Is(err, ErrScreeningFailed) {
+					ob.logger.WatchInTx.Error().Err(err).Msgf("error screening inTx %s, scanning block %d again", inTx.TxHash, bn)
+					return err
+				}
+				continue
+			}
 			zetaHash, err := ob.zetaClient.PostSend(PostSendEVMGasLimit, msg)
+			if errors.Is(err, ErrPostRateLimited) {
+				// the block is scanned again once under the rate, its votes already posted are skipped then
+				ob.logger.WatchInTx.Warn().Err(err).Msgf("post of inTx %s rate limited, scanning block %d again", inTx.TxHash, bn)
+				return nil
+			}
 			if err != nil {
 				ob.logger.WatchInTx.Error().Err(err).Msg("error posting to zeta core")
 				continue
@@ -475,20 +527,18 @@ func (ob *BitcoinChainClient) IsSendOutTxProcessed(sendHash string, nonce uint64
 	}
 
 	logger.Debug().Msgf("Bitcoin outTx confirmed: txid %s, amount %s\n", res.TxID, amountInSat.String())
-	zetaHash, err := ob.zetaClient.PostReceiveConfirmation(
-		sendHash,
-		res.TxID,
+	// gas used, price and limit are not used with Bitcoin
+	zetaHash, err := ob.zetaClient.PostReceiveConfirmation(OutboundConfirmation{
+		SendHash:  sendHash,
+		OutTxHash: res.TxID,
 		// #nosec G701 always positive
-		uint64(res.BlockIndex),
-		0,   // gas used not used with Bitcoin
-		nil, // gas price not used with Bitcoin
-		0,   // gas limit not used with Bitcoin
-		amountInSat,
-		common.ReceiveStatus_Success,
-		ob.chain,
-		nonce,
-		common.CoinType_Gas,
-	)
+		OutBlockHeight: uint64(res.BlockIndex),
+		Amount:         amountInSat,
+		Status:         common.ReceiveStatus_Success,
+		Chain:          ob.chain,
+		Nonce:          nonce,
+		CoinType:       common.CoinType_Gas,
+	})
 	if err != nil {
 		logger.Error().Err(err).Msgf("error posting to zeta core")
 	} else {
@@ -588,28 +638,24 @@ func FilterAndParseIncomingTx(txs []btcjson.TxRawResult, blockNumber uint64, tar
 	return inTxs
 }
 
-func (ob *BitcoinChainClient) GetInboundVoteMessageFromBtcEvent(inTx *BTCInTxEvnet) *types.MsgVoteOnObservedInboundTx {
+func (ob *BitcoinChainClient) GetInboundVoteMessageFromBtcEvent(inTx *BTCInTxEvnet) (*types.MsgVoteOnObservedInboundTx, error) {
 	ob.logger.WatchInTx.Debug().Msgf("Processing inTx: %s", inTx.TxHash)
 	amount := big.NewFloat(inTx.Value)
 	amount = amount.Mul(amount, big.NewFloat(1e8))
 	amountInt, _ := amount.Int(nil)
-	message := hex.EncodeToString(inTx.MemoBytes)
-	return GetInBoundVoteMessage(
-		inTx.FromAddress,
-		ob.chain.ChainId,
-		inTx.FromAddress,
-		inTx.FromAddress,
-		common.ZetaChain().ChainId,
-		cosmosmath.NewUintFromBigInt(amountInt),
-		message,
-		inTx.TxHash,
-		inTx.BlockNumber,
-		0,
-		common.CoinType_Gas,
-		"",
-		ob.zetaClient.GetKeys().GetOperatorAddress().String(),
-		0,
-	)
+	message := types.EncodeDepositMessage(inTx.MemoBytes)
+	return InboundEvent{
+		Sender:        inTx.FromAddress,
+		SenderChain:   ob.chain,
+		TxOrigin:      inTx.FromAddress,
+		Receiver:      inTx.FromAddress,
+		ReceiverChain: common.ZetaChain(),
+		Amount:        amountInt,
+		Message:       message,
+		InTxHash:      inTx.TxHash,
+		InBlockHeight: inTx.BlockNumber,
+		CoinType:      common.CoinType_Gas,
+	}.VoteMessage(ob.zetaClient.GetKeys().GetOperatorAddress().String())
 }
 
 func GetBtcEvent(tx btcjson.TxRawResult, targetAddress string, blockNumber uint64, logger *zerolog.Logger) (*BTCInTxEvnet, error) {
@@ -652,14 +698,19 @@ func GetBtcEvent(tx btcjson.TxRawResult, targetAddress string, blockNumber uint6
 					logger.Info().Msgf("donation tx: %s; value %f", tx.Txid, value)
 					return nil, fmt.Errorf("donation tx: %s; value %f", tx.Txid, value)
 				}
-				memo = memoBytes
+				parsed, err := common.ParseMemo(memoBytes)
+				if err != nil {
+					logger.Warn().Err(err).Msgf("invalid memo in tx %s; value %f", tx.Txid, value)
+					return nil, err
+				}
+				memo = parsed.Bytes()
 				found = true
 			}
 		}
 
 	}
 	if found {
-		fmt.Println("found tx: ", tx.Txid)
+		logger.Info().Msgf("found inbound tx %s to %s; value %f", tx.Txid, targetAddress, value)
 		var fromAddress string
 		if len(tx.Vin) > 0 {
 			vin := tx.Vin[0]
@@ -0,0 +1,67 @@
+package zetaclient
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcutil"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/zeta-chain/zetacore/common"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
+)
+
+// makeBtcInboundTx returns a tx paying value to the p2wpkh address of pubkeyHash followed by an OP_RETURN memo
+func makeBtcInboundTx(pubkeyHash []byte, value float64, memo []byte) btcjson.TxRawResult {
+	return btcjson.TxRawResult{
+		Txid: "inbound",
+		Vout: []btcjson.Vout{
+			{Value: value, ScriptPubKey: btcjson.ScriptPubKeyResult{Hex: "0014" + hex.EncodeToString(pubkeyHash)}},
+			{ScriptPubKey: btcjson.ScriptPubKeyResult{Hex: fmt.Sprintf("6a%02x%s", len(memo), hex.EncodeToString(memo))}},
+		},
+	}
+}
+
+func TestGetBtcEvent(t *testing.T) {
+	logger := zerolog.Nop()
+	pubkeyHash := make([]byte, 20)
+	pubkeyHash[0] = 1
+	tssAddress, err := btcutil.NewAddressWitnessPubKeyHash(pubkeyHash, config.BitconNetParams)
+	require.NoError(t, err)
+	memo := []byte("0x1234567890123456789012345678901234567890")
+
+	event, err := GetBtcEvent(makeBtcInboundTx(pubkeyHash, 0.5, memo), tssAddress.EncodeAddress(), 100, &logger)
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	require.Equal(t, tssAddress.EncodeAddress(), event.ToAddress)
+	require.Equal(t, 0.5, event.Value)
+	require.Equal(t, memo, event.MemoBytes)
+	require.Equal(t, uint64(100), event.BlockNumber)
+
+	// payment to another address
+	otherHash := make([]byte, 20)
+	event, err = GetBtcEvent(makeBtcInboundTx(otherHash, 0.5, memo), tssAddress.EncodeAddress(), 100, &logger)
+	require.NoError(t, err)
+	require.Nil(t, event)
+
+	// memo size does not match the OP_RETURN push
+	tx := makeBtcInboundTx(pubkeyHash, 0.5, memo)
+	tx.Vout[1].ScriptPubKey.Hex = "6a01" + hex.EncodeToString(memo)
+	_, err = GetBtcEvent(tx, tssAddress.EncodeAddress(), 100, &logger)
+	require.Error(t, err)
+
+	// versioned memos are relayed in the legacy layout, and rejected if invalid
+	receiver := ethcommon.HexToAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	event, err = GetBtcEvent(makeBtcInboundTx(pubkeyHash, 0.5, common.EncodeMemo(receiver, []byte{0x01})), tssAddress.EncodeAddress(), 100, &logger)
+	require.NoError(t, err)
+	require.Equal(t, append(receiver.Bytes(), 0x01), event.MemoBytes)
+	_, err = GetBtcEvent(makeBtcInboundTx(pubkeyHash, 0.5, common.EncodeMemo(ethcommon.Address{}, nil)), tssAddress.EncodeAddress(), 100, &logger)
+	require.ErrorIs(t, err, common.ErrInvalidMemo)
+
+	// donations are not deposits
+	_, err = GetBtcEvent(makeBtcInboundTx(pubkeyHash, 0.5, []byte(DonationMessage)), tssAddress.EncodeAddress(), 100, &logger)
+	require.Error(t, err)
+}
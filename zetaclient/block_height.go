@@ -10,7 +10,7 @@ import (
 // GetBlockHeight returns the current height for metachain blocks
 // FIXME: deprecate this in favor of tendermint RPC?
 func (b *ZetaCoreBridge) GetBlockHeight() (int64, error) {
-	client := types.NewQueryClient(b.grpcConn)
+	client := types.NewQueryClient(b.conn())
 	height, err := client.LastZetaHeight(
 		context.Background(),
 		&types.QueryLastZetaHeightRequest{},
@@ -0,0 +1,71 @@
+package zetaclient
+
+import (
+	"math"
+	"sync"
+)
+
+// BlockTimeWindow is the number of header samples over which the block time of a chain is averaged
+const BlockTimeWindow = 20
+
+type blockTimeSample struct {
+	number    uint64
+	timestamp uint64
+}
+
+// BlockTimeEstimator measures the average block time of a chain from the headers seen by the observer
+type BlockTimeEstimator struct {
+	mu      sync.Mutex
+	samples []blockTimeSample
+}
+
+func NewBlockTimeEstimator() *BlockTimeEstimator {
+	return &BlockTimeEstimator{}
+}
+
+// Observe records the timestamp of a block; samples of blocks not above the last one are ignored
+func (e *BlockTimeEstimator) Observe(number uint64, timestamp uint64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.samples) > 0 {
+		last := e.samples[len(e.samples)-1]
+		if number <= last.number {
+			if number < last.number { // reorg or rpc endpoint behind; start over
+				e.samples = e.samples[:0]
+			} else {
+				return
+			}
+		}
+	}
+	e.samples = append(e.samples, blockTimeSample{number: number, timestamp: timestamp})
+	if len(e.samples) > BlockTimeWindow {
+		e.samples = e.samples[len(e.samples)-BlockTimeWindow:]
+	}
+}
+
+// BlockTime returns the average block time in seconds over the recorded samples, false if not enough blocks were seen
+func (e *BlockTimeEstimator) BlockTime() (float64, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.samples) < 2 {
+		return 0, false
+	}
+	first, last := e.samples[0], e.samples[len(e.samples)-1]
+	if last.timestamp < first.timestamp {
+		return 0, false
+	}
+	return float64(last.timestamp-first.timestamp) / float64(last.number-first.number), true
+}
+
+// TickerInterval returns the observed block time rounded to whole seconds, at least 1 and at most maxInterval if set
+func (e *BlockTimeEstimator) TickerInterval(maxInterval uint64) (uint64, bool) {
+	blockTime, ok := e.BlockTime()
+	if !ok {
+		return 0, false
+	}
+	interval := uint64(math.Max(1, math.Round(blockTime)))
+	if maxInterval > 0 && interval > maxInterval {
+		interval = maxInterval
+	}
+	return interval, true
+}
@@ -0,0 +1,42 @@
+package zetaclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockTimeEstimator(t *testing.T) {
+	e := NewBlockTimeEstimator()
+	_, ok := e.BlockTime()
+	require.False(t, ok)
+
+	// polygon-like blocks of 2s slowing down to 3s under congestion
+	e.Observe(100, 1000)
+	e.Observe(100, 1000) // same block seen again
+	e.Observe(105, 1010)
+	blockTime, ok := e.BlockTime()
+	require.True(t, ok)
+	require.Equal(t, 2.0, blockTime)
+	e.Observe(110, 1025)
+	blockTime, _ = e.BlockTime()
+	require.Equal(t, 2.5, blockTime)
+
+	interval, ok := e.TickerInterval(0)
+	require.True(t, ok)
+	require.Equal(t, uint64(3), interval)
+	interval, _ = e.TickerInterval(2)
+	require.Equal(t, uint64(2), interval)
+
+	// older samples leave the window
+	for i := uint64(1); i <= BlockTimeWindow; i++ {
+		e.Observe(110+i, 1025+12*i)
+	}
+	blockTime, _ = e.BlockTime()
+	require.Equal(t, 12.0, blockTime)
+
+	// a lower block, e.g. from an endpoint behind, resets the samples
+	e.Observe(50, 500)
+	_, ok = e.BlockTime()
+	require.False(t, ok)
+}
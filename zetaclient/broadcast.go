@@ -1,10 +1,11 @@
 package zetaclient
 
 import (
+	"encoding/hex"
+	"errors"
 	"fmt"
-	"regexp"
-	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	clienttx "github.com/cosmos/cosmos-sdk/client/tx"
@@ -13,100 +14,176 @@ import (
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	flag "github.com/spf13/pflag"
 	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
+	metricsPkg "github.com/zeta-chain/zetacore/zetaclient/metrics"
 )
 
+// InclusionPollInterval is the interval the inclusion of a broadcast tx is polled at
+const InclusionPollInterval = time.Second
+
 // Broadcast Broadcasts tx to metachain. Returns txHash and error
+// The tx is written ahead to the outbox, if enabled, so that it is broadcast again until it is included
 func (b *ZetaCoreBridge) Broadcast(gaslimit uint64, authzWrappedMsg sdktypes.Msg, authzSigner AuthZSigner) (string, error) {
-	gaslimit = gaslimit * 3
-	b.broadcastLock.Lock()
-	defer b.broadcastLock.Unlock()
-	var err error
-
-	blockHeight, err := b.GetZetaBlockHeight()
+	defer b.trackInFlight()()
+	if b.outbox == nil {
+		txHash, _, err := b.broadcast(gaslimit, authzWrappedMsg, authzSigner)
+		return txHash, err
+	}
+	entry, err := b.outbox.Append(gaslimit, authzWrappedMsg, authzSigner.KeyType)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("fail to write tx to outbox: %w", err)
 	}
-
-	if blockHeight > b.blockHeight {
-		b.blockHeight = blockHeight
-		accountNumber, seqNumber, err := b.GetAccountNumberAndSequenceNumber(authzSigner.KeyType)
-		if err != nil {
-			return "", err
-		}
-		b.accountNumber[authzSigner.KeyType] = accountNumber
-		if b.seqNumber[authzSigner.KeyType] < seqNumber {
-			b.seqNumber[authzSigner.KeyType] = seqNumber
-		}
+	txHash, included, err := b.broadcast(gaslimit, authzWrappedMsg, authzSigner)
+	if recordErr := b.outbox.Record(entry, txHash, included); recordErr != nil {
+		b.logger.Error().Err(recordErr).Msgf("fail to write outbox entry %s", entry.Key)
+	} else if included {
+		metricsPkg.OutboxEntries.WithLabelValues(metricsPkg.OutboxCompleted).Inc()
 	}
-	//b.logger.Info().Uint64("account_number", b.accountNumber).Uint64("sequence_number", b.seqNumber).Msg("account info")
+	return txHash, err
+}
 
+// broadcast signs and broadcasts the tx and returns its hash and whether it is known to be included, i.e. broadcast
+// in block mode or awaited until included
+func (b *ZetaCoreBridge) broadcast(gaslimit uint64, authzWrappedMsg sdktypes.Msg, authzSigner AuthZSigner) (txHash string, included bool, err error) {
+	defer func(begin time.Time) {
+		observeCoreCall(broadcastMethod(authzWrappedMsg), begin, err)
+	}(time.Now())
+	if err := b.breaker.Allow(); err != nil {
+		return "", false, err
+	}
+	gaslimit = b.fees.GasLimit(gaslimit)
 	flags := flag.NewFlagSet("zetacore", 0)
 
 	ctx, err := b.GetContext()
 	if err != nil {
-		return "", err
+		return "", false, err
 	}
-	factory := clienttx.NewFactoryCLI(ctx, flags)
-	factory = factory.WithAccountNumber(b.accountNumber[authzSigner.KeyType])
-	factory = factory.WithSequence(b.seqNumber[authzSigner.KeyType])
-	factory = factory.WithSignMode(signing.SignMode_SIGN_MODE_DIRECT)
-	builder, err := factory.BuildUnsignedTx(authzWrappedMsg)
+	err = b.sequences.Use(authzSigner.KeyType, func(accountNumber, sequence uint64) error {
+		factory := clienttx.NewFactoryCLI(ctx, flags)
+		factory = factory.WithAccountNumber(accountNumber)
+		factory = factory.WithSequence(sequence)
+		factory = factory.WithSignMode(signing.SignMode_SIGN_MODE_DIRECT)
+		builder, err := factory.BuildUnsignedTx(authzWrappedMsg)
+		if err != nil {
+			return err
+		}
+		builder.SetGasLimit(gaslimit)
+		builder.SetFeeAmount(b.fees.Fee(gaslimit))
+		builder.SetMemo(NewTxMemo(authzWrappedMsg).String())
+		if b.feeGranter != nil {
+			builder.SetFeeGranter(b.feeGranter)
+		}
+		if b.simulate {
+			if err := b.simulateTx(ctx, builder, sequence); err != nil {
+				return err
+			}
+		}
+		err = clienttx.Sign(factory, ctx.GetFromName(), builder, true)
+		if err != nil {
+			return err
+		}
+
+		txBytes, err := ctx.TxConfig.TxEncoder()(builder.GetTx())
+		if err != nil {
+			return err
+		}
+
+		// broadcast to a Tendermint node
+		commit, err := b.broadcastTx(ctx, txBytes)
+		b.breaker.Record(err)
+		if err != nil {
+			b.logger.Error().Err(err).Msgf("fail to broadcast tx %s", err.Error())
+			return err
+		}
+		txHash = commit.TxHash
+		// Code will be the tendermint ABICode , it start at 1 , so if it is an error , code will not be zero
+		if commit.Code > 0 {
+			return &txResultError{
+				codespace: commit.Codespace,
+				code:      commit.Code,
+				msg:       fmt.Sprintf("fail to broadcast to zetachain,code:%d, log:%s", commit.Code, commit.RawLog),
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		return "", err
+		return txHash, false, err
 	}
-	builder.SetGasLimit(gaslimit)
-	fee := sdktypes.NewCoins(sdktypes.NewCoin("azeta", sdktypes.NewInt(40000)))
-	builder.SetFeeAmount(fee)
-	//fmt.Printf("signing from name: %s\n", ctx.GetFromName())
-	err = clienttx.Sign(factory, ctx.GetFromName(), builder, true)
-	if err != nil {
-		return "", err
+	if b.broadcastMode == config.BroadcastModeBlock {
+		return txHash, true, nil
+	}
+	if b.inclusionTimeout == 0 {
+		return txHash, false, nil
+	}
+	if err := b.awaitInclusion(ctx, txHash); err != nil {
+		// a tx failed by zetacore was still included, the other errors tell zetacore isn't producing blocks
+		var txErr *txResultError
+		if !errors.As(err, &txErr) {
+			b.breaker.Record(err)
+		}
+		return txHash, false, err
 	}
+	return txHash, true, nil
+}
 
-	txBytes, err := ctx.TxConfig.TxEncoder()(builder.GetTx())
-	if err != nil {
-		return "", err
+// SetBroadcastMode sets the mode the txs are broadcast with and, if inclusionTimeout is set, how long a tx broadcast
+// in sync or async mode is awaited until it is included in a block
+func (b *ZetaCoreBridge) SetBroadcastMode(mode string, inclusionTimeout time.Duration) {
+	b.broadcastMode = mode
+	b.inclusionTimeout = inclusionTimeout
+}
+
+// SetTxFees sets the gas adjustment and the fee of the txs
+func (b *ZetaCoreBridge) SetTxFees(fees config.TxFees) {
+	b.fees = fees
+}
+
+// SetFeeGranter sets the account paying the fees of the txs through its fee allowance to the hotkey
+func (b *ZetaCoreBridge) SetFeeGranter(granter sdktypes.AccAddress) {
+	b.feeGranter = granter
+}
+
+// broadcastTx broadcasts the tx in the broadcast mode of the bridge
+func (b *ZetaCoreBridge) broadcastTx(ctx client.Context, txBytes []byte) (*sdktypes.TxResponse, error) {
+	switch b.broadcastMode {
+	case config.BroadcastModeAsync:
+		return ctx.BroadcastTxAsync(txBytes)
+	case config.BroadcastModeBlock:
+		return ctx.BroadcastTxCommit(txBytes)
+	default:
+		return ctx.BroadcastTxSync(txBytes)
 	}
+}
 
-	// broadcast to a Tendermint node
-	commit, err := ctx.BroadcastTxSync(txBytes)
+// awaitInclusion waits until the tx is included in a block, for at most the inclusion timeout of the bridge, and
+// returns an error if it failed or wasn't included in time
+func (b *ZetaCoreBridge) awaitInclusion(ctx client.Context, txHash string) error {
+	hash, err := hex.DecodeString(txHash)
 	if err != nil {
-		b.logger.Error().Err(err).Msgf("fail to broadcast tx %s", err.Error())
-		return "", err
-	}
-	// Code will be the tendermint ABICode , it start at 1 , so if it is an error , code will not be zero
-	if commit.Code > 0 {
-		if commit.Code == 32 {
-			errMsg := commit.RawLog
-			re := regexp.MustCompile(`account sequence mismatch, expected ([0-9]*), got ([0-9]*)`)
-			matches := re.FindStringSubmatch(errMsg)
-			if len(matches) != 3 {
-				return "", err
-			}
-			expectedSeq, err := strconv.ParseUint(matches[1], 10, 64)
-			if err != nil {
-				b.logger.Warn().Msgf("cannot parse expected seq %s", matches[1])
-				return "", err
-			}
-			gotSeq, err := strconv.Atoi(matches[2])
-			if err != nil {
-				b.logger.Warn().Msgf("cannot parse got seq %s", matches[2])
-				return "", err
+		return err
+	}
+	timeout := time.NewTimer(b.inclusionTimeout)
+	defer timeout.Stop()
+	for {
+		res, err := ctx.Client.Tx(b.ctx, hash, false)
+		if err == nil {
+			if res.TxResult.Code > 0 {
+				return &txResultError{
+					codespace: res.TxResult.Codespace,
+					code:      res.TxResult.Code,
+					msg:       fmt.Sprintf("zeta tx %s failed,code:%d, log:%s", txHash, res.TxResult.Code, res.TxResult.Log),
+				}
 			}
-			b.seqNumber[authzSigner.KeyType] = expectedSeq
-			b.logger.Warn().Msgf("Reset seq number to %d (from err msg) from %d", b.seqNumber[authzSigner.KeyType], gotSeq)
+			return nil
+		}
+		select {
+		case <-b.ctx.Done():
+			return b.ctx.Err()
+		case <-timeout.C:
+			return fmt.Errorf("zeta tx %s not included after %s: %w", txHash, b.inclusionTimeout, err)
+		case <-time.After(InclusionPollInterval):
 		}
-		return commit.TxHash, fmt.Errorf("fail to broadcast to zetachain,code:%d, log:%s", commit.Code, commit.RawLog)
 	}
-	//b.logger.Debug().Msgf("Received a TxHash of %v from the metachain, Code %d, log %s", commit.TxHash, commit.Code, commit.Logs)
-
-	// increment seqNum
-	//seq := b.seqNumber[authzSigner.KeyType]
-	//atomic.AddUint64(&seq, 1)
-	b.seqNumber[authzSigner.KeyType] = b.seqNumber[authzSigner.KeyType] + 1
-	//b.logger.Debug().Msgf("b.sequence number increased to %d", b.seqNumber)
-
-	return commit.TxHash, nil
 }
 
 // GetContext return a valid context with all relevant values set
@@ -132,7 +209,11 @@ func (b *ZetaCoreBridge) GetContext() (client.Context, error) {
 	ctx = ctx.WithHomeDir(b.cfg.ChainHomeFolder)
 	ctx = ctx.WithFromName(b.cfg.SignerName)
 	ctx = ctx.WithFromAddress(addr)
-	ctx = ctx.WithBroadcastMode("sync")
+	broadcastMode := config.BroadcastModeSync
+	if b.broadcastMode != "" {
+		broadcastMode = b.broadcastMode
+	}
+	ctx = ctx.WithBroadcastMode(broadcastMode)
 
 	ctx = ctx.WithCodec(b.encodingCfg.Codec)
 	ctx = ctx.WithInterfaceRegistry(b.encodingCfg.InterfaceRegistry)
@@ -140,7 +221,7 @@ func (b *ZetaCoreBridge) GetContext() (client.Context, error) {
 	ctx = ctx.WithLegacyAmino(b.encodingCfg.Amino)
 	ctx = ctx.WithAccountRetriever(authtypes.AccountRetriever{})
 
-	remote := b.cfg.ChainRPC
+	remote := b.chainRPC()
 	if !strings.HasPrefix(b.cfg.ChainHost, "http") {
 		remote = fmt.Sprintf("tcp://%s", remote)
 	}
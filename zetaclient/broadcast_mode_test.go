@@ -0,0 +1,43 @@
+package zetaclient
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+// txClient serves the txs included in a block
+type txClient struct {
+	rpcclient.Client
+	included map[string]abci.ResponseDeliverTx
+}
+
+func (c *txClient) Tx(_ context.Context, hash []byte, _ bool) (*ctypes.ResultTx, error) {
+	result, found := c.included[strings.ToUpper(hex.EncodeToString(hash))]
+	if !found {
+		return nil, errors.New("tx not found")
+	}
+	return &ctypes.ResultTx{Hash: hash, TxResult: result}, nil
+}
+
+func TestZetaCoreBridge_AwaitInclusion(t *testing.T) {
+	ctx := client.Context{}.WithClient(&txClient{included: map[string]abci.ResponseDeliverTx{
+		"AA": {Code: 0},
+		"BB": {Code: 5, Log: "insufficient funds"},
+	}})
+	bridge := &ZetaCoreBridge{ctx: context.Background()}
+	bridge.SetBroadcastMode("sync", 10*time.Millisecond)
+
+	require.NoError(t, bridge.awaitInclusion(ctx, "AA"))
+	require.ErrorContains(t, bridge.awaitInclusion(ctx, "BB"), "insufficient funds")
+	require.ErrorContains(t, bridge.awaitInclusion(ctx, "CC"), "not included")
+}
@@ -0,0 +1,134 @@
+package zetaclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	metricsPkg "github.com/zeta-chain/zetacore/zetaclient/metrics"
+)
+
+var (
+	// ErrCoreUnavailable is returned by the posts to zetacore while the circuit breaker is open
+	ErrCoreUnavailable = errors.New("zetacore unavailable, circuit breaker open")
+
+	// CoreBreakerThreshold is the number of consecutive failed broadcasts to zetacore that trips the circuit breaker
+	CoreBreakerThreshold = 5
+
+	// CoreBreakerProbeInterval is the interval zetacore is probed at while the circuit breaker is open
+	CoreBreakerProbeInterval = 15 * time.Second
+)
+
+// CircuitBreaker stops the posts to zetacore once enough of them failed in a row, so that the event handlers fail
+// fast and buffer their events locally rather than block on retries bound to fail. While open, zetacore is probed
+// periodically and the posts resume once a probe succeeds
+type CircuitBreaker struct {
+	threshold     int
+	probeInterval time.Duration
+	probe         func() error
+	logger        zerolog.Logger
+
+	mu       sync.Mutex
+	failures int
+	open     bool
+	openedAt time.Time
+}
+
+// NewCircuitBreaker creates a closed circuit breaker tripping after threshold consecutive failures
+func NewCircuitBreaker(threshold int, probeInterval time.Duration, probe func() error, logger zerolog.Logger) *CircuitBreaker {
+	return &CircuitBreaker{
+		threshold:     threshold,
+		probeInterval: probeInterval,
+		probe:         probe,
+		logger:        logger,
+	}
+}
+
+// Allow returns ErrCoreUnavailable while the breaker is open; a nil breaker always allows
+func (c *CircuitBreaker) Allow() error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.open {
+		return ErrCoreUnavailable
+	}
+	return nil
+}
+
+// Record counts the outcome of a call to zetacore: a success resets the failures, a failure trips the breaker once
+// the threshold is reached
+func (c *CircuitBreaker) Record(err error) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err == nil {
+		c.failures = 0
+		return
+	}
+	c.failures++
+	if !c.open && c.failures >= c.threshold {
+		c.open = true
+		c.openedAt = time.Now()
+		metricsPkg.CoreCircuitOpen.Set(1)
+		c.logger.Error().Err(err).Msgf("CircuitBreaker: zetacore unavailable after %d failed calls, pausing posts", c.failures)
+	}
+}
+
+// Run probes zetacore while the breaker is open and closes it once a probe succeeds, until ctx is done
+func (c *CircuitBreaker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.probeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if c.Allow() == nil {
+				continue
+			}
+			c.tryClose()
+		}
+	}
+}
+
+// tryClose probes zetacore and closes the breaker if it answered
+func (c *CircuitBreaker) tryClose() {
+	if err := c.probe(); err != nil {
+		c.logger.Warn().Err(err).Msg("CircuitBreaker: zetacore probe failed")
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.open = false
+	c.failures = 0
+	metricsPkg.CoreCircuitOpen.Set(0)
+	c.logger.Info().Msgf("CircuitBreaker: zetacore available again after %s, resuming posts", time.Since(c.openedAt).Round(time.Second))
+}
+
+// probeCore checks the zetacore node the txs are broadcast to answers and is synced
+func (b *ZetaCoreBridge) probeCore() error {
+	ctx, err := b.GetContext()
+	if err != nil {
+		return err
+	}
+	status, err := ctx.Client.Status(b.ctx)
+	if err != nil {
+		return err
+	}
+	if status.SyncInfo.CatchingUp {
+		return fmt.Errorf("zetacore catching up at height %d", status.SyncInfo.LatestBlockHeight)
+	}
+	return nil
+}
+
+// CoreAvailable returns false while the circuit breaker pauses the posts to zetacore
+func (b *ZetaCoreBridge) CoreAvailable() bool {
+	return b.breaker.Allow() == nil
+}
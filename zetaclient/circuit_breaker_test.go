@@ -0,0 +1,39 @@
+package zetaclient
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	probeErr := errors.New("connection refused")
+	breaker := NewCircuitBreaker(3, time.Second, func() error { return probeErr }, zerolog.Nop())
+
+	// a success resets the consecutive failures
+	breaker.Record(errors.New("connection refused"))
+	breaker.Record(errors.New("connection refused"))
+	breaker.Record(nil)
+	breaker.Record(errors.New("connection refused"))
+	require.NoError(t, breaker.Allow())
+
+	// trips once the failures reach the threshold
+	breaker.Record(errors.New("connection refused"))
+	breaker.Record(errors.New("connection refused"))
+	require.ErrorIs(t, breaker.Allow(), ErrCoreUnavailable)
+
+	// stays open until a probe succeeds
+	breaker.tryClose()
+	require.ErrorIs(t, breaker.Allow(), ErrCoreUnavailable)
+	probeErr = nil
+	breaker.tryClose()
+	require.NoError(t, breaker.Allow())
+
+	// a nil breaker always allows
+	var disabled *CircuitBreaker
+	disabled.Record(errors.New("connection refused"))
+	require.NoError(t, disabled.Allow())
+}
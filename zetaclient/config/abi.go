@@ -0,0 +1,119 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/zeta-chain/protocol-contracts/pkg/contracts/evm/erc20custody.sol"
+	"github.com/zeta-chain/protocol-contracts/pkg/contracts/evm/zetaconnector.non-eth.sol"
+	connectorzevm "github.com/zeta-chain/protocol-contracts/pkg/contracts/zevm/connectorzevm.sol"
+	zrc20 "github.com/zeta-chain/protocol-contracts/pkg/contracts/zevm/zrc20.sol"
+)
+
+const abiFetchTimeout = 10 * time.Second
+
+var (
+	// ConnectorABIMethods are the connector methods called by the signer
+	ConnectorABIMethods = []string{"onReceive", "onRevert"}
+	// ERC20CustodyABIMethods are the ERC20 custody methods called by the signer
+	ERC20CustodyABIMethods = []string{"withdraw", "whitelist", "unwhitelist"}
+	// InboundABIEvents are the inbound events observed by kind of watched contract
+	InboundABIEvents = map[string]string{
+		ContractKindConnector:     "ZetaSent",
+		ContractKindERC20Custody:  "Deposited",
+		ContractKindZRC20:         "Withdrawal",
+		ContractKindConnectorZEVM: "ZetaSent",
+	}
+)
+
+// GetConnectorABI returns the ABI of the connector contract from the generated bindings
+func GetConnectorABI() string {
+	return zetaconnector.ZetaConnectorNonEthMetaData.ABI
+}
+
+// GetERC20CustodyABI returns the ABI of the ERC20 custody contract from the generated bindings
+func GetERC20CustodyABI() string {
+	return erc20custody.ERC20CustodyMetaData.ABI
+}
+
+// GetZRC20ABI returns the ABI of the ZRC20 contracts of zEVM from the generated bindings
+func GetZRC20ABI() string {
+	return zrc20.ZRC20MetaData.ABI
+}
+
+// GetConnectorZEVMABI returns the ABI of the connector contract of zEVM from the generated bindings
+func GetConnectorZEVMABI() string {
+	return connectorzevm.ZetaConnectorZEVMMetaData.ABI
+}
+
+// LoadABI reads an ABI JSON from a file path or an http(s) URL and validates it
+func LoadABI(location string, methods []string) (string, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		data, err = fetchABI(location)
+	} else {
+		if strings.HasPrefix(location, "~") {
+			location = GetPath(location)
+		}
+		data, err = os.ReadFile(filepath.Clean(location))
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to load abi from %s: %w", location, err)
+	}
+	abiJSON := string(data)
+	if err := ValidateABI(abiJSON, methods); err != nil {
+		return "", fmt.Errorf("invalid abi in %s: %w", location, err)
+	}
+	return abiJSON, nil
+}
+
+// ValidateABI checks that abiJSON parses and has all the given methods
+func ValidateABI(abiJSON string, methods []string) error {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return err
+	}
+	for _, method := range methods {
+		if _, found := parsed.Methods[method]; !found {
+			return fmt.Errorf("method %s not found", method)
+		}
+	}
+	return nil
+}
+
+// ValidateABIEvents checks that abiJSON parses and has all the given events
+func ValidateABIEvents(abiJSON string, events []string) error {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		if _, found := parsed.Events[event]; !found {
+			return fmt.Errorf("event %s not found", event)
+		}
+	}
+	return nil
+}
+
+func fetchABI(url string) ([]byte, error) {
+	client := http.Client{Timeout: abiFetchTimeout}
+	// #nosec G107 url is set by the operator in config
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
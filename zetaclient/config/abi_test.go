@@ -0,0 +1,31 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContractABIs(t *testing.T) {
+	require.NoError(t, ValidateABI(GetConnectorABI(), ConnectorABIMethods))
+	require.NoError(t, ValidateABI(GetERC20CustodyABI(), ERC20CustodyABIMethods))
+}
+
+func TestLoadABI(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "erc20custody.json")
+	require.NoError(t, os.WriteFile(path, []byte(GetERC20CustodyABI()), 0600))
+
+	abiJSON, err := LoadABI(path, ERC20CustodyABIMethods)
+	require.NoError(t, err)
+	require.Equal(t, GetERC20CustodyABI(), abiJSON)
+
+	// the custody abi does not have the connector methods
+	_, err = LoadABI(path, ConnectorABIMethods)
+	require.Error(t, err)
+
+	_, err = LoadABI(filepath.Join(dir, "missing.json"), ERC20CustodyABIMethods)
+	require.Error(t, err)
+}
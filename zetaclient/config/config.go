@@ -3,9 +3,12 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"os"
 	"path/filepath"
 	"strings"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
 )
 
 const filename string = "zetaclient_config.json"
@@ -57,15 +60,86 @@ func Load(path string) (*Config, error) {
 	if cfg.KeyringBackend == KeyringBackendUndefined {
 		cfg.KeyringBackend = KeyringBackendTest
 	}
-	if cfg.KeyringBackend != KeyringBackendFile && cfg.KeyringBackend != KeyringBackendTest {
+	if cfg.KeyringBackend != KeyringBackendFile && cfg.KeyringBackend != KeyringBackendTest && cfg.KeyringBackend != KeyringBackendOS {
 		return nil, fmt.Errorf("invalid keyring backend %s", cfg.KeyringBackend)
 	}
 
 	// fields sanitization
 	cfg.TssPath = GetPath(cfg.TssPath)
 	cfg.PreParamsPath = GetPath(cfg.PreParamsPath)
+	if cfg.ObserverDBPath != "" {
+		cfg.ObserverDBPath = GetPath(cfg.ObserverDBPath)
+	}
+	if strings.HasPrefix(cfg.HotkeyPasswordFile, "~") {
+		cfg.HotkeyPasswordFile = GetPath(cfg.HotkeyPasswordFile)
+	}
+	if strings.HasPrefix(cfg.SanctionedAddressesPath, "~") {
+		cfg.SanctionedAddressesPath = GetPath(cfg.SanctionedAddressesPath)
+	}
 	cfg.CurrentTssPubkey = ""
 	cfg.ZetaCoreHome = path
+	if cfg.VoteBatchSize > 1 && cfg.VoteBatchWindowMs == 0 {
+		return nil, fmt.Errorf("vote batches of %d votes need a window", cfg.VoteBatchSize)
+	}
+	if cfg.BroadcastMode == "" {
+		cfg.BroadcastMode = BroadcastModeSync
+	}
+	if cfg.BroadcastMode != BroadcastModeSync && cfg.BroadcastMode != BroadcastModeAsync && cfg.BroadcastMode != BroadcastModeBlock {
+		return nil, fmt.Errorf("invalid broadcast mode %q", cfg.BroadcastMode)
+	}
+	if _, err := cfg.GetTxFees(); err != nil {
+		return nil, err
+	}
+	if cfg.GRPCMaxRecvMsgSize < 0 || cfg.GRPCMaxSendMsgSize < 0 {
+		return nil, fmt.Errorf("invalid grpc message sizes %d and %d", cfg.GRPCMaxRecvMsgSize, cfg.GRPCMaxSendMsgSize)
+	}
+	if cfg.GRPCCompression != GRPCCompressionNone && cfg.GRPCCompression != GRPCCompressionGzip {
+		return nil, fmt.Errorf("invalid grpc compression %q", cfg.GRPCCompression)
+	}
+	if cfg.MaxInFlightPosts < 0 || cfg.MaxOutboxBacklog < 0 {
+		return nil, fmt.Errorf("invalid backpressure limits %d and %d", cfg.MaxInFlightPosts, cfg.MaxOutboxBacklog)
+	}
+
+	for chainID, evmConfig := range cfg.EVMChainConfigs {
+		if evmConfig.FinalityTag != "" && evmConfig.FinalityTag != FinalityTagSafe && evmConfig.FinalityTag != FinalityTagFinalized {
+			return nil, fmt.Errorf("invalid finality tag %q for chain %d", evmConfig.FinalityTag, chainID)
+		}
+		if evmConfig.ReadQuorum > len(evmConfig.GetEndpoints()) {
+			return nil, fmt.Errorf("read quorum %d of chain %d exceeds its %d endpoints", evmConfig.ReadQuorum, chainID, len(evmConfig.GetEndpoints()))
+		}
+		if evmConfig.TraceAPI != "" && evmConfig.TraceAPI != TraceAPIDebug && evmConfig.TraceAPI != TraceAPIParity {
+			return nil, fmt.Errorf("invalid trace api %q for chain %d", evmConfig.TraceAPI, chainID)
+		}
+		if evmConfig.MinSignerBalance != "" && evmConfig.GetMinSignerBalance() == nil {
+			return nil, fmt.Errorf("invalid min signer balance %q for chain %d", evmConfig.MinSignerBalance, chainID)
+		}
+		if err := validateAssetAmounts(evmConfig.InboundAmountCaps); err != nil {
+			return nil, fmt.Errorf("invalid inbound amount caps for chain %d: %w", chainID, err)
+		}
+		if err := validateAssetAmounts(evmConfig.InboundMinAmounts); err != nil {
+			return nil, fmt.Errorf("invalid inbound min amounts for chain %d: %w", chainID, err)
+		}
+		if evmConfig.CheckpointContract != "" && !ethcommon.IsHexAddress(evmConfig.CheckpointContract) {
+			return nil, fmt.Errorf("invalid checkpoint contract %s for chain %d", evmConfig.CheckpointContract, chainID)
+		}
+		for _, asset := range evmConfig.ERC20Whitelist {
+			if !ethcommon.IsHexAddress(asset) {
+				return nil, fmt.Errorf("invalid whitelisted asset %s for chain %d", asset, chainID)
+			}
+		}
+	}
+
+	// custom evm chains must be known before zetacore core params are applied
+	err = cfg.RegisterCustomChains()
+	if err != nil {
+		return nil, err
+	}
+
+	// load contract ABIs from files or URLs if set
+	err = cfg.LoadContractABIs()
+	if err != nil {
+		return nil, err
+	}
 
 	return cfg, nil
 }
@@ -83,3 +157,16 @@ func GetPath(inputPath string) string {
 	}
 	return filepath.Join(path...)
 }
+
+// validateAssetAmounts checks the amounts keyed by asset of the inbound amount caps and min amounts
+func validateAssetAmounts(amounts map[string]string) error {
+	for asset, value := range amounts {
+		if asset != InboundCapAssetGas && asset != InboundCapAssetZeta && !ethcommon.IsHexAddress(asset) {
+			return fmt.Errorf("invalid asset %s", asset)
+		}
+		if amount, ok := new(big.Int).SetString(value, 10); !ok || amount.Sign() < 0 {
+			return fmt.Errorf("invalid amount %q of asset %s", value, asset)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DefaultGasAdjustment is the multiplier of the gas limit of the txs broadcast to zetacore if unset
+const DefaultGasAdjustment = 3
+
+// TxFees are the gas limit multiplier and the fee of the txs broadcast to zetacore
+type TxFees struct {
+	GasAdjustment float64
+	GasPrices     sdk.DecCoins // fee per unit of gas, if set
+	Fees          sdk.Coins    // flat fee otherwise
+}
+
+// DefaultTxFees returns the fees of the txs broadcast to zetacore if none is configured: a flat fee of 40000azeta
+func DefaultTxFees() TxFees {
+	return TxFees{
+		GasAdjustment: DefaultGasAdjustment,
+		Fees:          sdk.NewCoins(sdk.NewInt64Coin("azeta", 40000)),
+	}
+}
+
+// GetTxFees parses the fee settings of the txs broadcast to zetacore; GasPrices and Fees are exclusive
+func (c *Config) GetTxFees() (TxFees, error) {
+	fees := TxFees{GasAdjustment: c.GasAdjustment}
+	if fees.GasAdjustment == 0 {
+		fees.GasAdjustment = DefaultGasAdjustment
+	}
+	if fees.GasAdjustment < 1 {
+		return TxFees{}, fmt.Errorf("gas adjustment %f is below 1", fees.GasAdjustment)
+	}
+	if c.GasPrices != "" && c.Fees != "" {
+		return TxFees{}, fmt.Errorf("gas prices %s and fees %s are both set", c.GasPrices, c.Fees)
+	}
+	if c.GasPrices != "" {
+		gasPrices, err := sdk.ParseDecCoins(c.GasPrices)
+		if err != nil {
+			return TxFees{}, fmt.Errorf("invalid gas prices %s: %w", c.GasPrices, err)
+		}
+		fees.GasPrices = gasPrices
+		return fees, nil
+	}
+	if c.Fees == "" {
+		fees.Fees = DefaultTxFees().Fees
+		return fees, nil
+	}
+	coins, err := sdk.ParseCoinsNormalized(c.Fees)
+	if err != nil {
+		return TxFees{}, fmt.Errorf("invalid fees %s: %w", c.Fees, err)
+	}
+	fees.Fees = coins
+	return fees, nil
+}
+
+// GasLimit returns the gas limit of a tx posted with gasLimit, multiplied by the gas adjustment
+func (f TxFees) GasLimit(gasLimit uint64) uint64 {
+	return uint64(math.Ceil(float64(gasLimit) * f.GasAdjustment))
+}
+
+// Fee returns the fee of a tx with the gas limit: the gas prices times the gas limit if set, the flat fee otherwise
+func (f TxFees) Fee(gasLimit uint64) sdk.Coins {
+	if f.GasPrices.Empty() {
+		return f.Fees
+	}
+	limit := sdk.NewDecFromBigInt(new(big.Int).SetUint64(gasLimit))
+	fees := make(sdk.Coins, len(f.GasPrices))
+	for i, gasPrice := range f.GasPrices {
+		fees[i] = sdk.NewCoin(gasPrice.Denom, gasPrice.Amount.Mul(limit).Ceil().RoundInt())
+	}
+	return fees.Sort()
+}
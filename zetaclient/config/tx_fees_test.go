@@ -0,0 +1,37 @@
+package config
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_GetTxFees(t *testing.T) {
+	// a flat fee by default
+	fees, err := (&Config{}).GetTxFees()
+	require.NoError(t, err)
+	require.Equal(t, DefaultTxFees(), fees)
+	require.EqualValues(t, 600_000, fees.GasLimit(200_000))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("azeta", 40000)), fees.Fee(600_000))
+
+	// or the gas prices times the adjusted gas limit
+	fees, err = (&Config{GasAdjustment: 1.5, GasPrices: "0.25azeta"}).GetTxFees()
+	require.NoError(t, err)
+	require.EqualValues(t, 300_002, fees.GasLimit(200_001))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("azeta", 75_001)), fees.Fee(300_001))
+
+	fees, err = (&Config{Fees: "100stake"}).GetTxFees()
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 100)), fees.Fee(1))
+
+	for _, invalid := range []Config{
+		{GasAdjustment: 0.5},
+		{GasPrices: "0.25azeta", Fees: "100azeta"},
+		{GasPrices: "azeta"},
+		{Fees: "-1azeta"},
+	} {
+		_, err := invalid.GetTxFees()
+		require.Error(t, err)
+	}
+}
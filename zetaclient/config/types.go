@@ -3,6 +3,7 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"sort"
 	"strings"
 	"sync"
@@ -20,6 +21,7 @@ const (
 	KeyringBackendUndefined KeyringBackend = ""
 	KeyringBackendTest      KeyringBackend = "test"
 	KeyringBackendFile      KeyringBackend = "file"
+	KeyringBackendOS        KeyringBackend = "os"
 )
 
 type ClientConfiguration struct {
@@ -30,10 +32,361 @@ type ClientConfiguration struct {
 	SignerPasswd    string
 }
 
+// MumbaiRootChainAddress is the contract on Goerli where the Polygon Mumbai blocks are checkpointed
+const MumbaiRootChainAddress = "0x2890bA17EfE978480615e330ecB65333b880928e"
+
+// Block tags of the json-rpc that a chain can use as its source of finality
+const (
+	FinalityTagSafe      = "safe"
+	FinalityTagFinalized = "finalized"
+)
+
+// Trace apis of the json-rpc that can be used to find the gas token deposits sent from inside contract calls
+const (
+	TraceAPIDebug  = "debug" // debug_traceBlockByNumber with the call tracer, served by geth and its forks
+	TraceAPIParity = "trace" // trace_block, served by erigon, nethermind and openethereum
+)
+
+// Modes the txs are broadcast to zetacore with
+const (
+	BroadcastModeSync  = "sync"  // returns once the tx passed CheckTx
+	BroadcastModeAsync = "async" // returns once the tx is sent
+	BroadcastModeBlock = "block" // returns once the tx is committed in a block
+)
+
+// Compressions of the grpc messages to zetacore
+const (
+	GRPCCompressionNone = ""
+	GRPCCompressionGzip = "gzip"
+)
+
+// DefaultBalanceDropAlertPercent is the drop of a watched balance between two checks, in percent, that raises an
+// alert if the chain doesn't set BalanceDropAlertPercent
+const DefaultBalanceDropAlertPercent = 10
+
+// Assets of the inbound amount caps besides the ERC20s, which are keyed by their address
+const (
+	InboundCapAssetGas  = "gas"
+	InboundCapAssetZeta = "zeta"
+)
+
+// TronConfirmationCount is the number of confirmations after which a tron block is solidified
+// by more than 2/3 of the 27 super representatives
+const TronConfirmationCount = 19
+
+// Kinds of the contracts observed for inbound events
+const (
+	ContractKindConnector    = "connector"    // ZetaSent events are observed
+	ContractKindERC20Custody = "erc20custody" // Deposited events are observed
+
+	// contracts of zEVM, observed when zetaclient observes the zeta chain itself
+	ContractKindZRC20         = "zrc20"          // Withdrawal events are observed
+	ContractKindConnectorZEVM = "connector-zevm" // ZetaSent events are observed
+)
+
+// WatchedContract is a contract observed for inbound events in addition to the connector and ERC20 custody
+// set in core params, e.g. a legacy router still used by dapps
+type WatchedContract struct {
+	Address string
+	Kind    string
+	// ABIPath overrides the ABI of the chain for the kind of the contract
+	ABIPath string
+	abi     string
+}
+
+// GetABI returns the ABI loaded from ABIPath, empty if not set
+func (c WatchedContract) GetABI() string {
+	return c.abi
+}
+
+// RPCClientConfig sets up the http client of an rpc endpoint; zero values keep the defaults of zetaclient
+type RPCClientConfig struct {
+	// DialTimeout is the max number of seconds to connect to the endpoint, tls handshake included
+	DialTimeout uint64
+	// RequestTimeout is the max number of seconds of a request, reading the response included
+	RequestTimeout uint64
+	// MaxIdleConns is the max number of idle keep-alive connections kept open to the endpoint
+	MaxIdleConns int
+	// Headers are sent with every request, e.g. the api key of a provider; http endpoints only
+	Headers map[string]string
+}
+
+// Copy returns a deep copy of the rpc client config
+func (c RPCClientConfig) Copy() RPCClientConfig {
+	copied := c
+	if c.Headers != nil {
+		copied.Headers = make(map[string]string, len(c.Headers))
+		for key, value := range c.Headers {
+			copied.Headers[key] = value
+		}
+	}
+	return copied
+}
+
+// WebhookConfig is a webhook receiving the events observed on external chains
+type WebhookConfig struct {
+	URL    string
+	Secret string // key of the HMAC-SHA256 signature of the payloads; payloads are not signed if empty
+}
+
+// VoteRetryPolicy is the retry schedule of a flow of votes to zetacore; the fields unset keep the default of the flow
+type VoteRetryPolicy struct {
+	InitialIntervalMs uint64 `json:"InitialIntervalMs"`
+	MaxIntervalMs     uint64 `json:"MaxIntervalMs"`
+	MaxRetries        int    `json:"MaxRetries"`
+}
+
 type EVMConfig struct {
 	observertypes.CoreParams
 	Chain    common.Chain
 	Endpoint string
+
+	// BackupEndpoints are used in order when Endpoint is unreachable
+	BackupEndpoints []string
+
+	// ReadQuorum is the number of endpoints among Endpoint and BackupEndpoints that must return the same headers and
+	// inbound logs for a block range before it is voted on; the votes are withheld otherwise. Disabled if 0 or 1
+	ReadQuorum int
+
+	// RPCClient sets up the http client of the endpoints; EndpointRPCClients overrides it for single endpoints
+	RPCClient          RPCClientConfig
+	EndpointRPCClients map[string]RPCClientConfig
+
+	// StartBlock is the first block observed, e.g. the block the contracts were deployed at. The observer never starts
+	// below it, whatever the last scanned block in db or zetacore; it starts there rather than at the chain head if
+	// neither has one. The <chain>_SCAN_FROM envvar overrides it
+	StartBlock uint64
+
+	// MinConfirmationCount is a local floor on the number of confirmations required before
+	// inbound events are posted to zetacore; the larger of this and the core param is used
+	MinConfirmationCount uint64
+
+	// BlocksPerScan is the max number of blocks the inbound observer scans per tick
+	// MaxBlocksPerPeriod is used if not set
+	BlocksPerScan uint64
+
+	// CatchUpBlocksPerScan is the max number of blocks scanned per tick while the observer
+	// is more than CatchUpThreshold blocks behind the confirmed tip; catch-up is disabled if not set
+	CatchUpBlocksPerScan uint64
+	CatchUpThreshold     uint64
+
+	// FinalityTag is the block tag ("safe" or "finalized") of the highest block the chain itself considers final,
+	// e.g. the last L2 block whose batch is finalized on L1 or the last block finalized by the beacon chain on Ethereum.
+	// If set, inbound events are never posted beyond that block, unless the rpc endpoint doesn't serve the tag
+	FinalityTag string
+
+	// CheckpointContract is the root chain contract on CheckpointChainID where the blocks of the chain are checkpointed,
+	// e.g. the RootChain contract of Polygon on Ethereum. If set, inbound events are never posted beyond the last
+	// checkpointed block. The endpoint of CheckpointChainID is used to read the contract
+	CheckpointContract string
+	CheckpointChainID  int64
+
+	// TraceAPI is the trace api ("debug" or "trace") used to detect the gas tokens sent to the TSS address from inside
+	// contract calls, which no top level tx of the block shows. Internal transfers are not detected if not set
+	TraceAPI string
+
+	// Shadow runs the observer of the chain in shadow mode, e.g. while onboarding it: events are processed and the
+	// inbound votes compared with the ballots of the other observers, but nothing is voted or signed. The operator flips
+	// the chain live by clearing it and restarting
+	Shadow bool
+
+	// Name is the name of a custom evm chain, i.e. a chain not built into zetaclient and defined by this config
+	// entry alone; its chain id is the key of the entry in EVMChainConfigs
+	Name string
+
+	// BlockTime is the average block time in seconds, used as the inbound ticker interval if zetacore sets none
+	BlockTime uint64
+
+	// VerifyHeaders makes the observer check that the headers of every scanned range link up into a valid chain
+	// before voting on it, instead of trusting the rpc provider. Only for chains whose headers hash like ethereum's
+	VerifyHeaders bool
+
+	// InboundProofs makes the observer prove the tx of every inbound event against its block header and have
+	// zetacore verify the proof against the block headers voted by the observers before voting on the event. The
+	// proof and the receipt of the tx are kept with the event and published to the webhooks for the peers to check.
+	// Requires the block headers of the chain to be posted to zetacore
+	InboundProofs bool
+
+	// MempoolEndpoint is a websocket endpoint used to watch pending inbound txs; mempool watching is disabled if not set
+	MempoolEndpoint string
+
+	// ConnectorABIPath and ERC20CustodyABIPath override the contract ABIs for this chain. The ABIs are used to sign the
+	// outbound txs and to decode the inbound events; the events of the outbound receipts are still decoded with the
+	// compiled-in bindings
+	ConnectorABIPath    string
+	ERC20CustodyABIPath string
+	connectorABI        string
+	erc20CustodyABI     string
+
+	// InboundLatencySLO is the max number of seconds between the block of an inbound event and its vote posted to
+	// zetacore; slower votes are logged and counted as breaches. The latency is exported whether set or not
+	InboundLatencySLO uint64
+
+	// StrictDecoding quarantines the inbound events that can't be decoded into a vote, with their raw logs, instead of
+	// only logging and skipping them; quarantined events can be processed again with the quarantine command
+	StrictDecoding bool
+
+	// WatchedContracts are observed for inbound events in addition to the contracts set in core params
+	WatchedContracts []WatchedContract
+
+	// BalanceDropAlertPercent is the drop of the balance of the TSS address or of the custody contract, in percent
+	// between two checks, that raises an alert; DefaultBalanceDropAlertPercent is used if not set
+	BalanceDropAlertPercent uint64
+
+	// MinSignerBalance is the balance of the TSS address, in the smallest unit of the gas token, below which operators
+	// are alerted to top it up before the outbound txs it broadcasts start failing; no alert is raised if not set
+	MinSignerBalance string
+
+	// InboundAmountCaps are the max amounts, in the smallest unit of the asset, of the inbound transfers voted on, keyed
+	// by asset: InboundCapAssetGas, InboundCapAssetZeta or the address of an ERC20. Larger transfers are withheld for
+	// operators to review, the events quarantined with StrictDecoding; they are only flagged with InboundCapsAlertOnly
+	InboundAmountCaps    map[string]string
+	InboundCapsAlertOnly bool
+
+	// InboundMinAmounts are the min amounts, in the smallest unit of the asset, of the inbound transfers voted on, keyed
+	// like InboundAmountCaps. Smaller transfers cost more in zetacore gas and outbound fees than they are worth; they
+	// are recorded as processed but not voted on
+	InboundMinAmounts map[string]string
+
+	// SenderRateLimit is the max number of inbound events per minute voted on per sender, the origin of the tx, after a
+	// burst of up to SenderRateBurst events (SenderRateLimit if not set); the events above are throttled into the
+	// quarantine. Sender rate limiting is disabled if not set
+	SenderRateLimit uint64
+	SenderRateBurst uint64
+
+	// PauseOnProxyUpgrade withholds all votes from the block range holding an upgrade of a watched proxy contract to an
+	// implementation not listed in ConfirmedImplementations on, until operators confirm its ABI by listing it
+	PauseOnProxyUpgrade      bool
+	ConfirmedImplementations []string
+
+	// ERC20Whitelist are the ERC20 assets whose deposits are voted on, in place of the whitelist of zetacore; the
+	// deposits of other assets are rejected
+	ERC20Whitelist []string
+}
+
+// Copy returns a deep copy of the evm config
+func (c EVMConfig) Copy() *EVMConfig {
+	copied := c
+	copied.BackupEndpoints = append([]string(nil), c.BackupEndpoints...)
+	copied.WatchedContracts = append([]WatchedContract(nil), c.WatchedContracts...)
+	copied.ConfirmedImplementations = append([]string(nil), c.ConfirmedImplementations...)
+	copied.ERC20Whitelist = append([]string(nil), c.ERC20Whitelist...)
+	copied.RPCClient = c.RPCClient.Copy()
+	if c.EndpointRPCClients != nil {
+		copied.EndpointRPCClients = make(map[string]RPCClientConfig, len(c.EndpointRPCClients))
+		for endpoint, rpcClient := range c.EndpointRPCClients {
+			copied.EndpointRPCClients[endpoint] = rpcClient.Copy()
+		}
+	}
+	copied.InboundAmountCaps = copyAssetAmounts(c.InboundAmountCaps)
+	copied.InboundMinAmounts = copyAssetAmounts(c.InboundMinAmounts)
+	return &copied
+}
+
+// GetMinSignerBalance returns the min balance of the TSS address, or nil if not set
+func (c EVMConfig) GetMinSignerBalance() *big.Int {
+	minBalance, ok := new(big.Int).SetString(c.MinSignerBalance, 10)
+	if !ok {
+		return nil
+	}
+	return minBalance
+}
+
+// GetInboundAmountCap returns the max amount of the inbound transfers of an asset, or nil if not capped
+func (c EVMConfig) GetInboundAmountCap(asset string) *big.Int {
+	return getAssetAmount(c.InboundAmountCaps, asset)
+}
+
+// GetInboundMinAmount returns the min amount of the inbound transfers of an asset, or nil if not set
+func (c EVMConfig) GetInboundMinAmount(asset string) *big.Int {
+	return getAssetAmount(c.InboundMinAmounts, asset)
+}
+
+// getAssetAmount returns the amount of an asset in amounts keyed by asset, the ERC20s matched by address whatever its
+// case, or nil if not set
+func getAssetAmount(amounts map[string]string, asset string) *big.Int {
+	for key, value := range amounts {
+		if strings.EqualFold(key, asset) {
+			amount, ok := new(big.Int).SetString(value, 10)
+			if !ok {
+				return nil
+			}
+			return amount
+		}
+	}
+	return nil
+}
+
+func copyAssetAmounts(amounts map[string]string) map[string]string {
+	if amounts == nil {
+		return nil
+	}
+	copied := make(map[string]string, len(amounts))
+	for asset, amount := range amounts {
+		copied[asset] = amount
+	}
+	return copied
+}
+
+// GetRPCClientConfig returns the config of the http client of an endpoint
+func (c EVMConfig) GetRPCClientConfig(endpoint string) RPCClientConfig {
+	if rpcClient, found := c.EndpointRPCClients[endpoint]; found {
+		return rpcClient
+	}
+	return c.RPCClient
+}
+
+// GetEndpoints returns the primary endpoint followed by the backup endpoints
+func (c EVMConfig) GetEndpoints() []string {
+	endpoints := make([]string, 0, len(c.BackupEndpoints)+1)
+	if c.Endpoint != "" {
+		endpoints = append(endpoints, c.Endpoint)
+	}
+	for _, endpoint := range c.BackupEndpoints {
+		if endpoint != "" {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints
+}
+
+// GetConfirmationCount returns the number of confirmations required before posting inbound events
+func (c EVMConfig) GetConfirmationCount() uint64 {
+	if c.MinConfirmationCount > c.ConfirmationCount {
+		return c.MinConfirmationCount
+	}
+	return c.ConfirmationCount
+}
+
+// GetInTxTicker returns the interval in seconds between two inbound observations
+func (c EVMConfig) GetInTxTicker() uint64 {
+	if c.InTxTicker > 0 {
+		return c.InTxTicker
+	}
+	if c.BlockTime > 0 {
+		return c.BlockTime
+	}
+	return 1
+}
+
+// GetBlocksPerScan returns the number of blocks to scan in one tick given how far behind the observer is
+// A node far behind the tip scans in large batches and throttles back to BlocksPerScan near the tip
+func (c EVMConfig) GetBlocksPerScan(blocksBehind uint64) uint64 {
+	blocksPerScan := c.BlocksPerScan
+	if blocksPerScan == 0 {
+		blocksPerScan = MaxBlocksPerPeriod
+	}
+	if c.CatchUpBlocksPerScan <= blocksPerScan {
+		return blocksPerScan
+	}
+	threshold := c.CatchUpThreshold
+	if threshold == 0 {
+		threshold = c.CatchUpBlocksPerScan
+	}
+	if blocksBehind > threshold {
+		return c.CatchUpBlocksPerScan
+	}
+	return blocksPerScan
 }
 
 type BTCConfig struct {
@@ -50,24 +403,96 @@ type BTCConfig struct {
 // TODO: use snake case for json fields
 // https://github.com/zeta-chain/node/issues/1020
 type Config struct {
-	Peer                string         `json:"Peer"`
-	PublicIP            string         `json:"PublicIP"`
-	LogFormat           string         `json:"LogFormat"`
-	LogLevel            int8           `json:"LogLevel"`
-	LogSampler          bool           `json:"LogSampler"`
-	PreParamsPath       string         `json:"PreParamsPath"`
-	ZetaCoreHome        string         `json:"ZetaCoreHome"`
-	ChainID             string         `json:"ChainID"`
-	ZetaCoreURL         string         `json:"ZetaCoreURL"`
-	AuthzGranter        string         `json:"AuthzGranter"`
-	AuthzHotkey         string         `json:"AuthzHotkey"`
-	P2PDiagnostic       bool           `json:"P2PDiagnostic"`
-	ConfigUpdateTicker  uint64         `json:"ConfigUpdateTicker"`
-	P2PDiagnosticTicker uint64         `json:"P2PDiagnosticTicker"`
-	TssPath             string         `json:"TssPath"`
-	TestTssKeysign      bool           `json:"TestTssKeysign"`
-	CurrentTssPubkey    string         `json:"CurrentTssPubkey"`
-	KeyringBackend      KeyringBackend `json:"KeyringBackend"`
+	Peer                string          `json:"Peer"`
+	PublicIP            string          `json:"PublicIP"`
+	LogFormat           string          `json:"LogFormat"`
+	LogLevel            int8            `json:"LogLevel"`
+	LogSampler          bool            `json:"LogSampler"`
+	PreParamsPath       string          `json:"PreParamsPath"`
+	ZetaCoreHome        string          `json:"ZetaCoreHome"`
+	ChainID             string          `json:"ChainID"`
+	ZetaCoreURL         string          `json:"ZetaCoreURL"`
+	AuthzGranter        string          `json:"AuthzGranter"`
+	AuthzHotkey         string          `json:"AuthzHotkey"`
+	P2PDiagnostic       bool            `json:"P2PDiagnostic"`
+	ConfigUpdateTicker  uint64          `json:"ConfigUpdateTicker"`
+	P2PDiagnosticTicker uint64          `json:"P2PDiagnosticTicker"`
+	TssPath             string          `json:"TssPath"`
+	TestTssKeysign      bool            `json:"TestTssKeysign"`
+	CurrentTssPubkey    string          `json:"CurrentTssPubkey"`
+	KeyringBackend      KeyringBackend  `json:"KeyringBackend"`
+	ObserverDBPath      string          `json:"ObserverDBPath"`
+	ConnectorABIPath    string          `json:"ConnectorABIPath"`
+	ERC20CustodyABIPath string          `json:"ERC20CustodyABIPath"`
+	MetricsPort         uint16          `json:"MetricsPort"`
+	Webhooks            []WebhookConfig `json:"Webhooks"`
+
+	// ZetaCoreBackupURLs are the zetacore nodes to fail over to, in order of preference, once the connection to the
+	// node of ZetaCoreURL is lost
+	ZetaCoreBackupURLs []string `json:"ZetaCoreBackupURLs"`
+
+	// SanctionedAddressesPath is the file listing the sanctioned addresses, one per line; the inbound transfers from
+	// or to them are not voted on
+	SanctionedAddressesPath string `json:"SanctionedAddressesPath"`
+
+	// VoteBatchSize is the max number of inbound votes broadcast in a single zetacore tx, one if unset; the votes are
+	// gathered for at most VoteBatchWindowMs milliseconds
+	VoteBatchSize     int    `json:"VoteBatchSize"`
+	VoteBatchWindowMs uint64 `json:"VoteBatchWindowMs"`
+
+	// BroadcastMode is the mode the txs are broadcast to zetacore with, sync if unset. With InclusionTimeoutSec set,
+	// the txs broadcast in sync or async mode are awaited until they are included in a block, for at most that long
+	BroadcastMode       string `json:"BroadcastMode"`
+	InclusionTimeoutSec uint64 `json:"InclusionTimeoutSec"`
+
+	// GasAdjustment multiplies the gas limit of the txs broadcast to zetacore, DefaultGasAdjustment if unset. The txs
+	// pay GasPrices per unit of gas, e.g. "0.1azeta", or the flat Fees, those of DefaultTxFees if neither is set
+	GasAdjustment float64 `json:"GasAdjustment"`
+	GasPrices     string  `json:"GasPrices"`
+	Fees          string  `json:"Fees"`
+
+	// HotkeyPasswordFile is the file holding the password of the hotkey with the file keyring backend, read in place
+	// of the HOTKEY_PASSWORD environment variable if set
+	HotkeyPasswordFile string `json:"HotkeyPasswordFile"`
+
+	// FeeGrant has the fees of the txs of the hotkey paid by the operator AuthzGranter through its fee allowance to
+	// the hotkey, so that the hotkey holds no funds
+	FeeGrant bool `json:"FeeGrant"`
+
+	// SimulateTxs has the txs simulated by zetacore before they are broadcast, so that the txs zetacore would reject
+	// fail locally with the reason
+	SimulateTxs bool `json:"SimulateTxs"`
+
+	// InboundVoteRetry and OutboundVoteRetry are the retry policies of the inbound votes, latency-sensitive, and of the
+	// outbound confirmations, which must never be lost. The outbound confirmations are batched like the inbound votes,
+	// by OutboundVoteBatchSize and OutboundVoteBatchWindowMs
+	InboundVoteRetry          VoteRetryPolicy `json:"InboundVoteRetry"`
+	OutboundVoteRetry         VoteRetryPolicy `json:"OutboundVoteRetry"`
+	OutboundVoteBatchSize     int             `json:"OutboundVoteBatchSize"`
+	OutboundVoteBatchWindowMs uint64          `json:"OutboundVoteBatchWindowMs"`
+
+	// GRPCMaxRecvMsgSize and GRPCMaxSendMsgSize are the max sizes in bytes of the grpc messages received from and sent
+	// to zetacore, the grpc defaults if unset, for the large query results and proofs. GRPCCompression compresses the
+	// grpc messages, GRPCCompressionGzip or none if unset
+	GRPCMaxRecvMsgSize int    `json:"GRPCMaxRecvMsgSize"`
+	GRPCMaxSendMsgSize int    `json:"GRPCMaxSendMsgSize"`
+	GRPCCompression    string `json:"GRPCCompression"`
+
+	// PostRateLimit is the max number of inbound votes per minute posted to zetacore per chain, after a burst of up to
+	// PostRateBurst votes (PostRateLimit if not set); the votes above are queued for a retry. The outbound
+	// confirmations are never limited. Post rate limiting is disabled if not set
+	PostRateLimit uint64 `json:"PostRateLimit"`
+	PostRateBurst uint64 `json:"PostRateBurst"`
+
+	// MaxInFlightPosts and MaxOutboxBacklog are the number of txs broadcast to zetacore at once and of txs of the
+	// outbox not included yet above which the chain clients stop scanning new blocks and slow their polling until
+	// zetacore catches up; 32 and 500 if not set
+	MaxInFlightPosts int `json:"MaxInFlightPosts"`
+	MaxOutboxBacklog int `json:"MaxOutboxBacklog"`
+
+	// contract ABIs loaded from ConnectorABIPath and ERC20CustodyABIPath, used like those of EVMConfig
+	connectorABI    string
+	erc20CustodyABI string
 
 	// chain specific fields are updatable at runtime and shared across threads
 	cfgLock         *sync.RWMutex        `json:"-"`
@@ -114,11 +539,42 @@ func (c *Config) GetEnabledChains() []common.Chain {
 	return copiedChains
 }
 
+// IsChainSupported returns true if zetacore supports the chain, i.e. it's among the chains enabled in zetacore, or it's
+// the zeta chain itself. The chains are not known until read from zetacore; the known chains are supported until then
+func (c *Config) IsChainSupported(chainID int64) bool {
+	if chainID == common.ZetaChain().ChainId {
+		return true
+	}
+	c.cfgLock.RLock()
+	defer c.cfgLock.RUnlock()
+	if len(c.ChainsEnabled) == 0 {
+		return common.GetChainFromChainID(chainID) != nil
+	}
+	for _, chain := range c.ChainsEnabled {
+		if chain.ChainId == chainID {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *Config) GetEVMConfig(chainID int64) (EVMConfig, bool) {
 	c.cfgLock.RLock()
 	defer c.cfgLock.RUnlock()
 	evmCfg, found := c.EVMChainConfigs[chainID]
-	return *evmCfg, found
+	if !found {
+		return EVMConfig{}, false
+	}
+	return *evmCfg.Copy(), true
+}
+
+// IsZEVMObserved returns true if the zeta chain has an evm config with an endpoint, i.e. its zEVM is observed for the
+// withdrawals and ZETA sent to external chains like the external chains are for inbound txs
+func (c *Config) IsZEVMObserved() bool {
+	c.cfgLock.RLock()
+	defer c.cfgLock.RUnlock()
+	evmCfg, found := c.EVMChainConfigs[common.ZetaChain().ChainId]
+	return found && evmCfg.Endpoint != ""
 }
 
 func (c *Config) GetAllEVMConfigs() map[int64]*EVMConfig {
@@ -128,8 +584,7 @@ func (c *Config) GetAllEVMConfigs() map[int64]*EVMConfig {
 	// deep copy evm configs
 	copied := make(map[int64]*EVMConfig, len(c.EVMChainConfigs))
 	for chainID, evmConfig := range c.EVMChainConfigs {
-		copied[chainID] = &EVMConfig{}
-		*copied[chainID] = *evmConfig
+		copied[chainID] = evmConfig.Copy()
 	}
 	return copied
 }
@@ -148,6 +603,112 @@ func (c *Config) GetBTCConfig() (common.Chain, BTCConfig, bool) {
 	return *chain, *c.BitcoinConfig, true
 }
 
+// GetConnectorABI returns the connector ABI loaded from config, or the ABI of the generated bindings if not set
+func (c *Config) GetConnectorABI() string {
+	if c.connectorABI != "" {
+		return c.connectorABI
+	}
+	return GetConnectorABI()
+}
+
+// GetERC20CustodyABI returns the ERC20 custody ABI loaded from config, or the ABI of the generated bindings if not set
+func (c *Config) GetERC20CustodyABI() string {
+	if c.erc20CustodyABI != "" {
+		return c.erc20CustodyABI
+	}
+	return GetERC20CustodyABI()
+}
+
+// GetChainConnectorABI returns the connector ABI of a chain, falling back to the connector ABI of all chains
+func (c *Config) GetChainConnectorABI(chainID int64) string {
+	if evmCfg, found := c.GetEVMConfig(chainID); found && evmCfg.connectorABI != "" {
+		return evmCfg.connectorABI
+	}
+	return c.GetConnectorABI()
+}
+
+// GetChainERC20CustodyABI returns the ERC20 custody ABI of a chain, falling back to the ERC20 custody ABI of all chains
+func (c *Config) GetChainERC20CustodyABI(chainID int64) string {
+	if evmCfg, found := c.GetEVMConfig(chainID); found && evmCfg.erc20CustodyABI != "" {
+		return evmCfg.erc20CustodyABI
+	}
+	return c.GetERC20CustodyABI()
+}
+
+// LoadContractABIs loads and validates the contract ABIs set in config
+func (c *Config) LoadContractABIs() error {
+	if c.ConnectorABIPath != "" {
+		connectorABI, err := LoadABI(c.ConnectorABIPath, ConnectorABIMethods)
+		if err != nil {
+			return err
+		}
+		c.connectorABI = connectorABI
+	}
+	if c.ERC20CustodyABIPath != "" {
+		erc20CustodyABI, err := LoadABI(c.ERC20CustodyABIPath, ERC20CustodyABIMethods)
+		if err != nil {
+			return err
+		}
+		c.erc20CustodyABI = erc20CustodyABI
+	}
+	for chainID, evmCfg := range c.EVMChainConfigs {
+		if evmCfg.ConnectorABIPath != "" {
+			connectorABI, err := LoadABI(evmCfg.ConnectorABIPath, ConnectorABIMethods)
+			if err != nil {
+				return err
+			}
+			evmCfg.connectorABI = connectorABI
+		}
+		if evmCfg.ERC20CustodyABIPath != "" {
+			erc20CustodyABI, err := LoadABI(evmCfg.ERC20CustodyABIPath, ERC20CustodyABIMethods)
+			if err != nil {
+				return err
+			}
+			evmCfg.erc20CustodyABI = erc20CustodyABI
+		}
+		for i, contract := range evmCfg.WatchedContracts {
+			if !ethcommon.IsHexAddress(contract.Address) {
+				return fmt.Errorf("invalid address %s of watched contract on chain %d", contract.Address, chainID)
+			}
+			event, found := InboundABIEvents[contract.Kind]
+			if !found {
+				return fmt.Errorf("unknown kind %s of watched contract %s", contract.Kind, contract.Address)
+			}
+			if contract.ABIPath == "" {
+				continue
+			}
+			contractABI, err := LoadABI(contract.ABIPath, nil)
+			if err != nil {
+				return err
+			}
+			if err := ValidateABIEvents(contractABI, []string{event}); err != nil {
+				return fmt.Errorf("invalid abi of watched contract %s: %w", contract.Address, err)
+			}
+			evmCfg.WatchedContracts[i].abi = contractABI
+		}
+	}
+	return nil
+}
+
+// RegisterCustomChains registers the custom evm chains defined in config; their chain id is the key of their entry
+func (c *Config) RegisterCustomChains() error {
+	for chainID, evmCfg := range c.EVMChainConfigs {
+		if evmCfg.Name == "" {
+			continue
+		}
+		if evmCfg.Chain.ChainName != common.ChainName_empty {
+			return fmt.Errorf("custom chain %s cannot set the name of a built-in chain", evmCfg.Name)
+		}
+		err := common.RegisterCustomEVMChain(chainID, evmCfg.Name)
+		if err != nil {
+			return err
+		}
+		evmCfg.Chain.ChainId = chainID
+		evmCfg.CoreParams.ChainId = chainID
+	}
+	return nil
+}
+
 func (c *Config) GetKeyringBackend() KeyringBackend {
 	c.cfgLock.RLock()
 	defer c.cfgLock.RUnlock()
@@ -175,16 +736,9 @@ func (c *Config) UpdateCoreParams(
 		logger.Warn().Msg("UpdateCoreParams: No chains enabled in ZeroCore")
 	}
 
-	// Add some warnings if chain list changes at runtime
-	if !init {
-		if len(c.ChainsEnabled) != len(newChains) {
-			logger.Warn().Msgf("UpdateCoreParams: ChainsEnabled changed at runtime!! current: %v, new: %v", c.ChainsEnabled, newChains)
-		}
-		for i, chain := range newChains {
-			if chain != c.ChainsEnabled[i] {
-				logger.Warn().Msgf("UpdateCoreParams: ChainsEnabled changed at runtime!! current: %v, new: %v", c.ChainsEnabled, newChains)
-			}
-		}
+	// Chains can be added or removed at runtime; chain clients are synced to the new list by the supervisor
+	if !init && !chainsEqual(c.ChainsEnabled, newChains) {
+		logger.Info().Msgf("UpdateCoreParams: ChainsEnabled changed at runtime, current: %v, new: %v", c.ChainsEnabled, newChains)
 	}
 	c.Keygen = *keygen
 	c.ChainsEnabled = newChains
@@ -195,10 +749,24 @@ func (c *Config) UpdateCoreParams(
 		curCfg, found := c.EVMChainConfigs[params.ChainId]
 		if found {
 			curCfg.CoreParams = *params
+		} else {
+			logger.Debug().Msgf("UpdateCoreParams: no local config for evm chain %d", params.ChainId)
 		}
 	}
 }
 
+func chainsEqual(a, b []common.Chain) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // Make a separate (deep) copy of the config
 func (c *Config) Clone() *Config {
 	c.cfgLock.RLock()
@@ -220,6 +788,41 @@ func (c *Config) Clone() *Config {
 		TssPath:             c.TssPath,
 		TestTssKeysign:      c.TestTssKeysign,
 		KeyringBackend:      c.KeyringBackend,
+		ObserverDBPath:      c.ObserverDBPath,
+		ConnectorABIPath:    c.ConnectorABIPath,
+		ERC20CustodyABIPath: c.ERC20CustodyABIPath,
+		MetricsPort:         c.MetricsPort,
+		Webhooks:            append([]WebhookConfig(nil), c.Webhooks...),
+		connectorABI:        c.connectorABI,
+		erc20CustodyABI:     c.erc20CustodyABI,
+
+		ZetaCoreBackupURLs:      append([]string(nil), c.ZetaCoreBackupURLs...),
+		SanctionedAddressesPath: c.SanctionedAddressesPath,
+		VoteBatchSize:           c.VoteBatchSize,
+		VoteBatchWindowMs:       c.VoteBatchWindowMs,
+		BroadcastMode:           c.BroadcastMode,
+		InclusionTimeoutSec:     c.InclusionTimeoutSec,
+		GasAdjustment:           c.GasAdjustment,
+		GasPrices:               c.GasPrices,
+		Fees:                    c.Fees,
+		HotkeyPasswordFile:      c.HotkeyPasswordFile,
+		FeeGrant:                c.FeeGrant,
+		SimulateTxs:             c.SimulateTxs,
+		InboundVoteRetry:        c.InboundVoteRetry,
+		OutboundVoteRetry:       c.OutboundVoteRetry,
+
+		OutboundVoteBatchSize:     c.OutboundVoteBatchSize,
+		OutboundVoteBatchWindowMs: c.OutboundVoteBatchWindowMs,
+
+		GRPCMaxRecvMsgSize: c.GRPCMaxRecvMsgSize,
+		GRPCMaxSendMsgSize: c.GRPCMaxSendMsgSize,
+		GRPCCompression:    c.GRPCCompression,
+
+		PostRateLimit: c.PostRateLimit,
+		PostRateBurst: c.PostRateBurst,
+
+		MaxInFlightPosts: c.MaxInFlightPosts,
+		MaxOutboxBacklog: c.MaxOutboxBacklog,
 
 		cfgLock:         &sync.RWMutex{},
 		Keygen:          c.GetKeygen(),
@@ -229,8 +832,7 @@ func (c *Config) Clone() *Config {
 	}
 	// deep copy evm & btc configs
 	for chainID, evmConfig := range c.EVMChainConfigs {
-		copied.EVMChainConfigs[chainID] = &EVMConfig{}
-		*copied.EVMChainConfigs[chainID] = *evmConfig
+		copied.EVMChainConfigs[chainID] = evmConfig.Copy()
 	}
 	if c.BitcoinConfig != nil {
 		copied.BitcoinConfig = &BTCConfig{}
@@ -0,0 +1,176 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zeta-chain/zetacore/common"
+)
+
+func TestEVMConfig_GetBlocksPerScan(t *testing.T) {
+	tt := []struct {
+		name         string
+		cfg          EVMConfig
+		blocksBehind uint64
+		expected     uint64
+	}{
+		{
+			name:         "default batch size",
+			cfg:          EVMConfig{},
+			blocksBehind: 10000,
+			expected:     MaxBlocksPerPeriod,
+		},
+		{
+			name:         "custom batch size",
+			cfg:          EVMConfig{BlocksPerScan: 20},
+			blocksBehind: 10000,
+			expected:     20,
+		},
+		{
+			name:         "catch-up batch size when far behind",
+			cfg:          EVMConfig{BlocksPerScan: 20, CatchUpBlocksPerScan: 1000, CatchUpThreshold: 5000},
+			blocksBehind: 5001,
+			expected:     1000,
+		},
+		{
+			name:         "throttle back near the tip",
+			cfg:          EVMConfig{BlocksPerScan: 20, CatchUpBlocksPerScan: 1000, CatchUpThreshold: 5000},
+			blocksBehind: 5000,
+			expected:     20,
+		},
+		{
+			name:         "catch-up threshold defaults to catch-up batch size",
+			cfg:          EVMConfig{BlocksPerScan: 20, CatchUpBlocksPerScan: 1000},
+			blocksBehind: 1001,
+			expected:     1000,
+		},
+		{
+			name:         "catch-up ignored if smaller than batch size",
+			cfg:          EVMConfig{BlocksPerScan: 20, CatchUpBlocksPerScan: 10},
+			blocksBehind: 10000,
+			expected:     20,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.cfg.GetBlocksPerScan(tc.blocksBehind))
+		})
+	}
+}
+
+func TestEVMConfig_GetConfirmationCount(t *testing.T) {
+	cfg := EVMConfig{}
+	cfg.ConfirmationCount = 2
+	require.Equal(t, uint64(2), cfg.GetConfirmationCount())
+
+	cfg.MinConfirmationCount = 12
+	require.Equal(t, uint64(12), cfg.GetConfirmationCount())
+
+	cfg.ConfirmationCount = 20
+	require.Equal(t, uint64(20), cfg.GetConfirmationCount())
+}
+
+func TestEVMConfig_GetEndpoints(t *testing.T) {
+	cfg := EVMConfig{
+		Endpoint:        "http://primary:8545",
+		BackupEndpoints: []string{"", "http://backup1:8545", "http://backup2:8545"},
+	}
+	require.Equal(t, []string{"http://primary:8545", "http://backup1:8545", "http://backup2:8545"}, cfg.GetEndpoints())
+
+	// copy does not share backup endpoints
+	copied := cfg.Copy()
+	copied.BackupEndpoints[1] = "http://other:8545"
+	require.Equal(t, "http://backup1:8545", cfg.BackupEndpoints[1])
+}
+
+func TestEVMConfig_GetRPCClientConfig(t *testing.T) {
+	cfg := EVMConfig{
+		Endpoint:        "http://primary:8545",
+		BackupEndpoints: []string{"https://provider.io"},
+		RPCClient:       RPCClientConfig{RequestTimeout: 10},
+		EndpointRPCClients: map[string]RPCClientConfig{
+			"https://provider.io": {RequestTimeout: 5, Headers: map[string]string{"X-Api-Key": "key"}},
+		},
+	}
+	require.Equal(t, uint64(10), cfg.GetRPCClientConfig("http://primary:8545").RequestTimeout)
+	require.Equal(t, "key", cfg.GetRPCClientConfig("https://provider.io").Headers["X-Api-Key"])
+
+	copied := cfg.Copy()
+	copied.EndpointRPCClients["https://provider.io"].Headers["X-Api-Key"] = "other"
+	require.Equal(t, "key", cfg.GetRPCClientConfig("https://provider.io").Headers["X-Api-Key"])
+}
+
+func TestEVMConfig_GetInTxTicker(t *testing.T) {
+	cfg := EVMConfig{}
+	require.Equal(t, uint64(1), cfg.GetInTxTicker())
+
+	cfg.BlockTime = 2
+	require.Equal(t, uint64(2), cfg.GetInTxTicker())
+
+	// core params set by zetacore take precedence over the block time
+	cfg.InTxTicker = 6
+	require.Equal(t, uint64(6), cfg.GetInTxTicker())
+}
+
+func TestConfig_RegisterCustomChains(t *testing.T) {
+	cfg := NewConfig()
+	cfg.EVMChainConfigs = map[int64]*EVMConfig{
+		99001: {Name: "custom_mainnet", Endpoint: "http://custom:8545", BlockTime: 2},
+	}
+	require.NoError(t, cfg.RegisterCustomChains())
+
+	evmCfg, found := cfg.GetEVMConfig(99001)
+	require.True(t, found)
+	require.Equal(t, int64(99001), evmCfg.Chain.ChainId)
+	require.Equal(t, int64(99001), evmCfg.CoreParams.ChainId)
+	require.Equal(t, "custom_mainnet", evmCfg.Chain.Name())
+	require.True(t, common.IsEVMChain(99001))
+
+	// custom chains cannot take the name or the chain id of a built-in chain
+	cfg.EVMChainConfigs = map[int64]*EVMConfig{99002: {Name: "eth_mainnet"}}
+	require.Error(t, cfg.RegisterCustomChains())
+	cfg.EVMChainConfigs = map[int64]*EVMConfig{1: {Name: "my_eth"}}
+	require.Error(t, cfg.RegisterCustomChains())
+}
+
+func TestConfig_LoadWatchedContracts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "router.json")
+	require.NoError(t, os.WriteFile(path, []byte(GetConnectorABI()), 0600))
+	router := WatchedContract{Address: "0x00000000000000000000000000000000000000aa", Kind: ContractKindConnector, ABIPath: path}
+
+	cfg := NewConfig()
+	cfg.EVMChainConfigs = map[int64]*EVMConfig{1: {Chain: common.EthChain(), WatchedContracts: []WatchedContract{router}}}
+	require.NoError(t, cfg.LoadContractABIs())
+	evmCfg, found := cfg.GetEVMConfig(1)
+	require.True(t, found)
+	require.Equal(t, GetConnectorABI(), evmCfg.WatchedContracts[0].GetABI())
+
+	// the custody abi has no ZetaSent event
+	require.NoError(t, os.WriteFile(path, []byte(GetERC20CustodyABI()), 0600))
+	require.Error(t, cfg.LoadContractABIs())
+
+	router.ABIPath = ""
+	router.Kind = "router"
+	cfg.EVMChainConfigs[1].WatchedContracts = []WatchedContract{router}
+	require.Error(t, cfg.LoadContractABIs())
+
+	router.Kind = ContractKindERC20Custody
+	router.Address = "0xaa"
+	cfg.EVMChainConfigs[1].WatchedContracts = []WatchedContract{router}
+	require.Error(t, cfg.LoadContractABIs())
+}
+
+func TestConfig_IsChainSupported(t *testing.T) {
+	cfg := NewConfig()
+	// the known chains are supported until the chains are read from zetacore
+	require.True(t, cfg.IsChainSupported(common.BscMainnetChain().ChainId))
+	require.False(t, cfg.IsChainSupported(99001))
+
+	cfg.ChainsEnabled = []common.Chain{common.EthChain()}
+	require.True(t, cfg.IsChainSupported(common.EthChain().ChainId))
+	require.False(t, cfg.IsChainSupported(common.BscMainnetChain().ChainId))
+	// the zeta chain is always supported
+	require.True(t, cfg.IsChainSupported(common.ZetaChain().ChainId))
+}
@@ -0,0 +1,79 @@
+package zetaclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	metricsPkg "github.com/zeta-chain/zetacore/zetaclient/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// txResultError is a tx rejected or failed by zetacore with an ABCI code
+type txResultError struct {
+	codespace string
+	code      uint32
+	msg       string
+}
+
+func (e *txResultError) Error() string {
+	return e.msg
+}
+
+// coreCallCode returns the result code of a call to zetacore: the ABCI codespace and code of a tx rejected or failed,
+// CircuitOpen for the posts held by the circuit breaker, otherwise the grpc status code of the error, which tells a slow or unreachable zetacore from a rejected call
+func coreCallCode(err error) string {
+	var txErr *txResultError
+	if errors.As(err, &txErr) {
+		return fmt.Sprintf("%s_%d", txErr.codespace, txErr.code)
+	}
+	if errors.Is(err, ErrCoreUnavailable) {
+		return "CircuitOpen"
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return status.FromContextError(err).Code().String()
+	}
+	if s, ok := status.FromError(err); ok {
+		return s.Code().String()
+	}
+	return codes.Unknown.String()
+}
+
+// observeCoreCall records the latency and the result code of a call to zetacore
+func observeCoreCall(method string, begin time.Time, err error) {
+	code := coreCallCode(err)
+	metricsPkg.CoreCalls.WithLabelValues(method, code).Inc()
+	metricsPkg.CoreCallLatency.WithLabelValues(method, code).Observe(time.Since(begin).Seconds())
+}
+
+// observeCoreQuery is the grpc interceptor recording the queries to zetacore, labeled by the name of the grpc method
+func observeCoreQuery(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) (err error) {
+	defer func(begin time.Time) {
+		observeCoreCall(path.Base(method), begin, err)
+	}(time.Now())
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+// broadcastMethod returns the method a broadcast is recorded as: the name of the message wrapped by authz, e.g.
+// Broadcast/MsgVoteOnObservedInboundTx
+func broadcastMethod(msg sdktypes.Msg) string {
+	typeURL := sdktypes.MsgTypeURL(msg)
+	if exec, ok := msg.(*authz.MsgExec); ok && len(exec.Msgs) > 0 {
+		typeURL = exec.Msgs[0].TypeUrl
+	}
+	return "Broadcast/" + typeURL[strings.LastIndex(typeURL, ".")+1:]
+}
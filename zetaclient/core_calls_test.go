@@ -0,0 +1,63 @@
+package zetaclient
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	crosschaintypes "github.com/zeta-chain/zetacore/x/crosschain/types"
+	observertypes "github.com/zeta-chain/zetacore/x/observer/types"
+	metricsPkg "github.com/zeta-chain/zetacore/zetaclient/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCoreCallCode(t *testing.T) {
+	require.Equal(t, "OK", coreCallCode(nil))
+	require.Equal(t, "NotFound", coreCallCode(status.Error(codes.NotFound, "not found ballot")))
+	require.Equal(t, "DeadlineExceeded", coreCallCode(fmt.Errorf("query: %w", context.DeadlineExceeded)))
+	require.Equal(t, "Unknown", coreCallCode(fmt.Errorf("connection refused")))
+	require.Equal(t, "CircuitOpen", coreCallCode(fmt.Errorf("PostSend: %w", ErrCoreUnavailable)))
+
+	// the txs rejected by zetacore are recorded by ABCI code, and their error is still matched on by message
+	err := fmt.Errorf("broadcast: %w", &txResultError{codespace: "sdk", code: 32, msg: "account sequence mismatch, expected 5, got 4"})
+	require.Equal(t, "sdk_32", coreCallCode(err))
+	expected, ok := ExpectedSequence(err.Error())
+	require.True(t, ok)
+	require.Equal(t, uint64(5), expected)
+}
+
+func TestBroadcastMethod(t *testing.T) {
+	msg := &crosschaintypes.MsgGasPriceVoter{Creator: "creator", ChainId: 1, Price: 10}
+	exec := authz.NewMsgExec(sdktypes.AccAddress("grantee"), []sdktypes.Msg{msg})
+	require.Equal(t, "Broadcast/MsgGasPriceVoter", broadcastMethod(&exec))
+	require.Equal(t, "Broadcast/MsgGasPriceVoter", broadcastMethod(msg))
+}
+
+func TestObserveCoreQuery(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	observertypes.RegisterQueryServer(server, &ballotQueryServer{})
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+	conn, err := dialZetaCore(listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+	bridge := &ZetaCoreBridge{ctx: context.Background(), logger: zerolog.Nop(), endpoints: []*coreEndpoint{{host: "127.0.0.1", grpcConn: conn}}}
+
+	notFound := metricsPkg.CoreCalls.WithLabelValues("BallotByIdentifier", "NotFound")
+	before := testutil.ToFloat64(notFound)
+	_, err = bridge.GetBallot("0xunknown")
+	require.Error(t, err)
+	require.Equal(t, before+1, testutil.ToFloat64(notFound))
+}
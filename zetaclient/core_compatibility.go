@@ -0,0 +1,110 @@
+package zetaclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client/grpc/reflection"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/zeta-chain/zetacore/common"
+	crosschaintypes "github.com/zeta-chain/zetacore/x/crosschain/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrIncompatibleCore is returned when zetacore doesn't know some of the msgs zetaclient broadcasts, e.g. after an
+// upgrade renamed them, so that their txs would be rejected
+var ErrIncompatibleCore = errors.New("zetacore incompatible with zetaclient")
+
+// CoreCompatibility is the compatibility of zetacore with the msgs and the version of zetaclient
+type CoreCompatibility struct {
+	CoreVersion   string
+	ClientVersion string
+
+	// MissingMsgs are the msgs zetaclient broadcasts which zetacore doesn't register
+	MissingMsgs []string
+
+	// VersionMismatch is set when the major versions of zetacore and zetaclient differ
+	VersionMismatch bool
+}
+
+// CheckCoreCompatibility checks zetacore registers every msg zetaclient broadcasts and runs the major version of
+// zetaclient. The missing msgs fail the check as their txs would be rejected; a version mismatch is only reported, as
+// the msgs may be unchanged across versions
+func (b *ZetaCoreBridge) CheckCoreCompatibility() (CoreCompatibility, error) {
+	nodeInfo, err := b.GetNodeInfo()
+	if err != nil {
+		return CoreCompatibility{}, fmt.Errorf("fail to query node info of zetacore: %w", err)
+	}
+	coreMsgs, err := b.GetCoreMsgs()
+	if status.Code(err) == codes.Unimplemented {
+		// zetacore doesn't serve the reflection service, the msgs can't be checked
+		b.logger.Warn().Msg("CheckCoreCompatibility: zetacore doesn't list its msgs, skipping their check")
+		coreMsgs = nil
+	} else if err != nil {
+		return CoreCompatibility{}, fmt.Errorf("fail to query msgs of zetacore: %w", err)
+	}
+
+	coreVersion := nodeInfo.GetApplicationVersion().GetVersion()
+	compatibility := checkCoreCompatibility(coreVersion, common.Version, coreMsgs)
+	if compatibility.VersionMismatch {
+		b.logger.Warn().Msgf("CheckCoreCompatibility: zetacore runs version %s, zetaclient version %s; upgrade zetaclient to the version of zetacore",
+			compatibility.CoreVersion, compatibility.ClientVersion)
+	}
+	if len(compatibility.MissingMsgs) > 0 {
+		return compatibility, fmt.Errorf("%w: zetacore version %s doesn't register %s", ErrIncompatibleCore,
+			compatibility.CoreVersion, strings.Join(compatibility.MissingMsgs, ", "))
+	}
+	return compatibility, nil
+}
+
+// GetCoreMsgs returns the type urls of the msgs registered by zetacore
+func (b *ZetaCoreBridge) GetCoreMsgs() ([]string, error) {
+	client := reflection.NewReflectionServiceClient(b.conn())
+	resp, err := client.ListImplementations(context.Background(), &reflection.ListImplementationsRequest{
+		InterfaceName: sdk.MsgInterfaceProtoName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.ImplementationMessageNames, nil
+}
+
+// checkCoreCompatibility checks the msgs registered by zetacore, unchecked if nil, against those zetaclient
+// broadcasts, and the version of zetacore against that of zetaclient; the versions of dev builds are unchecked
+func checkCoreCompatibility(coreVersion string, clientVersion string, coreMsgs []string) CoreCompatibility {
+	compatibility := CoreCompatibility{CoreVersion: coreVersion, ClientVersion: clientVersion}
+	if coreMsgs != nil {
+		registered := make(map[string]bool, len(coreMsgs))
+		for _, msg := range coreMsgs {
+			registered[msg] = true
+		}
+		// the votes are broadcast wrapped in an authz exec
+		required := append([]string{sdk.MsgTypeURL(&authz.MsgExec{})}, crosschaintypes.GetAllAuthzZetaclientTxTypes()...)
+		for _, msg := range required {
+			if !registered[msg] {
+				compatibility.MissingMsgs = append(compatibility.MissingMsgs, msg)
+				registered[msg] = true // reported once
+			}
+		}
+		sort.Strings(compatibility.MissingMsgs)
+	}
+	coreMajor, coreOk := majorVersion(coreVersion)
+	clientMajor, clientOk := majorVersion(clientVersion)
+	compatibility.VersionMismatch = coreOk && clientOk && coreMajor != clientMajor
+	return compatibility
+}
+
+// majorVersion returns the major version of a semantic version, e.g. 10 of v10.1.2; false if the version is not one
+func majorVersion(version string) (string, bool) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	major, _, found := strings.Cut(version, ".")
+	if !found || major == "" || strings.Trim(major, "0123456789") != "" {
+		return "", false
+	}
+	return major, true
+}
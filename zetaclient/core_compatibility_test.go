@@ -0,0 +1,47 @@
+package zetaclient
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/stretchr/testify/require"
+	crosschaintypes "github.com/zeta-chain/zetacore/x/crosschain/types"
+)
+
+func TestCheckCoreCompatibility(t *testing.T) {
+	coreMsgs := append([]string{sdk.MsgTypeURL(&authz.MsgExec{})}, crosschaintypes.GetAllAuthzZetaclientTxTypes()...)
+
+	compatibility := checkCoreCompatibility("v10.1.2", "v10.0.0", coreMsgs)
+	require.Empty(t, compatibility.MissingMsgs)
+	require.False(t, compatibility.VersionMismatch)
+
+	// a msg renamed by an upgrade of zetacore is missing
+	inbound := sdk.MsgTypeURL(&crosschaintypes.MsgVoteOnObservedInboundTx{})
+	var upgraded []string
+	for _, msg := range coreMsgs {
+		if msg != inbound {
+			upgraded = append(upgraded, msg)
+		}
+	}
+	compatibility = checkCoreCompatibility("v11.0.0", "v10.0.0", upgraded)
+	require.Equal(t, []string{inbound}, compatibility.MissingMsgs)
+	require.True(t, compatibility.VersionMismatch)
+
+	// the msgs are unchecked if zetacore doesn't list them, and the versions of dev builds are unchecked
+	compatibility = checkCoreCompatibility("v11.0.0", "", nil)
+	require.Empty(t, compatibility.MissingMsgs)
+	require.False(t, compatibility.VersionMismatch)
+}
+
+func TestMajorVersion(t *testing.T) {
+	for version, major := range map[string]string{"v10.1.2": "10", "1.0.0-rc1": "1", " v0.2.0 ": "0"} {
+		got, ok := majorVersion(version)
+		require.True(t, ok, version)
+		require.Equal(t, major, got)
+	}
+	for _, version := range []string{"", "v", "main", "vX.1.0", "10"} {
+		_, ok := majorVersion(version)
+		require.False(t, ok, version)
+	}
+}
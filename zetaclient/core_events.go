@@ -0,0 +1,241 @@
+package zetaclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/rs/zerolog"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	crosschaintypes "github.com/zeta-chain/zetacore/x/crosschain/types"
+	observertypes "github.com/zeta-chain/zetacore/x/observer/types"
+	metricsPkg "github.com/zeta-chain/zetacore/zetaclient/metrics"
+)
+
+// CoreEventType is the kind of a zetacore state change pushed to the core event listener
+type CoreEventType string
+
+const (
+	CoreEventNewBlock          CoreEventType = "new_block"
+	CoreEventInboundFinalized  CoreEventType = "inbound_finalized"
+	CoreEventOutboundScheduled CoreEventType = "outbound_scheduled"
+	CoreEventKeygen            CoreEventType = "keygen"
+)
+
+// CoreEventsConnectBackoff schedules the attempts to subscribe to the events of zetacore; once subscribed, the
+// websocket reconnects and subscribes again by itself
+var CoreEventsConnectBackoff = Backoff{
+	InitialInterval: time.Second,
+	MaxInterval:     30 * time.Second,
+	Multiplier:      2,
+	Jitter:          0.2,
+}
+
+// CoreEvent is a state change of zetacore
+type CoreEvent struct {
+	Type      CoreEventType
+	Height    int64
+	CctxIndex string // cctx of the finalized inbound or scheduled outbound, if any
+}
+
+// coreEventQuery is a websocket subscription to the events of a type; key is the attribute of the module event the
+// query matches, if any
+type coreEventQuery struct {
+	eventType CoreEventType
+	query     string
+	key       string
+}
+
+// typedEventQuery returns the query of the txs emitting the typed module event, whose attributes are named after the
+// proto message of the event
+func typedEventQuery(eventType CoreEventType, event proto.Message, attribute string) coreEventQuery {
+	key := fmt.Sprintf("%s.%s", proto.MessageName(event), attribute)
+	return coreEventQuery{
+		eventType: eventType,
+		query:     fmt.Sprintf("%s AND %s EXISTS", tmtypes.EventQueryTx.String(), key),
+		key:       key,
+	}
+}
+
+// coreEventQueries are the subscriptions of the listener; zetacore allows 5 subscriptions per client by default
+var coreEventQueries = []coreEventQuery{
+	{eventType: CoreEventNewBlock, query: tmtypes.EventQueryNewBlockHeader.String()},
+	typedEventQuery(CoreEventInboundFinalized, &crosschaintypes.EventInboundFinalized{}, "cctx_index"),
+	typedEventQuery(CoreEventOutboundScheduled, &crosschaintypes.EventZrcWithdrawCreated{}, "cctx_index"),
+	typedEventQuery(CoreEventOutboundScheduled, &crosschaintypes.EventZetaWithdrawCreated{}, "cctx_index"),
+	typedEventQuery(CoreEventKeygen, &observertypes.EventKeygenBlockUpdated{}, "keygen_block"),
+}
+
+// CoreEventListener subscribes to the new blocks and module events of zetacore over the websocket and pushes them to
+// its subscribers, so that they react to the state changes of zetacore rather than wait for their next poll
+type CoreEventListener struct {
+	remote string
+	logger zerolog.Logger
+
+	mu          sync.Mutex
+	subscribers map[CoreEventType][]chan CoreEvent
+}
+
+// NewCoreEventListener creates a listener of the tendermint rpc at remote
+func NewCoreEventListener(remote string, logger zerolog.Logger) *CoreEventListener {
+	return &CoreEventListener{
+		remote:      remote,
+		logger:      logger,
+		subscribers: make(map[CoreEventType][]chan CoreEvent),
+	}
+}
+
+// Subscribe returns a channel receiving the events of the types. An event is dropped while the channel is full, so
+// the subscribers keep polling zetacore as a fallback
+func (l *CoreEventListener) Subscribe(types ...CoreEventType) <-chan CoreEvent {
+	events := make(chan CoreEvent, 16)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, eventType := range types {
+		l.subscribers[eventType] = append(l.subscribers[eventType], events)
+	}
+	return events
+}
+
+// Run subscribes to the events of zetacore, retrying with backoff, and pushes them to the subscribers until ctx is done
+func (l *CoreEventListener) Run(ctx context.Context) {
+	for attempt := 0; ; attempt++ {
+		client, results, err := l.connect(ctx)
+		if err == nil {
+			l.logger.Info().Msgf("CoreEventListener: subscribed to the events of zetacore at %s", l.remote)
+			l.listen(ctx, results)
+			if err := client.Stop(); err != nil {
+				l.logger.Debug().Err(err).Msg("CoreEventListener: error stopping websocket client")
+			}
+			return
+		}
+		l.logger.Warn().Err(err).Msgf("CoreEventListener: fail to subscribe to the events of zetacore at %s", l.remote)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(CoreEventsConnectBackoff.Interval(attempt)):
+		}
+	}
+}
+
+// connect starts a websocket client and subscribes to the queries of the listener; the results of all queries are
+// forwarded to the returned channel until ctx is done
+func (l *CoreEventListener) connect(ctx context.Context) (*rpchttp.HTTP, <-chan ctypes.ResultEvent, error) {
+	client, err := rpchttp.New(l.remote, "/websocket")
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := client.Start(); err != nil {
+		return nil, nil, err
+	}
+	results := make(chan ctypes.ResultEvent)
+	for _, query := range coreEventQueries {
+		subscribeCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		out, err := client.Subscribe(subscribeCtx, "zetaclient", query.query, 16)
+		cancel()
+		if err != nil {
+			_ = client.Stop()
+			return nil, nil, fmt.Errorf("fail to subscribe to %s: %w", query.query, err)
+		}
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case result := <-out:
+					select {
+					case results <- result:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}()
+	}
+	return client, results, nil
+}
+
+// listen pushes the events of the results to the subscribers until ctx is done
+func (l *CoreEventListener) listen(ctx context.Context, results <-chan ctypes.ResultEvent) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case result := <-results:
+			for _, event := range parseCoreEvents(result) {
+				l.publish(event)
+			}
+		}
+	}
+}
+
+// publish pushes the event to the subscribers of its type, dropping it for the subscribers whose channel is full
+func (l *CoreEventListener) publish(event CoreEvent) {
+	metricsPkg.CoreEvents.WithLabelValues(string(event.Type)).Inc()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, events := range l.subscribers[event.Type] {
+		select {
+		case events <- event:
+		default:
+			l.logger.Debug().Msgf("CoreEventListener: subscriber busy, dropping %s event at height %d", event.Type, event.Height)
+		}
+	}
+}
+
+// parseCoreEvents returns the core events of the result of a subscription: a new block, or one event per module
+// event of the tx
+func parseCoreEvents(result ctypes.ResultEvent) []CoreEvent {
+	if header, ok := result.Data.(tmtypes.EventDataNewBlockHeader); ok {
+		return []CoreEvent{{Type: CoreEventNewBlock, Height: header.Header.Height}}
+	}
+	var height int64
+	if heights := result.Events[tmtypes.TxHeightKey]; len(heights) > 0 {
+		height, _ = strconv.ParseInt(heights[0], 10, 64)
+	}
+	var events []CoreEvent
+	for _, query := range coreEventQueries {
+		if query.query != result.Query || query.key == "" {
+			continue
+		}
+		for _, value := range result.Events[query.key] {
+			event := CoreEvent{Type: query.eventType, Height: height}
+			if strings.HasSuffix(query.key, ".cctx_index") {
+				event.CctxIndex = unquoteAttribute(value)
+			}
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// unquoteAttribute returns the value of an attribute of a typed event, which is JSON encoded
+func unquoteAttribute(value string) string {
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		return unquoted
+	}
+	return value
+}
+
+// EnableCoreEvents starts listening to the events of the zetacore node the bridge was created with until the bridge
+// is stopped; the config updater then updates the keygen as soon as it changes
+func (b *ZetaCoreBridge) EnableCoreEvents() *CoreEventListener {
+	remote := b.chainRPC()
+	if !strings.HasPrefix(remote, "http") {
+		remote = fmt.Sprintf("tcp://%s", remote)
+	}
+	b.coreEvents = NewCoreEventListener(remote, b.logger.With().Str("module", "CoreEventListener").Logger())
+	go b.coreEvents.Run(b.ctx)
+	return b.coreEvents
+}
+
+// CoreEvents returns the core event listener of the bridge, nil unless enabled
+func (b *ZetaCoreBridge) CoreEvents() *CoreEventListener {
+	return b.coreEvents
+}
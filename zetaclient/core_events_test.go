@@ -0,0 +1,59 @@
+package zetaclient
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+func TestParseCoreEvents(t *testing.T) {
+	events := parseCoreEvents(ctypes.ResultEvent{
+		Query: tmtypes.EventQueryNewBlockHeader.String(),
+		Data:  tmtypes.EventDataNewBlockHeader{Header: tmtypes.Header{Height: 42}},
+	})
+	require.Equal(t, []CoreEvent{{Type: CoreEventNewBlock, Height: 42}}, events)
+
+	// the attributes of the typed events are JSON encoded
+	inbound := coreEventQueries[1]
+	events = parseCoreEvents(ctypes.ResultEvent{
+		Query: inbound.query,
+		Data:  tmtypes.EventDataTx{},
+		Events: map[string][]string{
+			tmtypes.TxHeightKey: {"43"},
+			inbound.key:         {`"0xcctx1"`, `"0xcctx2"`},
+		},
+	})
+	require.Equal(t, []CoreEvent{
+		{Type: CoreEventInboundFinalized, Height: 43, CctxIndex: "0xcctx1"},
+		{Type: CoreEventInboundFinalized, Height: 43, CctxIndex: "0xcctx2"},
+	}, events)
+
+	keygen := coreEventQueries[4]
+	events = parseCoreEvents(ctypes.ResultEvent{
+		Query:  keygen.query,
+		Data:   tmtypes.EventDataTx{},
+		Events: map[string][]string{tmtypes.TxHeightKey: {"44"}, keygen.key: {`"100"`}},
+	})
+	require.Equal(t, []CoreEvent{{Type: CoreEventKeygen, Height: 44}}, events)
+
+	require.Empty(t, parseCoreEvents(ctypes.ResultEvent{Query: "unknown", Data: tmtypes.EventDataTx{}}))
+}
+
+func TestCoreEventListener_Publish(t *testing.T) {
+	listener := NewCoreEventListener("tcp://127.0.0.1:26657", zerolog.Nop())
+	blocks := listener.Subscribe(CoreEventNewBlock, CoreEventOutboundScheduled)
+	keygens := listener.Subscribe(CoreEventKeygen)
+
+	listener.publish(CoreEvent{Type: CoreEventOutboundScheduled, Height: 10, CctxIndex: "0xcctx"})
+	require.Equal(t, CoreEvent{Type: CoreEventOutboundScheduled, Height: 10, CctxIndex: "0xcctx"}, <-blocks)
+	require.Empty(t, keygens)
+
+	// the events are dropped rather than blocking while a subscriber is busy
+	for i := 0; i < cap(blocks)+1; i++ {
+		listener.publish(CoreEvent{Type: CoreEventNewBlock, Height: int64(i)})
+	}
+	require.Len(t, blocks, cap(blocks))
+}
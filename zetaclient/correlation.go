@@ -0,0 +1,37 @@
+package zetaclient
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+	"github.com/zeta-chain/zetacore/x/crosschain/types"
+)
+
+// CorrelationIDField is the log field, and the exemplar label of the event metrics, carrying the correlation id of a
+// cross-chain transfer
+const CorrelationIDField = "correlation_id"
+
+// InboundCorrelationID returns the correlation id of an inbound event: the digest of its vote, which is the index of
+// the cctx zetacore creates. The outbound of the cctx is observed and confirmed under the same index, so a single id
+// follows a transfer from the inbound event through the vote to the outbound confirmation, on every observer
+func InboundCorrelationID(msg *types.MsgVoteOnObservedInboundTx) string {
+	return msg.Digest()
+}
+
+// WithCorrelationID returns the logger adding the correlation id to every log line; the logger is returned as is if
+// the id is empty
+func WithCorrelationID(logger zerolog.Logger, correlationID string) zerolog.Logger {
+	if correlationID == "" {
+		return logger
+	}
+	return logger.With().Str(CorrelationIDField, correlationID).Logger()
+}
+
+// addWithCorrelationID increments the counter, with the correlation id as exemplar if set so that a metric spike
+// leads to the logs of a transfer behind it
+func addWithCorrelationID(counter prometheus.Counter, correlationID string) {
+	if adder, ok := counter.(prometheus.ExemplarAdder); ok && correlationID != "" {
+		adder.AddWithExemplar(1, prometheus.Labels{CorrelationIDField: correlationID})
+		return
+	}
+	counter.Inc()
+}
@@ -0,0 +1,26 @@
+package zetaclient
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zeta-chain/zetacore/common"
+)
+
+func TestInboundCorrelationID(t *testing.T) {
+	msg, err := InboundEvent{Sender: "0x01", SenderChain: common.EthChain(), TxOrigin: "0x01", Receiver: "0x02", ReceiverChain: common.ZetaChain(),
+		Amount: big.NewInt(100), InTxHash: "0xabc", InBlockHeight: 100, GasLimit: 90_000, CoinType: common.CoinType_Gas}.VoteMessage("zeta1observer")
+	require.NoError(t, err)
+	// every observer derives the same id, which is the index of the cctx
+	id := InboundCorrelationID(msg)
+	require.Equal(t, msg.Digest(), id)
+	otherObserver := *msg
+	otherObserver.Creator = "zeta1other"
+	require.Equal(t, id, InboundCorrelationID(&otherObserver))
+
+	// and another event of the tx gets another one
+	other := *msg
+	other.EventIndex = 1
+	require.NotEqual(t, id, InboundCorrelationID(&other))
+}
@@ -7,4 +7,37 @@ import (
 var (
 	ErrBech32ifyPubKey = errors.New("Bech32ifyPubKey fail in main")
 	ErrNewPubKey       = errors.New("NewPubKey error from string")
+
+	// ErrInboundRejected is returned for the inbound events deliberately not voted on, e.g. donations or deposits of
+	// assets not whitelisted; such events are skipped but never quarantined
+	ErrInboundRejected = errors.New("inbound event rejected")
+
+	// ErrInboundAboveCap is returned for the inbound transfers withheld because they are above the amount cap of their
+	// asset; unlike rejected events they are quarantined, to be processed once reviewed
+	ErrInboundAboveCap = errors.New("inbound amount above cap")
+
+	// ErrInboundThrottled is returned for the inbound events of a sender above its rate limit; they are quarantined
+	// whatever StrictDecoding, to be voted on with the quarantine command once reviewed
+	ErrInboundThrottled = errors.New("inbound event throttled")
+
+	// ErrScreeningFailed is returned when the addresses of an inbound transfer can't be screened, e.g. the screening
+	// provider is down; the range is scanned again rather than voting on an unscreened transfer
+	ErrScreeningFailed = errors.New("address screening failed")
+
+	// ErrInvalidInboundReceipt is returned for the inbound event logs whose tx failed or whose receipt doesn't hold the
+	// log of the expected contract; such events are skipped, while a receipt that can't be fetched has the range scanned
+	// again
+	ErrInvalidInboundReceipt = errors.New("invalid inbound receipt")
+
+	// ErrInboundProofRejected is returned when zetacore finds the proof of the tx of an inbound event invalid against
+	// the block headers voted by the observers; the event is quarantined whatever StrictDecoding
+	ErrInboundProofRejected = errors.New("inbound proof rejected")
+
+	// ErrPostRateLimited is returned for the inbound votes of a chain above the post rate limit of the bridge; they are
+	// queued and posted again once the chain is back under its rate
+	ErrPostRateLimited = errors.New("post to zetacore rate limited")
+
+	// ErrVoteRejected is returned for the votes of a failed batch that zetacore rejects on their own in simulation
+	// while the other votes of the batch pass; they are dead-lettered rather than retried
+	ErrVoteRejected = errors.New("vote rejected by zetacore")
 )
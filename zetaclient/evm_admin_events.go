@@ -0,0 +1,185 @@
+package zetaclient
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	metricsPkg "github.com/zeta-chain/zetacore/zetaclient/metrics"
+	clienttypes "github.com/zeta-chain/zetacore/zetaclient/types"
+)
+
+// Admin events of the ERC-1967 proxies and of the Ownable, Pausable and AccessControl contracts
+var (
+	ProxyUpgradedEventID        = crypto.Keccak256Hash([]byte("Upgraded(address)"))
+	ProxyAdminChangedEventID    = crypto.Keccak256Hash([]byte("AdminChanged(address,address)"))
+	OwnershipTransferredEventID = crypto.Keccak256Hash([]byte("OwnershipTransferred(address,address)"))
+	PausedEventID               = crypto.Keccak256Hash([]byte("Paused(address)"))
+	UnpausedEventID             = crypto.Keccak256Hash([]byte("Unpaused(address)"))
+	RoleGrantedEventID          = crypto.Keccak256Hash([]byte("RoleGranted(bytes32,address,address)"))
+	RoleRevokedEventID          = crypto.Keccak256Hash([]byte("RoleRevoked(bytes32,address,address)"))
+)
+
+// adminEvents are the admin events watched on the watched contracts, by topic hash
+var adminEvents = map[ethcommon.Hash]adminEventDecoder{
+	// Upgraded(address indexed implementation)
+	ProxyUpgradedEventID: {name: WebhookEventProxyUpgraded, target: indexedAddress(1)},
+	// AdminChanged(address previousAdmin, address newAdmin)
+	ProxyAdminChangedEventID: {name: WebhookEventProxyAdminChanged, target: dataAddress(1, 2)},
+	// OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+	OwnershipTransferredEventID: {name: WebhookEventOwnershipTransferred, target: indexedAddress(2)},
+	// Paused(address account) and Unpaused(address account)
+	PausedEventID:   {name: WebhookEventPaused, target: dataAddress(0, 1)},
+	UnpausedEventID: {name: WebhookEventUnpaused, target: dataAddress(0, 1)},
+	// RoleGranted(bytes32 indexed role, address indexed account, address indexed sender) and RoleRevoked
+	RoleGrantedEventID: {name: WebhookEventRoleGranted, target: indexedAddress(2), withRole: true},
+	RoleRevokedEventID: {name: WebhookEventRoleRevoked, target: indexedAddress(2), withRole: true},
+}
+
+// AdminEventIDs returns the topic hashes of the watched admin events
+func AdminEventIDs() []ethcommon.Hash {
+	return []ethcommon.Hash{
+		ProxyUpgradedEventID,
+		ProxyAdminChangedEventID,
+		OwnershipTransferredEventID,
+		PausedEventID,
+		UnpausedEventID,
+		RoleGrantedEventID,
+		RoleRevokedEventID,
+	}
+}
+
+// ErrProxyUpgradeUnconfirmed is returned when a watched proxy was upgraded to an implementation operators didn't confirm
+var ErrProxyUpgradeUnconfirmed = errors.New("proxy upgraded to an unconfirmed implementation")
+
+// AdminEvent is an admin event of a watched contract: an implementation upgrade, a change of admin or owner, a pause
+// or a role change, any of which may mean the bridge contracts are tampered with
+type AdminEvent struct {
+	Contract ethcommon.Address
+	Event    string            // one of the WebhookEvent types of the admin events
+	Target   ethcommon.Address // the new implementation, admin or owner, the pauser or the account of the role
+	Role     *ethcommon.Hash   // the role granted or revoked
+	Log      ethtypes.Log
+}
+
+type adminEventDecoder struct {
+	name     string
+	target   func(vLog ethtypes.Log) (ethcommon.Address, bool)
+	withRole bool
+}
+
+// indexedAddress reads an address from the i-th topic
+func indexedAddress(i int) func(vLog ethtypes.Log) (ethcommon.Address, bool) {
+	return func(vLog ethtypes.Log) (ethcommon.Address, bool) {
+		if len(vLog.Topics) <= i {
+			return ethcommon.Address{}, false
+		}
+		return ethcommon.BytesToAddress(vLog.Topics[i].Bytes()), true
+	}
+}
+
+// dataAddress reads an address from the i-th of the n words of the data
+func dataAddress(i, n int) func(vLog ethtypes.Log) (ethcommon.Address, bool) {
+	return func(vLog ethtypes.Log) (ethcommon.Address, bool) {
+		if len(vLog.Data) != n*ethcommon.HashLength {
+			return ethcommon.Address{}, false
+		}
+		return ethcommon.BytesToAddress(vLog.Data[i*ethcommon.HashLength : (i+1)*ethcommon.HashLength]), true
+	}
+}
+
+// MatchAdminEvent returns the admin event of vLog if it was emitted by one of the contracts
+func (w WatchedContracts) MatchAdminEvent(vLog ethtypes.Log) (AdminEvent, bool) {
+	if _, found := w.byAddress[vLog.Address]; !found || len(vLog.Topics) == 0 {
+		return AdminEvent{}, false
+	}
+	decoder, found := adminEvents[vLog.Topics[0]]
+	if !found {
+		return AdminEvent{}, false
+	}
+	target, ok := decoder.target(vLog)
+	if !ok {
+		return AdminEvent{}, false
+	}
+	event := AdminEvent{Contract: vLog.Address, Event: decoder.name, Target: target, Log: vLog}
+	if decoder.withRole {
+		event.Role = &vLog.Topics[1]
+	}
+	return event, true
+}
+
+// adminEventsSeen holds the admin events already alerted on, so that a range scanned again alerts only once
+type adminEventsSeen struct {
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+// checkAdminEvents alerts operators of the admin events of the watched contracts among logs. With
+// PauseOnProxyUpgrade, it returns ErrProxyUpgradeUnconfirmed if a proxy was upgraded to an implementation not listed
+// in ConfirmedImplementations, so that no vote is posted until operators confirm the new ABI
+func (ob *EVMChainClient) checkAdminEvents(contracts WatchedContracts, logs []ethtypes.Log) error {
+	evmCfg, _ := ob.cfg.GetEVMConfig(ob.chain.ChainId)
+	var unconfirmed error
+	for _, vLog := range logs {
+		event, found := contracts.MatchAdminEvent(vLog)
+		if !found || vLog.Removed {
+			continue
+		}
+		if ob.markAdminEventSeen(clienttypes.InboundEventKey(vLog.TxHash, vLog.Index)) {
+			ob.alertAdminEvent(event)
+		}
+		if event.Event == WebhookEventProxyUpgraded && evmCfg.PauseOnProxyUpgrade && unconfirmed == nil &&
+			!isConfirmedImplementation(evmCfg.ConfirmedImplementations, event.Target) {
+			unconfirmed = fmt.Errorf("%w: contract %s upgraded to %s at block %d", ErrProxyUpgradeUnconfirmed,
+				event.Contract.Hex(), event.Target.Hex(), vLog.BlockNumber)
+		}
+	}
+	return unconfirmed
+}
+
+// alertAdminEvent logs the admin event at error level, counts it in metrics and publishes it to the webhooks
+func (ob *EVMChainClient) alertAdminEvent(event AdminEvent) {
+	detail := event.Target.Hex()
+	if event.Role != nil {
+		detail = fmt.Sprintf("%s role %s", detail, event.Role.Hex())
+	}
+	metricsPkg.ContractAdminEvents.WithLabelValues(ob.chain.Name(), event.Contract.Hex(), event.Event).Inc()
+	ob.logger.ExternalChainWatcher.Error().Msgf("alertAdminEvent: %s event of watched contract %s: %s in tx %s at block %d",
+		event.Event, event.Contract.Hex(), detail, event.Log.TxHash.Hex(), event.Log.BlockNumber)
+	ob.webhooks.Publish(WebhookEvent{
+		Type:        event.Event,
+		ChainID:     ob.chain.ChainId,
+		Chain:       ob.chain.Name(),
+		TxHash:      event.Log.TxHash.Hex(),
+		BlockNumber: event.Log.BlockNumber,
+		Receiver:    event.Contract.Hex(),
+		Message:     detail,
+	})
+}
+
+// markAdminEventSeen returns true if the admin event is seen for the first time
+func (ob *EVMChainClient) markAdminEventSeen(key string) bool {
+	ob.adminEvents.mu.Lock()
+	defer ob.adminEvents.mu.Unlock()
+	if ob.adminEvents.seen == nil {
+		ob.adminEvents.seen = make(map[string]bool)
+	}
+	if ob.adminEvents.seen[key] {
+		return false
+	}
+	ob.adminEvents.seen[key] = true
+	return true
+}
+
+func isConfirmedImplementation(confirmed []string, implementation ethcommon.Address) bool {
+	for _, address := range confirmed {
+		if strings.EqualFold(address, implementation.Hex()) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package zetaclient
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/zeta-chain/zetacore/common"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
+)
+
+func TestEVMChainClient_CheckAdminEvents(t *testing.T) {
+	chain := common.EthChain()
+	proxy := ethcommon.HexToAddress("0x01")
+	implementation := ethcommon.HexToAddress("0x02")
+	admin := ethcommon.HexToAddress("0x03")
+	contracts := newWatchedContracts([]*watchedContract{{kind: config.ContractKindConnector, address: proxy, event: abi.Event{ID: ethcommon.HexToHash("0xaa")}}})
+	require.Contains(t, contracts.Topics(), ProxyUpgradedEventID)
+
+	upgraded := ethtypes.Log{Address: proxy, Topics: []ethcommon.Hash{ProxyUpgradedEventID, ethcommon.BytesToHash(implementation.Bytes())}, BlockNumber: 100}
+	adminChanged := ethtypes.Log{
+		Address: proxy,
+		Topics:  []ethcommon.Hash{ProxyAdminChangedEventID},
+		Data:    append(ethcommon.LeftPadBytes(proxy.Bytes(), 32), ethcommon.LeftPadBytes(admin.Bytes(), 32)...),
+		Index:   1,
+	}
+	event, found := contracts.MatchAdminEvent(upgraded)
+	require.True(t, found)
+	require.Equal(t, implementation, event.Target)
+	event, found = contracts.MatchAdminEvent(adminChanged)
+	require.True(t, found)
+	require.Equal(t, admin, event.Target)
+	_, found = contracts.MatchAdminEvent(ethtypes.Log{Address: ethcommon.HexToAddress("0x04"), Topics: upgraded.Topics})
+	require.False(t, found)
+
+	role := ethcommon.HexToHash("0xbb")
+	granted := ethtypes.Log{Address: proxy, Topics: []ethcommon.Hash{RoleGrantedEventID, role, ethcommon.BytesToHash(admin.Bytes()), ethcommon.BytesToHash(proxy.Bytes())}}
+	event, found = contracts.MatchAdminEvent(granted)
+	require.True(t, found)
+	require.Equal(t, WebhookEventRoleGranted, event.Event)
+	require.Equal(t, admin, event.Target)
+	require.Equal(t, role, *event.Role)
+	paused := ethtypes.Log{Address: proxy, Topics: []ethcommon.Hash{PausedEventID}, Data: ethcommon.LeftPadBytes(admin.Bytes(), 32)}
+	event, found = contracts.MatchAdminEvent(paused)
+	require.True(t, found)
+	require.Equal(t, admin, event.Target)
+
+	cfg := config.NewConfig()
+	cfg.EVMChainConfigs = map[int64]*config.EVMConfig{chain.ChainId: {Chain: chain}}
+	ob := &EVMChainClient{
+		chain:  chain,
+		cfg:    cfg,
+		logger: EVMLog{ExternalChainWatcher: zerolog.Nop()},
+	}
+	logs := []ethtypes.Log{upgraded, adminChanged}
+
+	// alerts only by default
+	require.NoError(t, ob.checkAdminEvents(contracts, logs))
+
+	// votes are withheld until the implementation is confirmed
+	cfg.EVMChainConfigs[chain.ChainId].PauseOnProxyUpgrade = true
+	err := ob.checkAdminEvents(contracts, logs)
+	require.True(t, errors.Is(err, ErrProxyUpgradeUnconfirmed))
+
+	cfg.EVMChainConfigs[chain.ChainId].ConfirmedImplementations = []string{implementation.Hex()}
+	require.NoError(t, ob.checkAdminEvents(contracts, logs))
+}
@@ -0,0 +1,197 @@
+package zetaclient
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/zeta-chain/zetacore/common"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
+	metricsPkg "github.com/zeta-chain/zetacore/zetaclient/metrics"
+)
+
+// BalanceCheckInterval is the interval, in seconds, between two checks of the balances of the TSS address and of the
+// custody contract
+const BalanceCheckInterval = 60
+
+// BalanceAssetGas is the asset label of the balances in the gas token of the chain
+const BalanceAssetGas = "gas"
+
+// erc20BalanceABI is the part of the ERC20 interface reading the balance of an account
+const erc20BalanceABI = `[{"inputs":[{"internalType":"address","name":"account","type":"address"}],"name":"balanceOf","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"}]`
+
+// BalanceMonitor keeps the last balance seen of every watched address and asset to detect unexpected drops
+type BalanceMonitor struct {
+	mu   sync.Mutex
+	last map[string]*big.Int
+	low  map[string]bool
+}
+
+func NewBalanceMonitor() *BalanceMonitor {
+	return &BalanceMonitor{last: make(map[string]*big.Int), low: make(map[string]bool)}
+}
+
+// CheckMinimum returns whether the balance of the address and asset is below minBalance, and whether it crossed
+// minBalance since the previous check, so that operators are alerted once per crossing
+func (m *BalanceMonitor) CheckMinimum(address ethcommon.Address, asset string, balance, minBalance *big.Int) (low, crossed bool) {
+	key := address.Hex() + "/" + asset
+	low = balance.Cmp(minBalance) < 0
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	crossed = low != m.low[key]
+	m.low[key] = low
+	return low, crossed
+}
+
+// Observe records the balance of the address and asset, and returns true if it dropped by at least dropPercent since
+// the previous check. The first balance seen never raises an alert
+func (m *BalanceMonitor) Observe(address ethcommon.Address, asset string, balance *big.Int, dropPercent uint64) bool {
+	key := address.Hex() + "/" + asset
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	previous, found := m.last[key]
+	m.last[key] = new(big.Int).Set(balance)
+	if !found || previous.Sign() <= 0 || balance.Cmp(previous) >= 0 {
+		return false
+	}
+	// drop * 100 >= previous * dropPercent
+	drop := new(big.Int).Sub(previous, balance)
+	drop.Mul(drop, big.NewInt(100))
+	return drop.Cmp(new(big.Int).Mul(previous, new(big.Int).SetUint64(dropPercent))) >= 0
+}
+
+// WatchBalances periodically checks the balances of the TSS address and of the custody contract
+func (ob *EVMChainClient) WatchBalances() {
+	ticker := time.NewTicker(BalanceCheckInterval * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := ob.checkBalances(); err != nil {
+				ob.logger.ChainLogger.Warn().Err(err).Msg("checkBalances: error checking the watched balances")
+			}
+		case <-ob.stop:
+			ob.logger.ChainLogger.Info().Msg("WatchBalances stopped")
+			return
+		}
+	}
+}
+
+// checkBalances exports the gas token and whitelisted ERC20 balances of the TSS address and of the custody contract,
+// and alerts on the drops larger than BalanceDropAlertPercent
+func (ob *EVMChainClient) checkBalances() error {
+	addresses := []ethcommon.Address{ob.Tss.EVMAddress()}
+	if custody := ob.GetCoreParams().Erc20CustodyContractAddress; ethcommon.IsHexAddress(custody) {
+		addresses = append(addresses, ethcommon.HexToAddress(custody))
+	}
+	for i, address := range addresses {
+		var balance *big.Int
+		err := Retry(ob.ctx, "BalanceAt", RPCBackoff, func() (err error) {
+			balance, err = ob.evmClient.BalanceAt(ob.ctx, address, nil)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		ob.observeBalance(address, BalanceAssetGas, common.CoinType_Gas, balance)
+		if i == 0 {
+			ob.checkSignerBalance(address, balance)
+		}
+	}
+
+	assets, err := ob.whitelist.Assets()
+	if err != nil {
+		return err
+	}
+	parsed, err := abi.JSON(strings.NewReader(erc20BalanceABI))
+	if err != nil {
+		return err
+	}
+	for _, address := range addresses {
+		for _, asset := range assets {
+			var balance *big.Int
+			token := bind.NewBoundContract(asset, parsed, ob.evmClient, nil, nil)
+			err := Retry(ob.ctx, "balanceOf", RPCBackoff, func() error {
+				var out []interface{}
+				if err := token.Call(&bind.CallOpts{Context: ob.ctx}, &out, "balanceOf", address); err != nil {
+					return err
+				}
+				balance = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("balance of %s in %s: %w", address.Hex(), asset.Hex(), err)
+			}
+			ob.observeBalance(address, asset.Hex(), common.CoinType_ERC20, balance)
+		}
+	}
+	return nil
+}
+
+// observeBalance exports the balance and alerts operators if it dropped unexpectedly
+func (ob *EVMChainClient) observeBalance(address ethcommon.Address, asset string, coinType common.CoinType, balance *big.Int) {
+	balanceFloat, _ := new(big.Float).SetInt(balance).Float64()
+	metricsPkg.WatchedBalance.WithLabelValues(ob.chain.Name(), address.Hex(), asset).Set(balanceFloat)
+
+	evmCfg, _ := ob.cfg.GetEVMConfig(ob.chain.ChainId)
+	dropPercent := evmCfg.BalanceDropAlertPercent
+	if dropPercent == 0 {
+		dropPercent = config.DefaultBalanceDropAlertPercent
+	}
+	if !ob.balances.Observe(address, asset, balance, dropPercent) {
+		return
+	}
+	metricsPkg.BalanceDrops.WithLabelValues(ob.chain.Name(), address.Hex(), asset).Inc()
+	ob.logger.ChainLogger.Error().Msgf("observeBalance: balance of %s in %s dropped by %d%% or more to %s",
+		address.Hex(), asset, dropPercent, balance)
+	ob.webhooks.Publish(WebhookEvent{
+		Type:     WebhookEventBalanceDrop,
+		ChainID:  ob.chain.ChainId,
+		Chain:    ob.chain.Name(),
+		Receiver: address.Hex(),
+		CoinType: coinType.String(),
+		Asset:    asset,
+		Amount:   balance.String(),
+		Message:  fmt.Sprintf("balance dropped by %d%% or more since the last check", dropPercent),
+	})
+}
+
+// checkSignerBalance exports the balance of the TSS address, which pays the gas of the outbound txs, and alerts
+// operators once it falls below MinSignerBalance, and again once it is topped up
+func (ob *EVMChainClient) checkSignerBalance(signer ethcommon.Address, balance *big.Int) {
+	balanceFloat, _ := new(big.Float).SetInt(balance).Float64()
+	metricsPkg.SignerBalance.WithLabelValues(ob.chain.Name()).Set(balanceFloat)
+
+	evmCfg, _ := ob.cfg.GetEVMConfig(ob.chain.ChainId)
+	minBalance := evmCfg.GetMinSignerBalance()
+	if minBalance == nil {
+		return
+	}
+	low, crossed := ob.balances.CheckMinimum(signer, BalanceAssetGas, balance, minBalance)
+	if !low {
+		metricsPkg.SignerBalanceLow.WithLabelValues(ob.chain.Name()).Set(0)
+		if crossed {
+			ob.logger.ChainLogger.Info().Msgf("checkSignerBalance: balance %s of signer %s is back above %s", balance, signer.Hex(), minBalance)
+		}
+		return
+	}
+	metricsPkg.SignerBalanceLow.WithLabelValues(ob.chain.Name()).Set(1)
+	ob.logger.ChainLogger.Error().Msgf("checkSignerBalance: balance %s of signer %s is below %s; outbound txs may fail",
+		balance, signer.Hex(), minBalance)
+	if crossed {
+		ob.webhooks.Publish(WebhookEvent{
+			Type:     WebhookEventSignerBalanceLow,
+			ChainID:  ob.chain.ChainId,
+			Chain:    ob.chain.Name(),
+			Receiver: signer.Hex(),
+			CoinType: common.CoinType_Gas.String(),
+			Amount:   balance.String(),
+			Message:  fmt.Sprintf("signer balance below %s", minBalance),
+		})
+	}
+}
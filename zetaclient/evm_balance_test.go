@@ -0,0 +1,50 @@
+package zetaclient
+
+import (
+	"math/big"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBalanceMonitor(t *testing.T) {
+	tss := ethcommon.HexToAddress("0x01")
+	custody := ethcommon.HexToAddress("0x02")
+	m := NewBalanceMonitor()
+
+	// the first balance seen is the reference
+	require.False(t, m.Observe(tss, BalanceAssetGas, big.NewInt(1000), 10))
+	// rises and small drops are expected
+	require.False(t, m.Observe(tss, BalanceAssetGas, big.NewInt(1200), 10))
+	require.False(t, m.Observe(tss, BalanceAssetGas, big.NewInt(1100), 10))
+	// a drop of 10% or more raises an alert
+	require.True(t, m.Observe(tss, BalanceAssetGas, big.NewInt(990), 10))
+	require.True(t, m.Observe(tss, BalanceAssetGas, big.NewInt(0), 10))
+	// and the balance is tracked by address and asset
+	require.False(t, m.Observe(custody, BalanceAssetGas, big.NewInt(10), 10))
+	require.False(t, m.Observe(tss, "0x03", big.NewInt(10), 10))
+}
+
+func TestBalanceMonitor_CheckMinimum(t *testing.T) {
+	signer := ethcommon.HexToAddress("0x01")
+	minBalance := big.NewInt(100)
+	m := NewBalanceMonitor()
+
+	low, crossed := m.CheckMinimum(signer, BalanceAssetGas, big.NewInt(150), minBalance)
+	require.False(t, low)
+	require.False(t, crossed)
+
+	// crossing below is reported once
+	low, crossed = m.CheckMinimum(signer, BalanceAssetGas, big.NewInt(99), minBalance)
+	require.True(t, low)
+	require.True(t, crossed)
+	low, crossed = m.CheckMinimum(signer, BalanceAssetGas, big.NewInt(50), minBalance)
+	require.True(t, low)
+	require.False(t, crossed)
+
+	// and so is the top up
+	low, crossed = m.CheckMinimum(signer, BalanceAssetGas, big.NewInt(100), minBalance)
+	require.False(t, low)
+	require.True(t, crossed)
+}
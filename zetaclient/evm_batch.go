@@ -0,0 +1,150 @@
+package zetaclient
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// BatchRPCClient is implemented by the rpc clients able to send several requests in a single round trip
+type BatchRPCClient interface {
+	BatchCallContext(ctx context.Context, b []rpc.BatchElem) error
+}
+
+// tickBatch holds the latest header, the header of the finality tag and the inbound logs of the next blocks to scan,
+// fetched in a single round trip at the start of a tick. Whatever the batch failed to fetch is fetched the usual way
+type tickBatch struct {
+	latest *ethtypes.Header
+
+	tag       string // finality tag; empty if the tagged header was not fetched
+	tagged    *ethtypes.Header
+	taggedErr error
+
+	query   *ethereum.FilterQuery // nil if the logs were not fetched
+	logs    []ethtypes.Log
+	logsErr error
+}
+
+// finalizedBlock returns the block of the finality tag, or an error for which IsBlockTagUnsupportedError is true
+// if the endpoint doesn't serve the tag
+func (b *tickBatch) finalizedBlock() (uint64, error) {
+	if b.taggedErr != nil {
+		return 0, b.taggedErr
+	}
+	return b.tagged.Number.Uint64(), nil
+}
+
+// logsOf returns the logs matching query if the batch fetched the logs of a range including the range of query
+func (b *tickBatch) logsOf(query ethereum.FilterQuery) ([]ethtypes.Log, bool) {
+	if b.query == nil || b.logsErr != nil ||
+		!reflect.DeepEqual(b.query.Addresses, query.Addresses) || !reflect.DeepEqual(b.query.Topics, query.Topics) ||
+		b.query.FromBlock.Cmp(query.FromBlock) > 0 || b.query.ToBlock.Cmp(query.ToBlock) < 0 {
+		return nil, false
+	}
+	logs := make([]ethtypes.Log, 0, len(b.logs))
+	for _, vLog := range b.logs {
+		if vLog.BlockNumber >= query.FromBlock.Uint64() && vLog.BlockNumber <= query.ToBlock.Uint64() {
+			logs = append(logs, vLog)
+		}
+	}
+	return logs, true
+}
+
+// finalizedBlock returns the highest final block of the finality model, read from the batch of the tick if it has it
+func (ob *EVMChainClient) finalizedBlock(finality Finality, batch *tickBatch, latest uint64) (uint64, error) {
+	if _, ok := finality.(*BlockTagFinality); ok && batch != nil && batch.tag != "" && batch.tag == ob.GetFinalityTag() {
+		finalized, err := batch.finalizedBlock()
+		if err == nil || IsBlockTagUnsupportedError(err) {
+			return finalized, err
+		}
+	}
+	return finality.FinalizedBlock(latest)
+}
+
+// tickBatchHolder holds the batch of the current tick
+type tickBatchHolder struct {
+	mu    sync.Mutex
+	batch *tickBatch
+}
+
+func (ob *EVMChainClient) setTickBatch(batch *tickBatch) {
+	ob.tick.mu.Lock()
+	defer ob.tick.mu.Unlock()
+	ob.tick.batch = batch
+}
+
+func (ob *EVMChainClient) getTickBatch() *tickBatch {
+	ob.tick.mu.Lock()
+	defer ob.tick.mu.Unlock()
+	return ob.tick.batch
+}
+
+// fetchTickBatch fetches the latest header, the header of the finality tag and the inbound logs of the blocks after
+// lastBlock in a single round trip. It returns nil if the rpc client can't batch requests or the batch failed
+func (ob *EVMChainClient) fetchTickBatch(lastBlock int64) *tickBatch {
+	client, ok := ob.evmClient.(BatchRPCClient)
+	if !ok {
+		return nil
+	}
+	batch := &tickBatch{}
+	elems := []rpc.BatchElem{{Method: "eth_getBlockByNumber", Args: []interface{}{"latest", false}, Result: &batch.latest}}
+
+	evmCfg, _ := ob.cfg.GetEVMConfig(ob.chain.ChainId)
+	taggedIndex := -1
+	if evmCfg.FinalityTag != "" && evmCfg.CheckpointContract == "" {
+		batch.tag = evmCfg.FinalityTag
+		taggedIndex = len(elems)
+		elems = append(elems, rpc.BatchElem{Method: "eth_getBlockByNumber", Args: []interface{}{evmCfg.FinalityTag, false}, Result: &batch.tagged})
+	}
+
+	logsIndex := -1
+	if contracts, err := ob.getWatchedContracts(); err == nil {
+		// #nosec G701 always in range
+		toBlock := lastBlock + int64(ob.GetBlocksPerScan(0))
+		if query, ok := BuildInboundFilterQuery(lastBlock+1, toBlock, contracts.Addresses(), contracts.Topics()); ok {
+			batch.query = &query
+			logsIndex = len(elems)
+			elems = append(elems, rpc.BatchElem{Method: "eth_getLogs", Args: []interface{}{filterQueryArg(query)}, Result: &batch.logs})
+		}
+	}
+
+	// no retry; the requests are sent one by one with retries if the batch fails
+	err := client.BatchCallContext(ob.ctx, elems)
+	if err == nil && elems[0].Error == nil && batch.latest == nil {
+		err = ethereum.NotFound
+	}
+	if err == nil {
+		err = elems[0].Error
+	}
+	if err != nil {
+		ob.logger.ExternalChainWatcher.Warn().Err(err).Msg("fetchTickBatch: batch call failed")
+		return nil
+	}
+	if taggedIndex >= 0 {
+		batch.taggedErr = elems[taggedIndex].Error
+		if batch.taggedErr == nil && batch.tagged == nil {
+			batch.taggedErr = ethereum.NotFound
+		}
+	}
+	if logsIndex >= 0 {
+		batch.logsErr = elems[logsIndex].Error
+	}
+	ob.headers.Add(int64(rpc.LatestBlockNumber), batch.latest, time.Now())
+	return batch
+}
+
+// filterQueryArg is the eth_getLogs parameter of query
+func filterQueryArg(query ethereum.FilterQuery) interface{} {
+	return map[string]interface{}{
+		"address":   query.Addresses,
+		"topics":    query.Topics,
+		"fromBlock": hexutil.EncodeBig(query.FromBlock),
+		"toBlock":   hexutil.EncodeBig(query.ToBlock),
+	}
+}
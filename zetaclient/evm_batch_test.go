@@ -0,0 +1,109 @@
+package zetaclient
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/zeta-chain/zetacore/common"
+	observertypes "github.com/zeta-chain/zetacore/x/observer/types"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
+)
+
+// batchRPCClient answers batch calls with the headers of stubEVMRPCClient and logs; single requests are counted
+type batchRPCClient struct {
+	stubEVMRPCClient
+	logs    []ethtypes.Log
+	batches int
+	calls   int
+}
+
+func (c *batchRPCClient) BatchCallContext(_ context.Context, b []rpc.BatchElem) error {
+	c.batches++
+	for i := range b {
+		switch result := b[i].Result.(type) {
+		case **ethtypes.Header:
+			number := c.latest
+			if b[i].Args[0] == config.FinalityTagFinalized {
+				number = c.finalized
+			}
+			*result = &ethtypes.Header{Number: big.NewInt(number)}
+		case *[]ethtypes.Log:
+			*result = c.logs
+		}
+	}
+	return nil
+}
+
+func (c *batchRPCClient) HeaderByNumber(ctx context.Context, number *big.Int) (*ethtypes.Header, error) {
+	c.calls++
+	return c.stubEVMRPCClient.HeaderByNumber(ctx, number)
+}
+
+func (c *batchRPCClient) FilterLogs(_ context.Context, _ ethereum.FilterQuery) ([]ethtypes.Log, error) {
+	c.calls++
+	return nil, nil
+}
+
+func TestEVMChainClient_FetchTickBatch(t *testing.T) {
+	chain := common.EthChain()
+	cfg := config.NewConfig()
+	cfg.EVMChainConfigs = map[int64]*config.EVMConfig{chain.ChainId: {
+		Chain:         chain,
+		FinalityTag:   config.FinalityTagFinalized,
+		BlocksPerScan: 10,
+	}}
+	connector := ethcommon.HexToAddress("0x01")
+	client := &batchRPCClient{
+		stubEVMRPCClient: stubEVMRPCClient{latest: 1000, finalized: 950},
+		logs: []ethtypes.Log{
+			{Address: connector, BlockNumber: 901},
+			{Address: connector, BlockNumber: 905},
+			{Address: connector, BlockNumber: 910},
+		},
+	}
+	ob := &EVMChainClient{
+		ChainMetrics: NewChainMetrics(chain.Name(), nil),
+		Mu:           &sync.Mutex{},
+		ctx:          context.Background(),
+		chain:        chain,
+		cfg:          cfg,
+		params:       observertypes.CoreParams{ConfirmationCount: 10, ConnectorContractAddress: connector.Hex()},
+		logger:       EVMLog{ExternalChainWatcher: zerolog.Nop()},
+		evmClient:    client,
+		blockTimes:   NewBlockTimeEstimator(),
+		stall:        NewStallDetector(),
+	}
+
+	ob.setTickBatch(ob.fetchTickBatch(900))
+	require.Equal(t, 1, client.batches)
+
+	// the headers and the logs of the tick come from the batch
+	confirmed, err := ob.getConfirmedBlockNumber()
+	require.NoError(t, err)
+	require.Equal(t, uint64(950), confirmed)
+
+	contracts, err := ob.getWatchedContracts()
+	require.NoError(t, err)
+	logs, err := ob.filterInboundLogs(901, 905, contracts.Addresses(), contracts.Topics())
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+	require.Equal(t, 0, client.calls)
+
+	// ranges not covered by the batch are fetched the usual way
+	_, err = ob.filterInboundLogs(905, 920, contracts.Addresses(), contracts.Topics())
+	require.NoError(t, err)
+	require.Equal(t, 1, client.calls)
+
+	ob.setTickBatch(nil)
+	_, err = ob.getConfirmedBlockNumber()
+	require.NoError(t, err)
+	require.Equal(t, 3, client.calls)
+}
@@ -8,6 +8,7 @@ import (
 	"math/big"
 	"os"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -16,11 +17,11 @@ import (
 	"github.com/zeta-chain/protocol-contracts/pkg/contracts/evm/zeta.non-eth.sol"
 	zetaconnectoreth "github.com/zeta-chain/protocol-contracts/pkg/contracts/evm/zetaconnector.eth.sol"
 
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
@@ -66,6 +67,7 @@ type EVMChainClient struct {
 	chain                     common.Chain
 	evmClient                 EVMRPCClient
 	KlaytnClient              KlaytnRPCClient
+	rollupClient              RollupRPCClient
 	zetaClient                ZetaCoreBridger
 	Tss                       TSSSigner
 	lastBlockScanned          int64
@@ -80,13 +82,40 @@ type EVMChainClient struct {
 	MaxNonce                  int64
 	OutTxChan                 chan OutTx // send to this channel if you want something back!
 	stop                      chan struct{}
+	stopOnce                  sync.Once
+	keepLastScanned           bool // Stop() leaves the last scanned block of the db as is, see KeepLastScannedBlock
+	watchers                  *WatcherGroup
+	ctx                       context.Context // cancelled on Stop() to abort in-flight rpc calls
+	cancel                    context.CancelFunc
 	fileLogger                *zerolog.Logger // for critical info
 	logger                    EVMLog
 	cfg                       *config.Config
 	params                    observertypes.CoreParams
 	ts                        *TelemetryServer
 
-	BlockCache *lru.Cache
+	BlockCache  *lru.Cache
+	blockHashes *BlockHashTracker
+	headers     *HeaderCache
+	mempool     *MempoolTracker
+	blockTimes  *BlockTimeEstimator
+	stall       *StallDetector
+	webhooks    *WebhookPublisher
+	whitelist   *ERC20Whitelist
+	balances    *BalanceMonitor
+	senders     *SenderRateLimiter
+	screener    AddressScreener
+
+	tokenDecimals *TokenDecimals
+
+	pendingOutTxs *PendingOutTxTracker
+	watched       watchedContractsCache
+	checkpoint    checkpointClient
+	tick          tickBatchHolder
+	adminEvents   adminEventsSeen
+	zevm          zevmContractsCache
+
+	crosschainFlags crosschainFlagsCache
+	backpressured   bool // the last inbound scan was held by a congested bridge, see ZetaCoreBridge.Congested
 }
 
 var _ ChainClient = (*EVMChainClient)(nil)
@@ -103,10 +132,10 @@ func NewEVMChainClient(
 	ts *TelemetryServer,
 ) (*EVMChainClient, error) {
 	ob := EVMChainClient{
-		ChainMetrics: NewChainMetrics(evmCfg.Chain.ChainName.String(), metrics),
+		ChainMetrics: NewChainMetrics(evmCfg.Chain.Name(), metrics),
 		ts:           ts,
 	}
-	chainLogger := logger.With().Str("chain", evmCfg.Chain.ChainName.String()).Logger()
+	chainLogger := logger.With().Str("chain", evmCfg.Chain.Name()).Logger()
 	ob.logger = EVMLog{
 		ChainLogger:          chainLogger,
 		ExternalChainWatcher: chainLogger.With().Str("module", "ExternalChainWatcher").Logger(),
@@ -116,28 +145,42 @@ func NewEVMChainClient(
 	ob.cfg = cfg
 	ob.params = evmCfg.CoreParams
 	ob.stop = make(chan struct{})
+	ob.ctx, ob.cancel = context.WithCancel(context.Background())
 	ob.chain = evmCfg.Chain
+	ob.watchers = NewWatcherGroup(ob.chain.Name(), ob.stop, chainLogger.With().Str("module", "WatcherGroup").Logger())
 	ob.Mu = &sync.Mutex{}
 	ob.zetaClient = bridge
+	if evmCfg.Shadow {
+		ob.logger.ChainLogger.Warn().Msgf("chain %s runs in shadow mode; nothing is voted or signed", ob.chain.Name())
+		ob.zetaClient = NewShadowBridge(bridge, ob.chain, chainLogger.With().Str("module", "ShadowBridge").Logger())
+	} else if ob.chain.IsZetaChain() {
+		// zetacore creates the cctxs of zEVM events itself; the votes are only matched against them
+		ob.zetaClient = NewShadowBridge(bridge, ob.chain, chainLogger.With().Str("module", "ShadowBridge").Logger())
+	}
 	ob.txWatchList = make(map[ethcommon.Hash]string)
 	ob.Tss = tss
 	ob.outTXConfirmedReceipts = make(map[string]*ethtypes.Receipt)
 	ob.outTXConfirmedTransaction = make(map[string]*ethtypes.Transaction)
 	ob.OutTxChan = make(chan OutTx, 100)
 
-	logFile, err := os.OpenFile(ob.chain.ChainName.String()+"_debug.log", os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0600)
+	logFile, err := os.OpenFile(ob.chain.Name()+"_debug.log", os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0600)
 	if err != nil {
-		log.Error().Err(err).Msgf("there was an error creating a logFile chain %s", ob.chain.ChainName.String())
+		log.Error().Err(err).Msgf("there was an error creating a logFile chain %s", ob.chain.Name())
 	}
 	fileLogger := zerolog.New(logFile).With().Logger()
 	ob.fileLogger = &fileLogger
 
-	ob.logger.ChainLogger.Info().Msgf("Chain %s endpoint %s", ob.chain.ChainName.String(), evmCfg.Endpoint)
-	client, err := ethclient.Dial(evmCfg.Endpoint)
+	ob.logger.ChainLogger.Info().Msgf("Chain %s endpoint %s, %d backup endpoints", ob.chain.Name(), evmCfg.Endpoint, len(evmCfg.BackupEndpoints))
+	client, err := NewFailoverEVMClient(ob.chain.Name(), evmCfg.GetEndpoints(), evmCfg.GetRPCClientConfig, chainLogger.With().Str("module", "FailoverEVMClient").Logger())
 	if err != nil {
 		ob.logger.ChainLogger.Error().Err(err).Msg("eth Client Dial")
 		return nil, err
 	}
+	// never observe a chain with the endpoint of another chain
+	if err := client.VerifyChainID(context.Background(), ob.chain.ChainId); err != nil {
+		ob.logger.ChainLogger.Error().Err(err).Msgf("endpoint of chain %s serves another chain", ob.chain.Name())
+		return nil, err
+	}
 	ob.evmClient = client
 
 	ob.BlockCache, err = lru.New(1000)
@@ -145,6 +188,37 @@ func NewEVMChainClient(
 		ob.logger.ChainLogger.Error().Err(err).Msg("failed to create block cache")
 		return nil, err
 	}
+	ob.blockHashes = NewBlockHashTracker(ReorgTrackDepth)
+	ob.headers, err = NewHeaderCache(HeaderCacheSize)
+	if err != nil {
+		ob.logger.ChainLogger.Error().Err(err).Msg("failed to create header cache")
+		return nil, err
+	}
+	ob.mempool = NewMempoolTracker()
+	ob.blockTimes = NewBlockTimeEstimator()
+	ob.stall = NewStallDetector()
+	if len(evmCfg.ERC20Whitelist) > 0 {
+		ob.whitelist = NewStaticERC20Whitelist(ob.chain.ChainId, evmCfg.ERC20Whitelist)
+	} else {
+		ob.whitelist = NewERC20Whitelist(ob.chain.ChainId, bridge.GetForeignCoins)
+	}
+	ob.balances = NewBalanceMonitor()
+	ob.senders = NewSenderRateLimiter()
+	ob.tokenDecimals = NewTokenDecimals(ob.fetchTokenDecimals)
+	ob.screener, err = NewAddressScreener(cfg)
+	if err != nil {
+		ob.logger.ChainLogger.Error().Err(err).Msg("failed to create the address screener")
+		return nil, err
+	}
+	ob.pendingOutTxs = NewPendingOutTxTracker()
+	// build the event decoders once rather than on every tick
+	if _, err := ob.getWatchedContracts(); err != nil {
+		ob.logger.ChainLogger.Error().Err(err).Msg("failed to build the decoders of the watched contracts")
+		return nil, err
+	}
+	if len(cfg.Webhooks) > 0 {
+		ob.webhooks = NewWebhookPublisher(cfg.Webhooks, chainLogger.With().Str("module", "WebhookPublisher").Logger())
+	}
 
 	if ob.chain.IsKlaytnChain() {
 		client, err := Dial(evmCfg.Endpoint)
@@ -154,6 +228,18 @@ func NewEVMChainClient(
 		}
 		ob.KlaytnClient = client
 	}
+	if common.IsArbitrumChain(ob.chain.ChainId) || common.IsOPStackChain(ob.chain.ChainId) {
+		client, err := DialRollupClient(evmCfg.Endpoint)
+		if err != nil {
+			ob.logger.ChainLogger.Err(err).Msg("rollup Client Dial")
+			return nil, err
+		}
+		ob.rollupClient = client
+	}
+	if common.IsTronChain(ob.chain.ChainId) {
+		// tron is observed through its evm compatible json-rpc; deposits are sent to the base58 encoding of the TSS address
+		ob.logger.ChainLogger.Info().Msgf("tron TSS address %s; outbound txs are not supported", common.EVMAddressToTron(tss.EVMAddress()))
+	}
 
 	// create metric counters
 	err = ob.RegisterPromCounter("rpc_getLogs_count", "Number of getLogs")
@@ -168,6 +254,10 @@ func NewEVMChainClient(
 	if err != nil {
 		return nil, err
 	}
+	err = ob.RegisterPromGauge(metricsPkg.UnconfirmedInboundTxs, "Number of inbound transactions in mempool")
+	if err != nil {
+		return nil, err
+	}
 
 	err = ob.LoadDB(dbpath, ob.chain)
 	if err != nil {
@@ -267,23 +357,48 @@ func FetchERC20CustodyContract(addr ethcommon.Address, client EVMRPCClient) (*er
 }
 
 func (ob *EVMChainClient) Start() {
-	go ob.ExternalChainWatcherForNewInboundTrackerSuggestions()
-	go ob.ExternalChainWatcher() // Observes external Chains for incoming trasnactions
-	go ob.WatchGasPrice()        // Observes external Chains for Gas prices and posts to core
-	go ob.observeOutTx()         // Populates receipts and confirmed outbound transactions
+	ob.watchers.Go("InboundTrackerSuggestions", ob.ExternalChainWatcherForNewInboundTrackerSuggestions)
+	ob.watchers.Go("ExternalChainWatcher", ob.ExternalChainWatcher) // Observes external Chains for incoming trasnactions
+	ob.watchers.Go("WatchGasPrice", ob.WatchGasPrice)               // Observes external Chains for Gas prices and posts to core
+	ob.watchers.Go("ObserveOutTx", ob.observeOutTx)                 // Populates receipts and confirmed outbound transactions
+	ob.watchers.Go("WatchPendingOutTx", ob.WatchPendingOutTx)       // Follows the outbound txs broadcast by this signer
+	ob.watchers.Go("WatchRPCHealth", ob.WatchRPCHealth)             // Fails over between rpc endpoints
+	ob.watchers.Go("WatchMempool", ob.WatchMempool)                 // Reports inbound txs before they are mined
+	ob.watchers.Go("WatchBalances", ob.WatchBalances)               // Alerts on drops of the TSS and custody balances
+	if ob.webhooks != nil {
+		ob.watchers.Go("PublishWebhooks", func() { ob.webhooks.Run(ob.stop) }) // Posts observed events to webhooks
+	}
 }
 
+// GetWatcherStatus returns the status of the goroutines of the chain client
+func (ob *EVMChainClient) GetWatcherStatus() []clienttypes.WatcherStatus {
+	return ob.watchers.Status()
+}
+
+// Stop notifies all goroutines to stop, waits for them to exit and then flushes state to db
+// It is safe to call Stop() more than once
 func (ob *EVMChainClient) Stop() {
+	ob.stopOnce.Do(ob.stopWatchers)
+}
+
+func (ob *EVMChainClient) stopWatchers() {
 	ob.logger.ChainLogger.Info().Msgf("ob %s is stopping", ob.chain.String())
 	close(ob.stop) // this notifies all goroutines to stop
+	ob.cancel()    // this aborts in-flight rpc calls
+	ob.watchers.Wait()
+
+	// flush last scanned block so that the observer resumes from it after restart
+	if !ob.keepLastScanned {
+		if err := ob.db.Save(clienttypes.ToLastBlockSQLType(ob.GetLastBlockHeightScanned())).Error; err != nil {
+			ob.logger.ChainLogger.Error().Err(err).Msg("error writing last scanned block to db")
+		}
+	}
 
 	ob.logger.ChainLogger.Info().Msg("closing ob.db")
 	dbInst, err := ob.db.DB()
 	if err != nil {
 		ob.logger.ChainLogger.Info().Msg("error getting database instance")
-	}
-	err = dbInst.Close()
-	if err != nil {
+	} else if err = dbInst.Close(); err != nil {
 		ob.logger.ChainLogger.Error().Err(err).Msg("error closing database")
 	}
 
@@ -304,25 +419,25 @@ func (ob *EVMChainClient) IsSendOutTxProcessed(sendHash string, nonce uint64, co
 	}
 
 	sendID := fmt.Sprintf("%s-%d", ob.chain.String(), nonce)
-	logger = logger.With().Str("sendID", sendID).Logger()
+	logger = WithCorrelationID(logger.With().Str("sendID", sendID).Logger(), sendHash)
 	if cointype == common.CoinType_Cmd {
 		recvStatus := common.ReceiveStatus_Failed
 		if receipt.Status == 1 {
 			recvStatus = common.ReceiveStatus_Success
 		}
-		zetaHash, err := ob.zetaClient.PostReceiveConfirmation(
-			sendHash,
-			receipt.TxHash.Hex(),
-			receipt.BlockNumber.Uint64(),
-			receipt.GasUsed,
-			transaction.GasPrice(),
-			transaction.Gas(),
-			transaction.Value(),
-			recvStatus,
-			ob.chain,
-			nonce,
-			common.CoinType_Cmd,
-		)
+		zetaHash, err := ob.zetaClient.PostReceiveConfirmation(OutboundConfirmation{
+			SendHash:          sendHash,
+			OutTxHash:         receipt.TxHash.Hex(),
+			OutBlockHeight:    receipt.BlockNumber.Uint64(),
+			GasUsed:           receipt.GasUsed,
+			EffectiveGasPrice: transaction.GasPrice(),
+			EffectiveGasLimit: transaction.Gas(),
+			Amount:            transaction.Value(),
+			Status:            recvStatus,
+			Chain:             ob.chain,
+			Nonce:             nonce,
+			CoinType:          common.CoinType_Cmd,
+		})
 		if err != nil {
 			logger.Error().Err(err).Msg("error posting confirmation to meta core")
 		}
@@ -331,43 +446,28 @@ func (ob *EVMChainClient) IsSendOutTxProcessed(sendHash string, nonce uint64, co
 
 	} else if cointype == common.CoinType_Gas { // the outbound is a regular Ether/BNB/Matic transfer; no need to check events
 		if receipt.Status == 1 {
-			zetaHash, err := ob.zetaClient.PostReceiveConfirmation(
-				sendHash,
-				receipt.TxHash.Hex(),
-				receipt.BlockNumber.Uint64(),
-				receipt.GasUsed,
-				transaction.GasPrice(),
-				transaction.Gas(),
-				transaction.Value(),
-				common.ReceiveStatus_Success,
-				ob.chain,
-				nonce,
-				common.CoinType_Gas,
-			)
+			start := time.Now()
+			zetaHash, err := ob.zetaClient.PostReceiveConfirmation(OutboundConfirmation{
+				SendHash:          sendHash,
+				OutTxHash:         receipt.TxHash.Hex(),
+				OutBlockHeight:    receipt.BlockNumber.Uint64(),
+				GasUsed:           receipt.GasUsed,
+				EffectiveGasPrice: transaction.GasPrice(),
+				EffectiveGasLimit: transaction.Gas(),
+				Amount:            transaction.Value(),
+				Status:            common.ReceiveStatus_Success,
+				Chain:             ob.chain,
+				Nonce:             nonce,
+				CoinType:          common.CoinType_Gas,
+			})
+			ob.recordEventPosted(EventNameGasTransfer, sendHash, start, zetaHash, err)
 			if err != nil {
 				logger.Error().Err(err).Msg("error posting confirmation to meta core")
 			}
 			logger.Info().Msgf("Zeta tx hash: %s cctx %s nonce %d", zetaHash, sendHash, nonce)
 			return true, true, nil
 		} else if receipt.Status == 0 { // the same as below events flow
-			logger.Info().Msgf("Found (failed tx) sendHash %s on chain %s txhash %s", sendHash, ob.chain.String(), receipt.TxHash.Hex())
-			zetaTxHash, err := ob.zetaClient.PostReceiveConfirmation(
-				sendHash,
-				receipt.TxHash.Hex(),
-				receipt.BlockNumber.Uint64(),
-				receipt.GasUsed,
-				transaction.GasPrice(),
-				transaction.Gas(),
-				big.NewInt(0),
-				common.ReceiveStatus_Failed,
-				ob.chain,
-				nonce,
-				common.CoinType_Gas,
-			)
-			if err != nil {
-				logger.Error().Err(err).Msgf("PostReceiveConfirmation error in WatchTxHashWithTimeout; zeta tx hash %s", zetaTxHash)
-			}
-			logger.Info().Msgf("Zeta tx hash: %s cctx %s nonce %d", zetaTxHash, sendHash, nonce)
+			ob.postOutboundFailed(sendHash, nonce, common.CoinType_Gas, receipt, transaction, logger)
 			return true, true, nil
 		}
 	} else if cointype == common.CoinType_Zeta { // the outbound is a Zeta transfer; need to check events ZetaReceived
@@ -396,24 +496,27 @@ func (ob *EVMChainClient) IsSendOutTxProcessed(sendHash string, nonce uint64, co
 						sendhash := vLog.Topics[3].Hex()
 						//var rxAddress string = ethcommon.HexToAddress(vLog.Topics[1].Hex()).Hex()
 						mMint := receivedLog.ZetaValue
-						zetaHash, err := ob.zetaClient.PostReceiveConfirmation(
-							sendhash,
-							vLog.TxHash.Hex(),
-							vLog.BlockNumber,
-							receipt.GasUsed,
-							transaction.GasPrice(),
-							transaction.Gas(),
-							mMint,
-							common.ReceiveStatus_Success,
-							ob.chain,
-							nonce,
-							common.CoinType_Zeta,
-						)
+						start := time.Now()
+						zetaHash, err := ob.zetaClient.PostReceiveConfirmation(OutboundConfirmation{
+							SendHash:          sendhash,
+							OutTxHash:         vLog.TxHash.Hex(),
+							OutBlockHeight:    vLog.BlockNumber,
+							GasUsed:           receipt.GasUsed,
+							EffectiveGasPrice: transaction.GasPrice(),
+							EffectiveGasLimit: transaction.Gas(),
+							Amount:            mMint,
+							Status:            common.ReceiveStatus_Success,
+							Chain:             ob.chain,
+							Nonce:             nonce,
+							CoinType:          common.CoinType_Zeta,
+						})
+						ob.recordEventPosted(WebhookEventZetaReceived, sendHash, start, zetaHash, err)
 						if err != nil {
 							logger.Error().Err(err).Msg("error posting confirmation to meta core")
 							continue
 						}
 						logger.Info().Msgf("Zeta tx hash: %s cctx %s nonce %d", zetaHash, sendHash, nonce)
+						ob.publishOutboundEvent(WebhookEventZetaReceived, sendhash, vLog, common.CoinType_Zeta, mMint, zetaHash)
 						return true, true, nil
 					}
 					// #nosec G701 always in range
@@ -432,24 +535,27 @@ func (ob *EVMChainClient) IsSendOutTxProcessed(sendHash string, nonce uint64, co
 						}
 						sendhash := vLog.Topics[2].Hex()
 						mMint := revertedLog.RemainingZetaValue
-						metaHash, err := ob.zetaClient.PostReceiveConfirmation(
-							sendhash,
-							vLog.TxHash.Hex(),
-							vLog.BlockNumber,
-							receipt.GasUsed,
-							transaction.GasPrice(),
-							transaction.Gas(),
-							mMint,
-							common.ReceiveStatus_Success,
-							ob.chain,
-							nonce,
-							common.CoinType_Zeta,
-						)
+						start := time.Now()
+						metaHash, err := ob.zetaClient.PostReceiveConfirmation(OutboundConfirmation{
+							SendHash:          sendhash,
+							OutTxHash:         vLog.TxHash.Hex(),
+							OutBlockHeight:    vLog.BlockNumber,
+							GasUsed:           receipt.GasUsed,
+							EffectiveGasPrice: transaction.GasPrice(),
+							EffectiveGasLimit: transaction.Gas(),
+							Amount:            mMint,
+							Status:            common.ReceiveStatus_Success,
+							Chain:             ob.chain,
+							Nonce:             nonce,
+							CoinType:          common.CoinType_Zeta,
+						})
+						ob.recordEventPosted(WebhookEventZetaReverted, sendHash, start, metaHash, err)
 						if err != nil {
 							logger.Err(err).Msg("error posting confirmation to meta core")
 							continue
 						}
 						logger.Info().Msgf("Zeta tx hash: %s cctx %s nonce %d", metaHash, sendHash, nonce)
+						ob.publishOutboundEvent(WebhookEventZetaReverted, sendhash, vLog, common.CoinType_Zeta, mMint, metaHash)
 						return true, true, nil
 					}
 					// #nosec G701 always in range
@@ -459,24 +565,7 @@ func (ob *EVMChainClient) IsSendOutTxProcessed(sendHash string, nonce uint64, co
 			}
 		} else if receipt.Status == 0 {
 			//FIXME: check nonce here by getTransaction RPC
-			logger.Info().Msgf("Found (failed tx) sendHash %s on chain %s txhash %s", sendHash, ob.chain.String(), receipt.TxHash.Hex())
-			zetaTxHash, err := ob.zetaClient.PostReceiveConfirmation(
-				sendHash,
-				receipt.TxHash.Hex(),
-				receipt.BlockNumber.Uint64(),
-				receipt.GasUsed,
-				transaction.GasPrice(),
-				transaction.Gas(),
-				big.NewInt(0),
-				common.ReceiveStatus_Failed,
-				ob.chain,
-				nonce,
-				common.CoinType_Zeta,
-			)
-			if err != nil {
-				logger.Error().Err(err).Msgf("PostReceiveConfirmation error in WatchTxHashWithTimeout; zeta tx hash %s", zetaTxHash)
-			}
-			logger.Info().Msgf("Zeta tx hash: %s cctx %s nonce %d", zetaTxHash, sendHash, nonce)
+			ob.postOutboundFailed(sendHash, nonce, common.CoinType_Zeta, receipt, transaction, logger)
 			return true, true, nil
 		}
 	} else if cointype == common.CoinType_ERC20 {
@@ -498,24 +587,27 @@ func (ob *EVMChainClient) IsSendOutTxProcessed(sendHash string, nonce uint64, co
 					if int64(confHeight) < ob.GetLastBlockHeight() {
 
 						logger.Info().Msg("Confirmed! Sending PostConfirmation to zetacore...")
-						zetaHash, err := ob.zetaClient.PostReceiveConfirmation(
-							sendHash,
-							vLog.TxHash.Hex(),
-							vLog.BlockNumber,
-							receipt.GasUsed,
-							transaction.GasPrice(),
-							transaction.Gas(),
-							event.Amount,
-							common.ReceiveStatus_Success,
-							ob.chain,
-							nonce,
-							common.CoinType_ERC20,
-						)
+						start := time.Now()
+						zetaHash, err := ob.zetaClient.PostReceiveConfirmation(OutboundConfirmation{
+							SendHash:          sendHash,
+							OutTxHash:         vLog.TxHash.Hex(),
+							OutBlockHeight:    vLog.BlockNumber,
+							GasUsed:           receipt.GasUsed,
+							EffectiveGasPrice: transaction.GasPrice(),
+							EffectiveGasLimit: transaction.Gas(),
+							Amount:            event.Amount,
+							Status:            common.ReceiveStatus_Success,
+							Chain:             ob.chain,
+							Nonce:             nonce,
+							CoinType:          common.CoinType_ERC20,
+						})
+						ob.recordEventPosted(WebhookEventWithdrawn, sendHash, start, zetaHash, err)
 						if err != nil {
 							logger.Error().Err(err).Msg("error posting confirmation to meta core")
 							continue
 						}
 						logger.Info().Msgf("Zeta tx hash: %s cctx %s nonce %d", zetaHash, sendHash, nonce)
+						ob.publishOutboundEvent(WebhookEventWithdrawn, sendHash, vLog, common.CoinType_ERC20, event.Amount, zetaHash)
 						return true, true, nil
 					}
 					// #nosec G701 always in range
@@ -524,24 +616,7 @@ func (ob *EVMChainClient) IsSendOutTxProcessed(sendHash string, nonce uint64, co
 				}
 			}
 		} else {
-			logger.Info().Msgf("Found (failed tx) sendHash %s on chain %s txhash %s", sendHash, ob.chain.String(), receipt.TxHash.Hex())
-			zetaTxHash, err := ob.zetaClient.PostReceiveConfirmation(
-				sendHash,
-				receipt.TxHash.Hex(),
-				receipt.BlockNumber.Uint64(),
-				receipt.GasUsed,
-				transaction.GasPrice(),
-				transaction.Gas(),
-				big.NewInt(0),
-				common.ReceiveStatus_Failed,
-				ob.chain,
-				nonce,
-				common.CoinType_ERC20,
-			)
-			if err != nil {
-				logger.Error().Err(err).Msgf("PostReceiveConfirmation error in WatchTxHashWithTimeout; zeta tx hash %s", zetaTxHash)
-			}
-			logger.Info().Msgf("Zeta tx hash: %s cctx %s nonce %d", zetaTxHash, sendHash, nonce)
+			ob.postOutboundFailed(sendHash, nonce, common.CoinType_ERC20, receipt, transaction, logger)
 			return true, true, nil
 		}
 	}
@@ -595,6 +670,9 @@ func (ob *EVMChainClient) observeOutTx() {
 					case <-outTimeout:
 						ob.logger.ObserveOutTx.Warn().Msgf("observeOutTx timeout on chain %d nonce %d", ob.chain.ChainId, nonceInt)
 						break TRACKERLOOP
+					case <-ob.stop:
+						ob.logger.ObserveOutTx.Info().Msg("observeOutTx: stopped")
+						return
 					default:
 						ob.Mu.Lock()
 						_, found := ob.outTXConfirmedReceipts[ob.GetTxID(nonceInt)]
@@ -604,7 +682,12 @@ func (ob *EVMChainClient) observeOutTx() {
 						}
 
 						receipt, transaction, err := ob.queryTxByHash(txHash.TxHash, nonceInt)
-						time.Sleep(time.Duration(rpcRestTime) * time.Millisecond)
+						select {
+						case <-ob.stop:
+							ob.logger.ObserveOutTx.Info().Msg("observeOutTx: stopped")
+							return
+						case <-time.After(time.Duration(rpcRestTime) * time.Millisecond):
+						}
 						if err == nil && receipt != nil { // confirmed
 							ob.Mu.Lock()
 							ob.outTXConfirmedReceipts[ob.GetTxID(nonceInt)] = receipt
@@ -637,7 +720,8 @@ func (ob *EVMChainClient) queryTxByHash(txHash string, nonce uint64) (*ethtypes.
 	if ob.outTXConfirmedReceipts[ob.GetTxID(nonce)] != nil && ob.outTXConfirmedTransaction[ob.GetTxID(nonce)] != nil {
 		return nil, nil, fmt.Errorf("queryTxByHash: txHash %s receipts already recorded", txHash)
 	}
-	ctxt, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	// the queries are aborted on Stop()
+	ctxt, cancel := context.WithTimeout(ob.ctx, 3*time.Second)
 	defer cancel()
 
 	receipt, err := ob.evmClient.TransactionReceipt(ctxt, ethcommon.HexToHash(txHash))
@@ -677,6 +761,7 @@ func (ob *EVMChainClient) SetLastBlockHeightScanned(block int64) {
 	}
 	atomic.StoreInt64(&ob.lastBlockScanned, block)
 	ob.ts.SetLastScannedBlockNumber(ob.chain.ChainId, block)
+	metricsPkg.LastScannedBlock.WithLabelValues(ob.chain.Name()).Set(float64(block))
 }
 
 // GetLastBlockHeightScanned get last block height scanned (not necessarily caught up with external block; could be slow/paused)
@@ -714,9 +799,147 @@ func (ob *EVMChainClient) GetLastBlockHeight() int64 {
 	return height
 }
 
+// GetConfirmationCount returns the number of confirmations required before posting inbound events
+func (ob *EVMChainClient) GetConfirmationCount() uint64 {
+	evmCfg, found := ob.cfg.GetEVMConfig(ob.chain.ChainId)
+	if !found {
+		return ob.GetCoreParams().ConfirmationCount
+	}
+	evmCfg.CoreParams = ob.GetCoreParams()
+	return evmCfg.GetConfirmationCount()
+}
+
+// GetFinalityTag returns the block tag used by the chain as its source of finality; empty if not set
+func (ob *EVMChainClient) GetFinalityTag() string {
+	evmCfg, found := ob.cfg.GetEVMConfig(ob.chain.ChainId)
+	if !found {
+		return ""
+	}
+	return evmCfg.FinalityTag
+}
+
+// getConfirmedBlockNumber returns the highest block whose inbound events can be posted: the latest block minus
+// the confirmation count, capped by the block tagged safe or finalized if the chain has a finality tag
+func (ob *EVMChainClient) getConfirmedBlockNumber() (uint64, error) {
+	batch := ob.getTickBatch()
+	var header *ethtypes.Header
+	var err error
+	if batch != nil {
+		header = batch.latest
+	} else {
+		header, err = ob.headerByNumber(-1)
+		if err != nil {
+			return 0, err
+		}
+	}
+	ob.blockTimes.Observe(header.Number.Uint64(), header.Time)
+	ob.stall.Observe(header.Number.Uint64(), time.Now())
+	metricsPkg.ChainHeadBlock.WithLabelValues(ob.chain.Name()).Set(float64(header.Number.Uint64()))
+	confirmationCount := ob.GetConfirmationCount()
+	confirmed, err := ConfirmationFinality{Confirmations: confirmationCount}.FinalizedBlock(header.Number.Uint64())
+	if err != nil {
+		return 0, err
+	}
+
+	// the finality model of the chain, if any, caps the confirmed block
+	finality, err := ob.getFinality()
+	if err != nil || finality == nil {
+		return confirmed, err
+	}
+	finalized, err := ob.finalizedBlock(finality, batch, header.Number.Uint64())
+	if IsBlockTagUnsupportedError(err) {
+		// e.g. a node not upgraded for the merge; the confirmation count is all there is
+		ob.logger.ExternalChainWatcher.Warn().Err(err).Msgf("getConfirmedBlockNumber: %s block tag not supported by the rpc endpoint; using %d confirmations", ob.GetFinalityTag(), confirmationCount)
+		return confirmed, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if finalized < confirmed {
+		confirmed = finalized
+	}
+	return confirmed, nil
+}
+
+// headerByNumber returns the header of a block; negative numbers are the block tags of rpc.BlockNumber, -1 being the latest block.
+// Headers are shared through the header cache by the components of the observer
+func (ob *EVMChainClient) headerByNumber(number int64) (*ethtypes.Header, error) {
+	if header, found := ob.headers.Get(number, time.Now()); found {
+		return header, nil
+	}
+	var blockNumber *big.Int // latest block
+	if number != int64(rpc.LatestBlockNumber) {
+		blockNumber = big.NewInt(number)
+	}
+	var header *ethtypes.Header
+	err := Retry(ob.ctx, "HeaderByNumber", RPCBackoff, func() (err error) {
+		header, err = ob.evmClient.HeaderByNumber(ob.ctx, blockNumber)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	ob.headers.Add(number, header, time.Now())
+	return header, nil
+}
+
+// IsBlockTagUnsupportedError returns true if the rpc endpoint doesn't serve the "safe" or "finalized" block tag
+func IsBlockTagUnsupportedError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ethereum.NotFound) { // null block
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, pattern := range []string{
+		"safe block not found", // geth before the merge
+		"finalized block not found",
+		"unknown block",
+		"invalid block number",
+		"invalid block tag",
+	} {
+		if strings.Contains(msg, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// isInTxConfirmed returns true if the inbound tx included in the given block has enough confirmations
+func (ob *EVMChainClient) isInTxConfirmed(blockNumber uint64) bool {
+	// #nosec G701 always positive
+	return blockNumber <= uint64(ob.GetLastBlockHeight())
+}
+
+// GetBlocksPerScan returns the number of blocks to scan in one tick, larger while the observer is catching up
+func (ob *EVMChainClient) GetBlocksPerScan(blocksBehind uint64) uint64 {
+	evmCfg, found := ob.cfg.GetEVMConfig(ob.chain.ChainId)
+	if !found {
+		return config.MaxBlocksPerPeriod
+	}
+	return evmCfg.GetBlocksPerScan(blocksBehind)
+}
+
+// GetInTxTicker returns the interval in seconds between two inbound observations. It follows the block time observed
+// from headers but never exceeds the interval set in core params, or the block time set in config if zetacore sets none
+func (ob *EVMChainClient) GetInTxTicker() uint64 {
+	interval := ob.GetCoreParams().InTxTicker
+	if interval == 0 {
+		interval = 1
+		if evmCfg, found := ob.cfg.GetEVMConfig(ob.chain.ChainId); found {
+			interval = evmCfg.GetInTxTicker()
+		}
+	}
+	if observed, ok := ob.blockTimes.TickerInterval(interval); ok {
+		return observed
+	}
+	return interval
+}
+
 func (ob *EVMChainClient) ExternalChainWatcher() {
 	// At each tick, query the Connector contract
-	ticker := NewDynamicTicker(fmt.Sprintf("EVM_ExternalChainWatcher_%d", ob.chain.ChainId), ob.GetCoreParams().InTxTicker)
+	ticker := NewDynamicTicker(fmt.Sprintf("EVM_ExternalChainWatcher_%d", ob.chain.ChainId), ob.GetInTxTicker())
 	defer ticker.Stop()
 	ob.logger.ExternalChainWatcher.Info().Msg("ExternalChainWatcher started")
 	for {
@@ -726,7 +949,11 @@ func (ob *EVMChainClient) ExternalChainWatcher() {
 			if err != nil {
 				ob.logger.ExternalChainWatcher.Err(err).Msg("observeInTX error")
 			}
-			ticker.UpdateInterval(ob.GetCoreParams().InTxTicker, ob.logger.ExternalChainWatcher)
+			interval := ob.GetInTxTicker()
+			if ob.backpressured {
+				interval *= BackpressureSlowdown
+			}
+			ticker.UpdateInterval(interval, ob.logger.ExternalChainWatcher)
 		case <-ob.stop:
 			ob.logger.ExternalChainWatcher.Info().Msg("ExternalChainWatcher stopped")
 			return
@@ -734,6 +961,44 @@ func (ob *EVMChainClient) ExternalChainWatcher() {
 	}
 }
 
+// WatchRPCHealth periodically checks the rpc endpoints, switches back to the primary endpoint once it recovers
+// and alerts if the chain stalls
+func (ob *EVMChainClient) WatchRPCHealth() {
+	client, ok := ob.evmClient.(*FailoverEVMClient)
+	failover := ok && len(client.endpoints) > 1
+	ticker := time.NewTicker(RPCHealthCheckInterval * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			// a stuck endpoint is left for one that is still following the chain
+			if failover {
+				client.CheckHealth(ob.ctx)
+			}
+			ob.checkStall()
+		case <-ob.stop:
+			ob.logger.ChainLogger.Info().Msg("WatchRPCHealth stopped")
+			return
+		}
+	}
+}
+
+// checkStall raises an alert if no new block was seen for StallBlockTimeMultiple block times,
+// i.e. the chain halted or none of its endpoints follows it anymore
+func (ob *EVMChainClient) checkStall() {
+	blockTime, ok := ob.blockTimes.BlockTime()
+	if !ok {
+		blockTime = float64(ob.GetInTxTicker())
+	}
+	stalled, lastBlock, since := ob.stall.Stalled(time.Now(), StallTimeout(blockTime))
+	if !stalled {
+		metricsPkg.ChainStalled.WithLabelValues(ob.chain.Name()).Set(0)
+		return
+	}
+	metricsPkg.ChainStalled.WithLabelValues(ob.chain.Name()).Set(1)
+	ob.logger.ChainLogger.Error().Msgf("checkStall: chain stalled; no new block since block %d seen %s ago", lastBlock, since.Round(time.Second))
+}
+
 func (ob *EVMChainClient) postBlockHeader(tip int64) error {
 	bn := tip
 
@@ -772,22 +1037,39 @@ func (ob *EVMChainClient) postBlockHeader(tip int64) error {
 }
 
 func (ob *EVMChainClient) observeInTX() error {
-	header, err := ob.evmClient.HeaderByNumber(context.Background(), nil)
+	// fetch the headers and the logs of the tick in a single round trip where the rpc client can batch requests
+	ob.setTickBatch(ob.fetchTickBatch(ob.GetLastBlockHeightScanned()))
+	defer ob.setTickBatch(nil)
+
+	// match the votes withheld in shadow mode against the ballots of the other observers
+	if shadow, ok := ob.zetaClient.(*ShadowBridge); ok {
+		shadow.MatchPendingVotes(time.Now())
+	}
+
+	// "confirmed" current block number
+	confirmedBlockNum, err := ob.getConfirmedBlockNumber()
 	if err != nil {
 		return err
 	}
-	// "confirmed" current block number
-	confirmedBlockNum := header.Number.Uint64() - ob.GetCoreParams().ConfirmationCount
 	// #nosec G701 always in range
 	ob.SetLastBlockHeight(int64(confirmedBlockNum))
 
-	crosschainFlags, err := ob.zetaClient.GetCrosschainFlags()
+	crosschainFlags, err := ob.getCrosschainFlags()
 	if err != nil {
 		return err
 	}
 	if !crosschainFlags.IsInboundEnabled {
 		return errors.New("inbound TXS / Send has been disabled by the protocol")
 	}
+	ob.retryPendingVotes(time.Now())
+	ob.reportQueuedVotes()
+	// no new block is scanned while zetacore is slow to take the votes already decoded
+	ob.backpressured = ob.zetaClient.Congested()
+	if ob.backpressured {
+		metricsPkg.BackpressuredScans.WithLabelValues(ob.chain.Name()).Inc()
+		ob.logger.ExternalChainWatcher.Warn().Msgf("observeInTx: bridge to zetacore congested, holding at block %d", ob.GetLastBlockHeightScanned())
+		return nil
+	}
 	counter, err := ob.GetPromCounter("rpc_getBlockByNumber_count")
 	if err != nil {
 		ob.logger.ExternalChainWatcher.Error().Err(err).Msg("GetPromCounter:")
@@ -805,9 +1087,16 @@ func (ob *EVMChainClient) observeInTX() error {
 		sampledLogger.Debug().Msg("Skipping observer , No new block is produced ")
 		return nil
 	}
-	lastBlock := ob.GetLastBlockHeightScanned()
+	// re-scan from the common ancestor if the chain reorged below the last scanned block
+	lastBlock, err := ob.rollbackOnReorg(ob.GetLastBlockHeightScanned())
+	if err != nil {
+		return err
+	}
 	startBlock := lastBlock + 1
-	toBlock := lastBlock + config.MaxBlocksPerPeriod // read at most 10 blocks in one go
+	// #nosec G701 checked in range
+	blocksPerScan := ob.GetBlocksPerScan(confirmedBlockNum - uint64(lastBlock))
+	// #nosec G701 always in range
+	toBlock := lastBlock + int64(blocksPerScan) // read at most blocksPerScan blocks in one go
 	// #nosec G701 always positive
 	if uint64(toBlock) >= confirmedBlockNum {
 		// #nosec G701 checked in range
@@ -820,96 +1109,170 @@ func (ob *EVMChainClient) observeInTX() error {
 		return fmt.Errorf("toBlock is negative or too large")
 	}
 	ob.logger.ExternalChainWatcher.Info().Msgf("Checking for all inTX : startBlock %d, toBlock %d", startBlock, toBlock)
-	//task 1:  Query evm chain for zeta sent logs
-	func() {
-		// #nosec G701 always positive
-		tb := uint64(toBlock)
-		connector, err := ob.GetConnectorContract()
+	err = ob.observeInTxRange(startBlock, toBlock, true)
+	if err != nil {
+		// don't move forward; the range will be scanned again in the next tick
+		return err
+	}
+	// don't move forward if the scan was interrupted by Stop(); the range will be scanned again after restart
+	if ob.ctx.Err() != nil {
+		return ob.ctx.Err()
+	}
+	// nor while a vote of the range is waiting for its retry; the range is posted or dead-lettered before moving on.
+	// While zetacore is unavailable the votes are queued durably and the chain keeps being observed, the queue is
+	// drained once zetacore is back
+	pending, err := ob.countPendingVotes(toBlock)
+	if err != nil {
+		return err
+	}
+	if pending > 0 && !ob.isDegraded() {
+		ob.logger.ExternalChainWatcher.Warn().Msgf("observeInTx: %d votes up to block %d pending, not moving forward", pending, toBlock)
+		return nil
+	}
+	if pending > 0 {
+		ob.logger.ExternalChainWatcher.Warn().Msgf("observeInTx: zetacore unavailable, moving forward with %d votes up to block %d queued", pending, toBlock)
+	}
+	// record the hash of the last scanned block to detect reorgs in the next round
+	header, err := ob.headerByNumber(toBlock)
+	if err != nil {
+		return err
+	}
+	ob.blockHashes.Add(toBlock, header.Hash())
+	ob.SetLastBlockHeightScanned(toBlock)
+	if err := ob.db.Save(clienttypes.ToLastBlockSQLType(ob.GetLastBlockHeightScanned())).Error; err != nil {
+		ob.logger.ExternalChainWatcher.Error().Err(err).Msg("error writing toBlock to db")
+	}
+	ob.pruneInboundEvents(toBlock)
+	return nil
+}
+
+// observeInTxRange observes the inbound txs in blocks [startBlock, toBlock] and posts the votes to zetacore.
+// If checkpoint is set, the progress in the range is checkpointed as each event is posted and a scan interrupted
+// by a crash resumes after the last event posted
+func (ob *EVMChainClient) observeInTxRange(startBlock, toBlock int64, checkpoint bool) error {
+	// never vote on a range whose headers don't link up into a valid chain
+	var verified map[uint64]ethcommon.Hash
+	evmCfg, _ := ob.cfg.GetEVMConfig(ob.chain.ChainId)
+	if evmCfg.VerifyHeaders {
+		var err error
+		verified, err = ob.verifyHeaderRange(startBlock, toBlock)
 		if err != nil {
-			ob.logger.ChainLogger.Warn().Err(err).Msgf("observeInTx: GetConnectorContract error:")
-			return
+			ob.logger.ExternalChainWatcher.Error().Err(err).Msgf("observeInTx: unverifiable block range [%d, %d]", startBlock, toBlock)
+			return err
 		}
-		cnt, err := ob.GetPromCounter("rpc_getLogs_count")
+	}
+	// nor on a range the endpoints disagree on, if a read quorum is required
+	var quorum *rangeQuorum
+	if evmCfg.ReadQuorum > 1 {
+		var err error
+		quorum, err = ob.readRangeQuorum(startBlock, toBlock, evmCfg.ReadQuorum)
 		if err != nil {
-			ob.logger.ExternalChainWatcher.Error().Err(err).Msg("GetPromCounter:")
-		} else {
-			cnt.Inc()
+			return err
 		}
-		logs, err := connector.FilterZetaSent(&bind.FilterOpts{
-			// #nosec G701 always positive
-			Start:   uint64(startBlock),
-			End:     &tb,
-			Context: context.TODO(),
-		}, []ethcommon.Address{}, []*big.Int{})
+		verified, err = mergeVerifiedHashes(verified, quorum.hashes)
 		if err != nil {
-			ob.logger.ChainLogger.Warn().Err(err).Msgf("observeInTx: FilterZetaSent error:")
-			return
+			ob.logger.ExternalChainWatcher.Error().Err(err).Msgf("observeInTx: unverifiable block range [%d, %d]", startBlock, toBlock)
+			return err
 		}
-		// Pull out arguments from logs
-		for logs.Next() {
-			msg, err := ob.GetInboundVoteMsgForZetaSentEvent(logs.Event)
-			if err != nil {
-				ob.logger.ExternalChainWatcher.Error().Err(err).Msg("error getting inbound vote msg")
-				continue
-			}
+	}
 
-			zetaHash, err := ob.zetaClient.PostSend(PostSendNonEVMGasLimit, &msg)
+	// task 1 & 2: Query evm chain for the inbound events of all watched contracts in a single topic-filtered FilterLogs call
+	err := func() error {
+		contracts, err := ob.getWatchedContracts()
+		if err != nil {
+			return err
+		}
+		if contracts.Len() == 0 {
+			ob.logger.ExternalChainWatcher.Warn().Msg("observeInTx: no contract to watch")
+			return nil
+		}
+		query, _ := BuildInboundFilterQuery(startBlock, toBlock, contracts.Addresses(), contracts.Topics())
+		logs, agreed := quorum.logsOf(query)
+		if !agreed {
+			logs, err = ob.filterInboundLogs(startBlock, toBlock, contracts.Addresses(), contracts.Topics())
 			if err != nil {
-				ob.logger.ExternalChainWatcher.Error().Err(err).Msg("error posting to zeta core")
-				return
+				ob.logger.ExternalChainWatcher.Warn().Err(err).Msgf("observeInTx: FilterLogs error:")
+				return err
 			}
-			ob.logger.ExternalChainWatcher.Info().Msgf("ZetaSent event detected and reported: PostSend zeta tx: %s", zetaHash)
 		}
-	}()
 
-	// task 2: Query evm chain for deposited logs
-	func() {
-		// #nosec G701 always positive
-		toB := uint64(toBlock)
-		erc20custody, err := ob.GetERC20CustodyContract()
-		if err != nil {
-			ob.logger.ExternalChainWatcher.Warn().Err(err).Msgf("observeInTx: GetERC20CustodyContract error:")
-			return
+		// alert on the admin events of the contracts; the events of a proxy upgraded to an unconfirmed implementation
+		// may no longer mean what they used to
+		if err := ob.checkAdminEvents(contracts, logs); err != nil {
+			ob.logger.ExternalChainWatcher.Error().Err(err).Msgf("observeInTx: withholding votes on block range [%d, %d]", startBlock, toBlock)
+			return err
 		}
-		depositedLogs, err := erc20custody.FilterDeposited(&bind.FilterOpts{
-			// #nosec G701 always positive
-			Start:   uint64(startBlock),
-			End:     &toB,
-			Context: context.TODO(),
-		}, []ethcommon.Address{})
 
-		if err != nil {
-			ob.logger.ExternalChainWatcher.Warn().Err(err).Msgf("observeInTx: FilterDeposited error:")
-			return
-		}
-		cnt, err := ob.GetPromCounter("rpc_getLogs_count")
-		if err != nil {
-			ob.logger.ExternalChainWatcher.Error().Err(err).Msg("GetPromCounter:")
-		} else {
-			cnt.Inc()
+		if checkpoint {
+			logs = skipCheckpointedLogs(logs, ob.loadInboundCheckpoint(startBlock, verified))
 		}
 
-		// Pull out arguments from logs
-		for depositedLogs.Next() {
-			msg, err := ob.GetInboundVoteMsgForDepositedEvent(depositedLogs.Event)
-			if err != nil {
+		// decode the logs concurrently, then post the votes and record them one by one in the order of the logs
+		decodedLogs := ob.decodeInboundLogs(contracts, verified, logs)
+		var decodeErr error
+		for i, decoded := range decodedLogs {
+			if decoded.err != nil {
+				decodedLogs, decodeErr = decodedLogs[:i], decoded.err
+				break
+			}
+		}
+		posts := ob.postDecodedInbounds(decodedLogs)
+		for i, decoded := range decodedLogs {
+			if decoded.dust {
+				if checkpoint {
+					ob.setInboundEventCheckpoint(decoded.eventKey, decoded.vLog, "")
+				} else {
+					ob.setInboundEventProcessed(decoded.eventKey, decoded.vLog.BlockNumber, "")
+				}
 				continue
 			}
-			zetaHash, err := ob.zetaClient.PostSend(PostSendEVMGasLimit, &msg)
+			if decoded.msg == nil {
+				continue
+			}
+			post := posts[i]
+			if errors.Is(post.err, ErrInboundThrottled) {
+				ob.recordEvent(decoded.contract.event.Name, metricsPkg.EventStagePost, metricsPkg.EventWithheld, decoded.correlationID, post.start)
+				ob.quarantineInboundEvent(decoded.contract, decoded.eventKey, decoded.vLog, post.err)
+				continue
+			}
+			zetaHash, err := post.zetaHash, post.err
+			ob.recordEventPosted(decoded.contract.event.Name, decoded.correlationID, post.start, zetaHash, err)
+			logger := WithCorrelationID(ob.logger.ExternalChainWatcher, decoded.correlationID)
 			if err != nil {
-				ob.logger.ExternalChainWatcher.Error().Err(err).Msg("error posting to zeta core")
-				return
+				logger.Error().Err(err).Msg("error posting to zeta core")
+				// the range is scanned again if the vote can't be enqueued for a retry either
+				if err := ob.enqueuePendingVote(decoded.eventKey, decoded.vLog.BlockNumber, decoded.gasLimit, decoded.msg, err); err != nil {
+					return err
+				}
+			}
+			if checkpoint {
+				ob.setInboundEventCheckpoint(decoded.eventKey, decoded.vLog, zetaHash)
+			} else {
+				ob.setInboundEventProcessed(decoded.eventKey, decoded.vLog.BlockNumber, zetaHash)
 			}
-			ob.logger.ExternalChainWatcher.Info().Msgf("ZRC20Custody Deposited event detected and reported: PostSend zeta tx: %s", zetaHash)
+			if zetaHash == "" {
+				continue
+			}
+			ob.recordInboundLatency(decoded.contract.event.Name, decoded.correlationID, decoded.vLog.BlockNumber, decoded.vLog.BlockHash, time.Now())
+			contract := decoded.contract
+			logger.Info().Msgf("%s event of %s contract %s detected and reported: PostSend zeta tx: %s", contract.event.Name, contract.kind, contract.address.Hex(), zetaHash)
 		}
+		return decodeErr
 	}()
+	if err != nil {
+		return err
+	}
 
 	// task 3: query the incoming tx to TSS address ==============
-	func() {
+	err = func() error {
+		// gas tokens sent to the TSS address on zEVM are no deposit
+		if ob.chain.IsZetaChain() {
+			return nil
+		}
 		tssAddress := ob.Tss.EVMAddress() // after keygen, ob.Tss.pubkey will be updated
 		if tssAddress == (ethcommon.Address{}) {
 			ob.logger.ExternalChainWatcher.Warn().Msgf("observeInTx: TSS address not set")
-			return
+			return nil
 		}
 
 		// query incoming gas asset
@@ -918,11 +1281,24 @@ func (ob *EVMChainClient) observeInTX() error {
 			if err != nil {
 				ob.logger.ExternalChainWatcher.Error().Err(err).Msg("error posting block header")
 			}
+			// blocks of rollups are read as raw json; no block header is posted for them
+			if ob.rollupClient != nil {
+				if err := ob.observeTssDepositsInRPCBlock(bn, tssAddress); err != nil {
+					ob.logger.ExternalChainWatcher.Error().Err(err).Msgf("error observing deposits in block: %d", bn)
+					return err
+				}
+				continue
+			}
 			block, err := ob.GetBlockByNumberCached(bn)
 			if err != nil {
 				ob.logger.ExternalChainWatcher.Error().Err(err).Msgf("error getting block: %d", bn)
 				continue
 			}
+			// #nosec G701 always positive
+			if verified != nil && block.Hash() != verified[uint64(bn)] {
+				return fmt.Errorf("block %d hash %s does not match the verified header", bn, block.Hash().Hex())
+			}
+			ob.blockHashes.Add(bn, block.Hash())
 			headerRLP, err := rlp.EncodeToBytes(block.Header())
 			if err != nil {
 				ob.logger.ExternalChainWatcher.Error().Err(err).Msgf("error encoding block header: %d", bn)
@@ -949,8 +1325,8 @@ func (ob *EVMChainClient) observeInTX() error {
 					continue
 				}
 
-				if *tx.To() == tssAddress {
-					receipt, err := ob.evmClient.TransactionReceipt(context.Background(), tx.Hash())
+				if IsTssGasDeposit(tx.To(), tx.Value(), tssAddress) {
+					receipt, err := ob.evmClient.TransactionReceipt(ob.ctx, tx.Hash())
 					if err != nil {
 						ob.logger.ExternalChainWatcher.Err(err).Msg("TransactionReceipt error")
 						continue
@@ -960,7 +1336,7 @@ func (ob *EVMChainClient) observeInTX() error {
 						continue
 					}
 
-					from, err := ob.evmClient.TransactionSender(context.Background(), tx, block.Hash(), receipt.TransactionIndex)
+					from, err := ob.evmClient.TransactionSender(ob.ctx, tx, block.Hash(), receipt.TransactionIndex)
 					if err != nil {
 						ob.logger.ExternalChainWatcher.Err(err).Msg("TransactionSender error; trying local recovery (assuming LondonSigner dynamic fee tx type) of sender address")
 						signer := ethtypes.NewLondonSigner(big.NewInt(ob.chain.ChainId))
@@ -970,25 +1346,76 @@ func (ob *EVMChainClient) observeInTX() error {
 							continue
 						}
 					}
-					msg := ob.GetInboundVoteMsgForTokenSentToTSS(tx.Hash(), tx.Value(), receipt, from, tx.Data())
-					if msg == nil {
-						continue
-					}
-					zetaHash, err := ob.zetaClient.PostSend(PostSendEVMGasLimit, msg)
-					if err != nil {
-						ob.logger.ExternalChainWatcher.Error().Err(err).Msg("error posting to zeta core")
-						continue
+					if err := ob.postTssGasDeposit(tx.Hash(), tx.Value(), receipt, from, tx.Data(), 0); err != nil {
+						return err
 					}
-					ob.logger.ExternalChainWatcher.Info().Msgf("Gas Deposit detected and reported: PostSend zeta tx: %s", zetaHash)
 				}
 			}
+
+			txHashes := make([]ethcommon.Hash, 0, len(block.Transactions()))
+			for _, tx := range block.Transactions() {
+				txHashes = append(txHashes, tx.Hash())
+			}
+			if err := ob.observeInternalTssDeposits(bn, txHashes, tssAddress); err != nil {
+				ob.logger.ExternalChainWatcher.Error().Err(err).Msgf("error observing internal deposits in block: %d", bn)
+				return err
+			}
 		}
+		return nil
 	}()
-	// ============= end of query the incoming tx to TSS address ==============
-	ob.SetLastBlockHeightScanned(toBlock)
-	if err := ob.db.Save(clienttypes.ToLastBlockSQLType(ob.GetLastBlockHeightScanned())).Error; err != nil {
-		ob.logger.ExternalChainWatcher.Error().Err(err).Msg("error writing toBlock to db")
+	return err
+}
+
+// postTssGasDeposit posts the vote for a gas token deposit to the TSS address. The calldata of the deposit is the memo,
+// see common.ParseMemo, relayed hex encoded: the receiver address on zEVM followed by the message of the contract call.
+// It returns an error if the range of the deposit must be scanned again
+func (ob *EVMChainClient) postTssGasDeposit(txHash ethcommon.Hash, value *big.Int, receipt *ethtypes.Receipt, from ethcommon.Address, data []byte, eventIndex uint) error {
+	metricsPkg.InboundEventsProcessed.WithLabelValues(ob.chain.Name(), EventNameGasDeposit).Inc()
+	start := time.Now()
+	msg, err := ob.GetInboundVoteMsgForTokenSentToTSS(txHash, value, receipt, from, data, eventIndex)
+	if err == nil {
+		err = ob.checkInboundCap(msg)
+	}
+	if err == nil {
+		err = ob.screenInbound(msg)
+	}
+	if err != nil {
+		ob.recordEventDecoded(EventNameGasDeposit, "", start, err)
+		// never vote on a deposit that couldn't be screened; the range is scanned again
+		if errors.Is(err, ErrScreeningFailed) {
+			return err
+		}
+		ob.quarantineGasDeposit(txHash, value, receipt, from, data, eventIndex, err)
+		return nil
+	}
+	correlationID := InboundCorrelationID(msg)
+	logger := WithCorrelationID(ob.logger.ExternalChainWatcher, correlationID)
+	ob.recordEventDecoded(EventNameGasDeposit, correlationID, start, nil)
+	if ob.isInboundDust(msg) {
+		return nil
+	}
+	// the deposit of a range scanned again is already waiting for its retry
+	if ob.isVotePending(msg.Digest()) {
+		return nil
 	}
+	start = time.Now()
+	if err := ob.checkSenderRateLimit(msg); err != nil {
+		ob.recordEvent(EventNameGasDeposit, metricsPkg.EventStagePost, metricsPkg.EventWithheld, correlationID, start)
+		ob.quarantineGasDeposit(txHash, value, receipt, from, data, eventIndex, err)
+		return nil
+	}
+	zetaHash, err := ob.postInboundVote(PostSendEVMGasLimit, msg)
+	ob.recordEventPosted(EventNameGasDeposit, correlationID, start, zetaHash, err)
+	if err != nil {
+		logger.Error().Err(err).Msg("error posting to zeta core")
+		// the range is scanned again if the vote can't be enqueued for a retry either
+		return ob.enqueuePendingVote(msg.Digest(), receipt.BlockNumber.Uint64(), PostSendEVMGasLimit, msg, err)
+	}
+	if zetaHash == "" {
+		return nil
+	}
+	ob.recordInboundLatency(EventNameGasDeposit, correlationID, receipt.BlockNumber.Uint64(), receipt.BlockHash, time.Now())
+	logger.Info().Msgf("Gas Deposit detected and reported: PostSend zeta tx: %s", zetaHash)
 	return nil
 }
 
@@ -1028,28 +1455,27 @@ func (ob *EVMChainClient) WatchGasPrice() {
 
 func (ob *EVMChainClient) PostGasPrice() error {
 	// GAS PRICE
-	gasPrice, err := ob.evmClient.SuggestGasPrice(context.TODO())
+	sample, err := ob.sampleGasPrice()
 	if err != nil {
-		ob.logger.WatchGasPrice.Err(err).Msg("Err SuggestGasPrice:")
+		ob.logger.WatchGasPrice.Err(err).Msg("Err sampleGasPrice:")
 		return err
 	}
-	blockNum, err := ob.evmClient.BlockNumber(context.TODO())
-	if err != nil {
-		ob.logger.WatchGasPrice.Err(err).Msg("Err Fetching Most recent Block : ")
-		return err
+	gasPrice := sample.EffectiveGasPrice()
+	if !gasPrice.IsUint64() {
+		return fmt.Errorf("gas price %s overflows uint64", gasPrice.String())
 	}
 
 	// SUPPLY
 	var supply string // lockedAmount on ETH, totalSupply on other chains
 	supply = "100"
 
-	zetaHash, err := ob.zetaClient.PostGasPrice(ob.chain, gasPrice.Uint64(), supply, blockNum)
+	zetaHash, err := ob.zetaClient.PostGasPrice(ob.chain, gasPrice.Uint64(), supply, sample.BlockNumber)
 	if err != nil {
 		ob.logger.WatchGasPrice.Err(err).Msg("PostGasPrice to zetacore failed")
 		return err
 	}
-	_ = zetaHash
-	//ob.logger.WatchGasPrice.Debug().Msgf("PostGasPrice zeta tx: %s", zetaHash)
+	ob.logger.WatchGasPrice.Debug().Msgf("PostGasPrice: posted gas price %s at block %d (suggested %s, base fee %v, tip %v), zeta tx %s",
+		gasPrice, sample.BlockNumber, sample.GasPrice, sample.BaseFee, sample.PriorityFee, zetaHash)
 
 	return nil
 }
@@ -1067,7 +1493,7 @@ func (ob *EVMChainClient) getLastHeight() (int64, error) {
 
 func (ob *EVMChainClient) BuildBlockIndex() error {
 	logger := ob.logger.ChainLogger.With().Str("module", "BuildBlockIndex").Logger()
-	envvar := ob.chain.ChainName.String() + "_SCAN_FROM"
+	envvar := ob.chain.Name() + "_SCAN_FROM"
 	scanFromBlock := os.Getenv(envvar)
 	if scanFromBlock != "" {
 		logger.Info().Msgf("envvar %s is set; scan from  block %s", envvar, scanFromBlock)
@@ -1085,6 +1511,7 @@ func (ob *EVMChainClient) BuildBlockIndex() error {
 			ob.SetLastBlockHeightScanned(scanFromBlockInt)
 		}
 	} else { // last observed block
+		evmCfg, _ := ob.cfg.GetEVMConfig(ob.chain.ChainId)
 		var lastBlockNum clienttypes.LastBlockSQLType
 		if err := ob.db.First(&lastBlockNum, clienttypes.LastBlockNumID).Error; err != nil {
 			logger.Info().Msg("db PosKey does not exist; read from ZetaCore")
@@ -1092,9 +1519,10 @@ func (ob *EVMChainClient) BuildBlockIndex() error {
 			if err != nil {
 				logger.Warn().Err(err).Msg("getLastHeight error")
 			}
-			ob.SetLastBlockHeightScanned(lastheight)
-			// if ZetaCore does not have last heard block height, then use current
-			if ob.GetLastBlockHeightScanned() == 0 {
+			ob.SetLastBlockHeightScanned(boundLastBlock(lastheight, evmCfg.StartBlock))
+			// if ZetaCore does not have last heard block height nor is a start block set, then use current
+			if ob.GetLastBlockHeightScanned() == 0 && evmCfg.StartBlock == 0 {
+				logger.Warn().Msgf("no last scanned block found in db or ZetaCore; set envvar %s to scan from an earlier block", envvar)
 				header, err := ob.evmClient.HeaderByNumber(context.Background(), nil)
 				if err != nil {
 					return err
@@ -1105,12 +1533,22 @@ func (ob *EVMChainClient) BuildBlockIndex() error {
 				logger.Error().Err(dbc.Error).Msg("error writing ob.LastBlock to db: ")
 			}
 		} else {
-			ob.SetLastBlockHeightScanned(lastBlockNum.Num)
+			ob.SetLastBlockHeightScanned(boundLastBlock(lastBlockNum.Num, evmCfg.StartBlock))
 		}
 	}
 	return nil
 }
 
+// boundLastBlock returns the last scanned block so that the scan starts at startBlock at the earliest; a zero
+// startBlock doesn't bound it
+func boundLastBlock(lastBlock int64, startBlock uint64) int64 {
+	// #nosec G701 always in range
+	if startBlock > 0 && lastBlock < int64(startBlock)-1 {
+		return int64(startBlock) - 1
+	}
+	return lastBlock
+}
+
 func (ob *EVMChainClient) BuildReceiptsMap() error {
 	logger := ob.logger
 	var receipts []clienttypes.ReceiptSQLType
@@ -1155,7 +1593,7 @@ func (ob *EVMChainClient) LoadDB(dbPath string, chain common.Chain) error {
 				return err
 			}
 		}
-		path := fmt.Sprintf("%s/%s", dbPath, chain.ChainName.String()) //Use "file::memory:?cache=shared" for temp db
+		path := fmt.Sprintf("%s/%s", dbPath, chain.Name()) //Use "file::memory:?cache=shared" for temp db
 		db, err := gorm.Open(sqlite.Open(path), &gorm.Config{})
 		if err != nil {
 			panic("failed to connect database")
@@ -1163,7 +1601,12 @@ func (ob *EVMChainClient) LoadDB(dbPath string, chain common.Chain) error {
 
 		err = db.AutoMigrate(&clienttypes.ReceiptSQLType{},
 			&clienttypes.TransactionSQLType{},
-			&clienttypes.LastBlockSQLType{})
+			&clienttypes.LastBlockSQLType{},
+			&clienttypes.InboundEventSQLType{},
+			&clienttypes.InboundCheckpointSQLType{},
+			&clienttypes.QuarantinedEventSQLType{},
+			&clienttypes.PendingVoteSQLType{},
+			&clienttypes.InboundProofSQLType{})
 		if err != nil {
 			return err
 		}
@@ -1223,7 +1666,11 @@ func (ob *EVMChainClient) GetBlockByNumberCached(blockNumber int64) (*ethtypes.B
 	if block, ok := ob.BlockCache.Get(blockNumber); ok {
 		return block.(*ethtypes.Block), nil
 	}
-	block, err := ob.evmClient.BlockByNumber(context.Background(), big.NewInt(blockNumber))
+	var block *ethtypes.Block
+	err := Retry(ob.ctx, "BlockByNumber", RPCBackoff, func() (err error) {
+		block, err = ob.evmClient.BlockByNumber(ob.ctx, big.NewInt(blockNumber))
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -1,14 +1,26 @@
 package zetaclient
 
 import (
+	"errors"
+	"fmt"
+	"math/big"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/suite"
+	zetacommon "github.com/zeta-chain/zetacore/common"
+	"github.com/zeta-chain/zetacore/x/crosschain/types"
+	observertypes "github.com/zeta-chain/zetacore/x/observer/types"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
 	clienttypes "github.com/zeta-chain/zetacore/zetaclient/types"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -39,7 +51,12 @@ func (suite *EVMClientTestSuite) SetupTest() {
 
 	err = db.AutoMigrate(&clienttypes.ReceiptSQLType{},
 		&clienttypes.TransactionSQLType{},
-		&clienttypes.LastBlockSQLType{})
+		&clienttypes.LastBlockSQLType{},
+		&clienttypes.InboundEventSQLType{},
+		&clienttypes.InboundCheckpointSQLType{},
+		&clienttypes.QuarantinedEventSQLType{},
+		&clienttypes.PendingVoteSQLType{},
+		&clienttypes.InboundProofSQLType{})
 	suite.NoError(err)
 
 	//Create some receipt entries in the DB
@@ -125,6 +142,376 @@ func (suite *EVMClientTestSuite) TestEVMLastBlock() {
 	suite.Equal(lastBlockNum, lastBlockDB.Num)
 }
 
+func (suite *EVMClientTestSuite) TestEVMInboundEventDedup() {
+	ob := &EVMChainClient{db: suite.db}
+	txHash := crypto.Keccak256Hash([]byte("inbound"))
+	key := clienttypes.InboundEventKey(txHash, 3)
+
+	suite.False(ob.isInboundEventProcessed(key))
+	ob.setInboundEventProcessed(key, 100, "zetahash")
+	suite.True(ob.isInboundEventProcessed(key))
+
+	// another event of the same tx is not deduplicated
+	suite.False(ob.isInboundEventProcessed(clienttypes.InboundEventKey(txHash, 4)))
+}
+
+func (suite *EVMClientTestSuite) TestEVMForgetInboundEventsAfterReorg() {
+	ob := &EVMChainClient{db: suite.db, logger: EVMLog{ExternalChainWatcher: zerolog.Nop()}}
+	kept := clienttypes.InboundEventKey(crypto.Keccak256Hash([]byte("kept")), 0)
+	reverted := clienttypes.InboundEventKey(crypto.Keccak256Hash([]byte("reverted")), 0)
+	ob.setInboundEventProcessed(kept, 200, "zetahash1")
+	ob.setInboundEventProcessed(reverted, 201, "zetahash2")
+
+	ob.forgetInboundEventsAfter(200)
+	suite.True(ob.isInboundEventProcessed(kept))
+	suite.False(ob.isInboundEventProcessed(reverted))
+
+	// the event can be recorded again once observed on the new canonical chain
+	ob.setInboundEventProcessed(reverted, 202, "zetahash3")
+	suite.True(ob.isInboundEventProcessed(reverted))
+}
+
+func (suite *EVMClientTestSuite) TestEVMPruneInboundEvents() {
+	ob := &EVMChainClient{db: suite.db, logger: EVMLog{ExternalChainWatcher: zerolog.Nop()}}
+	pruned := clienttypes.InboundEventKey(crypto.Keccak256Hash([]byte("pruned")), 0)
+	kept := clienttypes.InboundEventKey(crypto.Keccak256Hash([]byte("retained")), 0)
+	ob.setInboundEventProcessed(pruned, 1000-InboundEventRetention-1, "zetahash1")
+	ob.setInboundEventProcessed(kept, 1000-InboundEventRetention, "zetahash2")
+
+	// nothing is old enough yet
+	ob.pruneInboundEvents(InboundEventRetention)
+	suite.True(ob.isInboundEventProcessed(pruned))
+
+	ob.pruneInboundEvents(1000)
+	suite.False(ob.isInboundEventProcessed(pruned))
+	suite.True(ob.isInboundEventProcessed(kept))
+}
+
+func (suite *EVMClientTestSuite) TestEVMInboundCheckpoint() {
+	ob := &EVMChainClient{db: suite.db, logger: EVMLog{ExternalChainWatcher: zerolog.Nop()}}
+	blockHash := crypto.Keccak256Hash([]byte("block 301"))
+	verified := map[uint64]common.Hash{301: blockHash}
+	suite.Nil(ob.loadInboundCheckpoint(300, verified))
+
+	// the node stops after posting the first event of block 301
+	vLog := ethtypes.Log{BlockNumber: 301, BlockHash: blockHash, Index: 5, TxHash: crypto.Keccak256Hash([]byte("posted"))}
+	key := clienttypes.InboundEventKey(vLog.TxHash, vLog.Index)
+	ob.setInboundEventCheckpoint(key, vLog, "zetahash")
+	suite.True(ob.isInboundEventProcessed(key))
+
+	checkpoint := ob.loadInboundCheckpoint(300, verified)
+	suite.NotNil(checkpoint)
+	logs := []ethtypes.Log{
+		{BlockNumber: 300, Index: 9},
+		{BlockNumber: 301, Index: 5},
+		{BlockNumber: 301, Index: 6},
+		{BlockNumber: 302, Index: 0},
+	}
+	suite.Equal(logs[2:], skipCheckpointedLogs(logs, checkpoint))
+	suite.Empty(skipCheckpointedLogs(logs[:2], checkpoint))
+	suite.Equal(logs, skipCheckpointedLogs(logs, nil))
+
+	// ignored once the range is completed or the block is reverted
+	suite.Nil(ob.loadInboundCheckpoint(302, verified))
+	suite.Nil(ob.loadInboundCheckpoint(300, map[uint64]common.Hash{301: crypto.Keccak256Hash([]byte("fork"))}))
+	ob.forgetInboundCheckpointAfter(301)
+	suite.NotNil(ob.loadInboundCheckpoint(300, verified))
+	ob.forgetInboundCheckpointAfter(300)
+	suite.Nil(ob.loadInboundCheckpoint(300, verified))
+}
+
+func (suite *EVMClientTestSuite) TestEVMQuarantine() {
+	chain := zetacommon.EthChain()
+	cfg := config.NewConfig()
+	cfg.EVMChainConfigs = map[int64]*config.EVMConfig{chain.ChainId: {Chain: chain, StrictDecoding: true}}
+	ob := &EVMChainClient{db: suite.db, chain: chain, cfg: cfg, logger: EVMLog{ExternalChainWatcher: zerolog.Nop()}}
+	contract, err := newWatchedContract(config.ContractKindConnector, common.HexToAddress("0x01"), config.GetConnectorABI())
+	suite.NoError(err)
+
+	vLog := ethtypes.Log{
+		Address:     contract.address,
+		Topics:      []common.Hash{contract.event.ID, crypto.Keccak256Hash([]byte("topic"))},
+		Data:        []byte{0xde, 0xad},
+		BlockNumber: 400,
+		BlockHash:   crypto.Keccak256Hash([]byte("block 400")),
+		TxHash:      crypto.Keccak256Hash([]byte("malformed")),
+		TxIndex:     2,
+		Index:       7,
+	}
+	key := clienttypes.InboundEventKey(vLog.TxHash, vLog.Index)
+	ob.quarantineInboundEvent(contract, key, vLog, errors.New("abi: cannot unmarshal"))
+	// quarantining the event again, e.g. in a rescan, keeps one record
+	ob.quarantineInboundEvent(contract, key, vLog, errors.New("abi: cannot unmarshal"))
+	// rejected events are not quarantined
+	rejected := vLog
+	rejected.Index = 8
+	ob.quarantineInboundEvent(contract, clienttypes.InboundEventKey(rejected.TxHash, rejected.Index), rejected, ErrInboundRejected)
+
+	events, err := ob.GetQuarantinedEvents()
+	suite.NoError(err)
+	suite.Len(events, 1)
+	suite.Equal(key, events[0].Key)
+	suite.Equal(config.ContractKindConnector, events[0].Kind)
+	restored, err := clienttypes.FromQuarantinedEventSQLType(events[0])
+	suite.NoError(err)
+	suite.Equal(vLog, restored)
+}
+
+// keysBridge serves the keys of the observer
+type keysBridge struct {
+	ZetaCoreBridger
+}
+
+func (b *keysBridge) GetKeys() *Keys {
+	return &Keys{OperatorAddress: common.HexToAddress("0x0b").Bytes()}
+}
+
+func (suite *EVMClientTestSuite) TestEVMQuarantineGasDeposit() {
+	chain := zetacommon.EthChain()
+	cfg := config.NewConfig()
+	cfg.EVMChainConfigs = map[int64]*config.EVMConfig{chain.ChainId: {Chain: chain, StrictDecoding: true,
+		InboundAmountCaps: map[string]string{config.InboundCapAssetGas: "1000"}}}
+	ob := &EVMChainClient{db: suite.db, chain: chain, cfg: cfg, zetaClient: &keysBridge{}, logger: EVMLog{ExternalChainWatcher: zerolog.Nop()}}
+	txHash := crypto.Keccak256Hash([]byte("capped deposit"))
+	from := common.HexToAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	receipt := &ethtypes.Receipt{Status: ethtypes.ReceiptStatusSuccessful, TxHash: txHash, BlockNumber: big.NewInt(800),
+		BlockHash: crypto.Keccak256Hash([]byte("block 800")), TransactionIndex: 4}
+
+	// a deposit above the cap is withheld and quarantined rather than dropped
+	suite.NoError(ob.postTssGasDeposit(txHash, big.NewInt(1001), receipt, from, nil, 2))
+	var record clienttypes.QuarantinedEventSQLType
+	suite.Require().NoError(suite.db.Where(&clienttypes.QuarantinedEventSQLType{Key: clienttypes.GasDepositKey(txHash, 2)}).First(&record).Error)
+	suite.Equal(clienttypes.QuarantinedKindGasDeposit, record.Kind)
+	suite.Equal("1001", record.Value)
+	vLog, err := clienttypes.FromQuarantinedEventSQLType(record)
+	suite.NoError(err)
+	suite.Equal(from, vLog.Address)
+	suite.Equal(txHash, vLog.TxHash)
+	suite.Equal(receipt.BlockHash, vLog.BlockHash)
+	suite.EqualValues(800, vLog.BlockNumber)
+	suite.EqualValues(2, vLog.Index)
+}
+
+func (suite *EVMClientTestSuite) TestEVMThrottledGasDeposit() {
+	chain := zetacommon.EthChain()
+	cfg := config.NewConfig()
+	cfg.EVMChainConfigs = map[int64]*config.EVMConfig{chain.ChainId: {Chain: chain, SenderRateLimit: 1}}
+	bridge := &keysBridge{ZetaCoreBridger: &flakyBridge{}}
+	ob := &EVMChainClient{db: suite.db, chain: chain, cfg: cfg, zetaClient: bridge, senders: NewSenderRateLimiter(),
+		logger: EVMLog{ExternalChainWatcher: zerolog.Nop()}}
+	from := common.HexToAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	deposit := func(name string) common.Hash {
+		txHash := crypto.Keccak256Hash([]byte(name))
+		receipt := &ethtypes.Receipt{Status: ethtypes.ReceiptStatusSuccessful, TxHash: txHash, BlockNumber: big.NewInt(1000)}
+		suite.NoError(ob.postTssGasDeposit(txHash, big.NewInt(1000), receipt, from, nil, 0))
+		return txHash
+	}
+
+	// the deposits of a sender above its rate are quarantined like its events
+	allowed := deposit("first deposit")
+	throttled := deposit("second deposit")
+	var record clienttypes.QuarantinedEventSQLType
+	suite.Error(suite.db.Where(&clienttypes.QuarantinedEventSQLType{Key: clienttypes.GasDepositKey(allowed, 0)}).First(&record).Error)
+	suite.Require().NoError(suite.db.Where(&clienttypes.QuarantinedEventSQLType{Key: clienttypes.GasDepositKey(throttled, 0)}).First(&record).Error)
+	suite.Contains(record.Error, ErrInboundThrottled.Error())
+}
+
+// flakyBridge fails to post the votes until it is up
+type flakyBridge struct {
+	ZetaCoreBridger
+	up          bool
+	unavailable bool // the circuit breaker of the bridge is open
+}
+
+func (b *flakyBridge) GetBallot(string) (*observertypes.QueryBallotByIdentifierResponse, error) {
+	return nil, errors.New("ballot not found")
+}
+
+func (b *flakyBridge) GetCctxByHash(string) (*types.CrossChainTx, error) {
+	return nil, errors.New("cctx not found")
+}
+
+func (b *flakyBridge) CoreAvailable() bool {
+	return !b.unavailable
+}
+
+func (b *flakyBridge) PostSend(uint64, *types.MsgVoteOnObservedInboundTx) (string, error) {
+	if b.unavailable {
+		return "", fmt.Errorf("PostSend: %w", ErrCoreUnavailable)
+	}
+	if !b.up {
+		return "", errors.New("connection refused")
+	}
+	return "zetahash", nil
+}
+
+func (suite *EVMClientTestSuite) TestEVMPendingVotes() {
+	bridge := &flakyBridge{}
+	ob := &EVMChainClient{db: suite.db, chain: zetacommon.EthChain(), zetaClient: bridge, logger: EVMLog{ExternalChainWatcher: zerolog.Nop()}}
+	vote := func(txHash string, block uint64) *types.MsgVoteOnObservedInboundTx {
+		msg, err := InboundEvent{Sender: "0x01", SenderChain: ob.chain, TxOrigin: "0x01", Receiver: "0x02", ReceiverChain: zetacommon.ZetaChain(),
+			Amount: big.NewInt(1000), InTxHash: txHash, InBlockHeight: block, GasLimit: 90_000, CoinType: zetacommon.CoinType_Gas}.VoteMessage("zeta1observer")
+		suite.Require().NoError(err)
+		return msg
+	}
+	first := vote("0xfirst", 500)
+	second := vote("0xsecond", 501)
+	reverted := vote("0xreverted", 502)
+	now := time.Now()
+	for _, msg := range []*types.MsgVoteOnObservedInboundTx{first, second, reverted} {
+		suite.NoError(ob.enqueuePendingVote(msg.Digest(), msg.InBlockHeight, 90_000, msg, errors.New("connection refused")))
+	}
+	// enqueuing a vote again keeps one record
+	suite.NoError(ob.enqueuePendingVote(first.Digest(), first.InBlockHeight, 90_000, first, errors.New("connection refused")))
+	suite.True(ob.isVotePending(first.Digest()))
+	count, err := ob.countPendingVotes(502)
+	suite.NoError(err)
+	suite.EqualValues(3, count)
+
+	// the votes of a reverted block are dropped
+	ob.forgetPendingVotesAfter(501)
+	suite.False(ob.isVotePending(reverted.Digest()))
+
+	// no retry is due before the backoff
+	ob.retryPendingVotes(now)
+	votes, err := ob.GetPendingVotes()
+	suite.NoError(err)
+	suite.Len(votes, 2)
+	suite.Zero(votes[0].Attempts)
+
+	// a vote is dead-lettered once its retries are exhausted
+	for i := 0; i < PendingVoteBackoff.MaxRetries; i++ {
+		now = now.Add(time.Hour)
+		ob.retryPendingVotes(now)
+	}
+	count, err = ob.countPendingVotes(502)
+	suite.NoError(err)
+	suite.Zero(count)
+	votes, err = ob.GetPendingVotes()
+	suite.NoError(err)
+	suite.Len(votes, 2)
+	suite.True(votes[0].DeadLettered)
+	suite.Equal(PendingVoteBackoff.MaxRetries, votes[0].Attempts)
+
+	// a vote leaves the queue once posted
+	suite.NoError(suite.db.Unscoped().Delete(&votes[1]).Error)
+	suite.NoError(ob.enqueuePendingVote(second.Digest(), second.InBlockHeight, 90_000, second, errors.New("connection refused")))
+	bridge.up = true
+	ob.retryPendingVotes(now.Add(time.Hour))
+	suite.False(ob.isVotePending(second.Digest()))
+	votes, err = ob.GetPendingVotes()
+	suite.NoError(err)
+	suite.Len(votes, 1)
+	suite.Equal(first.Digest(), votes[0].Key)
+}
+
+func (suite *EVMClientTestSuite) TestEVMPendingVotes_CoreUnavailable() {
+	bridge := &flakyBridge{unavailable: true}
+	ob := &EVMChainClient{db: suite.db, chain: zetacommon.EthChain(), zetaClient: bridge, logger: EVMLog{ExternalChainWatcher: zerolog.Nop()}}
+	msg, err := InboundEvent{Sender: "0x01", SenderChain: ob.chain, TxOrigin: "0x01", Receiver: "0x02", ReceiverChain: zetacommon.ZetaChain(),
+		Amount: big.NewInt(1000), InTxHash: "0xheld", InBlockHeight: 600, GasLimit: 90_000, CoinType: zetacommon.CoinType_Gas}.VoteMessage("zeta1observer")
+	suite.Require().NoError(err)
+	suite.NoError(ob.enqueuePendingVote(msg.Digest(), msg.InBlockHeight, 90_000, msg, ErrCoreUnavailable))
+
+	// the vote is held without spending its attempts while zetacore is unavailable
+	now := time.Now()
+	for i := 0; i < PendingVoteBackoff.MaxRetries; i++ {
+		now = now.Add(time.Hour)
+		ob.retryPendingVotes(now)
+	}
+	var vote clienttypes.PendingVoteSQLType
+	suite.NoError(suite.db.Where(&clienttypes.PendingVoteSQLType{Key: msg.Digest()}).First(&vote).Error)
+	suite.Zero(vote.Attempts)
+	suite.False(vote.DeadLettered)
+
+	// and posted once zetacore is back
+	bridge.unavailable = false
+	bridge.up = true
+	ob.retryPendingVotes(now.Add(time.Hour))
+	suite.False(ob.isVotePending(msg.Digest()))
+}
+
+func (suite *EVMClientTestSuite) TestEVMPendingVotes_Rejected() {
+	bridge := &flakyBridge{}
+	ob := &EVMChainClient{db: suite.db, chain: zetacommon.EthChain(), zetaClient: bridge, logger: EVMLog{ExternalChainWatcher: zerolog.Nop()}}
+	msg, err := InboundEvent{Sender: "0x01", SenderChain: ob.chain, TxOrigin: "0x01", Receiver: "0x02", ReceiverChain: zetacommon.ZetaChain(),
+		Amount: big.NewInt(1000), InTxHash: "0xrejected", InBlockHeight: 700, GasLimit: 90_000, CoinType: zetacommon.CoinType_Gas}.VoteMessage("zeta1observer")
+	suite.Require().NoError(err)
+
+	// a vote zetacore rejected on its own is dead-lettered without a retry
+	suite.NoError(ob.enqueuePendingVote(msg.Digest(), msg.InBlockHeight, 90_000, msg, fmt.Errorf("%w: ballot already finalized", ErrVoteRejected)))
+	suite.False(ob.isVotePending(msg.Digest()))
+	var vote clienttypes.PendingVoteSQLType
+	suite.NoError(suite.db.Where(&clienttypes.PendingVoteSQLType{Key: msg.Digest()}).First(&vote).Error)
+	suite.True(vote.DeadLettered)
+	suite.Zero(vote.Attempts)
+}
+
+func (suite *EVMClientTestSuite) TestEVMPendingVotes_GasDeposit() {
+	bridge := &keysBridge{ZetaCoreBridger: &flakyBridge{}}
+	ob := &EVMChainClient{chain: zetacommon.EthChain(), cfg: config.NewConfig(), zetaClient: bridge, logger: EVMLog{ExternalChainWatcher: zerolog.Nop()}}
+	txHash := crypto.Keccak256Hash([]byte("gas deposit"))
+	from := common.HexToAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	receipt := &ethtypes.Receipt{Status: ethtypes.ReceiptStatusSuccessful, TxHash: txHash, BlockNumber: big.NewInt(900)}
+
+	// the range is scanned again if the vote can't be enqueued for a retry
+	suite.Error(ob.postTssGasDeposit(txHash, big.NewInt(1000), receipt, from, nil, 0))
+
+	ob.db = suite.db
+	suite.NoError(ob.postTssGasDeposit(txHash, big.NewInt(1000), receipt, from, nil, 0))
+	msg, err := ob.GetInboundVoteMsgForTokenSentToTSS(txHash, big.NewInt(1000), receipt, from, nil, 0)
+	suite.Require().NoError(err)
+	suite.True(ob.isVotePending(msg.Digest()))
+}
+
+// proofBridge verifies the inbound proofs on behalf of zetacore
+type proofBridge struct {
+	ZetaCoreBridger
+	valid bool
+}
+
+func (b *proofBridge) Prove(_ string, _ string, _ int64, _ *zetacommon.Proof, _ int64) (bool, error) {
+	return b.valid, nil
+}
+
+func (suite *EVMClientTestSuite) TestEVMInboundProofs() {
+	txs := []*ethtypes.Transaction{legacyTx(0), legacyTx(1), legacyTx(2)}
+	block := ethtypes.NewBlock(&ethtypes.Header{Number: big.NewInt(700)}, txs, nil, nil, trie.NewStackTrie(nil))
+	cache, err := lru.New(10)
+	suite.Require().NoError(err)
+	cache.Add(int64(700), block)
+	bridge := &proofBridge{valid: true}
+	ob := &EVMChainClient{db: suite.db, chain: zetacommon.EthChain(), zetaClient: bridge, BlockCache: cache, logger: EVMLog{ExternalChainWatcher: zerolog.Nop()}}
+	vLog := ethtypes.Log{BlockNumber: 700, BlockHash: block.Hash(), TxHash: txs[1].Hash(), TxIndex: 1, Index: 3}
+	receipt := &ethtypes.Receipt{Status: ethtypes.ReceiptStatusSuccessful, TxHash: txs[1].Hash(), Logs: []*ethtypes.Log{}}
+	key := clienttypes.InboundEventKey(vLog.TxHash, vLog.Index)
+
+	// the proof is recorded with the event once verified by zetacore
+	suite.NoError(ob.proveInbound(key, vLog, receipt))
+	proof, err := ob.GetInboundProof(key)
+	suite.NoError(err)
+	suite.Require().NotNil(proof)
+	suite.Equal(block.Hash().Hex(), proof.BlockHash)
+	suite.EqualValues(1, proof.TxIndex)
+	suite.Equal(txs[1].Hash(), proof.Receipt.TxHash)
+
+	// and can be checked by the peers against the header
+	header, err := rlp.EncodeToBytes(block.Header())
+	suite.NoError(err)
+	txBytes, err := proof.TxProof.Verify(zetacommon.NewEthereumHeader(header), int(proof.TxIndex))
+	suite.NoError(err)
+	var tx ethtypes.Transaction
+	suite.NoError(tx.UnmarshalBinary(txBytes))
+	suite.Equal(txs[1].Hash(), tx.Hash())
+
+	// a tx missing from the block is never proven
+	suite.Error(ob.proveInbound(key, ethtypes.Log{BlockNumber: 700, BlockHash: block.Hash(), TxHash: txs[0].Hash(), TxIndex: 1}, receipt))
+
+	// a proof zetacore finds invalid is rejected
+	bridge.valid = false
+	suite.ErrorIs(ob.proveInbound(key, vLog, receipt), ErrInboundProofRejected)
+}
+
 func legacyTx(nonce int) *ethtypes.Transaction {
 	gasPrice, err := hexutil.DecodeBig("0x2bd0875aed")
 	if err != nil {
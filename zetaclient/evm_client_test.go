@@ -0,0 +1,288 @@
+package zetaclient
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/zeta-chain/zetacore/common"
+	crosschaintypes "github.com/zeta-chain/zetacore/x/crosschain/types"
+	observertypes "github.com/zeta-chain/zetacore/x/observer/types"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
+	clienttypes "github.com/zeta-chain/zetacore/zetaclient/types"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// stubEVMRPCClient serves the headers of the latest, safe and finalized blocks
+type stubEVMRPCClient struct {
+	EVMRPCClient
+	latest    int64
+	safe      int64
+	finalized int64
+}
+
+func (c *stubEVMRPCClient) HeaderByNumber(_ context.Context, number *big.Int) (*ethtypes.Header, error) {
+	switch {
+	case number == nil:
+		return &ethtypes.Header{Number: big.NewInt(c.latest)}, nil
+	case number.Int64() == int64(rpc.SafeBlockNumber):
+		return &ethtypes.Header{Number: big.NewInt(c.safe)}, nil
+	case number.Int64() == int64(rpc.FinalizedBlockNumber):
+		return &ethtypes.Header{Number: big.NewInt(c.finalized)}, nil
+	}
+	return &ethtypes.Header{Number: number}, nil
+}
+
+// noTagRPCClient is an rpc endpoint serving no "safe" or "finalized" block, e.g. a node not upgraded for the merge
+type noTagRPCClient struct {
+	stubEVMRPCClient
+}
+
+func (c *noTagRPCClient) HeaderByNumber(ctx context.Context, number *big.Int) (*ethtypes.Header, error) {
+	if number != nil && number.Int64() < 0 {
+		return nil, errors.New("finalized block not found")
+	}
+	return c.stubEVMRPCClient.HeaderByNumber(ctx, number)
+}
+
+func TestEVMChainClient_GetConfirmedBlockNumber(t *testing.T) {
+	chain := common.EthChain()
+	cfg := config.NewConfig()
+	cfg.EVMChainConfigs = map[int64]*config.EVMConfig{chain.ChainId: {Chain: chain}}
+	ob := &EVMChainClient{
+		Mu:         &sync.Mutex{},
+		ctx:        context.Background(),
+		chain:      chain,
+		cfg:        cfg,
+		params:     observertypes.CoreParams{ConfirmationCount: 10},
+		evmClient:  &stubEVMRPCClient{latest: 1000, safe: 980, finalized: 950},
+		blockTimes: NewBlockTimeEstimator(),
+		stall:      NewStallDetector(),
+	}
+
+	// confirmations are counted from the latest block
+	confirmed, err := ob.getConfirmedBlockNumber()
+	require.NoError(t, err)
+	require.Equal(t, uint64(990), confirmed)
+
+	// the finality tag caps the confirmed block
+	cfg.EVMChainConfigs[chain.ChainId].FinalityTag = config.FinalityTagSafe
+	confirmed, err = ob.getConfirmedBlockNumber()
+	require.NoError(t, err)
+	require.Equal(t, uint64(980), confirmed)
+
+	cfg.EVMChainConfigs[chain.ChainId].FinalityTag = config.FinalityTagFinalized
+	confirmed, err = ob.getConfirmedBlockNumber()
+	require.NoError(t, err)
+	require.Equal(t, uint64(950), confirmed)
+
+	// confirmations still apply if the tagged block is more recent
+	ob.evmClient = &stubEVMRPCClient{latest: 1000, safe: 1000, finalized: 1000}
+	confirmed, err = ob.getConfirmedBlockNumber()
+	require.NoError(t, err)
+	require.Equal(t, uint64(990), confirmed)
+
+	cfg.EVMChainConfigs[chain.ChainId].FinalityTag = "latest"
+	_, err = ob.getConfirmedBlockNumber()
+	require.Error(t, err)
+}
+
+func TestEVMChainClient_GetConfirmedBlockNumberNoTag(t *testing.T) {
+	chain := common.EthChain()
+	cfg := config.NewConfig()
+	cfg.EVMChainConfigs = map[int64]*config.EVMConfig{chain.ChainId: {Chain: chain, FinalityTag: config.FinalityTagFinalized}}
+	ob := &EVMChainClient{
+		Mu:         &sync.Mutex{},
+		ctx:        context.Background(),
+		chain:      chain,
+		cfg:        cfg,
+		params:     observertypes.CoreParams{ConfirmationCount: 10},
+		logger:     EVMLog{ExternalChainWatcher: zerolog.Nop()},
+		evmClient:  &noTagRPCClient{stubEVMRPCClient{latest: 1000}},
+		blockTimes: NewBlockTimeEstimator(),
+		stall:      NewStallDetector(),
+	}
+
+	// falls back to the confirmation count
+	confirmed, err := ob.getConfirmedBlockNumber()
+	require.NoError(t, err)
+	require.Equal(t, uint64(990), confirmed)
+}
+
+func TestIsBlockTagUnsupportedError(t *testing.T) {
+	require.False(t, IsBlockTagUnsupportedError(nil))
+	require.False(t, IsBlockTagUnsupportedError(errors.New("connection refused")))
+	require.True(t, IsBlockTagUnsupportedError(ethereum.NotFound))
+	require.True(t, IsBlockTagUnsupportedError(errors.New("safe block not found")))
+	require.True(t, IsBlockTagUnsupportedError(errors.New("Invalid block tag: finalized")))
+}
+
+func TestIsTssGasDeposit(t *testing.T) {
+	tss := ethcommon.HexToAddress("0x70e967acFcC17c3941E87562161406d41676FD83")
+	other := ethcommon.HexToAddress("0x01")
+
+	require.True(t, IsTssGasDeposit(&tss, big.NewInt(1), tss))
+	require.False(t, IsTssGasDeposit(&other, big.NewInt(1), tss))
+	require.False(t, IsTssGasDeposit(nil, big.NewInt(1), tss))
+	require.False(t, IsTssGasDeposit(&tss, big.NewInt(0), tss))
+	require.False(t, IsTssGasDeposit(&tss, nil, tss))
+}
+
+func TestEVMChainClient_CheckDestinationChain(t *testing.T) {
+	chain := common.EthChain()
+	cfg := config.NewConfig()
+	cfg.ChainsEnabled = []common.Chain{chain, common.BscMainnetChain()}
+	ob := &EVMChainClient{
+		Mu:     &sync.Mutex{},
+		chain:  chain,
+		cfg:    cfg,
+		logger: EVMLog{ExternalChainWatcher: zerolog.Nop()},
+	}
+	sender := ethcommon.HexToAddress("0x01")
+
+	destChain, err := ob.checkDestinationChain(big.NewInt(common.BscMainnetChain().ChainId), sender, big.NewInt(1), ethtypes.Log{})
+	require.NoError(t, err)
+	require.Equal(t, common.BscMainnetChain(), *destChain)
+	_, err = ob.checkDestinationChain(big.NewInt(common.ZetaChain().ChainId), sender, big.NewInt(1), ethtypes.Log{})
+	require.NoError(t, err)
+
+	// unknown, unsupported and source chains are rejected
+	for _, chainID := range []*big.Int{
+		big.NewInt(99001),
+		new(big.Int).Lsh(big.NewInt(1), 70),
+		big.NewInt(common.PolygonChain().ChainId),
+		big.NewInt(chain.ChainId),
+	} {
+		_, err = ob.checkDestinationChain(chainID, sender, big.NewInt(1), ethtypes.Log{})
+		require.ErrorIs(t, err, ErrInboundRejected)
+	}
+}
+
+func TestEncodeReceiver(t *testing.T) {
+	receiver := ethcommon.HexToAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	address, err := encodeReceiver(common.EthChain(), receiver.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", address)
+	_, err = encodeReceiver(common.ZetaChain(), receiver.Bytes())
+	require.NoError(t, err)
+
+	// truncated and empty receivers are invalid
+	_, err = encodeReceiver(common.EthChain(), receiver.Bytes()[:19])
+	require.Error(t, err)
+	_, err = encodeReceiver(common.EthChain(), nil)
+	require.Error(t, err)
+	_, err = encodeReceiver(common.ZetaChain(), make([]byte, 20))
+	require.Error(t, err)
+
+	// bitcoin receivers are address strings of the network
+	address, err = encodeReceiver(common.BtcMainnetChain(), []byte("bc1qk0cc73p8m7hswn8y2q080xa4e5pxapnqgp7h9c"))
+	require.NoError(t, err)
+	require.Equal(t, "bc1qk0cc73p8m7hswn8y2q080xa4e5pxapnqgp7h9c", address)
+	_, err = encodeReceiver(common.BtcMainnetChain(), receiver.Bytes())
+	require.Error(t, err)
+	_, err = encodeReceiver(common.BtcMainnetChain(), []byte("bc1qk0cc73p8m7hswn8y2q080xa4e5pxapnqgp7h9\xff"))
+	require.Error(t, err)
+	_, err = encodeReceiver(common.BtcMainnetChain(), []byte("1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN\x80"))
+	require.Error(t, err)
+}
+
+func TestBoundLastBlock(t *testing.T) {
+	require.Equal(t, int64(100), boundLastBlock(100, 0))
+	require.Equal(t, int64(149), boundLastBlock(100, 150))
+	require.Equal(t, int64(200), boundLastBlock(200, 150))
+	require.Equal(t, int64(149), boundLastBlock(0, 150))
+}
+
+// trackerBridge serves the outbound trackers of a chain
+type trackerBridge struct {
+	ZetaCoreBridger
+	trackers []crosschaintypes.OutTxTracker
+}
+
+func (b *trackerBridge) GetAllOutTxTrackerByChain(common.Chain, Order) ([]crosschaintypes.OutTxTracker, error) {
+	return b.trackers, nil
+}
+
+// hangingRPCClient never answers the receipt queries until they are aborted
+type hangingRPCClient struct {
+	stubEVMRPCClient
+	queried chan struct{}
+	once    sync.Once
+}
+
+func (c *hangingRPCClient) TransactionReceipt(ctx context.Context, _ ethcommon.Hash) (*ethtypes.Receipt, error) {
+	c.once.Do(func() { close(c.queried) })
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestEVMChainClient_ObserveOutTxStop(t *testing.T) {
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	tracker := crosschaintypes.OutTxTracker{ChainId: common.EthChain().ChainId, Nonce: 1}
+	for i := 0; i < 100; i++ {
+		tracker.HashList = append(tracker.HashList, &crosschaintypes.TxHashList{TxHash: ethcommon.BigToHash(big.NewInt(int64(i))).Hex()})
+	}
+	client := &hangingRPCClient{queried: make(chan struct{})}
+	ob := &EVMChainClient{
+		Mu:                        &sync.Mutex{},
+		chain:                     common.EthChain(),
+		params:                    observertypes.CoreParams{OutTxTicker: 1},
+		zetaClient:                &trackerBridge{trackers: []crosschaintypes.OutTxTracker{tracker}},
+		evmClient:                 client,
+		Tss:                       TestSigner{PrivKey: privKey},
+		stop:                      make(chan struct{}),
+		outTXConfirmedReceipts:    map[string]*ethtypes.Receipt{},
+		outTXConfirmedTransaction: map[string]*ethtypes.Transaction{},
+		logger:                    EVMLog{ObserveOutTx: zerolog.Nop()},
+	}
+	ob.ctx, ob.cancel = context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		ob.observeOutTx()
+		close(done)
+	}()
+
+	// the hashes left to query are dropped and the query in flight is aborted on Stop()
+	<-client.queried
+	close(ob.stop)
+	ob.cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("observeOutTx not stopped")
+	}
+}
+
+func TestEVMChainClient_KeepLastScannedBlock(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	db, err := gorm.Open(sqlite.Open(dbPath), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&clienttypes.LastBlockSQLType{}))
+	require.NoError(t, db.Save(clienttypes.ToLastBlockSQLType(100)).Error)
+
+	// the node scanned further than the block the rescan loaded
+	stop := make(chan struct{})
+	ob := &EVMChainClient{chain: common.EthChain(), db: db, stop: stop, cancel: func() {}, lastBlockScanned: 50,
+		watchers: NewWatcherGroup("test", stop, zerolog.Nop()), logger: EVMLog{ChainLogger: zerolog.Nop()}}
+	ob.KeepLastScannedBlock()
+	ob.Stop()
+
+	db, err = gorm.Open(sqlite.Open(dbPath), &gorm.Config{})
+	require.NoError(t, err)
+	var lastBlock clienttypes.LastBlockSQLType
+	require.NoError(t, db.First(&lastBlock, clienttypes.LastBlockNumID).Error)
+	require.EqualValues(t, 100, lastBlock.Num)
+}
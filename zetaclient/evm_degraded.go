@@ -0,0 +1,47 @@
+package zetaclient
+
+import (
+	"math"
+	"sync"
+
+	observertypes "github.com/zeta-chain/zetacore/x/observer/types"
+	metricsPkg "github.com/zeta-chain/zetacore/zetaclient/metrics"
+)
+
+// crosschainFlagsCache holds the last crosschain flags read from zetacore
+type crosschainFlagsCache struct {
+	mu    sync.Mutex
+	flags *observertypes.CrosschainFlags
+}
+
+// getCrosschainFlags returns the crosschain flags of zetacore. While zetacore can't be queried the last flags read are
+// returned, so that the chain keeps being observed in degraded mode; zetacore checks the flags again on the votes
+func (ob *EVMChainClient) getCrosschainFlags() (observertypes.CrosschainFlags, error) {
+	flags, err := ob.zetaClient.GetCrosschainFlags()
+	ob.crosschainFlags.mu.Lock()
+	defer ob.crosschainFlags.mu.Unlock()
+	if err == nil {
+		ob.crosschainFlags.flags = &flags
+		return flags, nil
+	}
+	if ob.crosschainFlags.flags == nil {
+		return observertypes.CrosschainFlags{}, err
+	}
+	ob.logger.ExternalChainWatcher.Warn().Err(err).Msg("getCrosschainFlags: zetacore unreachable, observing with the last crosschain flags")
+	return *ob.crosschainFlags.flags, nil
+}
+
+// isDegraded returns true while the posts to zetacore are paused, the votes being queued durably until they resume
+func (ob *EVMChainClient) isDegraded() bool {
+	return !ob.zetaClient.CoreAvailable()
+}
+
+// reportQueuedVotes reports the number of votes queued for a retry
+func (ob *EVMChainClient) reportQueuedVotes() {
+	count, err := ob.countPendingVotes(math.MaxInt64)
+	if err != nil {
+		ob.logger.ExternalChainWatcher.Error().Err(err).Msg("reportQueuedVotes: error counting pending votes")
+		return
+	}
+	metricsPkg.QueuedVotes.WithLabelValues(ob.chain.Name()).Set(float64(count))
+}
@@ -0,0 +1,53 @@
+package zetaclient
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/zeta-chain/zetacore/common"
+	observertypes "github.com/zeta-chain/zetacore/x/observer/types"
+)
+
+// flagsBridge serves the crosschain flags until zetacore goes down
+type flagsBridge struct {
+	ZetaCoreBridger
+	flags observertypes.CrosschainFlags
+	down  bool
+}
+
+func (b *flagsBridge) GetCrosschainFlags() (observertypes.CrosschainFlags, error) {
+	if b.down {
+		return observertypes.CrosschainFlags{}, errors.New("connection refused")
+	}
+	return b.flags, nil
+}
+
+func (b *flagsBridge) CoreAvailable() bool {
+	return !b.down
+}
+
+func TestEVMChainClient_GetCrosschainFlags(t *testing.T) {
+	bridge := &flagsBridge{flags: observertypes.CrosschainFlags{IsInboundEnabled: true}, down: true}
+	ob := &EVMChainClient{chain: common.EthChain(), zetaClient: bridge, logger: EVMLog{ExternalChainWatcher: zerolog.Nop()}}
+
+	// no flags were ever read
+	_, err := ob.getCrosschainFlags()
+	require.Error(t, err)
+	require.True(t, ob.isDegraded())
+
+	bridge.down = false
+	flags, err := ob.getCrosschainFlags()
+	require.NoError(t, err)
+	require.True(t, flags.IsInboundEnabled)
+	require.False(t, ob.isDegraded())
+
+	// the last flags read are used while zetacore is down
+	bridge.down = true
+	bridge.flags.IsInboundEnabled = false
+	flags, err = ob.getCrosschainFlags()
+	require.NoError(t, err)
+	require.True(t, flags.IsInboundEnabled)
+	require.True(t, ob.isDegraded())
+}
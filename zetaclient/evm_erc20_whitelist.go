@@ -0,0 +1,132 @@
+package zetaclient
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/zeta-chain/protocol-contracts/pkg/contracts/evm/erc20custody.sol"
+	"github.com/zeta-chain/zetacore/common"
+	fungibletypes "github.com/zeta-chain/zetacore/x/fungible/types"
+)
+
+// ERC20WhitelistTTL is how long the ERC20 whitelist fetched from zetacore is used before it is fetched again
+const ERC20WhitelistTTL = 5 * time.Minute
+
+// ERC20Whitelist caches the ERC20 assets of a chain whitelisted in zetacore, i.e. the assets with a ZRC20 on zEVM
+type ERC20Whitelist struct {
+	mu      sync.Mutex
+	chainID int64
+	assets  map[ethcommon.Address]bool
+	coins   map[ethcommon.Address]fungibletypes.ForeignCoins
+	updated time.Time
+	fetch   func() ([]fungibletypes.ForeignCoins, error)
+}
+
+func NewERC20Whitelist(chainID int64, fetch func() ([]fungibletypes.ForeignCoins, error)) *ERC20Whitelist {
+	return &ERC20Whitelist{
+		chainID: chainID,
+		fetch:   fetch,
+	}
+}
+
+// NewStaticERC20Whitelist returns the whitelist of the assets set in the config of the chain, used in place of the
+// whitelist of zetacore
+func NewStaticERC20Whitelist(chainID int64, assets []string) *ERC20Whitelist {
+	coins := make([]fungibletypes.ForeignCoins, 0, len(assets))
+	for _, asset := range assets {
+		coins = append(coins, fungibletypes.ForeignCoins{Asset: asset, ForeignChainId: chainID, CoinType: common.CoinType_ERC20})
+	}
+	return NewERC20Whitelist(chainID, func() ([]fungibletypes.ForeignCoins, error) {
+		return coins, nil
+	})
+}
+
+// IsWhitelisted returns true if asset is whitelisted on the chain. The whitelist is fetched again if it is stale
+// or if asset is not in it, so that assets whitelisted since the last fetch are accepted at once.
+// A nil whitelist accepts every asset
+func (w *ERC20Whitelist) IsWhitelisted(asset ethcommon.Address) (bool, error) {
+	if w == nil {
+		return true, nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.assets != nil && time.Since(w.updated) < ERC20WhitelistTTL && w.assets[asset] {
+		return true, nil
+	}
+	if err := w.refresh(); err != nil {
+		return false, err
+	}
+	return w.assets[asset], nil
+}
+
+// Symbol returns the symbol of a whitelisted asset as set in zetacore, so that the observed deposits name the token they
+// carry; it is empty if the asset is not in the last whitelist fetched. A nil whitelist knows no symbol
+func (w *ERC20Whitelist) Symbol(asset ethcommon.Address) string {
+	if w == nil {
+		return ""
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.coins[asset].Symbol
+}
+
+// Decimals returns the decimals of the ZRC20 of a whitelisted asset as set in zetacore, 0 if the asset is not in the
+// last whitelist fetched or if the whitelist is set in the config
+func (w *ERC20Whitelist) Decimals(asset ethcommon.Address) uint32 {
+	if w == nil {
+		return 0
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.coins[asset].Decimals
+}
+
+// Assets returns the ERC20 assets whitelisted on the chain, fetched again if the whitelist is stale
+func (w *ERC20Whitelist) Assets() ([]ethcommon.Address, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.assets == nil || time.Since(w.updated) >= ERC20WhitelistTTL {
+		if err := w.refresh(); err != nil {
+			return nil, err
+		}
+	}
+	assets := make([]ethcommon.Address, 0, len(w.assets))
+	for asset := range w.assets {
+		assets = append(assets, asset)
+	}
+	sort.Slice(assets, func(i, j int) bool { return bytes.Compare(assets[i].Bytes(), assets[j].Bytes()) < 0 })
+	return assets, nil
+}
+
+// refresh fetches the whitelist from zetacore; the lock must be held
+func (w *ERC20Whitelist) refresh() error {
+	coins, err := w.fetch()
+	if err != nil {
+		return err
+	}
+	w.assets = make(map[ethcommon.Address]bool)
+	w.coins = make(map[ethcommon.Address]fungibletypes.ForeignCoins)
+	for _, coin := range coins {
+		if coin.ForeignChainId == w.chainID && coin.CoinType == common.CoinType_ERC20 && ethcommon.IsHexAddress(coin.Asset) {
+			w.assets[ethcommon.HexToAddress(coin.Asset)] = true
+			w.coins[ethcommon.HexToAddress(coin.Asset)] = coin
+		}
+	}
+	w.updated = time.Now()
+	return nil
+}
+
+// ValidateDepositedEvent checks the fields of a Deposited event of the ERC20 custody contract
+func ValidateDepositedEvent(event *erc20custody.ERC20CustodyDeposited) error {
+	if event.Asset == (ethcommon.Address{}) {
+		return errors.New("asset is not set")
+	}
+	if event.Amount == nil || event.Amount.Sign() <= 0 {
+		return errors.New("amount is zero")
+	}
+	return nil
+}
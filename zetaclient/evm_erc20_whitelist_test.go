@@ -0,0 +1,88 @@
+package zetaclient
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/zeta-chain/protocol-contracts/pkg/contracts/evm/erc20custody.sol"
+	"github.com/zeta-chain/zetacore/common"
+	fungibletypes "github.com/zeta-chain/zetacore/x/fungible/types"
+)
+
+func TestERC20Whitelist(t *testing.T) {
+	usdt := ethcommon.HexToAddress("0x01")
+	usdc := ethcommon.HexToAddress("0x02")
+	coins := []fungibletypes.ForeignCoins{
+		{Asset: usdt.Hex(), ForeignChainId: 1, CoinType: common.CoinType_ERC20, Symbol: "USDT"},
+		{Asset: usdc.Hex(), ForeignChainId: 56, CoinType: common.CoinType_ERC20},
+		{Asset: "", ForeignChainId: 1, CoinType: common.CoinType_Gas},
+	}
+	var fetches int
+	var fetchErr error
+	whitelist := NewERC20Whitelist(1, func() ([]fungibletypes.ForeignCoins, error) {
+		fetches++
+		return coins, fetchErr
+	})
+
+	whitelisted, err := whitelist.IsWhitelisted(usdt)
+	require.NoError(t, err)
+	require.True(t, whitelisted)
+	_, _ = whitelist.IsWhitelisted(usdt)
+	require.Equal(t, 1, fetches) // cached
+	require.Equal(t, "USDT", whitelist.Symbol(usdt))
+
+	// assets of other chains are not whitelisted
+	whitelisted, err = whitelist.IsWhitelisted(usdc)
+	require.NoError(t, err)
+	require.False(t, whitelisted)
+
+	// unknown assets are checked against the latest whitelist
+	coins = append(coins, fungibletypes.ForeignCoins{Asset: usdc.Hex(), ForeignChainId: 1, CoinType: common.CoinType_ERC20})
+	whitelisted, err = whitelist.IsWhitelisted(usdc)
+	require.NoError(t, err)
+	require.True(t, whitelisted)
+
+	fetchErr = errors.New("zetacore unreachable")
+	_, err = whitelist.IsWhitelisted(ethcommon.HexToAddress("0x03"))
+	require.Error(t, err)
+
+	var nilWhitelist *ERC20Whitelist
+	whitelisted, err = nilWhitelist.IsWhitelisted(usdt)
+	require.NoError(t, err)
+	require.True(t, whitelisted)
+	require.Empty(t, nilWhitelist.Symbol(usdt))
+}
+
+func TestStaticERC20Whitelist(t *testing.T) {
+	usdt := ethcommon.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+	whitelist := NewStaticERC20Whitelist(1, []string{usdt.Hex()})
+
+	whitelisted, err := whitelist.IsWhitelisted(usdt)
+	require.NoError(t, err)
+	require.True(t, whitelisted)
+	whitelisted, err = whitelist.IsWhitelisted(ethcommon.HexToAddress("0x01"))
+	require.NoError(t, err)
+	require.False(t, whitelisted)
+	assets, err := whitelist.Assets()
+	require.NoError(t, err)
+	require.Equal(t, []ethcommon.Address{usdt}, assets)
+}
+
+func TestValidateDepositedEvent(t *testing.T) {
+	event := &erc20custody.ERC20CustodyDeposited{
+		Recipient: []byte("recipient"),
+		Asset:     ethcommon.HexToAddress("0x01"),
+		Amount:    big.NewInt(100),
+	}
+	require.NoError(t, ValidateDepositedEvent(event))
+
+	event.Amount = big.NewInt(0)
+	require.Error(t, ValidateDepositedEvent(event))
+
+	event.Amount = big.NewInt(100)
+	event.Asset = ethcommon.Address{}
+	require.Error(t, ValidateDepositedEvent(event))
+}
@@ -0,0 +1,78 @@
+package zetaclient
+
+import (
+	"errors"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	metricsPkg "github.com/zeta-chain/zetacore/zetaclient/metrics"
+)
+
+// Event names of the inbound and outbound txs without an event log: the gas tokens sent to the TSS address, the gas
+// token transfers of the outbound txs and the failed outbound txs
+const (
+	EventNameGasDeposit     = "GasDeposit"
+	EventNameGasTransfer    = "GasTransfer"
+	EventNameOutboundFailed = "OutboundFailed"
+)
+
+// recordEvent counts the outcome of an inbound or outbound event and the time spent in the stage that produced it,
+// so that operators can see which flow is degrading. The correlation id of the event, if known, is the exemplar
+func (ob *EVMChainClient) recordEvent(event, stage, outcome, correlationID string, start time.Time) {
+	addWithCorrelationID(metricsPkg.EventCount.WithLabelValues(ob.chain.Name(), event, outcome), correlationID)
+	metricsPkg.EventDuration.WithLabelValues(ob.chain.Name(), event, stage).Observe(time.Since(start).Seconds())
+}
+
+// recordEventDecoded records the outcome of decoding an event into a vote or a confirmation; the events deliberately
+// rejected, e.g. donations, are no failures
+func (ob *EVMChainClient) recordEventDecoded(event, correlationID string, start time.Time, err error) {
+	switch {
+	case err == nil:
+		ob.recordEvent(event, metricsPkg.EventStageDecode, metricsPkg.EventDecoded, correlationID, start)
+	case errors.Is(err, ErrInboundRejected):
+		ob.recordEvent(event, metricsPkg.EventStageDecode, metricsPkg.EventRejected, correlationID, start)
+	case errors.Is(err, ErrInboundAboveCap):
+		ob.recordEvent(event, metricsPkg.EventStageDecode, metricsPkg.EventWithheld, correlationID, start)
+	default:
+		ob.recordEvent(event, metricsPkg.EventStageDecode, metricsPkg.EventFailed, correlationID, start)
+	}
+}
+
+// recordEventPosted records the outcome of posting the vote or the confirmation of an event to zetacore; the votes
+// skipped because already posted are not counted
+func (ob *EVMChainClient) recordEventPosted(event, correlationID string, start time.Time, zetaHash string, err error) {
+	if err != nil {
+		ob.recordEvent(event, metricsPkg.EventStagePost, metricsPkg.EventFailed, correlationID, start)
+		return
+	}
+	if zetaHash != "" {
+		ob.recordEvent(event, metricsPkg.EventStagePost, metricsPkg.EventPosted, correlationID, start)
+	}
+}
+
+// recordInboundLatency exports the time between the block of an inbound event and its vote posted to zetacore, and
+// logs the votes slower than the InboundLatencySLO of the chain
+func (ob *EVMChainClient) recordInboundLatency(event, correlationID string, blockNumber uint64, blockHash ethcommon.Hash, posted time.Time) {
+	header, found := ob.headers.GetByHash(blockHash)
+	if !found {
+		var err error
+		// #nosec G701 always in range
+		header, err = ob.headerByNumber(int64(blockNumber))
+		if err != nil {
+			ob.logger.ExternalChainWatcher.Debug().Err(err).Msgf("recordInboundLatency: no header of block %d", blockNumber)
+			return
+		}
+	}
+	// #nosec G701 always in range
+	latency := posted.Sub(time.Unix(int64(header.Time), 0))
+	metricsPkg.InboundLatency.WithLabelValues(ob.chain.Name()).Observe(latency.Seconds())
+
+	evmCfg, _ := ob.cfg.GetEVMConfig(ob.chain.ChainId)
+	if evmCfg.InboundLatencySLO == 0 || latency <= time.Duration(evmCfg.InboundLatencySLO)*time.Second {
+		return
+	}
+	metricsPkg.InboundLatencySLOBreaches.WithLabelValues(ob.chain.Name()).Inc()
+	logger := WithCorrelationID(ob.logger.ExternalChainWatcher, correlationID)
+	logger.Warn().Msgf("recordInboundLatency: %s event of block %d posted after %s, above the SLO of %ds",
+		event, blockNumber, latency.Round(time.Second), evmCfg.InboundLatencySLO)
+}
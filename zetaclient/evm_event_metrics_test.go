@@ -0,0 +1,44 @@
+package zetaclient
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/zeta-chain/zetacore/common"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
+	metricsPkg "github.com/zeta-chain/zetacore/zetaclient/metrics"
+)
+
+func TestEVMChainClient_RecordInboundLatency(t *testing.T) {
+	chain := common.EthChain()
+	cfg := config.NewConfig()
+	cfg.EVMChainConfigs = map[int64]*config.EVMConfig{chain.ChainId: {Chain: chain, InboundLatencySLO: 60}}
+	headers, err := NewHeaderCache(HeaderCacheSize)
+	require.NoError(t, err)
+	ob := &EVMChainClient{
+		Mu:      &sync.Mutex{},
+		chain:   chain,
+		cfg:     cfg,
+		headers: headers,
+		logger:  EVMLog{ExternalChainWatcher: zerolog.Nop()},
+	}
+	now := time.Now()
+	header := &ethtypes.Header{Number: big.NewInt(100), Time: uint64(now.Add(-time.Minute / 2).Unix())}
+	headers.Add(100, header, now)
+	breaches := metricsPkg.InboundLatencySLOBreaches.WithLabelValues(chain.Name())
+	before := testutil.ToFloat64(breaches)
+
+	// a vote posted within the SLO
+	ob.recordInboundLatency("Deposited", "", 100, header.Hash(), now)
+	require.Equal(t, before, testutil.ToFloat64(breaches))
+
+	// and one posted after
+	ob.recordInboundLatency("Deposited", "", 100, header.Hash(), now.Add(time.Minute))
+	require.Equal(t, before+1, testutil.ToFloat64(breaches))
+}
@@ -0,0 +1,553 @@
+package zetaclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rs/zerolog"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
+	"github.com/zeta-chain/zetacore/zetaclient/metrics"
+)
+
+const (
+	// RPCHealthCheckInterval is the interval in seconds between two health checks of the rpc endpoints
+	RPCHealthCheckInterval = 30
+	// RPCMaxBlockLag is the number of blocks an endpoint can be behind the others before it is considered stuck
+	RPCMaxBlockLag = 10
+	// RPCLatencySwitchRatio is how many times faster than the preferred endpoint another healthy endpoint must be
+	// for queries to be routed to it
+	RPCLatencySwitchRatio = 2
+	rpcHealthCheckTimeout = 5 * time.Second
+)
+
+// Defaults of the http client of the rpc endpoints
+const (
+	DefaultRPCDialTimeout    = 10 * time.Second
+	DefaultRPCRequestTimeout = 30 * time.Second
+	DefaultRPCMaxIdleConns   = 16
+)
+
+var _ EVMRPCClient = &FailoverEVMClient{}
+
+// ErrChainIDMismatch is returned when an endpoint serves another chain than the configured one
+var ErrChainIDMismatch = errors.New("chain id mismatch")
+
+// endpointClient is the connection to an endpoint; the rpc client is used for batch calls
+type endpointClient struct {
+	*ethclient.Client
+	rpc *rpc.Client
+}
+
+// dialEndpoint connects to an endpoint with the timeouts, the connection pool and the headers of rpcConfig.
+// Unlike ethclient.Dial, a hung endpoint can't block the caller forever
+func dialEndpoint(endpoint string, rpcConfig config.RPCClientConfig) (*endpointClient, error) {
+	dialTimeout := DefaultRPCDialTimeout
+	if rpcConfig.DialTimeout > 0 {
+		dialTimeout = time.Duration(rpcConfig.DialTimeout) * time.Second
+	}
+	requestTimeout := DefaultRPCRequestTimeout
+	if rpcConfig.RequestTimeout > 0 {
+		requestTimeout = time.Duration(rpcConfig.RequestTimeout) * time.Second
+	}
+	maxIdleConns := DefaultRPCMaxIdleConns
+	if rpcConfig.MaxIdleConns > 0 {
+		maxIdleConns = rpcConfig.MaxIdleConns
+	}
+
+	var client *rpc.Client
+	var err error
+	if strings.HasPrefix(endpoint, "http://") || strings.HasPrefix(endpoint, "https://") {
+		dialer := &net.Dialer{Timeout: dialTimeout, KeepAlive: 30 * time.Second}
+		client, err = rpc.DialHTTPWithClient(endpoint, &http.Client{
+			Timeout: requestTimeout,
+			Transport: &http.Transport{
+				Proxy:               http.ProxyFromEnvironment,
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: dialTimeout,
+				MaxIdleConns:        maxIdleConns,
+				MaxIdleConnsPerHost: maxIdleConns,
+				IdleConnTimeout:     90 * time.Second,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range rpcConfig.Headers {
+			client.SetHeader(key, value)
+		}
+	} else { // websocket or ipc
+		ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+		defer cancel()
+		client, err = rpc.DialContext(ctx, endpoint)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &endpointClient{Client: ethclient.NewClient(client), rpc: client}, nil
+}
+
+// FailoverEVMClient is an EVMRPCClient backed by multiple endpoints of the same chain.
+// Calls go to the active endpoint and are retried on the next endpoint if the active one is unreachable.
+type FailoverEVMClient struct {
+	mu        sync.RWMutex
+	chain     string // chain name used to label metrics
+	endpoints []string
+	rpcConfig func(endpoint string) config.RPCClientConfig
+	clients   []*endpointClient // nil if the endpoint could not be dialed
+	active    int
+	chainID   int64 // chain id the endpoints are checked against when dialed; not checked if zero
+	logger    zerolog.Logger
+}
+
+// NewFailoverEVMClient dials all endpoints; the first one is the primary endpoint
+// rpcConfig returns the config of the http client of an endpoint; the defaults are used if nil
+// It fails only if none of the endpoints can be dialed
+func NewFailoverEVMClient(chain string, endpoints []string, rpcConfig func(endpoint string) config.RPCClientConfig, logger zerolog.Logger) (*FailoverEVMClient, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("NewFailoverEVMClient: no endpoint provided")
+	}
+	c := &FailoverEVMClient{
+		chain:     chain,
+		endpoints: endpoints,
+		rpcConfig: rpcConfig,
+		clients:   make([]*endpointClient, len(endpoints)),
+		active:    -1,
+		logger:    logger,
+	}
+	for i, endpoint := range endpoints {
+		client, err := dialEndpoint(endpoint, c.getRPCConfig(endpoint))
+		if err != nil {
+			logger.Error().Err(err).Msgf("NewFailoverEVMClient: error dialing endpoint %d", i)
+			continue
+		}
+		c.clients[i] = client
+		if c.active < 0 {
+			c.active = i
+		}
+	}
+	if c.active < 0 {
+		return nil, fmt.Errorf("NewFailoverEVMClient: failed to dial all %d endpoints", len(endpoints))
+	}
+	return c, nil
+}
+
+// VerifyChainID checks that the endpoints serve the chain with the given id, e.g. to catch the endpoint of another
+// chain pasted in the config. Endpoints that can't be reached now are checked when they are redialed
+func (c *FailoverEVMClient) VerifyChainID(ctx context.Context, chainID int64) error {
+	c.mu.Lock()
+	c.chainID = chainID
+	c.mu.Unlock()
+	for i := range c.endpoints {
+		c.mu.RLock()
+		client := c.clients[i]
+		c.mu.RUnlock()
+		if client == nil {
+			continue
+		}
+		err := checkChainID(ctx, client.Client, chainID)
+		if errors.Is(err, ErrChainIDMismatch) {
+			return fmt.Errorf("VerifyChainID: endpoint %d: %w", i, err)
+		}
+		if err != nil {
+			c.logger.Warn().Err(err).Msgf("VerifyChainID: endpoint %d is unreachable; checking it when redialed", i)
+			c.reset(i)
+		}
+	}
+	return nil
+}
+
+func (c *FailoverEVMClient) getRPCConfig(endpoint string) config.RPCClientConfig {
+	if c.rpcConfig == nil {
+		return config.RPCClientConfig{}
+	}
+	return c.rpcConfig(endpoint)
+}
+
+// ActiveEndpoint returns the index of the endpoint currently in use
+func (c *FailoverEVMClient) ActiveEndpoint() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.active
+}
+
+// CheckHealth probes the head height and the latency of all endpoints, reconnects the ones that are down and switches
+// to the best healthy endpoint. Endpoints more than RPCMaxBlockLag blocks behind the others are stuck and considered
+// unhealthy
+func (c *FailoverEVMClient) CheckHealth(ctx context.Context) {
+	heights := make([]int64, len(c.endpoints))
+	latencies := make([]time.Duration, len(c.endpoints))
+	for i := range c.endpoints {
+		heights[i] = -1
+		client, err := c.getOrDial(i)
+		if err != nil {
+			c.logger.Warn().Err(err).Msgf("CheckHealth: endpoint %d is down", i)
+			continue
+		}
+		probeCtx, cancel := context.WithTimeout(ctx, rpcHealthCheckTimeout)
+		begin := time.Now()
+		height, err := client.BlockNumber(probeCtx)
+		latencies[i] = time.Since(begin)
+		cancel()
+		if err != nil {
+			c.logger.Warn().Err(err).Msgf("CheckHealth: endpoint %d is unhealthy", i)
+			c.reset(i)
+			continue
+		}
+		metrics.RPCEndpointLatency.WithLabelValues(c.chain, strconv.Itoa(i)).Set(latencies[i].Seconds())
+		// #nosec G701 always in range
+		heights[i] = int64(height)
+	}
+	best := selectBestEndpoint(heights, latencies, RPCMaxBlockLag)
+	if best < 0 {
+		c.logger.Error().Msg("CheckHealth: all endpoints are unhealthy")
+		return
+	}
+	c.mu.Lock()
+	if c.active != best {
+		c.logger.Info().Msgf("CheckHealth: switching from endpoint %d to endpoint %d at block %d, latency %s", c.active, best, heights[best], latencies[best])
+		c.active = best
+	}
+	c.mu.Unlock()
+}
+
+// selectBestEndpoint returns the highest priority healthy endpoint, unless another healthy endpoint is more than
+// RPCLatencySwitchRatio times faster; requiring a large margin keeps queries from bouncing between endpoints of
+// similar latency. It returns -1 if none is healthy
+func selectBestEndpoint(heights []int64, latencies []time.Duration, maxLag int64) int {
+	preferred := selectHealthyEndpoint(heights, maxLag)
+	if preferred < 0 {
+		return -1
+	}
+	var tip int64
+	for _, height := range heights {
+		if height > tip {
+			tip = height
+		}
+	}
+	fastest := preferred
+	for i, height := range heights {
+		if height >= 0 && tip-height <= maxLag && latencies[i] < latencies[fastest] {
+			fastest = i
+		}
+	}
+	if latencies[fastest]*RPCLatencySwitchRatio < latencies[preferred] {
+		return fastest
+	}
+	return preferred
+}
+
+// selectHealthyEndpoint returns the first endpoint at most maxLag blocks behind the most advanced one;
+// unreachable endpoints have a negative height. It returns -1 if none is reachable
+func selectHealthyEndpoint(heights []int64, maxLag int64) int {
+	var best int64 = -1
+	for _, height := range heights {
+		if height > best {
+			best = height
+		}
+	}
+	if best < 0 {
+		return -1
+	}
+	for i, height := range heights {
+		if height >= 0 && best-height <= maxLag {
+			return i
+		}
+	}
+	return -1
+}
+
+// getOrDial returns the client of the i-th endpoint, reconnecting it if needed
+func (c *FailoverEVMClient) getOrDial(i int) (*endpointClient, error) {
+	c.mu.RLock()
+	client := c.clients[i]
+	c.mu.RUnlock()
+	if client != nil {
+		return client, nil
+	}
+	client, err := dialEndpoint(c.endpoints[i], c.getRPCConfig(c.endpoints[i]))
+	if err != nil {
+		return nil, err
+	}
+	c.mu.RLock()
+	chainID := c.chainID
+	c.mu.RUnlock()
+	if chainID != 0 {
+		if err := checkChainID(context.Background(), client.Client, chainID); err != nil {
+			client.Close()
+			c.logger.Error().Err(err).Msgf("endpoint %d", i)
+			return nil, err
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.clients[i] != nil { // reconnected concurrently
+		client.Close()
+		return c.clients[i], nil
+	}
+	c.clients[i] = client
+	return client, nil
+}
+
+// checkChainID returns ErrChainIDMismatch if the endpoint of client doesn't serve the chain with the given id
+func checkChainID(ctx context.Context, client *ethclient.Client, chainID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, rpcHealthCheckTimeout)
+	defer cancel()
+	id, err := client.ChainID(ctx)
+	if err != nil {
+		return err
+	}
+	if !id.IsInt64() || id.Int64() != chainID {
+		return fmt.Errorf("%w: endpoint serves chain %s, expected %d", ErrChainIDMismatch, id, chainID)
+	}
+	return nil
+}
+
+// reset drops the connection to the i-th endpoint so that it is redialed next time
+func (c *FailoverEVMClient) reset(i int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.clients[i] != nil {
+		c.clients[i].Close()
+		c.clients[i] = nil
+	}
+}
+
+// call runs f against the active endpoint and fails over to the next endpoints on connectivity errors
+// The latency and the errors of the call are recorded by method
+func (c *FailoverEVMClient) call(ctx context.Context, method string, f func(client *endpointClient) error) (err error) {
+	defer func(begin time.Time) {
+		metrics.RPCLatency.WithLabelValues(c.chain, method).Observe(time.Since(begin).Seconds())
+		if err != nil && !errors.Is(err, ethereum.NotFound) {
+			metrics.RPCErrorCount.WithLabelValues(c.chain, method).Inc()
+		}
+	}(time.Now())
+	start := c.ActiveEndpoint()
+	for n := 0; n < len(c.endpoints); n++ {
+		i := (start + n) % len(c.endpoints)
+		client, dialErr := c.getOrDial(i)
+		if dialErr != nil {
+			err = dialErr
+			continue
+		}
+		err = f(client)
+		if !shouldFailover(ctx, err) {
+			if n > 0 {
+				c.mu.Lock()
+				c.logger.Warn().Msgf("failover from endpoint %d to endpoint %d", c.active, i)
+				c.active = i
+				c.mu.Unlock()
+			}
+			return err
+		}
+		c.logger.Warn().Err(err).Msgf("endpoint %d failed", i)
+		c.reset(i)
+	}
+	return err
+}
+
+// shouldFailover returns true if err indicates the endpoint is unreachable rather than a valid response
+func shouldFailover(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	if errors.Is(err, ethereum.NotFound) {
+		return false
+	}
+	var rpcErr rpc.Error // the endpoint did answer with a json-rpc error
+	return !errors.As(err, &rpcErr)
+}
+
+func (c *FailoverEVMClient) CodeAt(ctx context.Context, contract ethcommon.Address, blockNumber *big.Int) (code []byte, err error) {
+	err = c.call(ctx, "CodeAt", func(client *endpointClient) error {
+		code, err = client.CodeAt(ctx, contract, blockNumber)
+		return err
+	})
+	return
+}
+
+func (c *FailoverEVMClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) (result []byte, err error) {
+	err = c.call(ctx, "CallContract", func(client *endpointClient) error {
+		result, err = client.CallContract(ctx, call, blockNumber)
+		return err
+	})
+	return
+}
+
+func (c *FailoverEVMClient) PendingCodeAt(ctx context.Context, account ethcommon.Address) (code []byte, err error) {
+	err = c.call(ctx, "PendingCodeAt", func(client *endpointClient) error {
+		code, err = client.PendingCodeAt(ctx, account)
+		return err
+	})
+	return
+}
+
+func (c *FailoverEVMClient) PendingNonceAt(ctx context.Context, account ethcommon.Address) (nonce uint64, err error) {
+	err = c.call(ctx, "PendingNonceAt", func(client *endpointClient) error {
+		nonce, err = client.PendingNonceAt(ctx, account)
+		return err
+	})
+	return
+}
+
+func (c *FailoverEVMClient) NonceAt(ctx context.Context, account ethcommon.Address, blockNumber *big.Int) (nonce uint64, err error) {
+	err = c.call(ctx, "NonceAt", func(client *endpointClient) error {
+		nonce, err = client.NonceAt(ctx, account, blockNumber)
+		return err
+	})
+	return
+}
+
+func (c *FailoverEVMClient) BalanceAt(ctx context.Context, account ethcommon.Address, blockNumber *big.Int) (balance *big.Int, err error) {
+	err = c.call(ctx, "BalanceAt", func(client *endpointClient) error {
+		balance, err = client.BalanceAt(ctx, account, blockNumber)
+		return err
+	})
+	return
+}
+
+func (c *FailoverEVMClient) SuggestGasPrice(ctx context.Context) (price *big.Int, err error) {
+	err = c.call(ctx, "SuggestGasPrice", func(client *endpointClient) error {
+		price, err = client.SuggestGasPrice(ctx)
+		return err
+	})
+	return
+}
+
+func (c *FailoverEVMClient) SuggestGasTipCap(ctx context.Context) (tip *big.Int, err error) {
+	err = c.call(ctx, "SuggestGasTipCap", func(client *endpointClient) error {
+		tip, err = client.SuggestGasTipCap(ctx)
+		return err
+	})
+	return
+}
+
+func (c *FailoverEVMClient) EstimateGas(ctx context.Context, call ethereum.CallMsg) (gas uint64, err error) {
+	err = c.call(ctx, "EstimateGas", func(client *endpointClient) error {
+		gas, err = client.EstimateGas(ctx, call)
+		return err
+	})
+	return
+}
+
+func (c *FailoverEVMClient) SendTransaction(ctx context.Context, tx *ethtypes.Transaction) error {
+	return c.call(ctx, "SendTransaction", func(client *endpointClient) error {
+		return client.SendTransaction(ctx, tx)
+	})
+}
+
+func (c *FailoverEVMClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) (logs []ethtypes.Log, err error) {
+	err = c.call(ctx, "FilterLogs", func(client *endpointClient) error {
+		logs, err = client.FilterLogs(ctx, query)
+		return err
+	})
+	return
+}
+
+func (c *FailoverEVMClient) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- ethtypes.Log) (sub ethereum.Subscription, err error) {
+	err = c.call(ctx, "SubscribeFilterLogs", func(client *endpointClient) error {
+		sub, err = client.SubscribeFilterLogs(ctx, query, ch)
+		return err
+	})
+	return
+}
+
+func (c *FailoverEVMClient) BlockNumber(ctx context.Context) (number uint64, err error) {
+	err = c.call(ctx, "BlockNumber", func(client *endpointClient) error {
+		number, err = client.BlockNumber(ctx)
+		return err
+	})
+	return
+}
+
+func (c *FailoverEVMClient) BlockByNumber(ctx context.Context, number *big.Int) (block *ethtypes.Block, err error) {
+	err = c.call(ctx, "BlockByNumber", func(client *endpointClient) error {
+		block, err = client.BlockByNumber(ctx, number)
+		return err
+	})
+	return
+}
+
+func (c *FailoverEVMClient) HeaderByNumber(ctx context.Context, number *big.Int) (header *ethtypes.Header, err error) {
+	err = c.call(ctx, "HeaderByNumber", func(client *endpointClient) error {
+		header, err = client.HeaderByNumber(ctx, number)
+		return err
+	})
+	return
+}
+
+func (c *FailoverEVMClient) TransactionByHash(ctx context.Context, hash ethcommon.Hash) (tx *ethtypes.Transaction, isPending bool, err error) {
+	err = c.call(ctx, "TransactionByHash", func(client *endpointClient) error {
+		tx, isPending, err = client.TransactionByHash(ctx, hash)
+		return err
+	})
+	return
+}
+
+func (c *FailoverEVMClient) TransactionReceipt(ctx context.Context, txHash ethcommon.Hash) (receipt *ethtypes.Receipt, err error) {
+	err = c.call(ctx, "TransactionReceipt", func(client *endpointClient) error {
+		receipt, err = client.TransactionReceipt(ctx, txHash)
+		return err
+	})
+	return
+}
+
+func (c *FailoverEVMClient) TransactionSender(ctx context.Context, tx *ethtypes.Transaction, block ethcommon.Hash, index uint) (sender ethcommon.Address, err error) {
+	err = c.call(ctx, "TransactionSender", func(client *endpointClient) error {
+		sender, err = client.TransactionSender(ctx, tx, block, index)
+		return err
+	})
+	return
+}
+
+// BatchCallContext sends all the requests of b to the active endpoint in a single round trip
+func (c *FailoverEVMClient) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	return c.call(ctx, "BatchCall", func(client *endpointClient) error {
+		return client.rpc.BatchCallContext(ctx, b)
+	})
+}
+
+// CallContext sends a json-rpc request to the active endpoint, for the methods the client has no wrapper for
+func (c *FailoverEVMClient) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	return c.call(ctx, method, func(client *endpointClient) error {
+		return client.rpc.CallContext(ctx, result, method, args...)
+	})
+}
+
+// BatchCallEach sends a batch of requests to every endpoint concurrently, e.g. to compare their answers.
+// newBatch returns the requests sent to an endpoint; the batches and the errors are returned by endpoint
+func (c *FailoverEVMClient) BatchCallEach(ctx context.Context, newBatch func() []rpc.BatchElem) ([][]rpc.BatchElem, []error) {
+	batches := make([][]rpc.BatchElem, len(c.endpoints))
+	errs := make([]error, len(c.endpoints))
+	var wg sync.WaitGroup
+	for i := range c.endpoints {
+		batches[i] = newBatch()
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			client, err := c.getOrDial(i)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			errs[i] = client.rpc.BatchCallContext(ctx, batches[i])
+			if shouldFailover(ctx, errs[i]) {
+				c.reset(i)
+			}
+		}(i)
+	}
+	wg.Wait()
+	return batches, errs
+}
@@ -0,0 +1,102 @@
+package zetaclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
+)
+
+type testRPCError struct{}
+
+func (testRPCError) Error() string  { return "execution reverted" }
+func (testRPCError) ErrorCode() int { return 3 }
+
+func TestShouldFailover(t *testing.T) {
+	ctx := context.Background()
+	require.False(t, shouldFailover(ctx, nil))
+	require.False(t, shouldFailover(ctx, ethereum.NotFound))
+	require.False(t, shouldFailover(ctx, testRPCError{}))
+	require.True(t, shouldFailover(ctx, errors.New("dial tcp 127.0.0.1:8545: connect: connection refused")))
+
+	// don't fail over if the caller gave up
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	require.False(t, shouldFailover(cancelled, context.Canceled))
+}
+
+func TestSelectHealthyEndpoint(t *testing.T) {
+	// the primary endpoint is preferred while it follows the chain
+	require.Equal(t, 0, selectHealthyEndpoint([]int64{100, 105}, 10))
+	// a stuck primary endpoint is left for the backup
+	require.Equal(t, 1, selectHealthyEndpoint([]int64{100, 200, 195}, 10))
+	// unreachable endpoints are skipped
+	require.Equal(t, 2, selectHealthyEndpoint([]int64{-1, -1, 50}, 10))
+	require.Equal(t, -1, selectHealthyEndpoint([]int64{-1, -1}, 10))
+}
+
+func TestSelectBestEndpoint(t *testing.T) {
+	ms := time.Millisecond
+	// the primary endpoint is preferred unless another one is much faster
+	require.Equal(t, 0, selectBestEndpoint([]int64{100, 100}, []time.Duration{100 * ms, 60 * ms}, 10))
+	require.Equal(t, 1, selectBestEndpoint([]int64{100, 100}, []time.Duration{100 * ms, 40 * ms}, 10))
+	// a fast endpoint behind the others is a laggard
+	require.Equal(t, 0, selectBestEndpoint([]int64{100, 80}, []time.Duration{100 * ms, 10 * ms}, 10))
+	require.Equal(t, 2, selectBestEndpoint([]int64{100, 80, 98}, []time.Duration{100 * ms, 10 * ms, 20 * ms}, 10))
+	require.Equal(t, -1, selectBestEndpoint([]int64{-1, -1}, []time.Duration{ms, ms}, 10))
+}
+
+// newChainIDServer returns a json-rpc endpoint answering eth_chainId with the given chain id
+func newChainIDServer(chainID int64) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":"0x%x"}`, chainID)
+	}))
+}
+
+func TestFailoverEVMClient_VerifyChainID(t *testing.T) {
+	eth := newChainIDServer(1)
+	defer eth.Close()
+	bsc := newChainIDServer(56)
+	defer bsc.Close()
+
+	client, err := NewFailoverEVMClient("eth", []string{eth.URL}, nil, zerolog.Nop())
+	require.NoError(t, err)
+	require.NoError(t, client.VerifyChainID(context.Background(), 1))
+
+	// a bsc endpoint pasted as backup endpoint of eth
+	client, err = NewFailoverEVMClient("eth", []string{eth.URL, bsc.URL}, nil, zerolog.Nop())
+	require.NoError(t, err)
+	err = client.VerifyChainID(context.Background(), 1)
+	require.ErrorIs(t, err, ErrChainIDMismatch)
+
+	// redialed endpoints are checked too
+	client.reset(1)
+	_, err = client.getOrDial(1)
+	require.ErrorIs(t, err, ErrChainIDMismatch)
+}
+
+func TestDialEndpoint(t *testing.T) {
+	var apiKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiKey = r.Header.Get("X-Api-Key")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":"0x1"}`)
+	}))
+	defer server.Close()
+
+	client, err := dialEndpoint(server.URL, config.RPCClientConfig{RequestTimeout: 1, Headers: map[string]string{"X-Api-Key": "key"}})
+	require.NoError(t, err)
+	defer client.Close()
+	_, err = client.ChainID(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "key", apiKey)
+}
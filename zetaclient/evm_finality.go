@@ -0,0 +1,166 @@
+package zetaclient
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
+)
+
+// rootChainABI is the part of the Polygon RootChain contract reading the last checkpointed block
+const rootChainABI = `[{"inputs":[],"name":"getLastChildBlock","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"}]`
+
+// Finality is the finality model of a chain, telling up to which block inbound events can be posted
+type Finality interface {
+	// FinalizedBlock returns the highest final block of the chain given its latest block
+	FinalizedBlock(latest uint64) (uint64, error)
+}
+
+var (
+	_ Finality = ConfirmationFinality{}
+	_ Finality = (*BlockTagFinality)(nil)
+	_ Finality = (*CheckpointFinality)(nil)
+)
+
+// ConfirmationFinality considers a block final once it has a number of confirmations
+type ConfirmationFinality struct {
+	Confirmations uint64
+}
+
+func (f ConfirmationFinality) FinalizedBlock(latest uint64) (uint64, error) {
+	if latest < f.Confirmations {
+		return 0, fmt.Errorf("block %d has less than %d confirmations", latest, f.Confirmations)
+	}
+	return latest - f.Confirmations, nil
+}
+
+// BlockTagFinality reads the final block from the "safe" or "finalized" block tag of the chain: the blocks finalized
+// by the beacon chain on Ethereum, by the fast finality votes of the validators on BSC, or the L2 blocks whose batch
+// is finalized on L1
+type BlockTagFinality struct {
+	ctx    context.Context
+	client EVMRPCClient
+	tag    string
+}
+
+func NewBlockTagFinality(ctx context.Context, client EVMRPCClient, tag string) *BlockTagFinality {
+	return &BlockTagFinality{
+		ctx:    ctx,
+		client: client,
+		tag:    tag,
+	}
+}
+
+func (f *BlockTagFinality) FinalizedBlock(_ uint64) (uint64, error) {
+	var tag rpc.BlockNumber
+	switch f.tag {
+	case config.FinalityTagSafe:
+		tag = rpc.SafeBlockNumber
+	case config.FinalityTagFinalized:
+		tag = rpc.FinalizedBlockNumber
+	default:
+		return 0, fmt.Errorf("unknown finality tag %q", f.tag)
+	}
+	var number uint64
+	var unsupported error
+	err := Retry(f.ctx, "HeaderByNumber", RPCBackoff, func() error {
+		header, err := f.client.HeaderByNumber(f.ctx, big.NewInt(tag.Int64()))
+		if IsBlockTagUnsupportedError(err) { // not worth retrying
+			unsupported = err
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		number = header.Number.Uint64()
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return number, unsupported
+}
+
+// CheckpointFinality considers a block final once it is checkpointed on another chain, e.g. the Polygon blocks
+// checkpointed by the validators in the RootChain contract on Ethereum
+type CheckpointFinality struct {
+	ctx       context.Context
+	rootChain *bind.BoundContract
+}
+
+func NewCheckpointFinality(ctx context.Context, client EVMRPCClient, contract ethcommon.Address) (*CheckpointFinality, error) {
+	parsed, err := abi.JSON(strings.NewReader(rootChainABI))
+	if err != nil {
+		return nil, err
+	}
+	return &CheckpointFinality{
+		ctx:       ctx,
+		rootChain: bind.NewBoundContract(contract, parsed, client, nil, nil),
+	}, nil
+}
+
+func (f *CheckpointFinality) FinalizedBlock(_ uint64) (uint64, error) {
+	var lastChildBlock *big.Int
+	err := Retry(f.ctx, "getLastChildBlock", RPCBackoff, func() error {
+		var out []interface{}
+		if err := f.rootChain.Call(&bind.CallOpts{Context: f.ctx}, &out, "getLastChildBlock"); err != nil {
+			return err
+		}
+		lastChildBlock = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if !lastChildBlock.IsUint64() {
+		return 0, fmt.Errorf("invalid last checkpointed block %s", lastChildBlock)
+	}
+	return lastChildBlock.Uint64(), nil
+}
+
+// checkpointClient is the rpc client of the chain where the blocks of the chain are checkpointed, dialed on first use
+type checkpointClient struct {
+	mu     sync.Mutex
+	client EVMRPCClient
+}
+
+// getFinality returns the finality model of the chain beyond the confirmation count, nil if there is none
+func (ob *EVMChainClient) getFinality() (Finality, error) {
+	evmCfg, _ := ob.cfg.GetEVMConfig(ob.chain.ChainId)
+	switch {
+	case evmCfg.CheckpointContract != "":
+		client, err := ob.getCheckpointClient(evmCfg.CheckpointChainID)
+		if err != nil {
+			return nil, err
+		}
+		return NewCheckpointFinality(ob.ctx, client, ethcommon.HexToAddress(evmCfg.CheckpointContract))
+	case evmCfg.FinalityTag != "":
+		return NewBlockTagFinality(ob.ctx, ob.evmClient, evmCfg.FinalityTag), nil
+	}
+	return nil, nil
+}
+
+func (ob *EVMChainClient) getCheckpointClient(chainID int64) (EVMRPCClient, error) {
+	ob.checkpoint.mu.Lock()
+	defer ob.checkpoint.mu.Unlock()
+	if ob.checkpoint.client != nil {
+		return ob.checkpoint.client, nil
+	}
+	evmCfg, found := ob.cfg.GetEVMConfig(chainID)
+	if !found || evmCfg.Endpoint == "" {
+		return nil, fmt.Errorf("no endpoint for checkpoint chain %d", chainID)
+	}
+	client, err := NewFailoverEVMClient(evmCfg.Chain.Name(), evmCfg.GetEndpoints(), evmCfg.GetRPCClientConfig, ob.logger.ChainLogger)
+	if err != nil {
+		return nil, err
+	}
+	ob.checkpoint.client = client
+	return client, nil
+}
@@ -0,0 +1,41 @@
+package zetaclient
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// rootChainRPCClient serves the last checkpointed block of a RootChain contract
+type rootChainRPCClient struct {
+	stubEVMRPCClient
+	lastChildBlock int64
+}
+
+func (c *rootChainRPCClient) CallContract(_ context.Context, _ ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	return ethcommon.LeftPadBytes(big.NewInt(c.lastChildBlock).Bytes(), 32), nil
+}
+
+func TestConfirmationFinality(t *testing.T) {
+	finality := ConfirmationFinality{Confirmations: 10}
+	finalized, err := finality.FinalizedBlock(1000)
+	require.NoError(t, err)
+	require.Equal(t, uint64(990), finalized)
+
+	_, err = finality.FinalizedBlock(9)
+	require.Error(t, err)
+}
+
+func TestCheckpointFinality(t *testing.T) {
+	client := &rootChainRPCClient{lastChildBlock: 42_000_000}
+	finality, err := NewCheckpointFinality(context.Background(), client, ethcommon.HexToAddress("0x2890bA17EfE978480615e330ecB65333b880928e"))
+	require.NoError(t, err)
+
+	finalized, err := finality.FinalizedBlock(42_000_500)
+	require.NoError(t, err)
+	require.Equal(t, uint64(42_000_000), finalized)
+}
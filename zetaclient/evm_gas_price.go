@@ -0,0 +1,57 @@
+package zetaclient
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// GasPriceSample is the gas price of an evm chain sampled at a block
+type GasPriceSample struct {
+	BlockNumber uint64
+	GasPrice    *big.Int // suggested legacy gas price
+	BaseFee     *big.Int // nil on chains without EIP-1559
+	PriorityFee *big.Int // suggested tip; nil on chains without EIP-1559
+}
+
+// EffectiveGasPrice returns the price paid per gas by a tx included in the next block: the base fee plus the
+// suggested tip on EIP-1559 chains, but never less than the suggested legacy gas price
+func (s GasPriceSample) EffectiveGasPrice() *big.Int {
+	price := new(big.Int).Set(s.GasPrice)
+	if s.BaseFee == nil {
+		return price
+	}
+	dynamic := new(big.Int).Set(s.BaseFee)
+	if s.PriorityFee != nil {
+		dynamic.Add(dynamic, s.PriorityFee)
+	}
+	if dynamic.Cmp(price) > 0 {
+		return dynamic
+	}
+	return price
+}
+
+// sampleGasPrice samples the suggested gas price along with the base fee and tip of the latest block
+func (ob *EVMChainClient) sampleGasPrice() (GasPriceSample, error) {
+	header, err := ob.headerByNumber(int64(rpc.LatestBlockNumber))
+	if err != nil {
+		return GasPriceSample{}, err
+	}
+	sample := GasPriceSample{BlockNumber: header.Number.Uint64(), BaseFee: header.BaseFee}
+	err = Retry(ob.ctx, "SuggestGasPrice", RPCBackoff, func() (err error) {
+		sample.GasPrice, err = ob.evmClient.SuggestGasPrice(ob.ctx)
+		return err
+	})
+	if err != nil {
+		return GasPriceSample{}, err
+	}
+	if sample.BaseFee != nil {
+		tip, err := ob.evmClient.SuggestGasTipCap(ob.ctx)
+		if err != nil { // the base fee alone still prices txs in the next block
+			ob.logger.WatchGasPrice.Warn().Err(err).Msg("sampleGasPrice: SuggestGasTipCap error")
+		} else {
+			sample.PriorityFee = tip
+		}
+	}
+	return sample, nil
+}
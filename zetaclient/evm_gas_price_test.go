@@ -0,0 +1,27 @@
+package zetaclient
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGasPriceSample_EffectiveGasPrice(t *testing.T) {
+	// chains without EIP-1559 post the suggested gas price
+	sample := GasPriceSample{GasPrice: big.NewInt(5)}
+	require.Equal(t, big.NewInt(5), sample.EffectiveGasPrice())
+
+	// base fee plus tip
+	sample = GasPriceSample{GasPrice: big.NewInt(5), BaseFee: big.NewInt(30), PriorityFee: big.NewInt(2)}
+	require.Equal(t, big.NewInt(32), sample.EffectiveGasPrice())
+
+	// base fee alone if the tip could not be sampled
+	sample.PriorityFee = nil
+	require.Equal(t, big.NewInt(30), sample.EffectiveGasPrice())
+
+	// never less than the suggested gas price
+	sample.GasPrice = big.NewInt(40)
+	require.Equal(t, big.NewInt(40), sample.EffectiveGasPrice())
+	require.Equal(t, big.NewInt(40), sample.GasPrice) // the sample is not modified
+}
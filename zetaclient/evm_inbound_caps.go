@@ -0,0 +1,69 @@
+package zetaclient
+
+import (
+	"fmt"
+
+	"github.com/zeta-chain/zetacore/common"
+	"github.com/zeta-chain/zetacore/x/crosschain/types"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
+	metricsPkg "github.com/zeta-chain/zetacore/zetaclient/metrics"
+)
+
+// inboundCapAsset returns the asset of an inbound vote as keyed in InboundAmountCaps
+func inboundCapAsset(msg *types.MsgVoteOnObservedInboundTx) string {
+	switch msg.CoinType {
+	case common.CoinType_Gas:
+		return config.InboundCapAssetGas
+	case common.CoinType_Zeta:
+		return config.InboundCapAssetZeta
+	}
+	return msg.Asset
+}
+
+// checkInboundCap flags the inbound transfers above the amount cap of their asset, and returns ErrInboundAboveCap to
+// withhold their vote unless the chain sets InboundCapsAlertOnly
+func (ob *EVMChainClient) checkInboundCap(msg *types.MsgVoteOnObservedInboundTx) error {
+	evmCfg, _ := ob.cfg.GetEVMConfig(ob.chain.ChainId)
+	asset := inboundCapAsset(msg)
+	maxAmount := evmCfg.GetInboundAmountCap(asset)
+	if maxAmount == nil || msg.Amount.BigInt().Cmp(maxAmount) <= 0 {
+		return nil
+	}
+
+	metricsPkg.InboundAboveCap.WithLabelValues(ob.chain.Name(), asset).Inc()
+	logger := WithCorrelationID(ob.logger.ExternalChainWatcher, InboundCorrelationID(msg))
+	logger.Error().Msgf("checkInboundCap: amount %s of %s in tx %s is above the cap of %s", msg.Amount, asset, msg.InTxHash, maxAmount)
+	ob.webhooks.Publish(WebhookEvent{
+		Type:            WebhookEventInboundAboveCap,
+		ChainID:         ob.chain.ChainId,
+		Chain:           ob.chain.Name(),
+		TxHash:          msg.InTxHash,
+		BlockNumber:     msg.InBlockHeight,
+		Sender:          msg.Sender,
+		Receiver:        msg.Receiver,
+		ReceiverChainID: msg.ReceiverChain,
+		CoinType:        msg.CoinType.String(),
+		Asset:           msg.Asset,
+		Amount:          msg.Amount.String(),
+		Message:         fmt.Sprintf("amount above the cap of %s", maxAmount),
+		CctxIndex:       InboundCorrelationID(msg),
+	})
+	if evmCfg.InboundCapsAlertOnly {
+		return nil
+	}
+	return fmt.Errorf("%w: amount %s of %s in tx %s is above the cap of %s", ErrInboundAboveCap, msg.Amount, asset, msg.InTxHash, maxAmount)
+}
+
+// isInboundDust returns true for the inbound transfers below the min amount of their asset, which are not worth voting on
+func (ob *EVMChainClient) isInboundDust(msg *types.MsgVoteOnObservedInboundTx) bool {
+	evmCfg, _ := ob.cfg.GetEVMConfig(ob.chain.ChainId)
+	asset := inboundCapAsset(msg)
+	minAmount := evmCfg.GetInboundMinAmount(asset)
+	if minAmount == nil || msg.Amount.BigInt().Cmp(minAmount) >= 0 {
+		return false
+	}
+	metricsPkg.InboundDust.WithLabelValues(ob.chain.Name(), asset).Inc()
+	logger := WithCorrelationID(ob.logger.ExternalChainWatcher, InboundCorrelationID(msg))
+	logger.Info().Msgf("isInboundDust: skipping amount %s of %s in tx %s below the min amount of %s", msg.Amount, asset, msg.InTxHash, minAmount)
+	return true
+}
@@ -0,0 +1,74 @@
+package zetaclient
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/zeta-chain/zetacore/common"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
+)
+
+func TestEVMChainClient_CheckInboundCap(t *testing.T) {
+	chain := common.EthChain()
+	usdt := "0xdAC17F958D2ee523a2206206994597C13D831ec7"
+	cfg := config.NewConfig()
+	evmCfg := &config.EVMConfig{Chain: chain, InboundAmountCaps: map[string]string{
+		config.InboundCapAssetGas: "1000",
+		usdt:                      "500",
+	}}
+	cfg.EVMChainConfigs = map[int64]*config.EVMConfig{chain.ChainId: evmCfg}
+	ob := &EVMChainClient{
+		Mu:     &sync.Mutex{},
+		chain:  chain,
+		cfg:    cfg,
+		logger: EVMLog{ExternalChainWatcher: zerolog.Nop()},
+	}
+	deposit := func(coinType common.CoinType, asset string, amount int64) error {
+		msg, err := InboundEvent{Sender: "0x01", SenderChain: chain, TxOrigin: "0x01", Receiver: "0x02", ReceiverChain: common.ZetaChain(),
+			Amount: big.NewInt(amount), InTxHash: "0xabc", InBlockHeight: 100, GasLimit: 90_000, CoinType: coinType, Asset: asset}.VoteMessage("zeta1observer")
+		require.NoError(t, err)
+		return ob.checkInboundCap(msg)
+	}
+
+	require.NoError(t, deposit(common.CoinType_Gas, "", 1000))
+	require.ErrorIs(t, deposit(common.CoinType_Gas, "", 1001), ErrInboundAboveCap)
+	// ERC20s are capped by address whatever its case
+	require.NoError(t, deposit(common.CoinType_ERC20, usdt, 500))
+	require.ErrorIs(t, deposit(common.CoinType_ERC20, "0xdac17f958d2ee523a2206206994597c13d831ec7", 501), ErrInboundAboveCap)
+	// assets without a cap are not capped
+	require.NoError(t, deposit(common.CoinType_Zeta, "", 1_000_000))
+	require.NoError(t, deposit(common.CoinType_ERC20, "0x01", 1_000_000))
+
+	// transfers above the cap are only flagged in alert only mode
+	evmCfg.InboundCapsAlertOnly = true
+	require.NoError(t, deposit(common.CoinType_Gas, "", 1001))
+}
+
+func TestEVMChainClient_IsInboundDust(t *testing.T) {
+	chain := common.EthChain()
+	cfg := config.NewConfig()
+	cfg.EVMChainConfigs = map[int64]*config.EVMConfig{chain.ChainId: {Chain: chain, InboundMinAmounts: map[string]string{
+		config.InboundCapAssetGas:  "1000",
+		config.InboundCapAssetZeta: "10",
+	}}}
+	ob := &EVMChainClient{
+		Mu:     &sync.Mutex{},
+		chain:  chain,
+		cfg:    cfg,
+		logger: EVMLog{ExternalChainWatcher: zerolog.Nop()},
+	}
+	deposit := func(coinType common.CoinType, asset string, amount int64) bool {
+		msg, err := InboundEvent{Sender: "0x01", SenderChain: chain, TxOrigin: "0x01", Receiver: "0x02", ReceiverChain: common.ZetaChain(),
+			Amount: big.NewInt(amount), InTxHash: "0xabc", InBlockHeight: 100, GasLimit: 90_000, CoinType: coinType, Asset: asset}.VoteMessage("zeta1observer")
+		require.NoError(t, err)
+		return ob.isInboundDust(msg)
+	}
+
+	require.True(t, deposit(common.CoinType_Gas, "", 999))
+	require.False(t, deposit(common.CoinType_Gas, "", 1000))
+	require.True(t, deposit(common.CoinType_Zeta, "", 9))
+	require.False(t, deposit(common.CoinType_ERC20, "0x01", 1))
+}
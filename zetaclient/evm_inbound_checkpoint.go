@@ -0,0 +1,82 @@
+package zetaclient
+
+import (
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	clienttypes "github.com/zeta-chain/zetacore/zetaclient/types"
+	"gorm.io/gorm"
+)
+
+// loadInboundCheckpoint returns the checkpoint left by a scan of the range starting at startBlock that was interrupted,
+// e.g. by a crash, after posting some of its events. The checkpoint is discarded if its block is no longer canonical
+func (ob *EVMChainClient) loadInboundCheckpoint(startBlock int64, verified map[uint64]ethcommon.Hash) *clienttypes.InboundCheckpointSQLType {
+	if ob.db == nil {
+		return nil
+	}
+	var checkpoint clienttypes.InboundCheckpointSQLType
+	if err := ob.db.First(&checkpoint, clienttypes.InboundCheckpointID).Error; err != nil {
+		return nil
+	}
+	// #nosec G701 always positive
+	if checkpoint.BlockNumber < uint64(startBlock) { // left by a completed range
+		return nil
+	}
+	blockHash, found := verified[checkpoint.BlockNumber]
+	if !found {
+		// #nosec G701 always in range
+		header, err := ob.headerByNumber(int64(checkpoint.BlockNumber))
+		if err != nil {
+			ob.logger.ExternalChainWatcher.Warn().Err(err).Msgf("loadInboundCheckpoint: error getting header of block %d", checkpoint.BlockNumber)
+			return nil
+		}
+		blockHash = header.Hash()
+	}
+	if blockHash != ethcommon.HexToHash(checkpoint.BlockHash) {
+		ob.logger.ExternalChainWatcher.Warn().Msgf("loadInboundCheckpoint: block %d of checkpoint was reverted by a reorg", checkpoint.BlockNumber)
+		return nil
+	}
+	ob.logger.ExternalChainWatcher.Info().Msgf("loadInboundCheckpoint: resuming from log %d of block %d", checkpoint.LogIndex, checkpoint.BlockNumber)
+	return &checkpoint
+}
+
+// skipCheckpointedLogs drops the logs up to and including the checkpoint; the logs are in block and log index order
+func skipCheckpointedLogs(logs []ethtypes.Log, checkpoint *clienttypes.InboundCheckpointSQLType) []ethtypes.Log {
+	if checkpoint == nil {
+		return logs
+	}
+	for i, vLog := range logs {
+		if vLog.BlockNumber > checkpoint.BlockNumber || (vLog.BlockNumber == checkpoint.BlockNumber && vLog.Index > checkpoint.LogIndex) {
+			return logs[i:]
+		}
+	}
+	return nil
+}
+
+// setInboundEventCheckpoint records the inbound event as posted to zetacore and moves the checkpoint to its log
+// in a single db transaction, so that a restart neither skips nor posts again an event of the range
+func (ob *EVMChainClient) setInboundEventCheckpoint(key string, vLog ethtypes.Log, zetaHash string) {
+	if ob.db == nil {
+		return
+	}
+	err := ob.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(clienttypes.ToInboundEventSQLType(key, vLog.BlockNumber, zetaHash)).Error; err != nil {
+			return err
+		}
+		return tx.Save(clienttypes.ToInboundCheckpointSQLType(vLog.BlockNumber, vLog.BlockHash, vLog.Index)).Error
+	})
+	if err != nil {
+		ob.logger.ExternalChainWatcher.Error().Err(err).Msgf("setInboundEventCheckpoint: error writing inbound event %s to db", key)
+	}
+}
+
+// forgetInboundCheckpointAfter deletes the checkpoint if it is in a block after block, e.g. reverted by a reorg
+func (ob *EVMChainClient) forgetInboundCheckpointAfter(block int64) {
+	if ob.db == nil || block < 0 {
+		return
+	}
+	// #nosec G701 checked positive
+	err := ob.db.Unscoped().Where("block_number > ?", uint64(block)).Delete(&clienttypes.InboundCheckpointSQLType{}).Error
+	if err != nil {
+		ob.logger.ExternalChainWatcher.Error().Err(err).Msgf("forgetInboundCheckpointAfter: error deleting checkpoint after block %d from db", block)
+	}
+}
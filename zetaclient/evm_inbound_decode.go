@@ -0,0 +1,188 @@
+package zetaclient
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/zeta-chain/zetacore/x/crosschain/types"
+	metricsPkg "github.com/zeta-chain/zetacore/zetaclient/metrics"
+	clienttypes "github.com/zeta-chain/zetacore/zetaclient/types"
+)
+
+// InboundDecodeWorkers is the number of inbound event logs decoded concurrently
+const InboundDecodeWorkers = 8
+
+// decodedInbound is the result of decoding an inbound event log: the vote to post, nothing if the log is skipped,
+// or an error aborting the scan of the range before the log
+type decodedInbound struct {
+	vLog     ethtypes.Log
+	contract *watchedContract
+	eventKey string
+	msg      *types.MsgVoteOnObservedInboundTx
+	gasLimit uint64
+	err      error
+
+	// correlationID follows the event through its vote to the outbound of its cctx, see InboundCorrelationID
+	correlationID string
+	// dust is set for the transfers below the min amount of their asset, recorded as processed without a vote
+	dust bool
+}
+
+// runBounded calls f for every index in [0, n) with at most workers calls running at once and waits for all of them
+func runBounded(n, workers int, f func(i int)) {
+	if workers < 1 {
+		workers = 1
+	}
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			f(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// decodeInboundLogs decodes the logs concurrently; the results are in the order of the logs so that votes are
+// posted in block and log index order, and the events of a tx in the order they were emitted
+func (ob *EVMChainClient) decodeInboundLogs(contracts WatchedContracts, verified map[uint64]ethcommon.Hash, logs []ethtypes.Log) []decodedInbound {
+	results := make([]decodedInbound, len(logs))
+	runBounded(len(logs), InboundDecodeWorkers, func(i int) {
+		results[i] = ob.decodeInboundLog(contracts, verified, logs[i])
+	})
+	return results
+}
+
+// inboundPost is the outcome of posting the vote of a decoded inbound event
+type inboundPost struct {
+	zetaHash string
+	err      error
+	start    time.Time
+}
+
+// postDecodedInbounds posts the votes of the decoded events in the order of the logs: one by one or, if the bridge
+// gathers the votes into multi-vote txs, handed to it at once so that the votes sharing a tx keep that order. The
+// sender rate limit is checked in the order of the logs either way
+func (ob *EVMChainClient) postDecodedInbounds(decodedLogs []decodedInbound) []inboundPost {
+	posts := make([]inboundPost, len(decodedLogs))
+	var (
+		votes     []int
+		gasLimits []uint64
+		msgs      []*types.MsgVoteOnObservedInboundTx
+	)
+	for i, decoded := range decodedLogs {
+		if decoded.dust || decoded.msg == nil {
+			continue
+		}
+		posts[i].start = time.Now()
+		if err := ob.checkSenderRateLimit(decoded.msg); err != nil {
+			posts[i].err = err
+			continue
+		}
+		votes = append(votes, i)
+		gasLimits = append(gasLimits, decoded.gasLimit)
+		msgs = append(msgs, decoded.msg)
+	}
+	zetaHashes, errs := ob.postInboundVotes(gasLimits, msgs)
+	for j, i := range votes {
+		posts[i].zetaHash, posts[i].err = zetaHashes[j], errs[j]
+	}
+	return posts
+}
+
+// decodeInboundLog checks that an inbound event log is new, canonical and emitted by a successful tx and builds its vote
+func (ob *EVMChainClient) decodeInboundLog(contracts WatchedContracts, verified map[uint64]ethcommon.Hash, vLog ethtypes.Log) decodedInbound {
+	result := decodedInbound{vLog: vLog}
+	contract, found := contracts.Match(vLog)
+	if !found {
+		return result
+	}
+	result.contract = contract
+	// skip events already posted, e.g. before a restart mid-batch or in a rescanned range
+	result.eventKey = clienttypes.InboundEventKey(vLog.TxHash, vLog.Index)
+	if ob.isInboundEventProcessed(result.eventKey) {
+		ob.logger.ExternalChainWatcher.Debug().Msgf("inbound event %s already processed", result.eventKey)
+		return result
+	}
+	// never vote on an event of a reverted block; the range is scanned again once the reorg is handled
+	canonical := !vLog.Removed && verified[vLog.BlockNumber] == vLog.BlockHash
+	if verified == nil {
+		var err error
+		canonical, err = ob.isLogCanonical(vLog)
+		if err != nil {
+			result.err = err
+			return result
+		}
+	}
+	if !canonical {
+		ob.logger.ExternalChainWatcher.Warn().Msgf("inbound event %s of block %d was removed by a reorg; withholding vote", result.eventKey, vLog.BlockNumber)
+		if !vLog.Removed {
+			result.err = fmt.Errorf("block %d of inbound event %s is no longer canonical", vLog.BlockNumber, result.eventKey)
+		}
+		return result
+	}
+	eventName := contract.event.Name
+	metricsPkg.InboundEventsProcessed.WithLabelValues(ob.chain.Name(), eventName).Inc()
+	start := time.Now()
+	receipt, err := ob.checkInboundReceipt(vLog, contract.address)
+	if errors.Is(err, ErrInvalidInboundReceipt) {
+		ob.logger.ExternalChainWatcher.Warn().Err(err).Msgf("skipping %s event in tx %s", eventName, vLog.TxHash.Hex())
+		return result
+	}
+	if err != nil {
+		// the range is scanned again rather than dropping the event for a receipt the rpc couldn't serve
+		result.err = fmt.Errorf("error getting receipt of %s event in tx %s: %w", eventName, vLog.TxHash.Hex(), err)
+		return result
+	}
+	msg, gasLimit, err := ob.getInboundVoteMsgForLog(contract, vLog)
+	if err == nil {
+		err = ob.checkInboundCap(msg)
+	}
+	if err == nil {
+		err = ob.screenInbound(msg)
+	}
+	if err != nil {
+		ob.recordEventDecoded(eventName, "", start, err)
+		// never vote on a transfer that couldn't be screened; the range is scanned again
+		if errors.Is(err, ErrScreeningFailed) {
+			result.err = err
+			return result
+		}
+		ob.logger.ExternalChainWatcher.Error().Err(err).Msgf("error getting inbound vote msg of %s event in tx %s", eventName, vLog.TxHash.Hex())
+		ob.quarantineInboundEvent(contract, result.eventKey, vLog, err)
+		return result
+	}
+	result.correlationID = InboundCorrelationID(msg)
+	ob.recordEventDecoded(eventName, result.correlationID, start, nil)
+	if ob.isInboundDust(msg) {
+		result.dust = true
+		return result
+	}
+	if evmCfg, _ := ob.cfg.GetEVMConfig(ob.chain.ChainId); evmCfg.InboundProofs {
+		if err := ob.proveInbound(result.eventKey, vLog, receipt); err != nil {
+			ob.logger.ExternalChainWatcher.Error().Err(err).Msgf("error proving %s event in tx %s", eventName, vLog.TxHash.Hex())
+			// a proof zetacore found invalid is withheld for review, otherwise the range is scanned again
+			if errors.Is(err, ErrInboundProofRejected) {
+				ob.quarantineInboundEvent(contract, result.eventKey, vLog, err)
+			} else {
+				result.err = err
+			}
+			return result
+		}
+	}
+	logger := WithCorrelationID(ob.logger.ExternalChainWatcher, result.correlationID)
+	logger.Debug().Msgf("%s event %s decoded", eventName, result.eventKey)
+	result.msg = msg
+	result.gasLimit = gasLimit
+	return result
+}
@@ -0,0 +1,87 @@
+package zetaclient
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/zeta-chain/zetacore/common"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
+)
+
+func TestRunBounded(t *testing.T) {
+	const n, workers = 50, 4
+	var running, maxRunning int32
+	var mu sync.Mutex
+	done := make(map[int]int)
+
+	runBounded(n, workers, func(i int) {
+		cur := atomic.AddInt32(&running, 1)
+		for {
+			prev := atomic.LoadInt32(&maxRunning)
+			if cur <= prev || atomic.CompareAndSwapInt32(&maxRunning, prev, cur) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		mu.Lock()
+		done[i]++
+		mu.Unlock()
+	})
+
+	require.LessOrEqual(t, maxRunning, int32(workers))
+	require.Len(t, done, n)
+	for i := 0; i < n; i++ {
+		require.Equal(t, 1, done[i])
+	}
+
+	// no work, no workers
+	runBounded(0, 0, func(i int) { t.Fatal("unexpected call") })
+}
+
+// receiptRPCClient serves the receipt of the inbound txs, or fails to
+type receiptRPCClient struct {
+	stubEVMRPCClient
+	receipt *ethtypes.Receipt
+	err     error
+}
+
+func (c *receiptRPCClient) TransactionReceipt(context.Context, ethcommon.Hash) (*ethtypes.Receipt, error) {
+	return c.receipt, c.err
+}
+
+func TestEVMChainClient_DecodeInboundLogReceipt(t *testing.T) {
+	backoff := RPCBackoff
+	defer func() { RPCBackoff = backoff }()
+	RPCBackoff.MaxRetries = 0
+
+	connector := ethcommon.HexToAddress("0x01")
+	contract, err := newWatchedContract(config.ContractKindConnector, connector, config.GetConnectorABI())
+	require.NoError(t, err)
+	contracts := newWatchedContracts([]*watchedContract{contract})
+	blockHash := ethcommon.HexToHash("0xb1")
+	vLog := ethtypes.Log{Address: connector, Topics: []ethcommon.Hash{contract.event.ID}, BlockNumber: 100, BlockHash: blockHash, Index: 2}
+	verified := map[uint64]ethcommon.Hash{100: blockHash}
+	client := &receiptRPCClient{err: errors.New("connection refused")}
+	ob := &EVMChainClient{ctx: context.Background(), chain: common.EthChain(), evmClient: client, logger: EVMLog{ExternalChainWatcher: zerolog.Nop()}}
+
+	// a receipt the rpc can't serve has the range scanned again
+	result := ob.decodeInboundLog(contracts, verified, vLog)
+	require.Error(t, result.err)
+	require.Nil(t, result.msg)
+
+	// the event of a failed tx is skipped
+	client.err = nil
+	client.receipt = &ethtypes.Receipt{Status: ethtypes.ReceiptStatusFailed, BlockHash: blockHash}
+	result = ob.decodeInboundLog(contracts, verified, vLog)
+	require.NoError(t, result.err)
+	require.Nil(t, result.msg)
+}
@@ -0,0 +1,179 @@
+package zetaclient
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/zeta-chain/protocol-contracts/pkg/contracts/evm/erc20custody.sol"
+	"github.com/zeta-chain/protocol-contracts/pkg/contracts/evm/zetaconnector.non-eth.sol"
+)
+
+// InboundEventIDs returns the topic hashes of the ZetaSent event of the connector and the Deposited event of the custody contract
+func InboundEventIDs() (zetaSentID ethcommon.Hash, depositedID ethcommon.Hash, err error) {
+	connectorABI, err := zetaconnector.ZetaConnectorNonEthMetaData.GetAbi()
+	if err != nil {
+		return
+	}
+	custodyABI, err := erc20custody.ERC20CustodyMetaData.GetAbi()
+	if err != nil {
+		return
+	}
+	zetaSent, found := connectorABI.Events["ZetaSent"]
+	if !found {
+		err = fmt.Errorf("ZetaSent event not found in connector abi")
+		return
+	}
+	deposited, found := custodyABI.Events["Deposited"]
+	if !found {
+		err = fmt.Errorf("Deposited event not found in custody abi")
+		return
+	}
+	return zetaSent.ID, deposited.ID, nil
+}
+
+// BuildInboundFilterQuery returns a FilterQuery matching only the inbound events of the given contracts in [startBlock, toBlock]
+// Contracts that are not set are left out; it returns false if there is nothing to query
+func BuildInboundFilterQuery(startBlock, toBlock int64, contracts []ethcommon.Address, eventIDs []ethcommon.Hash) (ethereum.FilterQuery, bool) {
+	addresses := make([]ethcommon.Address, 0, len(contracts))
+	for _, addr := range contracts {
+		if addr != (ethcommon.Address{}) {
+			addresses = append(addresses, addr)
+		}
+	}
+	// an empty address list would match the logs of every contract on chain
+	if len(addresses) == 0 {
+		return ethereum.FilterQuery{}, false
+	}
+	return ethereum.FilterQuery{
+		FromBlock: big.NewInt(startBlock),
+		ToBlock:   big.NewInt(toBlock),
+		Addresses: addresses,
+		Topics:    [][]ethcommon.Hash{eventIDs}, // any of the event signatures in the first topic
+	}, true
+}
+
+// filterInboundLogs fetches the inbound event logs of the given contracts in [startBlock, toBlock] with a single FilterLogs call,
+// unless they were fetched in the batch of the tick. The range is split if the rpc endpoint caps the number of logs returned by a single call
+func (ob *EVMChainClient) filterInboundLogs(startBlock, toBlock int64, contracts []ethcommon.Address, eventIDs []ethcommon.Hash) ([]ethtypes.Log, error) {
+	query, ok := BuildInboundFilterQuery(startBlock, toBlock, contracts, eventIDs)
+	if !ok {
+		ob.logger.ExternalChainWatcher.Warn().Msg("filterInboundLogs: no contract address is set")
+		return nil, nil
+	}
+	if batch := ob.getTickBatch(); batch != nil {
+		if logs, ok := batch.logsOf(query); ok {
+			return logs, nil
+		}
+	}
+	return ob.filterLogsSplit(query)
+}
+
+// IsLogLimitError returns true if the rpc endpoint refused a getLogs query because the block range holds too many logs
+func IsLogLimitError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, pattern := range []string{
+		"query returned more than", // infura, geth based providers
+		"log response size exceeded",
+		"logs matched by query exceeds limit",
+		"response size exceeded",
+		"block range is too wide",
+		"block range too large",
+		"exceed maximum block range",
+	} {
+		if strings.Contains(msg, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterLogsSplit runs the query and splits its block range in halves, recursively, while the endpoint refuses to
+// return all logs of the range at once, so that busy ranges are never skipped
+func (ob *EVMChainClient) filterLogsSplit(query ethereum.FilterQuery) ([]ethtypes.Log, error) {
+	cnt, err := ob.GetPromCounter("rpc_getLogs_count")
+	if err != nil {
+		ob.logger.ExternalChainWatcher.Error().Err(err).Msg("GetPromCounter:")
+	} else {
+		cnt.Inc()
+	}
+	var logs []ethtypes.Log
+	var limitErr error
+	err = Retry(ob.ctx, "FilterLogs", RPCBackoff, func() (err error) {
+		logs, err = ob.evmClient.FilterLogs(ob.ctx, query)
+		if IsLogLimitError(err) { // retrying the same range is pointless
+			limitErr = err
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if limitErr == nil {
+		return logs, nil
+	}
+	from, to := query.FromBlock.Int64(), query.ToBlock.Int64()
+	if from >= to {
+		return nil, fmt.Errorf("too many logs in block %d: %w", from, limitErr)
+	}
+	mid := from + (to-from)/2
+	ob.logger.ExternalChainWatcher.Info().Msgf("filterLogsSplit: too many logs in blocks [%d, %d]; splitting at block %d", from, to, mid)
+	lower, upper := query, query
+	lower.ToBlock = big.NewInt(mid)
+	upper.FromBlock = big.NewInt(mid + 1)
+	lowerLogs, err := ob.filterLogsSplit(lower)
+	if err != nil {
+		return nil, err
+	}
+	upperLogs, err := ob.filterLogsSplit(upper)
+	if err != nil {
+		return nil, err
+	}
+	return append(lowerLogs, upperLogs...), nil
+}
+
+// ValidateInboundReceipt checks that the inbound event log was emitted by the expected contract in a successful tx.
+// The receipt must contain the log so that logs of failed or replaced txs are never voted on. A failed tx or a log
+// missing from the receipt returns ErrInvalidInboundReceipt; a missing receipt or one of another block may be
+// resolved by fetching it again
+func ValidateInboundReceipt(receipt *ethtypes.Receipt, vLog ethtypes.Log, contract ethcommon.Address) error {
+	if vLog.Address != contract {
+		return fmt.Errorf("%w: log emitted by %s, expected %s", ErrInvalidInboundReceipt, vLog.Address.Hex(), contract.Hex())
+	}
+	if receipt == nil {
+		return errors.New("receipt not found")
+	}
+	if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+		return fmt.Errorf("%w: tx %s failed", ErrInvalidInboundReceipt, vLog.TxHash.Hex())
+	}
+	if receipt.BlockHash != vLog.BlockHash {
+		return fmt.Errorf("tx %s included in block %s, log in block %s", vLog.TxHash.Hex(), receipt.BlockHash.Hex(), vLog.BlockHash.Hex())
+	}
+	for _, log := range receipt.Logs {
+		if log.Index == vLog.Index && log.Address == contract {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: log %d of contract %s not found in receipt of tx %s", ErrInvalidInboundReceipt, vLog.Index, contract.Hex(), vLog.TxHash.Hex())
+}
+
+// checkInboundReceipt fetches the receipt of the tx of an inbound event log and validates it against the expected contract
+func (ob *EVMChainClient) checkInboundReceipt(vLog ethtypes.Log, contract ethcommon.Address) (*ethtypes.Receipt, error) {
+	var receipt *ethtypes.Receipt
+	err := Retry(ob.ctx, "TransactionReceipt", RPCBackoff, func() (err error) {
+		receipt, err = ob.evmClient.TransactionReceipt(ob.ctx, vLog.TxHash)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return receipt, ValidateInboundReceipt(receipt, vLog, contract)
+}
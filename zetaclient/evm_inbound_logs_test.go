@@ -0,0 +1,210 @@
+package zetaclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/zeta-chain/protocol-contracts/pkg/contracts/evm/erc20custody.sol"
+	"github.com/zeta-chain/protocol-contracts/pkg/contracts/evm/zetaconnector.non-eth.sol"
+	"github.com/zeta-chain/zetacore/common"
+)
+
+// makeTestLog encodes an event log; indexed arguments go to topics and the others to data.
+// Arguments not in values are set to a default value of their type
+func makeTestLog(t *testing.T, event abi.Event, address ethcommon.Address, values map[string]interface{}) ethtypes.Log {
+	topics := []ethcommon.Hash{event.ID}
+	var data []interface{}
+	for _, input := range event.Inputs {
+		value, found := values[abi.ToCamelCase(input.Name)]
+		if !found {
+			switch input.Type.T {
+			case abi.AddressTy:
+				value = ethcommon.HexToAddress("0xdead")
+			case abi.UintTy:
+				value = big.NewInt(1)
+			case abi.BytesTy:
+				value = []byte("bytes")
+			default:
+				t.Fatalf("unsupported type %s of argument %s", input.Type.String(), input.Name)
+			}
+		}
+		if input.Indexed {
+			topic, err := abi.MakeTopics([]interface{}{value})
+			require.NoError(t, err)
+			topics = append(topics, topic[0][0])
+		} else {
+			data = append(data, value)
+		}
+	}
+	packed, err := event.Inputs.NonIndexed().Pack(data...)
+	require.NoError(t, err)
+	return ethtypes.Log{Address: address, Topics: topics, Data: packed}
+}
+
+func TestInboundEventIDs(t *testing.T) {
+	zetaSentID, depositedID, err := InboundEventIDs()
+	require.NoError(t, err)
+	require.NotEqual(t, ethcommon.Hash{}, zetaSentID)
+	require.NotEqual(t, ethcommon.Hash{}, depositedID)
+	require.NotEqual(t, zetaSentID, depositedID)
+}
+
+func TestBuildInboundFilterQuery(t *testing.T) {
+	connector := ethcommon.HexToAddress("0x01")
+	eventIDs := []ethcommon.Hash{ethcommon.HexToHash("0xaa"), ethcommon.HexToHash("0xbb")}
+
+	// unset contracts are left out of the query
+	query, ok := BuildInboundFilterQuery(10, 20, []ethcommon.Address{connector, {}}, eventIDs)
+	require.True(t, ok)
+	require.Equal(t, big.NewInt(10), query.FromBlock)
+	require.Equal(t, big.NewInt(20), query.ToBlock)
+	require.Equal(t, []ethcommon.Address{connector}, query.Addresses)
+	require.Equal(t, [][]ethcommon.Hash{eventIDs}, query.Topics)
+
+	// never query the logs of all contracts
+	_, ok = BuildInboundFilterQuery(10, 20, []ethcommon.Address{{}, {}}, eventIDs)
+	require.False(t, ok)
+}
+
+func TestParseInboundEvents(t *testing.T) {
+	address := ethcommon.HexToAddress("0x01")
+
+	// ZetaSent has indexed arguments that must be decoded from the topics
+	connectorABI, err := zetaconnector.ZetaConnectorNonEthMetaData.GetAbi()
+	require.NoError(t, err)
+	sender := ethcommon.HexToAddress("0x1234")
+	zetaSentLog := makeTestLog(t, connectorABI.Events["ZetaSent"], address, map[string]interface{}{
+		"ZetaTxSenderAddress": sender,
+		"DestinationChainId":  big.NewInt(7001),
+		"DestinationAddress":  []byte("destination"),
+		"ZetaValueAndGas":     big.NewInt(42),
+	})
+	connector, err := zetaconnector.NewZetaConnectorNonEthFilterer(address, nil)
+	require.NoError(t, err)
+	zetaSent, err := connector.ParseZetaSent(zetaSentLog)
+	require.NoError(t, err)
+	require.Equal(t, sender, zetaSent.ZetaTxSenderAddress)
+	require.Equal(t, big.NewInt(7001), zetaSent.DestinationChainId)
+	require.Equal(t, []byte("destination"), zetaSent.DestinationAddress)
+	require.Equal(t, big.NewInt(42), zetaSent.ZetaValueAndGas)
+
+	custodyABI, err := erc20custody.ERC20CustodyMetaData.GetAbi()
+	require.NoError(t, err)
+	asset := ethcommon.HexToAddress("0x5678")
+	depositedLog := makeTestLog(t, custodyABI.Events["Deposited"], address, map[string]interface{}{
+		"Recipient": []byte("recipient"),
+		"Asset":     asset,
+		"Amount":    big.NewInt(100),
+	})
+	custody, err := erc20custody.NewERC20CustodyFilterer(address, nil)
+	require.NoError(t, err)
+	deposited, err := custody.ParseDeposited(depositedLog)
+	require.NoError(t, err)
+	require.Equal(t, []byte("recipient"), deposited.Recipient)
+	require.Equal(t, asset, deposited.Asset)
+	require.Equal(t, big.NewInt(100), deposited.Amount)
+}
+
+func TestValidateInboundReceipt(t *testing.T) {
+	connector := ethcommon.HexToAddress("0x01")
+	blockHash := ethcommon.HexToHash("0xb1")
+	vLog := ethtypes.Log{Address: connector, BlockHash: blockHash, Index: 2}
+	newReceipt := func() *ethtypes.Receipt {
+		return &ethtypes.Receipt{
+			Status:    ethtypes.ReceiptStatusSuccessful,
+			BlockHash: blockHash,
+			Logs:      []*ethtypes.Log{{Address: connector, BlockHash: blockHash, Index: 2}},
+		}
+	}
+	require.NoError(t, ValidateInboundReceipt(newReceipt(), vLog, connector))
+
+	// log of another contract
+	require.ErrorIs(t, ValidateInboundReceipt(newReceipt(), vLog, ethcommon.HexToAddress("0x02")), ErrInvalidInboundReceipt)
+
+	// failed tx
+	receipt := newReceipt()
+	receipt.Status = ethtypes.ReceiptStatusFailed
+	require.ErrorIs(t, ValidateInboundReceipt(receipt, vLog, connector), ErrInvalidInboundReceipt)
+
+	// tx included in another block
+	receipt = newReceipt()
+	receipt.BlockHash = ethcommon.HexToHash("0xb2")
+	err := ValidateInboundReceipt(receipt, vLog, connector)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrInvalidInboundReceipt)
+
+	// log not in the receipt
+	receipt = newReceipt()
+	receipt.Logs = nil
+	require.ErrorIs(t, ValidateInboundReceipt(receipt, vLog, connector), ErrInvalidInboundReceipt)
+
+	err = ValidateInboundReceipt(nil, vLog, connector)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrInvalidInboundReceipt)
+}
+
+// logLimitRPCClient refuses getLogs queries matching more than limit logs, like infura does
+type logLimitRPCClient struct {
+	EVMRPCClient
+	limit   int
+	logs    []ethtypes.Log
+	queries int
+}
+
+func (c *logLimitRPCClient) FilterLogs(_ context.Context, query ethereum.FilterQuery) ([]ethtypes.Log, error) {
+	c.queries++
+	var logs []ethtypes.Log
+	for _, vLog := range c.logs {
+		if vLog.BlockNumber >= query.FromBlock.Uint64() && vLog.BlockNumber <= query.ToBlock.Uint64() {
+			logs = append(logs, vLog)
+		}
+	}
+	if len(logs) > c.limit {
+		return nil, fmt.Errorf("query returned more than %d results", c.limit)
+	}
+	return logs, nil
+}
+
+func TestIsLogLimitError(t *testing.T) {
+	require.False(t, IsLogLimitError(nil))
+	require.False(t, IsLogLimitError(errors.New("connection refused")))
+	require.True(t, IsLogLimitError(errors.New("query returned more than 10000 results")))
+	require.True(t, IsLogLimitError(errors.New("Log response size exceeded. You can make eth_getLogs requests with up to a 2K block range")))
+}
+
+func TestEVMChainClient_FilterLogsSplit(t *testing.T) {
+	chain := common.EthChain()
+	client := &logLimitRPCClient{limit: 2}
+	// a busy block range: two logs in block 105 and one in each of blocks 100, 107 and 110
+	for _, block := range []uint64{100, 105, 105, 107, 110} {
+		client.logs = append(client.logs, ethtypes.Log{BlockNumber: block})
+	}
+	ob := &EVMChainClient{
+		ctx:          context.Background(),
+		chain:        chain,
+		evmClient:    client,
+		ChainMetrics: NewChainMetrics(chain.Name(), nil),
+	}
+	query := ethereum.FilterQuery{FromBlock: big.NewInt(100), ToBlock: big.NewInt(110)}
+	logs, err := ob.filterLogsSplit(query)
+	require.NoError(t, err)
+	require.Len(t, logs, 5)
+	for i := 1; i < len(logs); i++ {
+		require.LessOrEqual(t, logs[i-1].BlockNumber, logs[i].BlockNumber)
+	}
+	require.Greater(t, client.queries, 1)
+
+	// a single block can't be split any further
+	client.logs = append(client.logs, ethtypes.Log{BlockNumber: 105})
+	_, err = ob.filterLogsSplit(query)
+	require.Error(t, err)
+	require.True(t, IsLogLimitError(err))
+}
@@ -0,0 +1,133 @@
+package zetaclient
+
+import (
+	"encoding/json"
+	"fmt"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/zeta-chain/zetacore/common"
+	"github.com/zeta-chain/zetacore/common/ethereum"
+	"github.com/zeta-chain/zetacore/x/crosschain/types"
+	clienttypes "github.com/zeta-chain/zetacore/zetaclient/types"
+)
+
+// InboundProof is the proof of the tx of an inbound event against the header of its block, with the receipt of the tx
+type InboundProof struct {
+	BlockHash string            `json:"block_hash"`
+	TxIndex   int64             `json:"tx_index"`
+	TxProof   *common.Proof     `json:"tx_proof"`
+	Receipt   *ethtypes.Receipt `json:"receipt,omitempty"`
+}
+
+// NewEVMTxProof returns the MPT proof of the index-th tx of the block against the tx root of its header
+func NewEVMTxProof(block *ethtypes.Block, index int) (*common.Proof, error) {
+	txs := block.Transactions()
+	if index < 0 || index >= len(txs) {
+		return nil, fmt.Errorf("tx index %d out of range of block %d", index, block.NumberU64())
+	}
+	txTrie := ethereum.NewTrie(txs)
+	if txTrie.Hash() != block.Header().TxHash {
+		return nil, fmt.Errorf("txs of block %d don't match its tx root %s", block.NumberU64(), block.Header().TxHash.Hex())
+	}
+	proof, err := txTrie.GenerateProof(index)
+	if err != nil {
+		return nil, err
+	}
+	return common.NewEthereumProof(proof), nil
+}
+
+// proveInbound proves the tx of the inbound event against its block header, has zetacore verify the proof against
+// the block headers voted by the observers and records it with the event. ErrInboundProofRejected is returned if
+// zetacore finds the proof invalid, i.e. the rpc provider served a tx missing from the block known to zetacore
+func (ob *EVMChainClient) proveInbound(key string, vLog ethtypes.Log, receipt *ethtypes.Receipt) error {
+	// #nosec G701 always positive
+	block, err := ob.GetBlockByNumberCached(int64(vLog.BlockNumber))
+	if err != nil {
+		return err
+	}
+	if block.Hash() != vLog.BlockHash {
+		return fmt.Errorf("block %d of inbound event %s is no longer canonical", vLog.BlockNumber, key)
+	}
+	txs := block.Transactions()
+	if int(vLog.TxIndex) >= len(txs) || txs[vLog.TxIndex].Hash() != vLog.TxHash {
+		return fmt.Errorf("tx %s of inbound event %s not found at index %d of block %d", vLog.TxHash.Hex(), key, vLog.TxIndex, vLog.BlockNumber)
+	}
+	txProof, err := NewEVMTxProof(block, int(vLog.TxIndex))
+	if err != nil {
+		return err
+	}
+	// #nosec G701 always in range
+	txIndex := int64(vLog.TxIndex)
+	valid, err := ob.zetaClient.Prove(vLog.BlockHash.Hex(), vLog.TxHash.Hex(), txIndex, txProof, ob.chain.ChainId)
+	if err != nil {
+		return fmt.Errorf("fail to verify the proof of inbound tx %s on zetacore: %w", vLog.TxHash.Hex(), err)
+	}
+	if !valid {
+		return fmt.Errorf("%w: tx %s at index %d of block %s", ErrInboundProofRejected, vLog.TxHash.Hex(), txIndex, vLog.BlockHash.Hex())
+	}
+	return ob.saveInboundProof(key, &InboundProof{
+		BlockHash: vLog.BlockHash.Hex(),
+		TxIndex:   txIndex,
+		TxProof:   txProof,
+		Receipt:   receipt,
+	})
+}
+
+// saveInboundProof records the proof of the inbound event
+func (ob *EVMChainClient) saveInboundProof(key string, proof *InboundProof) error {
+	if ob.db == nil {
+		return nil
+	}
+	txProof, err := proof.TxProof.Marshal()
+	if err != nil {
+		return err
+	}
+	receipt, err := json.Marshal(proof.Receipt)
+	if err != nil {
+		return err
+	}
+	record := clienttypes.InboundProofSQLType{
+		Key:       key,
+		BlockHash: proof.BlockHash,
+		TxIndex:   proof.TxIndex,
+		TxProof:   txProof,
+		Receipt:   receipt,
+	}
+	return ob.db.Where(&clienttypes.InboundProofSQLType{Key: key}).Assign(record).FirstOrCreate(&clienttypes.InboundProofSQLType{}).Error
+}
+
+// GetInboundProof returns the proof recorded with the inbound event of the key, nil if none
+func (ob *EVMChainClient) GetInboundProof(key string) (*InboundProof, error) {
+	if ob.db == nil {
+		return nil, nil
+	}
+	var records []clienttypes.InboundProofSQLType
+	if err := ob.db.Where(&clienttypes.InboundProofSQLType{Key: key}).Limit(1).Find(&records).Error; err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	proof := &InboundProof{BlockHash: records[0].BlockHash, TxIndex: records[0].TxIndex, TxProof: &common.Proof{}}
+	if err := proof.TxProof.Unmarshal(records[0].TxProof); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(records[0].Receipt, &proof.Receipt); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// inboundProof returns the proof recorded with the inbound event of the vote, if the chain proves its inbounds
+func (ob *EVMChainClient) inboundProof(msg *types.MsgVoteOnObservedInboundTx) *InboundProof {
+	if evmCfg, _ := ob.cfg.GetEVMConfig(ob.chain.ChainId); !evmCfg.InboundProofs {
+		return nil
+	}
+	key := clienttypes.InboundEventKey(ethcommon.HexToHash(msg.InTxHash), uint(msg.EventIndex))
+	proof, err := ob.GetInboundProof(key)
+	if err != nil {
+		ob.logger.ExternalChainWatcher.Error().Err(err).Msgf("error reading proof of inbound event %s from db", key)
+	}
+	return proof
+}
@@ -0,0 +1,278 @@
+package zetaclient
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/zeta-chain/protocol-contracts/pkg/contracts/evm/erc20custody.sol"
+	"github.com/zeta-chain/protocol-contracts/pkg/contracts/evm/zetaconnector.non-eth.sol"
+	"github.com/zeta-chain/zetacore/common"
+	metricsPkg "github.com/zeta-chain/zetacore/zetaclient/metrics"
+	clienttypes "github.com/zeta-chain/zetacore/zetaclient/types"
+)
+
+const (
+	// MempoolTxTTL is how long a pending inbound tx is reported before it is dropped if never mined
+	MempoolTxTTL = 30 * time.Minute
+	// MempoolPruneInterval is the interval in seconds between two checks of the pending inbound txs
+	MempoolPruneInterval = 15
+	// MempoolReconnectDelay is the delay before subscribing again after the mempool subscription failed
+	MempoolReconnectDelay = 10 * time.Second
+)
+
+// MempoolTracker keeps the inbound txs seen in the mempool until they are mined or expire
+type MempoolTracker struct {
+	mu  sync.Mutex
+	txs map[ethcommon.Hash]clienttypes.UnconfirmedInbound
+}
+
+func NewMempoolTracker() *MempoolTracker {
+	return &MempoolTracker{txs: make(map[ethcommon.Hash]clienttypes.UnconfirmedInbound)}
+}
+
+// Add tracks a pending inbound tx; it returns false if the tx is already tracked
+func (m *MempoolTracker) Add(hash ethcommon.Hash, inbound clienttypes.UnconfirmedInbound) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, found := m.txs[hash]; found {
+		return false
+	}
+	m.txs[hash] = inbound
+	return true
+}
+
+// Remove stops tracking a tx, e.g. because it was mined
+func (m *MempoolTracker) Remove(hash ethcommon.Hash) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.txs, hash)
+}
+
+// Expire stops tracking the txs first seen more than ttl before now
+func (m *MempoolTracker) Expire(now time.Time, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for hash, inbound := range m.txs {
+		if now.Sub(inbound.FirstSeen) > ttl {
+			delete(m.txs, hash)
+		}
+	}
+}
+
+// Hashes returns the hashes of the tracked txs
+func (m *MempoolTracker) Hashes() []ethcommon.Hash {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	hashes := make([]ethcommon.Hash, 0, len(m.txs))
+	for hash := range m.txs {
+		hashes = append(hashes, hash)
+	}
+	return hashes
+}
+
+// List returns the tracked txs sorted by the time they were first seen
+func (m *MempoolTracker) List() []clienttypes.UnconfirmedInbound {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	list := make([]clienttypes.UnconfirmedInbound, 0, len(m.txs))
+	for _, inbound := range m.txs {
+		list = append(list, inbound)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].FirstSeen.Before(list[j].FirstSeen)
+	})
+	return list
+}
+
+// DecodeUnconfirmedInbound decodes a pending tx sending gas to the TSS address, calling send() on the connector or
+// deposit() on the ERC20 custody contract. It returns nil if the tx is not an inbound tx
+func DecodeUnconfirmedInbound(
+	tx *ethtypes.Transaction,
+	sender ethcommon.Address,
+	chainID int64,
+	connector ethcommon.Address,
+	custody ethcommon.Address,
+	tss ethcommon.Address,
+) (*clienttypes.UnconfirmedInbound, error) {
+	if tx.To() == nil {
+		return nil, nil
+	}
+	inbound := &clienttypes.UnconfirmedInbound{
+		ChainID: chainID,
+		TxHash:  tx.Hash().Hex(),
+		Sender:  sender.Hex(),
+	}
+	to := *tx.To()
+	switch {
+	case to == tss:
+		if tx.Value().Sign() <= 0 {
+			return nil, nil
+		}
+		inbound.CoinType = common.CoinType_Gas.String()
+		inbound.Amount = tx.Value().String()
+		inbound.Receiver = sender.Hex()
+		return inbound, nil
+	case to == connector && connector != (ethcommon.Address{}):
+		args, err := unpackCalldata(zetaconnector.ZetaConnectorNonEthMetaData, "send", tx.Data())
+		if args == nil || err != nil {
+			return nil, err
+		}
+		input, ok := abi.ConvertType(args[0], new(zetaconnector.ZetaInterfacesSendInput)).(*zetaconnector.ZetaInterfacesSendInput)
+		if !ok {
+			return nil, fmt.Errorf("cannot convert send input of tx %s", tx.Hash().Hex())
+		}
+		inbound.CoinType = common.CoinType_Zeta.String()
+		inbound.Amount = input.ZetaValueAndGas.String()
+		inbound.Receiver = ethcommon.BytesToAddress(input.DestinationAddress).Hex()
+		inbound.ReceiverChainID = input.DestinationChainId.Int64()
+		return inbound, nil
+	case to == custody && custody != (ethcommon.Address{}):
+		args, err := unpackCalldata(erc20custody.ERC20CustodyMetaData, "deposit", tx.Data())
+		if args == nil || err != nil {
+			return nil, err
+		}
+		recipient, ok1 := args[0].([]byte)
+		asset, ok2 := args[1].(ethcommon.Address)
+		amount, ok3 := args[2].(*big.Int)
+		if !ok1 || !ok2 || !ok3 {
+			return nil, fmt.Errorf("cannot convert deposit arguments of tx %s", tx.Hash().Hex())
+		}
+		inbound.CoinType = common.CoinType_ERC20.String()
+		inbound.Asset = asset.Hex()
+		inbound.Amount = amount.String()
+		inbound.Receiver = ethcommon.BytesToAddress(recipient).Hex()
+		return inbound, nil
+	}
+	return nil, nil
+}
+
+// unpackCalldata unpacks the arguments of a call to the given method; it returns nil if the calldata calls another method
+func unpackCalldata(metaData *bind.MetaData, method string, data []byte) ([]interface{}, error) {
+	if len(data) < 4 {
+		return nil, nil
+	}
+	contractABI, err := metaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	m, err := contractABI.MethodById(data[:4])
+	if err != nil || m.Name != method {
+		return nil, nil
+	}
+	return m.Inputs.Unpack(data[4:])
+}
+
+// WatchMempool subscribes to the pending txs of the chain and reports the inbound ones to telemetry before they are mined.
+// It is disabled if no mempool endpoint is set in config
+func (ob *EVMChainClient) WatchMempool() {
+	evmCfg, found := ob.cfg.GetEVMConfig(ob.chain.ChainId)
+	if !found || evmCfg.MempoolEndpoint == "" {
+		return
+	}
+	for {
+		err := ob.subscribeMempool(evmCfg.MempoolEndpoint)
+		if err != nil {
+			ob.logger.ExternalChainWatcher.Warn().Err(err).Msgf("WatchMempool: subscription failed; retrying in %s", MempoolReconnectDelay)
+		}
+		select {
+		case <-ob.stop:
+			ob.logger.ExternalChainWatcher.Info().Msg("WatchMempool stopped")
+			return
+		case <-time.After(MempoolReconnectDelay):
+		}
+	}
+}
+
+func (ob *EVMChainClient) subscribeMempool(endpoint string) error {
+	client, err := rpc.DialContext(ob.ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	hashes := make(chan ethcommon.Hash, 1000)
+	sub, err := client.EthSubscribe(ob.ctx, hashes, "newPendingTransactions")
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+	ob.logger.ExternalChainWatcher.Info().Msg("WatchMempool: subscribed to pending txs")
+
+	ticker := time.NewTicker(MempoolPruneInterval * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case hash := <-hashes:
+			ob.observePendingTx(hash)
+		case err := <-sub.Err():
+			return err
+		case <-ticker.C:
+			ob.pruneMempool()
+		case <-ob.stop:
+			return nil
+		}
+	}
+}
+
+// observePendingTx tracks a pending tx if it is an inbound tx; it never votes
+func (ob *EVMChainClient) observePendingTx(hash ethcommon.Hash) {
+	tx, isPending, err := ob.evmClient.TransactionByHash(ob.ctx, hash)
+	if err != nil || !isPending { // dropped or already mined
+		return
+	}
+	sender, err := ethtypes.Sender(ethtypes.LatestSignerForChainID(big.NewInt(ob.chain.ChainId)), tx)
+	if err != nil {
+		return
+	}
+	params := ob.GetCoreParams()
+	inbound, err := DecodeUnconfirmedInbound(
+		tx,
+		sender,
+		ob.chain.ChainId,
+		ethcommon.HexToAddress(params.ConnectorContractAddress),
+		ethcommon.HexToAddress(params.Erc20CustodyContractAddress),
+		ob.Tss.EVMAddress(),
+	)
+	if err != nil {
+		ob.logger.ExternalChainWatcher.Warn().Err(err).Msgf("observePendingTx: cannot decode tx %s", hash.Hex())
+		return
+	}
+	if inbound == nil {
+		return
+	}
+	inbound.FirstSeen = time.Now()
+	if ob.mempool.Add(hash, *inbound) {
+		ob.logger.ExternalChainWatcher.Info().Msgf("observePendingTx: unconfirmed %s inbound tx %s of amount %s from %s",
+			inbound.CoinType, inbound.TxHash, inbound.Amount, inbound.Sender)
+		ob.reportMempool()
+	}
+}
+
+// pruneMempool stops tracking the pending inbound txs that were mined or expired
+func (ob *EVMChainClient) pruneMempool() {
+	for _, hash := range ob.mempool.Hashes() {
+		receipt, err := ob.evmClient.TransactionReceipt(ob.ctx, hash)
+		if err == nil && receipt != nil { // mined; voted on by the inbound observer once confirmed
+			ob.mempool.Remove(hash)
+		}
+	}
+	ob.mempool.Expire(time.Now(), MempoolTxTTL)
+	ob.reportMempool()
+}
+
+func (ob *EVMChainClient) reportMempool() {
+	list := ob.mempool.List()
+	if gauge, err := ob.GetPromGauge(metricsPkg.UnconfirmedInboundTxs); err == nil {
+		gauge.Set(float64(len(list)))
+	}
+	if ob.ts != nil {
+		ob.ts.SetUnconfirmedInbound(ob.chain.ChainId, list)
+	}
+}
@@ -0,0 +1,92 @@
+package zetaclient
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/zeta-chain/protocol-contracts/pkg/contracts/evm/erc20custody.sol"
+	"github.com/zeta-chain/protocol-contracts/pkg/contracts/evm/zetaconnector.non-eth.sol"
+	"github.com/zeta-chain/zetacore/common"
+	clienttypes "github.com/zeta-chain/zetacore/zetaclient/types"
+)
+
+func TestDecodeUnconfirmedInbound(t *testing.T) {
+	sender := ethcommon.HexToAddress("0x1234")
+	connector := ethcommon.HexToAddress("0x01")
+	custody := ethcommon.HexToAddress("0x02")
+	tss := ethcommon.HexToAddress("0x03")
+	receiver := ethcommon.HexToAddress("0x5678")
+	newTx := func(to ethcommon.Address, value *big.Int, data []byte) *ethtypes.Transaction {
+		return ethtypes.NewTx(&ethtypes.LegacyTx{To: &to, Value: value, Data: data})
+	}
+
+	// gas deposit to the TSS address
+	inbound, err := DecodeUnconfirmedInbound(newTx(tss, big.NewInt(1000), nil), sender, 1, connector, custody, tss)
+	require.NoError(t, err)
+	require.Equal(t, common.CoinType_Gas.String(), inbound.CoinType)
+	require.Equal(t, "1000", inbound.Amount)
+	require.Equal(t, sender.Hex(), inbound.Receiver)
+
+	// send() on the connector
+	connectorABI, err := zetaconnector.ZetaConnectorNonEthMetaData.GetAbi()
+	require.NoError(t, err)
+	data, err := connectorABI.Pack("send", zetaconnector.ZetaInterfacesSendInput{
+		DestinationChainId:  big.NewInt(7001),
+		DestinationAddress:  receiver.Bytes(),
+		DestinationGasLimit: big.NewInt(90000),
+		Message:             []byte{},
+		ZetaValueAndGas:     big.NewInt(42),
+		ZetaParams:          []byte{},
+	})
+	require.NoError(t, err)
+	inbound, err = DecodeUnconfirmedInbound(newTx(connector, big.NewInt(0), data), sender, 1, connector, custody, tss)
+	require.NoError(t, err)
+	require.Equal(t, common.CoinType_Zeta.String(), inbound.CoinType)
+	require.Equal(t, "42", inbound.Amount)
+	require.Equal(t, receiver.Hex(), inbound.Receiver)
+	require.Equal(t, int64(7001), inbound.ReceiverChainID)
+
+	// deposit() on the custody contract
+	custodyABI, err := erc20custody.ERC20CustodyMetaData.GetAbi()
+	require.NoError(t, err)
+	asset := ethcommon.HexToAddress("0x9999")
+	data, err = custodyABI.Pack("deposit", receiver.Bytes(), asset, big.NewInt(100), []byte{})
+	require.NoError(t, err)
+	inbound, err = DecodeUnconfirmedInbound(newTx(custody, big.NewInt(0), data), sender, 1, connector, custody, tss)
+	require.NoError(t, err)
+	require.Equal(t, common.CoinType_ERC20.String(), inbound.CoinType)
+	require.Equal(t, asset.Hex(), inbound.Asset)
+	require.Equal(t, "100", inbound.Amount)
+
+	// other calls and other contracts are not inbound txs
+	inbound, err = DecodeUnconfirmedInbound(newTx(custody, big.NewInt(0), []byte{0x01, 0x02, 0x03, 0x04}), sender, 1, connector, custody, tss)
+	require.NoError(t, err)
+	require.Nil(t, inbound)
+	inbound, err = DecodeUnconfirmedInbound(newTx(receiver, big.NewInt(1000), nil), sender, 1, connector, custody, tss)
+	require.NoError(t, err)
+	require.Nil(t, inbound)
+}
+
+func TestMempoolTracker(t *testing.T) {
+	tracker := NewMempoolTracker()
+	now := time.Now()
+	hash1 := ethcommon.HexToHash("0x01")
+	hash2 := ethcommon.HexToHash("0x02")
+	require.True(t, tracker.Add(hash2, clienttypes.UnconfirmedInbound{TxHash: hash2.Hex(), FirstSeen: now}))
+	require.True(t, tracker.Add(hash1, clienttypes.UnconfirmedInbound{TxHash: hash1.Hex(), FirstSeen: now.Add(-time.Hour)}))
+	require.False(t, tracker.Add(hash1, clienttypes.UnconfirmedInbound{TxHash: hash1.Hex(), FirstSeen: now}))
+
+	list := tracker.List()
+	require.Len(t, list, 2)
+	require.Equal(t, hash1.Hex(), list[0].TxHash)
+
+	tracker.Expire(now, MempoolTxTTL)
+	require.Equal(t, []ethcommon.Hash{hash2}, tracker.Hashes())
+
+	tracker.Remove(hash2)
+	require.Empty(t, tracker.List())
+}
@@ -0,0 +1,97 @@
+package zetaclient
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rs/zerolog"
+	"github.com/zeta-chain/zetacore/common"
+)
+
+// postOutboundFailed posts the failure of a reverted outbound tx, so that zetacore reverts its cctx and refunds the
+// sender on the origin chain, and publishes it to the webhooks with the revert reason
+func (ob *EVMChainClient) postOutboundFailed(sendHash string, nonce uint64, coinType common.CoinType, receipt *ethtypes.Receipt, transaction *ethtypes.Transaction, logger zerolog.Logger) {
+	logger.Info().Msgf("Found (failed tx) sendHash %s on chain %s txhash %s", sendHash, ob.chain.String(), receipt.TxHash.Hex())
+	reason := ob.outboundRevertReason(receipt, transaction)
+	if reason != "" {
+		logger.Warn().Msgf("outbound tx %s reverted: %s", receipt.TxHash.Hex(), reason)
+	}
+	start := time.Now()
+	zetaTxHash, err := ob.zetaClient.PostReceiveConfirmation(OutboundConfirmation{
+		SendHash:          sendHash,
+		OutTxHash:         receipt.TxHash.Hex(),
+		OutBlockHeight:    receipt.BlockNumber.Uint64(),
+		GasUsed:           receipt.GasUsed,
+		EffectiveGasPrice: transaction.GasPrice(),
+		EffectiveGasLimit: transaction.Gas(),
+		Amount:            big.NewInt(0),
+		Status:            common.ReceiveStatus_Failed,
+		Chain:             ob.chain,
+		Nonce:             nonce,
+		CoinType:          coinType,
+	})
+	ob.recordEventPosted(EventNameOutboundFailed, sendHash, start, zetaTxHash, err)
+	if err != nil {
+		logger.Error().Err(err).Msgf("PostReceiveConfirmation error in WatchTxHashWithTimeout; zeta tx hash %s", zetaTxHash)
+		return
+	}
+	logger.Info().Msgf("Zeta tx hash: %s cctx %s nonce %d", zetaTxHash, sendHash, nonce)
+	ob.webhooks.Publish(WebhookEvent{
+		Type:        WebhookEventOutboundFailed,
+		ChainID:     ob.chain.ChainId,
+		Chain:       ob.chain.Name(),
+		TxHash:      receipt.TxHash.Hex(),
+		BlockNumber: receipt.BlockNumber.Uint64(),
+		CoinType:    coinType.String(),
+		Amount:      "0",
+		Message:     reason,
+		CctxIndex:   sendHash,
+		ZetaTxHash:  zetaTxHash,
+	})
+}
+
+// outboundRevertReason returns why an outbound tx reverted, replaying it on the state before its block; it is empty
+// if the replay succeeds, e.g. if the tx reverted because of the txs before it in the block
+func (ob *EVMChainClient) outboundRevertReason(receipt *ethtypes.Receipt, transaction *ethtypes.Transaction) string {
+	if receipt.GasUsed == transaction.Gas() {
+		return "out of gas"
+	}
+	if transaction.To() == nil || receipt.BlockNumber.Sign() == 0 {
+		return ""
+	}
+	from, err := ethtypes.Sender(ethtypes.LatestSignerForChainID(big.NewInt(ob.chain.ChainId)), transaction)
+	if err != nil {
+		return ""
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	call := ethereum.CallMsg{From: from, To: transaction.To(), Gas: transaction.Gas(), Value: transaction.Value(), Data: transaction.Data()}
+	_, err = ob.evmClient.CallContract(ctx, call, new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1)))
+	if err == nil {
+		return ""
+	}
+	return revertReason(err)
+}
+
+// revertReason returns the reason of a reverted call: the message of its Error(string) revert data if any, the error
+// otherwise
+func revertReason(err error) string {
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			if revertData, decodeErr := hexutil.Decode(data); decodeErr == nil {
+				if reason, unpackErr := abi.UnpackRevert(revertData); unpackErr == nil {
+					return reason
+				}
+			}
+		}
+	}
+	return err.Error()
+}
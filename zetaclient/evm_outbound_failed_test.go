@@ -0,0 +1,33 @@
+package zetaclient
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+type revertError struct {
+	data string
+}
+
+func (e revertError) Error() string          { return "execution reverted" }
+func (e revertError) ErrorData() interface{} { return e.data }
+
+func TestRevertReason(t *testing.T) {
+	stringType, err := abi.NewType("string", "", nil)
+	require.NoError(t, err)
+	reason, err := abi.Arguments{{Type: stringType}}.Pack("insufficient balance")
+	require.NoError(t, err)
+	data := hexutil.Encode(append([]byte{0x08, 0xc3, 0x79, 0xa0}, reason...))
+
+	require.Equal(t, "insufficient balance", revertReason(revertError{data: data}))
+	require.Equal(t, "insufficient balance", revertReason(fmt.Errorf("call failed: %w", revertError{data: data})))
+
+	// the error itself if there is no revert message
+	require.Equal(t, "execution reverted", revertReason(revertError{data: "0x"}))
+	require.Equal(t, "connection refused", revertReason(errors.New("connection refused")))
+}
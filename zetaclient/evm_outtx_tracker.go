@@ -0,0 +1,183 @@
+package zetaclient
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// PendingOutTxDropTimeout is how long a broadcast outbound tx may be unknown to the rpc endpoint before it is considered
+// dropped from the mempool and its cctx is signed again
+const PendingOutTxDropTimeout = 10 * time.Minute
+
+// PendingOutTx is an outbound tx broadcast by this signer and not yet mined
+type PendingOutTx struct {
+	Nonce       uint64
+	TxHash      ethcommon.Hash
+	CctxIndex   string
+	Broadcasted time.Time
+}
+
+// PendingOutTxTracker follows the outbound txs broadcast by the signer of a chain until they are mined.
+// A nonce can have several txs, e.g. when the cctx is signed again with a higher gas price
+type PendingOutTxTracker struct {
+	mu      sync.Mutex
+	pending map[uint64]map[ethcommon.Hash]PendingOutTx
+	dropped map[uint64]bool
+}
+
+func NewPendingOutTxTracker() *PendingOutTxTracker {
+	return &PendingOutTxTracker{
+		pending: make(map[uint64]map[ethcommon.Hash]PendingOutTx),
+		dropped: make(map[uint64]bool),
+	}
+}
+
+// Add starts following a broadcast tx; the nonce is no longer considered dropped
+func (t *PendingOutTxTracker) Add(tx PendingOutTx) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.pending[tx.Nonce] == nil {
+		t.pending[tx.Nonce] = make(map[ethcommon.Hash]PendingOutTx)
+	}
+	if _, found := t.pending[tx.Nonce][tx.TxHash]; !found {
+		t.pending[tx.Nonce][tx.TxHash] = tx
+	}
+	delete(t.dropped, tx.Nonce)
+}
+
+// Remove stops following all the txs of a nonce
+func (t *PendingOutTxTracker) Remove(nonce uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.pending, nonce)
+	delete(t.dropped, nonce)
+}
+
+// Drop stops following a tx no longer known to the chain; the nonce is marked as dropped once none of its txs is left
+func (t *PendingOutTxTracker) Drop(nonce uint64, txHash ethcommon.Hash) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.pending[nonce], txHash)
+	if len(t.pending[nonce]) == 0 {
+		delete(t.pending, nonce)
+		t.dropped[nonce] = true
+	}
+}
+
+// IsDropped returns true if all the txs broadcast for nonce were dropped
+func (t *PendingOutTxTracker) IsDropped(nonce uint64) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.dropped[nonce]
+}
+
+// List returns the pending txs ordered by nonce
+func (t *PendingOutTxTracker) List() []PendingOutTx {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var txs []PendingOutTx
+	for _, byHash := range t.pending {
+		for _, tx := range byHash {
+			txs = append(txs, tx)
+		}
+	}
+	sort.Slice(txs, func(i, j int) bool {
+		if txs[i].Nonce != txs[j].Nonce {
+			return txs[i].Nonce < txs[j].Nonce
+		}
+		return txs[i].Broadcasted.Before(txs[j].Broadcasted)
+	})
+	return txs
+}
+
+// TrackOutTx follows an outbound tx broadcast by the signer until it is mined or dropped
+func (ob *EVMChainClient) TrackOutTx(nonce uint64, txHash ethcommon.Hash, cctxIndex string) {
+	ob.pendingOutTxs.Add(PendingOutTx{
+		Nonce:       nonce,
+		TxHash:      txHash,
+		CctxIndex:   cctxIndex,
+		Broadcasted: time.Now(),
+	})
+}
+
+// IsOutTxDropped returns true if the outbound txs of nonce broadcast by the signer were dropped; the cctx must be signed again
+func (ob *EVMChainClient) IsOutTxDropped(nonce uint64) bool {
+	return ob.pendingOutTxs.IsDropped(nonce)
+}
+
+// WatchPendingOutTx checks the outbound txs broadcast by the signer on every tick. The receipts of mined txs are
+// recorded for IsSendOutTxProcessed to post the confirmation to zetacore, without waiting for the outtx tracker of zetacore
+func (ob *EVMChainClient) WatchPendingOutTx() {
+	ticker := NewDynamicTicker(fmt.Sprintf("EVM_WatchPendingOutTx_%d", ob.chain.ChainId), ob.GetCoreParams().OutTxTicker)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C():
+			ob.checkPendingOutTxs()
+			ticker.UpdateInterval(ob.GetCoreParams().OutTxTicker, ob.logger.ObserveOutTx)
+		case <-ob.stop:
+			ob.logger.ObserveOutTx.Info().Msg("WatchPendingOutTx stopped")
+			return
+		}
+	}
+}
+
+func (ob *EVMChainClient) checkPendingOutTxs() {
+	pending := ob.pendingOutTxs.List()
+	if len(pending) == 0 {
+		return
+	}
+	// nonce of the next tss tx to be mined
+	var minedNonce uint64
+	err := Retry(ob.ctx, "NonceAt", RPCBackoff, func() (err error) {
+		minedNonce, err = ob.evmClient.NonceAt(ob.ctx, ob.Tss.EVMAddress(), nil)
+		return err
+	})
+	if err != nil {
+		ob.logger.ObserveOutTx.Error().Err(err).Msg("checkPendingOutTxs: error getting tss nonce")
+		return
+	}
+	for _, tx := range pending {
+		logger := ob.logger.ObserveOutTx.With().Uint64("nonce", tx.Nonce).Str("txHash", tx.TxHash.Hex()).Logger()
+		ob.Mu.Lock()
+		_, found := ob.outTXConfirmedReceipts[ob.GetTxID(tx.Nonce)]
+		ob.Mu.Unlock()
+		if found { // recorded by observeOutTx from the outtx tracker of zetacore
+			ob.pendingOutTxs.Remove(tx.Nonce)
+			continue
+		}
+		receipt, transaction, err := ob.queryTxByHash(tx.TxHash.Hex(), tx.Nonce)
+		if err == nil && receipt != nil {
+			ob.Mu.Lock()
+			ob.outTXConfirmedReceipts[ob.GetTxID(tx.Nonce)] = receipt
+			ob.outTXConfirmedTransaction[ob.GetTxID(tx.Nonce)] = transaction
+			ob.Mu.Unlock()
+			ob.pendingOutTxs.Remove(tx.Nonce)
+			logger.Info().Msgf("checkPendingOutTxs: outbound tx of cctx %s mined in block %d", tx.CctxIndex, receipt.BlockNumber)
+			continue
+		}
+		if !errors.Is(err, ethereum.NotFound) { // included but not confirmed yet, or rpc error
+			continue
+		}
+		if tx.Nonce < minedNonce {
+			// the nonce was taken by another tx, e.g. broadcast by another signer; the outtx tracker of zetacore has its hash
+			logger.Info().Msgf("checkPendingOutTxs: nonce of cctx %s mined by another tx", tx.CctxIndex)
+			ob.pendingOutTxs.Remove(tx.Nonce)
+			continue
+		}
+		if time.Since(tx.Broadcasted) < PendingOutTxDropTimeout {
+			continue
+		}
+		_, _, err = ob.evmClient.TransactionByHash(ob.ctx, tx.TxHash)
+		if errors.Is(err, ethereum.NotFound) {
+			logger.Warn().Msgf("checkPendingOutTxs: outbound tx of cctx %s dropped from mempool; it will be signed again", tx.CctxIndex)
+			ob.pendingOutTxs.Drop(tx.Nonce, tx.TxHash)
+		}
+	}
+}
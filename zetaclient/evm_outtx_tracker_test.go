@@ -0,0 +1,37 @@
+package zetaclient
+
+import (
+	"testing"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingOutTxTracker(t *testing.T) {
+	tracker := NewPendingOutTxTracker()
+	now := time.Now()
+	tx1 := PendingOutTx{Nonce: 5, TxHash: ethcommon.HexToHash("0x01"), CctxIndex: "0xc1", Broadcasted: now}
+	tx2 := PendingOutTx{Nonce: 3, TxHash: ethcommon.HexToHash("0x02"), CctxIndex: "0xc2", Broadcasted: now}
+	// nonce 5 signed again with a higher gas price
+	tx3 := PendingOutTx{Nonce: 5, TxHash: ethcommon.HexToHash("0x03"), CctxIndex: "0xc1", Broadcasted: now.Add(time.Minute)}
+	tracker.Add(tx1)
+	tracker.Add(tx2)
+	tracker.Add(tx3)
+	require.Equal(t, []PendingOutTx{tx2, tx1, tx3}, tracker.List())
+
+	// the nonce is dropped only once all its txs are
+	tracker.Drop(5, tx1.TxHash)
+	require.False(t, tracker.IsDropped(5))
+	tracker.Drop(5, tx3.TxHash)
+	require.True(t, tracker.IsDropped(5))
+	require.Equal(t, []PendingOutTx{tx2}, tracker.List())
+
+	// signing again clears the dropped nonce
+	tracker.Add(tx1)
+	require.False(t, tracker.IsDropped(5))
+
+	tracker.Remove(5)
+	tracker.Remove(3)
+	require.Empty(t, tracker.List())
+}
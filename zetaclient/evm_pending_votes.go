@@ -0,0 +1,179 @@
+package zetaclient
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zeta-chain/zetacore/x/crosschain/types"
+	metricsPkg "github.com/zeta-chain/zetacore/zetaclient/metrics"
+	clienttypes "github.com/zeta-chain/zetacore/zetaclient/types"
+)
+
+// PendingVoteBackoff schedules the retries of the inbound votes whose post to zetacore failed, one attempt per due
+// vote and tick; a vote is dead-lettered once its retries are exhausted
+var PendingVoteBackoff = Backoff{
+	InitialInterval: 10 * time.Second,
+	MaxInterval:     10 * time.Minute,
+	Multiplier:      2,
+	Jitter:          0.2,
+	MaxRetries:      10,
+}
+
+// enqueuePendingVote persists an inbound vote whose post to zetacore failed so that it is retried in the next ticks
+// rather than lost once its range is scanned. Enqueuing a vote already pending keeps its retry schedule. A vote
+// zetacore rejected on its own, ErrVoteRejected, is dead-lettered right away since posting it again would fail again
+func (ob *EVMChainClient) enqueuePendingVote(key string, blockNumber uint64, gasLimit uint64, msg *types.MsgVoteOnObservedInboundTx, postErr error) error {
+	if ob.db == nil {
+		return errors.New("no observer db")
+	}
+	data, err := msg.Marshal()
+	if err != nil {
+		return err
+	}
+	record := clienttypes.ToPendingVoteSQLType(key, blockNumber, gasLimit, data, time.Now().Add(PendingVoteBackoff.Interval(0)), postErr)
+	record.DeadLettered = errors.Is(postErr, ErrVoteRejected)
+	result := ob.db.Where(&clienttypes.PendingVoteSQLType{Key: key}).FirstOrCreate(record)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil
+	}
+	if record.DeadLettered {
+		metricsPkg.PendingVotes.WithLabelValues(ob.chain.Name(), metricsPkg.PendingVoteDeadLettered).Inc()
+		ob.logger.ExternalChainWatcher.Error().Err(postErr).Msgf("enqueuePendingVote: vote on inbound tx %s rejected by zetacore, dead-lettered", msg.InTxHash)
+		return nil
+	}
+	metricsPkg.PendingVotes.WithLabelValues(ob.chain.Name(), metricsPkg.PendingVoteEnqueued).Inc()
+	ob.logger.ExternalChainWatcher.Warn().Err(postErr).Msgf("enqueuePendingVote: vote on inbound tx %s enqueued for retry", msg.InTxHash)
+	return nil
+}
+
+// isVotePending returns true if the vote is waiting for a retry; dead-lettered votes are not pending
+func (ob *EVMChainClient) isVotePending(key string) bool {
+	if ob.db == nil {
+		return false
+	}
+	var count int64
+	err := ob.db.Model(&clienttypes.PendingVoteSQLType{}).Where(&clienttypes.PendingVoteSQLType{Key: key}).Where("dead_lettered = ?", false).Count(&count).Error
+	if err != nil {
+		ob.logger.ExternalChainWatcher.Error().Err(err).Msgf("isVotePending: error reading pending vote %s from db", key)
+		return false
+	}
+	return count > 0
+}
+
+// countPendingVotes returns the number of votes of blocks up to block still waiting for a retry
+func (ob *EVMChainClient) countPendingVotes(block int64) (int64, error) {
+	if ob.db == nil || block < 0 {
+		return 0, nil
+	}
+	var count int64
+	// #nosec G701 checked positive
+	err := ob.db.Model(&clienttypes.PendingVoteSQLType{}).Where("block_number <= ? AND dead_lettered = ?", uint64(block), false).Count(&count).Error
+	return count, err
+}
+
+// retryPendingVotes posts again the pending votes whose retry is due. A vote leaves the queue once posted and is
+// dead-lettered, for the operator to follow up, once its retries are exhausted or once zetacore rejects it on its own. The votes stay queued, without
+// spending their attempts, while zetacore is unavailable or the chain is above the post rate limit
+func (ob *EVMChainClient) retryPendingVotes(now time.Time) {
+	if ob.db == nil {
+		return
+	}
+	var votes []clienttypes.PendingVoteSQLType
+	err := ob.db.Where("dead_lettered = ? AND next_attempt <= ?", false, now).Order("block_number").Find(&votes).Error
+	if err != nil {
+		ob.logger.ExternalChainWatcher.Error().Err(err).Msg("retryPendingVotes: error reading pending votes from db")
+		return
+	}
+	for _, vote := range votes {
+		if ob.ctx != nil && ob.ctx.Err() != nil {
+			return
+		}
+		if !ob.retryPendingVote(vote, now) {
+			return
+		}
+	}
+}
+
+// retryPendingVote posts a pending vote again and updates its record with the outcome; it returns false if zetacore
+// is unavailable or the chain is above the post rate limit
+func (ob *EVMChainClient) retryPendingVote(vote clienttypes.PendingVoteSQLType, now time.Time) bool {
+	var msg types.MsgVoteOnObservedInboundTx
+	err := msg.Unmarshal(vote.Msg)
+	if err != nil {
+		err = fmt.Errorf("undecodable vote: %w", err)
+		vote.Attempts = PendingVoteBackoff.MaxRetries
+	} else {
+		var zetaHash string
+		zetaHash, err = ob.postInboundVote(vote.GasLimit, &msg)
+		if err == nil {
+			ob.setPendingVotePosted(vote, zetaHash)
+			return true
+		}
+		if errors.Is(err, ErrCoreUnavailable) || errors.Is(err, ErrPostRateLimited) {
+			return false
+		}
+		if errors.Is(err, ErrVoteRejected) {
+			vote.Attempts = PendingVoteBackoff.MaxRetries
+		}
+	}
+	vote.Attempts++
+	vote.Error = err.Error()
+	if vote.Attempts >= PendingVoteBackoff.MaxRetries {
+		vote.DeadLettered = true
+		metricsPkg.PendingVotes.WithLabelValues(ob.chain.Name(), metricsPkg.PendingVoteDeadLettered).Inc()
+		ob.logger.ExternalChainWatcher.Error().Err(err).Msgf("retryPendingVote: vote %s of block %d dead-lettered after %d attempts",
+			vote.Key, vote.BlockNumber, vote.Attempts)
+	} else {
+		vote.NextAttempt = now.Add(PendingVoteBackoff.Interval(vote.Attempts))
+		ob.logger.ExternalChainWatcher.Warn().Err(err).Msgf("retryPendingVote: attempt %d of vote %s failed, next attempt at %s",
+			vote.Attempts, vote.Key, vote.NextAttempt.Format(time.RFC3339))
+	}
+	if err := ob.db.Save(&vote).Error; err != nil {
+		ob.logger.ExternalChainWatcher.Error().Err(err).Msgf("retryPendingVote: error writing pending vote %s to db", vote.Key)
+	}
+	return true
+}
+
+// setPendingVotePosted removes a posted vote from the queue and records its zeta tx on the inbound event, if any
+func (ob *EVMChainClient) setPendingVotePosted(vote clienttypes.PendingVoteSQLType, zetaHash string) {
+	metricsPkg.PendingVotes.WithLabelValues(ob.chain.Name(), metricsPkg.PendingVotePosted).Inc()
+	ob.logger.ExternalChainWatcher.Info().Msgf("retryPendingVote: vote %s posted after %d failed attempts: zeta tx %s", vote.Key, vote.Attempts+1, zetaHash)
+	if zetaHash != "" {
+		err := ob.db.Model(&clienttypes.InboundEventSQLType{}).Where(&clienttypes.InboundEventSQLType{Key: vote.Key}).Update("zeta_hash", zetaHash).Error
+		if err != nil {
+			ob.logger.ExternalChainWatcher.Error().Err(err).Msgf("retryPendingVote: error writing inbound event %s to db", vote.Key)
+		}
+	}
+	// delete permanently so that the unique key can be enqueued again
+	if err := ob.db.Unscoped().Delete(&vote).Error; err != nil {
+		ob.logger.ExternalChainWatcher.Error().Err(err).Msgf("retryPendingVote: error deleting pending vote %s from db", vote.Key)
+	}
+}
+
+// forgetPendingVotesAfter drops the pending votes of blocks after block, e.g. reverted by a reorg
+func (ob *EVMChainClient) forgetPendingVotesAfter(block int64) {
+	if ob.db == nil || block < 0 {
+		return
+	}
+	// #nosec G701 checked positive
+	err := ob.db.Unscoped().Where("block_number > ?", uint64(block)).Delete(&clienttypes.PendingVoteSQLType{}).Error
+	if err != nil {
+		ob.logger.ExternalChainWatcher.Error().Err(err).Msgf("forgetPendingVotesAfter: error deleting pending votes after block %d from db", block)
+	}
+}
+
+// GetPendingVotes returns the inbound votes waiting for a retry and the dead-lettered ones in block order
+func (ob *EVMChainClient) GetPendingVotes() ([]clienttypes.PendingVoteSQLType, error) {
+	if ob.db == nil {
+		return nil, errors.New("no observer db")
+	}
+	var votes []clienttypes.PendingVoteSQLType
+	if err := ob.db.Order("block_number").Find(&votes).Error; err != nil {
+		return nil, err
+	}
+	return votes, nil
+}
@@ -0,0 +1,160 @@
+package zetaclient
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/zeta-chain/zetacore/x/crosschain/types"
+	metricsPkg "github.com/zeta-chain/zetacore/zetaclient/metrics"
+	clienttypes "github.com/zeta-chain/zetacore/zetaclient/types"
+)
+
+// quarantineInboundEvent persists, with StrictDecoding, the raw log of an inbound event that could not be decoded into
+// a vote so that it isn't lost once the range is scanned; the events deliberately rejected are not quarantined, the
+// throttled ones always are
+func (ob *EVMChainClient) quarantineInboundEvent(contract *watchedContract, key string, vLog ethtypes.Log, err error) {
+	record := clienttypes.ToQuarantinedEventSQLType(key, contract.kind, vLog, err)
+	if ob.quarantine(record, contract.event.Name, err) {
+		ob.logger.ExternalChainWatcher.Warn().Msgf("quarantineInboundEvent: %s event %s of %s contract %s quarantined",
+			contract.event.Name, key, contract.kind, contract.address.Hex())
+	}
+}
+
+// quarantineGasDeposit persists a gas token deposit to the TSS address that could not be voted on, like
+// quarantineInboundEvent does for the event logs
+func (ob *EVMChainClient) quarantineGasDeposit(txHash ethcommon.Hash, value *big.Int, receipt *ethtypes.Receipt, from ethcommon.Address, data []byte, eventIndex uint, err error) {
+	key := clienttypes.GasDepositKey(txHash, eventIndex)
+	vLog := ethtypes.Log{
+		Address:     from,
+		Data:        data,
+		BlockNumber: receipt.BlockNumber.Uint64(),
+		BlockHash:   receipt.BlockHash,
+		TxHash:      txHash,
+		TxIndex:     receipt.TransactionIndex,
+		Index:       eventIndex,
+	}
+	record := clienttypes.ToQuarantinedEventSQLType(key, clienttypes.QuarantinedKindGasDeposit, vLog, err)
+	record.Value = value.String()
+	if ob.quarantine(record, EventNameGasDeposit, err) {
+		ob.logger.ExternalChainWatcher.Warn().Msgf("quarantineGasDeposit: gas deposit %s from %s quarantined", key, from.Hex())
+	}
+}
+
+// quarantine writes the record of an event that could not be voted on, and returns whether it is quarantined
+func (ob *EVMChainClient) quarantine(record *clienttypes.QuarantinedEventSQLType, eventName string, err error) bool {
+	evmCfg, _ := ob.cfg.GetEVMConfig(ob.chain.ChainId)
+	quarantined := evmCfg.StrictDecoding || errors.Is(err, ErrInboundThrottled) || errors.Is(err, ErrInboundProofRejected)
+	if !quarantined || errors.Is(err, ErrInboundRejected) || ob.db == nil {
+		return false
+	}
+	if err := ob.db.Where(&clienttypes.QuarantinedEventSQLType{Key: record.Key}).Assign(record).FirstOrCreate(&clienttypes.QuarantinedEventSQLType{}).Error; err != nil {
+		ob.logger.ExternalChainWatcher.Error().Err(err).Msgf("quarantine: error writing inbound event %s to db", record.Key)
+		return false
+	}
+	metricsPkg.QuarantinedEvents.WithLabelValues(ob.chain.Name(), eventName).Inc()
+	return true
+}
+
+// GetQuarantinedEvents returns the quarantined inbound events in block order
+func (ob *EVMChainClient) GetQuarantinedEvents() ([]clienttypes.QuarantinedEventSQLType, error) {
+	if ob.db == nil {
+		return nil, errors.New("no observer db")
+	}
+	var events []clienttypes.QuarantinedEventSQLType
+	if err := ob.db.Order("block_number, log_index").Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// ReprocessQuarantinedEvent decodes a quarantined inbound event again, e.g. after a decoder fix, and posts its vote.
+// The event leaves the quarantine once posted; it stays there if it still can't be decoded
+func (ob *EVMChainClient) ReprocessQuarantinedEvent(key string) (string, error) {
+	if ob.db == nil {
+		return "", errors.New("no observer db")
+	}
+	var record clienttypes.QuarantinedEventSQLType
+	if err := ob.db.Where(&clienttypes.QuarantinedEventSQLType{Key: key}).First(&record).Error; err != nil {
+		return "", fmt.Errorf("quarantined event %s not found: %w", key, err)
+	}
+	vLog, err := clienttypes.FromQuarantinedEventSQLType(record)
+	if err != nil {
+		return "", err
+	}
+	canonical, err := ob.isLogCanonical(vLog)
+	if err != nil {
+		return "", err
+	}
+	if !canonical {
+		return "", fmt.Errorf("block %d of quarantined event %s is no longer canonical", vLog.BlockNumber, key)
+	}
+	start := time.Now()
+	var (
+		eventName string
+		msg       *types.MsgVoteOnObservedInboundTx
+		gasLimit  uint64
+	)
+	if record.Kind == clienttypes.QuarantinedKindGasDeposit {
+		eventName, gasLimit = EventNameGasDeposit, PostSendEVMGasLimit
+		msg, err = ob.getQuarantinedGasDepositVoteMsg(record, vLog)
+	} else {
+		var contracts WatchedContracts
+		contracts, err = ob.getWatchedContracts()
+		if err != nil {
+			return "", err
+		}
+		contract, found := contracts.Match(vLog)
+		if !found {
+			return "", fmt.Errorf("quarantined event %s is not an inbound event of a watched contract", key)
+		}
+		eventName = contract.event.Name
+		msg, gasLimit, err = ob.getInboundVoteMsgForLog(contract, vLog)
+	}
+	if err == nil {
+		err = ob.checkInboundCap(msg)
+	}
+	if err == nil {
+		err = ob.screenInbound(msg)
+	}
+	if err != nil {
+		ob.recordEventDecoded(eventName, "", start, err)
+		return "", err
+	}
+	correlationID := InboundCorrelationID(msg)
+	ob.recordEventDecoded(eventName, correlationID, start, nil)
+	start = time.Now()
+	zetaHash, err := ob.postInboundVote(gasLimit, msg)
+	ob.recordEventPosted(eventName, correlationID, start, zetaHash, err)
+	if err != nil {
+		return "", err
+	}
+	// the gas deposits are not recorded as processed events, the votes of a range scanned again are ballots already
+	// voted on
+	if record.Kind != clienttypes.QuarantinedKindGasDeposit {
+		ob.setInboundEventProcessed(key, vLog.BlockNumber, zetaHash)
+	}
+	if err := ob.db.Unscoped().Delete(&record).Error; err != nil {
+		return zetaHash, err
+	}
+	return zetaHash, nil
+}
+
+// getQuarantinedGasDepositVoteMsg returns the vote for a quarantined gas token deposit, checking its tx receipt again
+func (ob *EVMChainClient) getQuarantinedGasDepositVoteMsg(record clienttypes.QuarantinedEventSQLType, vLog ethtypes.Log) (*types.MsgVoteOnObservedInboundTx, error) {
+	value, ok := new(big.Int).SetString(record.Value, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid value %q of quarantined gas deposit %s", record.Value, record.Key)
+	}
+	receipt, err := ob.evmClient.TransactionReceipt(ob.ctx, vLog.TxHash)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("tx %s of quarantined gas deposit %s failed", vLog.TxHash.Hex(), record.Key)
+	}
+	return ob.GetInboundVoteMsgForTokenSentToTSS(vLog.TxHash, value, receipt, vLog.Address, vLog.Data, vLog.Index)
+}
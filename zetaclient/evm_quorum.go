@@ -0,0 +1,166 @@
+package zetaclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	metricsPkg "github.com/zeta-chain/zetacore/zetaclient/metrics"
+)
+
+// ErrQuorumNotReached is returned when too few rpc endpoints agree on a block range to vote on it
+var ErrQuorumNotReached = errors.New("rpc read quorum not reached")
+
+// QuorumRPCClient is implemented by the rpc clients able to send the same requests to several endpoints
+type QuorumRPCClient interface {
+	BatchCallEach(ctx context.Context, newBatch func() []rpc.BatchElem) ([][]rpc.BatchElem, []error)
+}
+
+// rangeReading is what an endpoint returned for a block range: its headers and the inbound logs of the range
+type rangeReading struct {
+	headers []*ethtypes.Header
+	logs    []ethtypes.Log
+}
+
+// digest identifies a reading; two endpoints agree if their readings have the same digest
+func (r *rangeReading) digest() (ethcommon.Hash, error) {
+	data := make([]byte, 0, len(r.headers)*ethcommon.HashLength)
+	for _, header := range r.headers {
+		data = append(data, header.Hash().Bytes()...)
+	}
+	logs, err := json.Marshal(r.logs)
+	if err != nil {
+		return ethcommon.Hash{}, err
+	}
+	return crypto.Keccak256Hash(data, logs), nil
+}
+
+// rangeQuorum is the reading of a block range a quorum of endpoints agreed on
+type rangeQuorum struct {
+	hashes map[uint64]ethcommon.Hash
+	query  *ethereum.FilterQuery // nil if the logs were not read
+	logs   []ethtypes.Log
+}
+
+// logsOf returns the agreed logs if they were read with query
+func (q *rangeQuorum) logsOf(query ethereum.FilterQuery) ([]ethtypes.Log, bool) {
+	if q == nil || q.query == nil {
+		return nil, false
+	}
+	batch := tickBatch{query: q.query, logs: q.logs}
+	return batch.logsOf(query)
+}
+
+// selectQuorum returns the index of an endpoint whose reading at least quorum endpoints share, or -1 if none is.
+// Endpoints that failed to answer don't count
+func selectQuorum(digests []ethcommon.Hash, errs []error, quorum int) int {
+	counts := make(map[ethcommon.Hash]int)
+	for i, digest := range digests {
+		if errs[i] == nil {
+			counts[digest]++
+		}
+	}
+	for i, digest := range digests {
+		if errs[i] == nil && counts[digest] >= quorum {
+			return i
+		}
+	}
+	return -1
+}
+
+// readRangeQuorum reads the headers and the inbound logs of [startBlock, toBlock] from every endpoint and returns the
+// reading at least quorum endpoints agree on. A disagreement is counted in metrics and returns ErrQuorumNotReached
+func (ob *EVMChainClient) readRangeQuorum(startBlock, toBlock int64, quorum int) (*rangeQuorum, error) {
+	client, ok := ob.evmClient.(QuorumRPCClient)
+	if !ok {
+		return nil, fmt.Errorf("rpc client can't read from several endpoints")
+	}
+	result := &rangeQuorum{}
+	if contracts, err := ob.getWatchedContracts(); err == nil {
+		if query, ok := BuildInboundFilterQuery(startBlock, toBlock, contracts.Addresses(), contracts.Topics()); ok {
+			result.query = &query
+		}
+	}
+
+	// the results are bound to the batch elements of each endpoint by newBatch
+	var readings []*rangeReading
+	newBatch := func() []rpc.BatchElem {
+		reading := &rangeReading{headers: make([]*ethtypes.Header, toBlock-startBlock+1)}
+		readings = append(readings, reading)
+		elems := make([]rpc.BatchElem, 0, len(reading.headers)+1)
+		for bn := startBlock; bn <= toBlock; bn++ {
+			elems = append(elems, rpc.BatchElem{
+				Method: "eth_getBlockByNumber",
+				// #nosec G701 always positive
+				Args:   []interface{}{hexutil.EncodeUint64(uint64(bn)), false},
+				Result: &reading.headers[bn-startBlock],
+			})
+		}
+		if result.query != nil {
+			elems = append(elems, rpc.BatchElem{Method: "eth_getLogs", Args: []interface{}{filterQueryArg(*result.query)}, Result: &reading.logs})
+		}
+		return elems
+	}
+	batches, errs := client.BatchCallEach(ob.ctx, newBatch)
+
+	digests := make([]ethcommon.Hash, len(batches))
+	for i, batch := range batches {
+		if errs[i] == nil {
+			errs[i] = checkBatchElems(batch)
+		}
+		if errs[i] == nil {
+			digests[i], errs[i] = readings[i].digest()
+		}
+		if errs[i] != nil {
+			ob.logger.ExternalChainWatcher.Warn().Err(errs[i]).Msgf("readRangeQuorum: endpoint %d failed to read block range [%d, %d]", i, startBlock, toBlock)
+		}
+	}
+	agreed := selectQuorum(digests, errs, quorum)
+	if agreed < 0 {
+		metricsPkg.RPCQuorumFailures.WithLabelValues(ob.chain.Name()).Inc()
+		ob.logger.ExternalChainWatcher.Error().Msgf("readRangeQuorum: less than %d of the %d endpoints agree on block range [%d, %d]; withholding votes",
+			quorum, len(batches), startBlock, toBlock)
+		return nil, fmt.Errorf("%w for block range [%d, %d]", ErrQuorumNotReached, startBlock, toBlock)
+	}
+
+	result.hashes = make(map[uint64]ethcommon.Hash, len(readings[agreed].headers))
+	for _, header := range readings[agreed].headers {
+		result.hashes[header.Number.Uint64()] = header.Hash()
+	}
+	result.logs = readings[agreed].logs
+	return result, nil
+}
+
+// checkBatchElems returns the first error of the requests of a batch; a missing block is an error
+func checkBatchElems(batch []rpc.BatchElem) error {
+	for _, elem := range batch {
+		if elem.Error != nil {
+			return elem.Error
+		}
+		if header, ok := elem.Result.(**ethtypes.Header); ok && *header == nil {
+			return ethereum.NotFound
+		}
+	}
+	return nil
+}
+
+// mergeVerifiedHashes returns the block hashes verified by the header chain and by the read quorum, either may be nil.
+// It fails if they disagree on a block
+func mergeVerifiedHashes(verified, agreed map[uint64]ethcommon.Hash) (map[uint64]ethcommon.Hash, error) {
+	if verified == nil {
+		return agreed, nil
+	}
+	for bn, hash := range agreed {
+		if verifiedHash, found := verified[bn]; found && verifiedHash != hash {
+			return nil, fmt.Errorf("block %d hash %s verified by the header chain differs from %s agreed by the endpoints", bn, verifiedHash.Hex(), hash.Hex())
+		}
+	}
+	return verified, nil
+}
@@ -0,0 +1,38 @@
+package zetaclient
+
+import (
+	"errors"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectQuorum(t *testing.T) {
+	a := ethcommon.HexToHash("0xaa")
+	b := ethcommon.HexToHash("0xbb")
+	down := errors.New("endpoint down")
+
+	// 2-of-3 agree
+	require.Equal(t, 1, selectQuorum([]ethcommon.Hash{b, a, a}, []error{nil, nil, nil}, 2))
+	// all disagree
+	require.Equal(t, -1, selectQuorum([]ethcommon.Hash{a, b, {}}, []error{nil, nil, nil}, 2))
+	// endpoints that failed don't count, whatever their digest
+	require.Equal(t, -1, selectQuorum([]ethcommon.Hash{a, {}, {}}, []error{nil, down, down}, 2))
+	require.Equal(t, 0, selectQuorum([]ethcommon.Hash{a, a, {}}, []error{nil, nil, down}, 2))
+}
+
+func TestMergeVerifiedHashes(t *testing.T) {
+	agreed := map[uint64]ethcommon.Hash{100: ethcommon.HexToHash("0xaa")}
+
+	merged, err := mergeVerifiedHashes(nil, agreed)
+	require.NoError(t, err)
+	require.Equal(t, agreed, merged)
+
+	merged, err = mergeVerifiedHashes(map[uint64]ethcommon.Hash{100: ethcommon.HexToHash("0xaa")}, agreed)
+	require.NoError(t, err)
+	require.Equal(t, agreed, merged)
+
+	_, err = mergeVerifiedHashes(map[uint64]ethcommon.Hash{100: ethcommon.HexToHash("0xbb")}, agreed)
+	require.Error(t, err)
+}
@@ -0,0 +1,106 @@
+package zetaclient
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/zeta-chain/zetacore/x/crosschain/types"
+	metricsPkg "github.com/zeta-chain/zetacore/zetaclient/metrics"
+)
+
+// SenderRateLimitIdle is how long the token bucket of a sender is kept once full again
+const SenderRateLimitIdle = 10 * time.Minute
+
+type senderBucket struct {
+	tokens    float64
+	updated   time.Time
+	throttled bool
+}
+
+// SenderRateLimiter throttles the inbound events of every sender with a token bucket refilled at perMinute tokens
+// per minute and holding up to burst tokens, so that a spammer can't flood zetacore with votes
+type SenderRateLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*senderBucket
+}
+
+func NewSenderRateLimiter() *SenderRateLimiter {
+	return &SenderRateLimiter{buckets: make(map[string]*senderBucket)}
+}
+
+// Allow takes a token from the bucket of the sender. It returns whether the event is allowed, and whether the sender
+// just started bursting above its rate, so that operators are alerted once per burst. A nil limiter allows everything
+func (l *SenderRateLimiter) Allow(sender string, perMinute, burst uint64, now time.Time) (allowed, burstStarted bool) {
+	if l == nil || perMinute == 0 {
+		return true, false
+	}
+	if burst == 0 {
+		burst = perMinute
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.prune(perMinute, burst, now)
+
+	bucket, found := l.buckets[sender]
+	if !found {
+		bucket = &senderBucket{tokens: float64(burst), updated: now}
+		l.buckets[sender] = bucket
+	}
+	bucket.tokens += now.Sub(bucket.updated).Minutes() * float64(perMinute)
+	if bucket.tokens > float64(burst) {
+		bucket.tokens = float64(burst)
+	}
+	bucket.updated = now
+	if bucket.tokens < 1 {
+		burstStarted = !bucket.throttled
+		bucket.throttled = true
+		return false, burstStarted
+	}
+	bucket.tokens--
+	bucket.throttled = false
+	return true, false
+}
+
+// prune forgets the senders whose bucket has been full for SenderRateLimitIdle
+func (l *SenderRateLimiter) prune(perMinute, burst uint64, now time.Time) {
+	refill := time.Duration(float64(burst) / float64(perMinute) * float64(time.Minute))
+	for sender, bucket := range l.buckets {
+		if now.Sub(bucket.updated) > refill+SenderRateLimitIdle {
+			delete(l.buckets, sender)
+		}
+	}
+}
+
+// inboundSender returns the sender an inbound vote is rate limited by: the origin of its tx if known, as contracts
+// relay the transfers of many users, the sender otherwise
+func inboundSender(msg *types.MsgVoteOnObservedInboundTx) string {
+	if msg.TxOrigin != "" {
+		return msg.TxOrigin
+	}
+	return msg.Sender
+}
+
+// checkSenderRateLimit returns ErrInboundThrottled if the sender of the vote is above the SenderRateLimit of the chain
+func (ob *EVMChainClient) checkSenderRateLimit(msg *types.MsgVoteOnObservedInboundTx) error {
+	evmCfg, _ := ob.cfg.GetEVMConfig(ob.chain.ChainId)
+	sender := inboundSender(msg)
+	allowed, burstStarted := ob.senders.Allow(sender, evmCfg.SenderRateLimit, evmCfg.SenderRateBurst, time.Now())
+	if allowed {
+		return nil
+	}
+	metricsPkg.ThrottledInbounds.WithLabelValues(ob.chain.Name()).Inc()
+	if burstStarted {
+		ob.logger.ExternalChainWatcher.Warn().Msgf("checkSenderRateLimit: sender %s is above %d inbound events per minute; throttling",
+			sender, evmCfg.SenderRateLimit)
+		ob.webhooks.Publish(WebhookEvent{
+			Type:    WebhookEventSenderThrottled,
+			ChainID: ob.chain.ChainId,
+			Chain:   ob.chain.Name(),
+			TxHash:  msg.InTxHash,
+			Sender:  sender,
+			Message: fmt.Sprintf("sender above %d inbound events per minute", evmCfg.SenderRateLimit),
+		})
+	}
+	return fmt.Errorf("%w: sender %s of tx %s", ErrInboundThrottled, sender, msg.InTxHash)
+}
@@ -0,0 +1,42 @@
+package zetaclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSenderRateLimiter(t *testing.T) {
+	l := NewSenderRateLimiter()
+	now := time.Now()
+
+	// a burst of 3 events is allowed, then the sender is throttled
+	for i := 0; i < 3; i++ {
+		allowed, _ := l.Allow("0x01", 6, 3, now)
+		require.True(t, allowed)
+	}
+	allowed, burstStarted := l.Allow("0x01", 6, 3, now)
+	require.False(t, allowed)
+	require.True(t, burstStarted)
+	allowed, burstStarted = l.Allow("0x01", 6, 3, now)
+	require.False(t, allowed)
+	require.False(t, burstStarted)
+
+	// other senders are not
+	allowed, _ = l.Allow("0x02", 6, 3, now)
+	require.True(t, allowed)
+
+	// until the bucket refills at 6 events per minute
+	allowed, _ = l.Allow("0x01", 6, 3, now.Add(10*time.Second))
+	require.True(t, allowed)
+	allowed, _ = l.Allow("0x01", 6, 3, now.Add(10*time.Second))
+	require.False(t, allowed)
+
+	// no limit is set or no limiter
+	allowed, _ = l.Allow("0x01", 0, 0, now)
+	require.True(t, allowed)
+	var nilLimiter *SenderRateLimiter
+	allowed, _ = nilLimiter.Allow("0x01", 1, 1, now)
+	require.True(t, allowed)
+}
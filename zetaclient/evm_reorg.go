@@ -0,0 +1,186 @@
+package zetaclient
+
+import (
+	"math/big"
+	"sync"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	clienttypes "github.com/zeta-chain/zetacore/zetaclient/types"
+)
+
+const (
+	// ReorgTrackDepth is the number of recent block hashes kept by the observer to detect chain reorgs
+	ReorgTrackDepth = 256
+)
+
+// BlockHashTracker keeps the hashes of recently scanned blocks so that the observer can detect chain reorgs
+type BlockHashTracker struct {
+	mu     sync.Mutex
+	depth  int64
+	hashes map[int64]ethcommon.Hash
+}
+
+func NewBlockHashTracker(depth int64) *BlockHashTracker {
+	return &BlockHashTracker{
+		depth:  depth,
+		hashes: make(map[int64]ethcommon.Hash),
+	}
+}
+
+// Add records the hash of a block and forgets blocks that are deeper than the tracked depth
+func (t *BlockHashTracker) Add(number int64, hash ethcommon.Hash) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.hashes[number] = hash
+	for bn := range t.hashes {
+		if bn <= number-t.depth {
+			delete(t.hashes, bn)
+		}
+	}
+}
+
+// Get returns the recorded hash of a block
+func (t *BlockHashTracker) Get(number int64) (ethcommon.Hash, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	hash, found := t.hashes[number]
+	return hash, found
+}
+
+// RemoveFrom forgets the hashes of all blocks at or above the given number
+func (t *BlockHashTracker) RemoveFrom(number int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for bn := range t.hashes {
+		if bn >= number {
+			delete(t.hashes, bn)
+		}
+	}
+}
+
+// FindCommonAncestor walks back from block `from` and returns the highest tracked block whose hash still matches the canonical chain
+// If no tracked block matches, it returns the block right below the lowest tracked one and false
+func (t *BlockHashTracker) FindCommonAncestor(from int64, getHash func(int64) (ethcommon.Hash, error)) (int64, bool, error) {
+	t.mu.Lock()
+	hashes := make(map[int64]ethcommon.Hash, len(t.hashes))
+	lowest := from
+	for bn, hash := range t.hashes {
+		hashes[bn] = hash
+		if bn < lowest {
+			lowest = bn
+		}
+	}
+	t.mu.Unlock()
+
+	for bn := from; bn >= lowest; bn-- {
+		tracked, found := hashes[bn]
+		if !found {
+			continue
+		}
+		canonical, err := getHash(bn)
+		if err != nil {
+			return 0, false, err
+		}
+		if canonical == tracked {
+			return bn, true, nil
+		}
+	}
+	if lowest < 1 {
+		return 0, false, nil
+	}
+	return lowest - 1, false, nil
+}
+
+// rollbackOnReorg checks the parent hash of the next block to scan against the recorded hash of the last scanned block.
+// On mismatch it rolls the last scanned block back to the common ancestor so that the affected range is scanned again.
+// Returns the (possibly rolled back) last scanned block
+func (ob *EVMChainClient) rollbackOnReorg(lastScanned int64) (int64, error) {
+	tracked, found := ob.blockHashes.Get(lastScanned)
+	if !found { // nothing to compare against, e.g. right after a restart
+		return lastScanned, nil
+	}
+	// not read from the header cache, which may still hold the header of the abandoned fork
+	header, err := ob.evmClient.HeaderByNumber(ob.ctx, big.NewInt(lastScanned+1))
+	if err != nil {
+		return lastScanned, err
+	}
+	if header.ParentHash == tracked {
+		return lastScanned, nil
+	}
+	ob.logger.ExternalChainWatcher.Warn().Msgf("rollbackOnReorg: parent hash mismatch at block %d: expected %s, got %s",
+		lastScanned+1, tracked.Hex(), header.ParentHash.Hex())
+
+	ancestor, found, err := ob.blockHashes.FindCommonAncestor(lastScanned-1, func(bn int64) (ethcommon.Hash, error) {
+		h, err := ob.evmClient.HeaderByNumber(ob.ctx, big.NewInt(bn))
+		if err != nil {
+			return ethcommon.Hash{}, err
+		}
+		return h.Hash(), nil
+	})
+	if err != nil {
+		return lastScanned, err
+	}
+	if !found {
+		ob.logger.ExternalChainWatcher.Error().Msgf("rollbackOnReorg: reorg deeper than %d tracked blocks, rolling back to block %d",
+			ReorgTrackDepth, ancestor)
+	}
+
+	// forget everything observed on the abandoned fork
+	ob.blockHashes.RemoveFrom(ancestor + 1)
+	ob.headers.RemoveFrom(ancestor + 1)
+	for bn := ancestor + 1; bn <= lastScanned; bn++ {
+		ob.BlockCache.Remove(bn)
+	}
+	ob.forgetInboundEventsAfter(ancestor)
+	ob.forgetInboundCheckpointAfter(ancestor)
+	ob.forgetPendingVotesAfter(ancestor)
+	ob.SetLastBlockHeightScanned(ancestor)
+	if err := ob.db.Save(clienttypes.ToLastBlockSQLType(ancestor)).Error; err != nil {
+		ob.logger.ExternalChainWatcher.Error().Err(err).Msg("rollbackOnReorg: error writing last scanned block to db")
+	}
+	ob.logger.ExternalChainWatcher.Warn().Msgf("rollbackOnReorg: chain reorg detected, rolled back from block %d to %d", lastScanned, ancestor)
+	return ancestor, nil
+}
+
+// isLogCanonical returns false if the log was removed by a reorg or its block is no longer on the canonical chain
+func (ob *EVMChainClient) isLogCanonical(vLog ethtypes.Log) (bool, error) {
+	if vLog.Removed {
+		return false, nil
+	}
+	var header *ethtypes.Header
+	err := Retry(ob.ctx, "HeaderByNumber", RPCBackoff, func() (err error) {
+		// #nosec G701 always in range
+		header, err = ob.evmClient.HeaderByNumber(ob.ctx, big.NewInt(int64(vLog.BlockNumber)))
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	return header.Hash() == vLog.BlockHash, nil
+}
+
+// forgetInboundEventsAfter removes the inbound events of blocks after block from the processed events so that
+// they are observed again on the new canonical chain.
+// Votes already posted cannot be retracted from zetacore; they are reported for the operator to follow up
+func (ob *EVMChainClient) forgetInboundEventsAfter(block int64) {
+	if ob.db == nil || block < 0 {
+		return
+	}
+	var events []clienttypes.InboundEventSQLType
+	// #nosec G701 checked positive
+	if err := ob.db.Where("block_number > ?", uint64(block)).Find(&events).Error; err != nil {
+		ob.logger.ExternalChainWatcher.Error().Err(err).Msgf("forgetInboundEventsAfter: error reading inbound events after block %d from db", block)
+		return
+	}
+	for _, event := range events {
+		if event.ZetaHash != "" {
+			ob.logger.ExternalChainWatcher.Error().Msgf("forgetInboundEventsAfter: inbound event %s of block %d was reverted by a reorg but has been voted in zeta tx %s",
+				event.Key, event.BlockNumber, event.ZetaHash)
+		}
+		// delete permanently so that the unique key can be recorded again
+		if err := ob.db.Unscoped().Delete(&event).Error; err != nil {
+			ob.logger.ExternalChainWatcher.Error().Err(err).Msgf("forgetInboundEventsAfter: error deleting inbound event %s from db", event.Key)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package zetaclient
+
+import (
+	"math/big"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockHashTracker(t *testing.T) {
+	tracker := NewBlockHashTracker(3)
+	for bn := int64(1); bn <= 5; bn++ {
+		tracker.Add(bn, ethcommon.BigToHash(big.NewInt(bn)))
+	}
+
+	// only the last 3 blocks are kept
+	_, found := tracker.Get(2)
+	require.False(t, found)
+	hash, found := tracker.Get(3)
+	require.True(t, found)
+	require.Equal(t, ethcommon.BigToHash(big.NewInt(3)), hash)
+
+	tracker.RemoveFrom(4)
+	_, found = tracker.Get(4)
+	require.False(t, found)
+	_, found = tracker.Get(3)
+	require.True(t, found)
+}
+
+func TestBlockHashTracker_FindCommonAncestor(t *testing.T) {
+	tracked := map[int64]ethcommon.Hash{
+		10: ethcommon.HexToHash("0x0a"),
+		11: ethcommon.HexToHash("0x0b"),
+		12: ethcommon.HexToHash("0x0c"),
+		13: ethcommon.HexToHash("0x0d"),
+	}
+	tracker := NewBlockHashTracker(ReorgTrackDepth)
+	for bn, hash := range tracked {
+		tracker.Add(bn, hash)
+	}
+
+	// blocks 12 and 13 were replaced by a fork
+	canonical := func(bn int64) (ethcommon.Hash, error) {
+		if bn >= 12 {
+			return ethcommon.HexToHash("0xff"), nil
+		}
+		return tracked[bn], nil
+	}
+	ancestor, found, err := tracker.FindCommonAncestor(13, canonical)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, int64(11), ancestor)
+
+	// reorg deeper than all tracked blocks
+	ancestor, found, err = tracker.FindCommonAncestor(13, func(int64) (ethcommon.Hash, error) {
+		return ethcommon.HexToHash("0xff"), nil
+	})
+	require.NoError(t, err)
+	require.False(t, found)
+	require.Equal(t, int64(9), ancestor)
+}
@@ -0,0 +1,138 @@
+package zetaclient
+
+import (
+	"fmt"
+
+	"github.com/zeta-chain/zetacore/x/crosschain/types"
+	metricsPkg "github.com/zeta-chain/zetacore/zetaclient/metrics"
+	clienttypes "github.com/zeta-chain/zetacore/zetaclient/types"
+)
+
+const (
+	// InboundEventRetention is the number of blocks below the last scanned block for which the processed inbound events
+	// are kept. Older events can't be reverted by a reorg the observer detects; a rescan of their blocks relies on
+	// zetacore ignoring the votes already posted
+	InboundEventRetention = ReorgTrackDepth
+)
+
+// InboundVotesPoster is implemented by the bridges able to post several inbound votes at once in their order, see
+// ZetaCoreBridge.PostSends
+type InboundVotesPoster interface {
+	PostSends(zetaGasLimits []uint64, msgs []*types.MsgVoteOnObservedInboundTx) ([]string, []error)
+}
+
+// postInboundVote posts the inbound vote to zetacore unless zetacore has already seen it from this observer.
+// Returns an empty zeta tx hash if the vote is skipped, so that rescanning a range is idempotent
+func (ob *EVMChainClient) postInboundVote(gasLimit uint64, msg *types.MsgVoteOnObservedInboundTx) (string, error) {
+	zetaHash, err := ob.zetaClient.PostSend(gasLimit, msg)
+	return ob.inboundVotePosted(msg, zetaHash, err)
+}
+
+// postInboundVotes posts the inbound votes in their order like postInboundVote, at once if the bridge can, one by one
+// otherwise, and returns the outcome of each
+func (ob *EVMChainClient) postInboundVotes(gasLimits []uint64, msgs []*types.MsgVoteOnObservedInboundTx) ([]string, []error) {
+	poster, ok := ob.zetaClient.(InboundVotesPoster)
+	if !ok {
+		zetaHashes := make([]string, len(msgs))
+		errs := make([]error, len(msgs))
+		for i, msg := range msgs {
+			zetaHashes[i], errs[i] = ob.postInboundVote(gasLimits[i], msg)
+		}
+		return zetaHashes, errs
+	}
+	zetaHashes, errs := poster.PostSends(gasLimits, msgs)
+	for i, msg := range msgs {
+		zetaHashes[i], errs[i] = ob.inboundVotePosted(msg, zetaHashes[i], errs[i])
+	}
+	return zetaHashes, errs
+}
+
+// inboundVotePosted counts the post of the inbound vote and publishes the vote once broadcast
+func (ob *EVMChainClient) inboundVotePosted(msg *types.MsgVoteOnObservedInboundTx, zetaHash string, err error) (string, error) {
+	if err != nil {
+		metricsPkg.PostSendCount.WithLabelValues(ob.chain.Name(), metricsPkg.PostSendFailure).Inc()
+		return "", err
+	}
+	if zetaHash == "" {
+		return "", nil // already voted
+	}
+	metricsPkg.PostSendCount.WithLabelValues(ob.chain.Name(), metricsPkg.PostSendSuccess).Inc()
+	ob.publishInboundEvent(msg, zetaHash)
+	return zetaHash, nil
+}
+
+// KeepLastScannedBlock has Stop() leave the last scanned block of the db as is, for the chain clients of the commands
+// run next to the zetaclient of the node, e.g. rescan, which share its db: the block they loaded on creation is stale
+// once the node scans further
+func (ob *EVMChainClient) KeepLastScannedBlock() {
+	ob.keepLastScanned = true
+}
+
+// Rescan observes the inbound txs in blocks [fromBlock, toBlock] again and posts the ones zetacore has not seen
+// The last scanned block and the checkpoint of the observer are left untouched
+func (ob *EVMChainClient) Rescan(fromBlock, toBlock int64) error {
+	if fromBlock < 0 || fromBlock > toBlock {
+		return fmt.Errorf("Rescan: invalid block range [%d, %d]", fromBlock, toBlock)
+	}
+	confirmedBlockNum, err := ob.getConfirmedBlockNumber()
+	if err != nil {
+		return err
+	}
+	// #nosec G701 always in range
+	if uint64(toBlock) > confirmedBlockNum {
+		return fmt.Errorf("Rescan: block %d is not confirmed yet, last confirmed block is %d", toBlock, confirmedBlockNum)
+	}
+	// #nosec G701 always in range
+	blocksPerScan := int64(ob.GetBlocksPerScan(0))
+	for start := fromBlock; start <= toBlock; start += blocksPerScan {
+		end := start + blocksPerScan - 1
+		if end > toBlock {
+			end = toBlock
+		}
+		ob.logger.ExternalChainWatcher.Info().Msgf("Rescan: scanning blocks %d to %d", start, end)
+		if err := ob.observeInTxRange(start, end, false); err != nil {
+			return fmt.Errorf("Rescan: error scanning blocks %d to %d: %w", start, end, err)
+		}
+		if ob.ctx.Err() != nil {
+			return ob.ctx.Err()
+		}
+	}
+	return nil
+}
+
+// isInboundEventProcessed returns true if the inbound event has already been posted to zetacore
+func (ob *EVMChainClient) isInboundEventProcessed(key string) bool {
+	if ob.db == nil {
+		return false
+	}
+	var count int64
+	if err := ob.db.Model(&clienttypes.InboundEventSQLType{}).Where(&clienttypes.InboundEventSQLType{Key: key}).Count(&count).Error; err != nil {
+		ob.logger.ExternalChainWatcher.Error().Err(err).Msgf("isInboundEventProcessed: error reading inbound event %s from db", key)
+		return false
+	}
+	return count > 0
+}
+
+// setInboundEventProcessed records the inbound event as posted to zetacore
+func (ob *EVMChainClient) setInboundEventProcessed(key string, blockNumber uint64, zetaHash string) {
+	if ob.db == nil {
+		return
+	}
+	if err := ob.db.Create(clienttypes.ToInboundEventSQLType(key, blockNumber, zetaHash)).Error; err != nil {
+		ob.logger.ExternalChainWatcher.Error().Err(err).Msgf("setInboundEventProcessed: error writing inbound event %s to db", key)
+	}
+}
+
+// pruneInboundEvents removes the processed inbound events of the blocks more than InboundEventRetention blocks below
+// the last scanned block, so that the db doesn't grow without bound
+func (ob *EVMChainClient) pruneInboundEvents(lastScanned int64) {
+	if ob.db == nil || lastScanned <= InboundEventRetention {
+		return
+	}
+	// #nosec G701 checked positive
+	below := uint64(lastScanned - InboundEventRetention)
+	err := ob.db.Unscoped().Where("block_number < ?", below).Delete(&clienttypes.InboundEventSQLType{}).Error
+	if err != nil {
+		ob.logger.ExternalChainWatcher.Error().Err(err).Msgf("pruneInboundEvents: error deleting inbound events below block %d from db", below)
+	}
+}
@@ -0,0 +1,72 @@
+package zetaclient
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// RollupClient fetches blocks of rollups as raw json. Their blocks contain system txs go-ethereum cannot decode,
+// e.g. the L1 deposit txs of OP-stack chains or the internal txs of arbitrum
+type RollupClient struct {
+	c *rpc.Client
+}
+
+func DialRollupClient(url string) (*RollupClient, error) {
+	c, err := rpc.Dial(url)
+	if err != nil {
+		return nil, err
+	}
+	return &RollupClient{c}, nil
+}
+
+func (ec *RollupClient) BlockByNumber(ctx context.Context, number *big.Int) (*RPCBlock, error) {
+	return getRPCBlock(ctx, ec.c, "eth_getBlockByNumber", toBlockNumArg(number), true)
+}
+
+// observeTssDepositsInRPCBlock posts the gas token deposits to the TSS address in a block read as raw json.
+// The sender is taken from the json, which also covers OP-stack deposit txs sent from L1
+func (ob *EVMChainClient) observeTssDepositsInRPCBlock(bn int64, tssAddress ethcommon.Address) error {
+	var block *RPCBlock
+	err := Retry(ob.ctx, "BlockByNumber", RPCBackoff, func() (err error) {
+		block, err = ob.rollupClient.BlockByNumber(ob.ctx, big.NewInt(bn))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if block.Hash != nil {
+		ob.blockHashes.Add(bn, *block.Hash)
+	}
+	txHashes := make([]ethcommon.Hash, 0, len(block.Transactions))
+	for _, tx := range block.Transactions {
+		txHashes = append(txHashes, tx.Hash)
+		if tx.To == nil {
+			continue
+		}
+		if bytes.Equal(tx.Input, []byte(DonationMessage)) {
+			ob.logger.ExternalChainWatcher.Info().Msgf("thank you rich folk for your donation!: %s", tx.Hash.Hex())
+			continue
+		}
+		if tx.From == nil || tx.Value == nil || !IsTssGasDeposit(tx.To, tx.Value.ToInt(), tssAddress) {
+			continue
+		}
+		receipt, err := ob.evmClient.TransactionReceipt(ob.ctx, tx.Hash)
+		if err != nil {
+			ob.logger.ExternalChainWatcher.Err(err).Msg("TransactionReceipt error")
+			continue
+		}
+		if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+			ob.logger.ExternalChainWatcher.Info().Msgf("tx %s failed; don't act", tx.Hash.Hex())
+			continue
+		}
+		if err := ob.postTssGasDeposit(tx.Hash, tx.Value.ToInt(), receipt, *tx.From, tx.Input, 0); err != nil {
+			return err
+		}
+	}
+	return ob.observeInternalTssDeposits(bn, txHashes, tssAddress)
+}
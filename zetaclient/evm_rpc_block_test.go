@@ -0,0 +1,54 @@
+package zetaclient
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+// an OP-stack block with the L1 attributes deposit tx and a deposit to the TSS address
+const opStackBlockJSON = `{
+	"hash": "0x2ba6e4f3c21cc1e3a0e5d2b4e9f1bdc2b0d4e3c0b6b79a1e8e6d7f6d3c0a1b2c",
+	"transactions": [
+		{
+			"type": "0x7e",
+			"sourceHash": "0x1e7e0dfa7f8f4b3e6a2d2c0c0b3c2b7e6a1d9f3b7e2a4c6b8d0f2e4a6c8b0d2f",
+			"from": "0xdeaddeaddeaddeaddeaddeaddeaddeaddead0001",
+			"to": "0x4200000000000000000000000000000000000015",
+			"mint": "0x0",
+			"value": "0x0",
+			"gas": "0xf4240",
+			"isSystemTx": false,
+			"input": "0x015d8eb9",
+			"hash": "0x8a5d5d1e0f9b6c0b1e3d2a4f6c8e0a2c4e6f8a0b2d4f6a8c0e2a4c6e8f0a2c4e"
+		},
+		{
+			"type": "0x2",
+			"from": "0x236c7f53a90493bb423411fe4117cb4c2de71dfb",
+			"to": "0xe80b6467863ebf8865092544f441da8fd3cf6074",
+			"value": "0xde0b6b3a7640000",
+			"input": "0x",
+			"hash": "0x3c2b1a0f9e8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d9c8b7a6f5e4d3c2b"
+		}
+	]
+}`
+
+func TestRPCBlock_OPStackDepositTx(t *testing.T) {
+	// go-ethereum cannot decode the deposit tx type
+	var tx ethtypes.Transaction
+	require.Error(t, tx.UnmarshalJSON([]byte(`{"type":"0x7e"}`)))
+
+	var block RPCBlock
+	require.NoError(t, json.Unmarshal([]byte(opStackBlockJSON), &block))
+
+	// the raw json block keeps the sender and value of every tx
+	require.Len(t, block.Transactions, 2)
+	deposit := block.Transactions[1]
+	require.Equal(t, ethcommon.HexToAddress("0x236C7f53a90493Bb423411fe4117Cb4c2De71DfB"), *deposit.From)
+	require.Equal(t, ethcommon.HexToAddress("0xE80B6467863EbF8865092544f441da8fD3cF6074"), *deposit.To)
+	require.Equal(t, big.NewInt(1e18), deposit.Value.ToInt())
+}
@@ -2,7 +2,6 @@ package zetaclient
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/hex"
 	"fmt"
 	"math/big"
@@ -15,13 +14,13 @@ import (
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/zeta-chain/protocol-contracts/pkg/contracts/evm/erc20custody.sol"
 	"github.com/zeta-chain/zetacore/common"
 	"github.com/zeta-chain/zetacore/x/crosschain/types"
 	observertypes "github.com/zeta-chain/zetacore/x/observer/types"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
 )
 
 type EVMSigner struct {
@@ -43,6 +42,7 @@ var _ ChainSigner = &EVMSigner{}
 func NewEVMSigner(
 	chain common.Chain,
 	endpoint string,
+	rpcConfig config.RPCClientConfig,
 	tssSigner TSSSigner,
 	abiString string,
 	erc20CustodyABIString string,
@@ -51,15 +51,19 @@ func NewEVMSigner(
 	logger zerolog.Logger,
 	ts *TelemetryServer,
 ) (*EVMSigner, error) {
-	client, err := ethclient.Dial(endpoint)
+	dialed, err := dialEndpoint(endpoint, rpcConfig)
 	if err != nil {
 		return nil, err
 	}
+	client := dialed.Client
 
 	chainID, err := client.ChainID(context.TODO())
 	if err != nil {
 		return nil, err
 	}
+	if !chainID.IsInt64() || chainID.Int64() != chain.ChainId {
+		return nil, fmt.Errorf("%w: endpoint of chain %s serves chain %s", ErrChainIDMismatch, chain.ChainName, chainID)
+	}
 	ethSigner := ethtypes.LatestSignerForChainID(chainID)
 	connectorABI, err := abi.JSON(strings.NewReader(abiString))
 	if err != nil {
@@ -81,7 +85,7 @@ func NewEVMSigner(
 		metaContractAddress:         metaContract,
 		erc20CustodyContractAddress: erc20CustodyContract,
 		logger: logger.With().
-			Str("chain", chain.ChainName.String()).
+			Str("chain", chain.Name()).
 			Str("module", "EVMSigner").Logger(),
 		ts: ts,
 	}, nil
@@ -314,10 +318,10 @@ func (signer *EVMSigner) TryProcessOutTx(
 	zetaBridge ZetaCoreBridger,
 	height uint64,
 ) {
-	logger := signer.logger.With().
+	logger := WithCorrelationID(signer.logger.With().
 		Str("outTxID", outTxID).
 		Str("SendHash", send.Index).
-		Logger()
+		Logger(), send.Index)
 	logger.Info().Msgf("start processing outTxID %s", outTxID)
 	logger.Info().Msgf("EVM Chain TryProcessOutTx: %s, value %d to %s", send.Index, send.GetCurrentOutTxParam().Amount.BigInt(), send.GetCurrentOutTxParam().Receiver)
 
@@ -365,9 +369,11 @@ func (signer *EVMSigner) TryProcessOutTx(
 
 	var message []byte
 	if send.GetCurrentOutTxParam().CoinType != common.CoinType_Cmd {
-		message, err = base64.StdEncoding.DecodeString(send.RelayedMessage)
+		message, err = types.DecodeRelayedMessage(send.RelayedMessage)
 		if err != nil {
+			// never call the destination contract without the payload it was sent
 			logger.Err(err).Msgf("decode CCTX.Message %s error", send.RelayedMessage)
+			return
 		}
 	}
 
@@ -582,6 +588,9 @@ func (signer *EVMSigner) TryProcessOutTx(
 				continue
 			}
 			logger.Info().Msgf("Broadcast success: nonce %d to chain %s outTxHash %s", send.GetCurrentOutTxParam().OutboundTxTssNonce, toChain, outTxHash)
+			if ob, ok := evmClient.(*EVMChainClient); ok {
+				ob.TrackOutTx(tx.Nonce(), tx.Hash(), send.Index)
+			}
 			zetaHash, err := zetaBridge.AddTxHashToOutTxTracker(toChain.ChainId, tx.Nonce(), outTxHash, nil, "", -1)
 			if err != nil {
 				logger.Err(err).Msgf("Unable to add to tracker on ZetaCore: nonce %d chain %s outTxHash %s", send.GetCurrentOutTxParam().OutboundTxTssNonce, toChain, outTxHash)
@@ -0,0 +1,127 @@
+package zetaclient
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/zeta-chain/zetacore/common"
+	"github.com/zeta-chain/zetacore/x/crosschain/types"
+)
+
+// CanonicalDecimals is the number of decimals amounts are normalized to, the decimals of ZETA and of the gas tokens
+// of the evm chains
+const CanonicalDecimals = 18
+
+// erc20DecimalsABI is the part of the ERC20 interface reading the decimals of the token
+const erc20DecimalsABI = `[{"inputs":[],"name":"decimals","outputs":[{"internalType":"uint8","name":"","type":"uint8"}],"stateMutability":"view","type":"function"}]`
+
+// TokenDecimals caches the decimals of the ERC20 tokens of a chain, read once from the token contracts
+type TokenDecimals struct {
+	mu       sync.Mutex
+	decimals map[ethcommon.Address]uint8
+	fetch    func(asset ethcommon.Address) (uint8, error)
+}
+
+func NewTokenDecimals(fetch func(asset ethcommon.Address) (uint8, error)) *TokenDecimals {
+	return &TokenDecimals{
+		decimals: make(map[ethcommon.Address]uint8),
+		fetch:    fetch,
+	}
+}
+
+// Get returns the decimals of the token, read from its contract the first time
+func (d *TokenDecimals) Get(asset ethcommon.Address) (uint8, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if decimals, found := d.decimals[asset]; found {
+		return decimals, nil
+	}
+	decimals, err := d.fetch(asset)
+	if err != nil {
+		return 0, err
+	}
+	d.decimals[asset] = decimals
+	return decimals, nil
+}
+
+// NormalizeAmount converts an amount in the smallest unit of a token with the given decimals to CanonicalDecimals
+func NormalizeAmount(amount *big.Int, decimals uint8) *big.Int {
+	switch {
+	case decimals < CanonicalDecimals:
+		scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(CanonicalDecimals-decimals)), nil)
+		return new(big.Int).Mul(amount, scale)
+	case decimals > CanonicalDecimals:
+		scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals-CanonicalDecimals)), nil)
+		return new(big.Int).Quo(amount, scale)
+	default:
+		return new(big.Int).Set(amount)
+	}
+}
+
+// fetchTokenDecimals reads the decimals of a token from its contract
+func (ob *EVMChainClient) fetchTokenDecimals(asset ethcommon.Address) (uint8, error) {
+	parsed, err := abi.JSON(strings.NewReader(erc20DecimalsABI))
+	if err != nil {
+		return 0, err
+	}
+	token := bind.NewBoundContract(asset, parsed, ob.evmClient, nil, nil)
+	var decimals uint8
+	err = Retry(ob.ctx, "decimals", RPCBackoff, func() error {
+		var out []interface{}
+		if err := token.Call(&bind.CallOpts{Context: ob.ctx}, &out, "decimals"); err != nil {
+			return err
+		}
+		decimals = *abi.ConvertType(out[0], new(uint8)).(*uint8)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("decimals of %s: %w", asset.Hex(), err)
+	}
+	return decimals, nil
+}
+
+// checkTokenDecimals checks that a deposited token has the decimals of its ZRC20 in zetacore, which mints the deposited
+// amount as is; the deposit would be minted in the wrong unit otherwise. Tokens whose decimals can't be read are not
+// held back, as for the whitelist
+func (ob *EVMChainClient) checkTokenDecimals(asset ethcommon.Address) error {
+	expected := ob.whitelist.Decimals(asset)
+	if expected == 0 || ob.tokenDecimals == nil {
+		return nil
+	}
+	decimals, err := ob.tokenDecimals.Get(asset)
+	if err != nil {
+		ob.logger.ExternalChainWatcher.Warn().Err(err).Msgf("can't check the decimals of asset %s", asset.Hex())
+		return nil
+	}
+	if uint32(decimals) != expected {
+		return fmt.Errorf("asset %s has %d decimals but its ZRC20 has %d", asset.Hex(), decimals, expected)
+	}
+	return nil
+}
+
+// normalizedAmount returns the amount of the inbound transfer in CanonicalDecimals, empty if the decimals of its asset
+// are unknown
+func (ob *EVMChainClient) normalizedAmount(msg *types.MsgVoteOnObservedInboundTx) string {
+	amount := msg.Amount.BigInt()
+	switch {
+	case msg.CoinType == common.CoinType_Zeta:
+		return amount.String()
+	case ob.chain.IsZetaChain():
+		// the ZRC20s withdrawn from zEVM have the decimals of their foreign chain
+		return ""
+	case msg.CoinType == common.CoinType_Gas:
+		return amount.String()
+	case msg.CoinType != common.CoinType_ERC20 || ob.tokenDecimals == nil || !ethcommon.IsHexAddress(msg.Asset):
+		return ""
+	}
+	decimals, err := ob.tokenDecimals.Get(ethcommon.HexToAddress(msg.Asset))
+	if err != nil {
+		return ""
+	}
+	return NormalizeAmount(amount, decimals).String()
+}
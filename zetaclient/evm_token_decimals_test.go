@@ -0,0 +1,56 @@
+package zetaclient
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/zeta-chain/zetacore/common"
+	fungibletypes "github.com/zeta-chain/zetacore/x/fungible/types"
+)
+
+func TestNormalizeAmount(t *testing.T) {
+	require.Equal(t, "1000000000000000000", NormalizeAmount(big.NewInt(1_000_000), 6).String())
+	require.Equal(t, "1000000000000000000", NormalizeAmount(big.NewInt(1_000_000_000_000_000_000), 18).String())
+	require.Equal(t, "1", NormalizeAmount(big.NewInt(1_000), 21).String())
+}
+
+func TestEVMChainClient_CheckTokenDecimals(t *testing.T) {
+	chain := common.EthChain()
+	usdc := ethcommon.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	usdt := ethcommon.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+	dai := ethcommon.HexToAddress("0x6B175474E89094C44Da98b954EedeAC495271d0F")
+	whitelist := NewERC20Whitelist(chain.ChainId, func() ([]fungibletypes.ForeignCoins, error) {
+		return []fungibletypes.ForeignCoins{
+			{Asset: usdc.Hex(), ForeignChainId: chain.ChainId, CoinType: common.CoinType_ERC20, Decimals: 6},
+			{Asset: usdt.Hex(), ForeignChainId: chain.ChainId, CoinType: common.CoinType_ERC20, Decimals: 18},
+		}, nil
+	})
+	_, err := whitelist.Assets()
+	require.NoError(t, err)
+	var fetches int
+	ob := &EVMChainClient{
+		Mu:        &sync.Mutex{},
+		chain:     chain,
+		whitelist: whitelist,
+		tokenDecimals: NewTokenDecimals(func(ethcommon.Address) (uint8, error) {
+			fetches++
+			return 6, nil
+		}),
+		logger: EVMLog{ExternalChainWatcher: zerolog.Nop()},
+	}
+
+	require.NoError(t, ob.checkTokenDecimals(usdc))
+	require.NoError(t, ob.checkTokenDecimals(usdc))
+	require.Equal(t, 1, fetches) // cached
+
+	// the token and its ZRC20 differ
+	require.Error(t, ob.checkTokenDecimals(usdt))
+
+	// assets without decimals in zetacore are not checked
+	require.NoError(t, ob.checkTokenDecimals(dai))
+	require.Equal(t, 2, fetches)
+}
@@ -0,0 +1,245 @@
+package zetaclient
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
+)
+
+// RawRPCClient is implemented by the rpc clients able to send the json-rpc requests they have no wrapper for
+type RawRPCClient interface {
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+}
+
+// InternalTransfer is a transfer of gas tokens made from inside a contract call
+type InternalTransfer struct {
+	TxHash ethcommon.Hash
+	From   ethcommon.Address
+	To     ethcommon.Address
+	Value  *big.Int
+	Input  []byte
+	// Index is the position of the call in the call tree of the tx walked depth first, the top level call being 0
+	Index uint
+}
+
+// callFrame is a call traced by the call tracer of debug_traceBlockByNumber
+type callFrame struct {
+	Type  string             `json:"type"`
+	From  ethcommon.Address  `json:"from"`
+	To    *ethcommon.Address `json:"to"`
+	Value *hexutil.Big       `json:"value"`
+	Input hexutil.Bytes      `json:"input"`
+	Error string             `json:"error"`
+	Calls []callFrame        `json:"calls"`
+}
+
+// txCallTrace is the trace of a tx returned by debug_traceBlockByNumber; txHash is only set by recent geth versions
+type txCallTrace struct {
+	TxHash *ethcommon.Hash `json:"txHash"`
+	Result callFrame       `json:"result"`
+	Error  string          `json:"error"`
+}
+
+// parityTrace is a call traced by trace_block
+type parityTrace struct {
+	Type   string `json:"type"`
+	Action struct {
+		CallType      string             `json:"callType"`
+		From          ethcommon.Address  `json:"from"`
+		To            *ethcommon.Address `json:"to"`
+		Value         *hexutil.Big       `json:"value"`
+		Input         hexutil.Bytes      `json:"input"`
+		Address       ethcommon.Address  `json:"address"`       // suicide
+		RefundAddress *ethcommon.Address `json:"refundAddress"` // suicide
+		Balance       *hexutil.Big       `json:"balance"`       // suicide
+	} `json:"action"`
+	Error           string          `json:"error"`
+	TraceAddress    []int           `json:"traceAddress"`
+	TransactionHash *ethcommon.Hash `json:"transactionHash"`
+}
+
+// internalTransfersOfCallFrame returns the transfers to address in the call tree of a tx, except the top level call.
+// Calls that failed, or whose caller failed, transferred nothing
+func internalTransfersOfCallFrame(txHash ethcommon.Hash, root callFrame, to ethcommon.Address) []InternalTransfer {
+	var transfers []InternalTransfer
+	var index uint
+	var walk func(frame callFrame, depth int)
+	walk = func(frame callFrame, depth int) {
+		frameIndex := index
+		index++
+		if frame.Error != "" {
+			// the subtree is still walked to keep the indexes of the other calls stable
+			countCalls(frame.Calls, &index)
+			return
+		}
+		isTransfer := frame.Type == "CALL" || frame.Type == "SELFDESTRUCT"
+		if depth > 0 && isTransfer && frame.To != nil && frame.Value != nil && IsTssGasDeposit(frame.To, frame.Value.ToInt(), to) {
+			transfers = append(transfers, InternalTransfer{
+				TxHash: txHash,
+				From:   frame.From,
+				To:     *frame.To,
+				Value:  frame.Value.ToInt(),
+				Input:  frame.Input,
+				Index:  frameIndex,
+			})
+		}
+		for _, call := range frame.Calls {
+			walk(call, depth+1)
+		}
+	}
+	walk(root, 0)
+	return transfers
+}
+
+func countCalls(calls []callFrame, count *uint) {
+	for _, call := range calls {
+		*count++
+		countCalls(call.Calls, count)
+	}
+}
+
+// internalTransfersOfParityTraces returns the transfers to address in the traces of a block, except the top level calls.
+// The traces of a tx are listed depth first, so a trace comes after the calls it is nested in
+func internalTransfersOfParityTraces(traces []parityTrace, to ethcommon.Address) []InternalTransfer {
+	var transfers []InternalTransfer
+	var txHash ethcommon.Hash
+	var index uint
+	var failed [][]int // trace addresses of the failed calls of the tx
+	for _, trace := range traces {
+		if trace.TransactionHash == nil { // block and uncle rewards
+			continue
+		}
+		if *trace.TransactionHash != txHash {
+			txHash = *trace.TransactionHash
+			index = 0
+			failed = failed[:0]
+		}
+		traceIndex := index
+		index++
+
+		reverted := trace.Error != ""
+		for _, address := range failed {
+			if isNestedTrace(trace.TraceAddress, address) {
+				reverted = true
+			}
+		}
+		if trace.Error != "" {
+			failed = append(failed, trace.TraceAddress)
+		}
+		if reverted || len(trace.TraceAddress) == 0 {
+			continue
+		}
+
+		var transfer InternalTransfer
+		switch {
+		case trace.Type == "call" && trace.Action.CallType == "call" && trace.Action.To != nil && trace.Action.Value != nil:
+			transfer = InternalTransfer{From: trace.Action.From, To: *trace.Action.To, Value: trace.Action.Value.ToInt(), Input: trace.Action.Input}
+		case trace.Type == "suicide" && trace.Action.RefundAddress != nil && trace.Action.Balance != nil:
+			transfer = InternalTransfer{From: trace.Action.Address, To: *trace.Action.RefundAddress, Value: trace.Action.Balance.ToInt()}
+		default:
+			continue
+		}
+		if !IsTssGasDeposit(&transfer.To, transfer.Value, to) {
+			continue
+		}
+		transfer.TxHash = txHash
+		transfer.Index = traceIndex
+		transfers = append(transfers, transfer)
+	}
+	return transfers
+}
+
+// isNestedTrace returns true if the call at trace address is nested in the call at parent
+func isNestedTrace(address, parent []int) bool {
+	if len(address) <= len(parent) {
+		return false
+	}
+	for i := range parent {
+		if address[i] != parent[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// getInternalTransfers returns the gas token transfers to address made from inside the contract calls of a block,
+// using the trace api set in the config. txHashes are the hashes of the txs of the block, in order.
+// The whole block is traced rather than filtered by address with trace_filter: a transfer is only kept if none of the
+// calls it is nested in failed, which the traces of the other calls tell
+func (ob *EVMChainClient) getInternalTransfers(bn int64, txHashes []ethcommon.Hash, to ethcommon.Address) ([]InternalTransfer, error) {
+	evmCfg, _ := ob.cfg.GetEVMConfig(ob.chain.ChainId)
+	if evmCfg.TraceAPI == "" {
+		return nil, nil
+	}
+	client, ok := ob.evmClient.(RawRPCClient)
+	if !ok {
+		return nil, fmt.Errorf("rpc client can't send trace requests")
+	}
+
+	var transfers []InternalTransfer
+	blockNumber := hexutil.EncodeBig(big.NewInt(bn))
+	switch evmCfg.TraceAPI {
+	case config.TraceAPIDebug:
+		var traces []txCallTrace
+		err := Retry(ob.ctx, "debug_traceBlockByNumber", RPCBackoff, func() error {
+			return client.CallContext(ob.ctx, &traces, "debug_traceBlockByNumber", blockNumber, map[string]string{"tracer": "callTracer"})
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(traces) != len(txHashes) {
+			return nil, fmt.Errorf("got %d traces for the %d txs of block %d", len(traces), len(txHashes), bn)
+		}
+		for i, trace := range traces {
+			if trace.Error != "" {
+				return nil, fmt.Errorf("tracing tx %s failed: %s", txHashes[i].Hex(), trace.Error)
+			}
+			if trace.TxHash != nil && *trace.TxHash != txHashes[i] {
+				return nil, fmt.Errorf("trace %d of block %d is for tx %s, not %s", i, bn, trace.TxHash.Hex(), txHashes[i].Hex())
+			}
+			transfers = append(transfers, internalTransfersOfCallFrame(txHashes[i], trace.Result, to)...)
+		}
+	case config.TraceAPIParity:
+		var traces []parityTrace
+		err := Retry(ob.ctx, "trace_block", RPCBackoff, func() error {
+			return client.CallContext(ob.ctx, &traces, "trace_block", blockNumber)
+		})
+		if err != nil {
+			return nil, err
+		}
+		transfers = internalTransfersOfParityTraces(traces, to)
+	default:
+		return nil, fmt.Errorf("unknown trace api %q", evmCfg.TraceAPI)
+	}
+	return transfers, nil
+}
+
+// observeInternalTssDeposits posts the gas token deposits sent to the TSS address from inside the contract calls of a
+// block. The deposits are made by the contracts sending the tokens, with the calldata they send as memo
+func (ob *EVMChainClient) observeInternalTssDeposits(bn int64, txHashes []ethcommon.Hash, tssAddress ethcommon.Address) error {
+	transfers, err := ob.getInternalTransfers(bn, txHashes, tssAddress)
+	if err != nil {
+		return err
+	}
+	for _, transfer := range transfers {
+		receipt, err := ob.evmClient.TransactionReceipt(ob.ctx, transfer.TxHash)
+		if err != nil {
+			ob.logger.ExternalChainWatcher.Err(err).Msg("TransactionReceipt error")
+			continue
+		}
+		if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+			ob.logger.ExternalChainWatcher.Info().Msgf("tx %s failed; don't act", transfer.TxHash.Hex())
+			continue
+		}
+		ob.logger.ExternalChainWatcher.Info().Msgf("internal transfer %d of tx %s to TSS address", transfer.Index, transfer.TxHash.Hex())
+		if err := ob.postTssGasDeposit(transfer.TxHash, transfer.Value, receipt, transfer.From, transfer.Input, transfer.Index); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package zetaclient
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var traceTssAddress = ethcommon.HexToAddress("0x70e967acFcC17c3941E87562161406d41676FD83")
+
+func TestInternalTransfersOfCallFrame(t *testing.T) {
+	// a contract sends 1 wei to TSS twice; the second call is nested in a reverted call
+	trace := `{
+		"type": "CALL", "from": "0x0000000000000000000000000000000000000001", "to": "0x0000000000000000000000000000000000000002", "value": "0x0",
+		"calls": [
+			{"type": "CALL", "from": "0x0000000000000000000000000000000000000002", "to": "0x70e967acFcC17c3941E87562161406d41676FD83", "value": "0x1", "input": "0x1234"},
+			{"type": "STATICCALL", "from": "0x0000000000000000000000000000000000000002", "to": "0x0000000000000000000000000000000000000003"},
+			{"type": "CALL", "from": "0x0000000000000000000000000000000000000002", "to": "0x0000000000000000000000000000000000000003", "value": "0x0", "error": "execution reverted",
+				"calls": [{"type": "CALL", "from": "0x0000000000000000000000000000000000000003", "to": "0x70e967acFcC17c3941E87562161406d41676FD83", "value": "0x1"}]},
+			{"type": "SELFDESTRUCT", "from": "0x0000000000000000000000000000000000000004", "to": "0x70e967acFcC17c3941E87562161406d41676FD83", "value": "0x5"}
+		]
+	}`
+	var root callFrame
+	require.NoError(t, json.Unmarshal([]byte(trace), &root))
+
+	txHash := ethcommon.HexToHash("0xaa")
+	transfers := internalTransfersOfCallFrame(txHash, root, traceTssAddress)
+	require.Len(t, transfers, 2)
+	require.Equal(t, ethcommon.HexToAddress("0x02"), transfers[0].From)
+	require.Equal(t, big.NewInt(1), transfers[0].Value)
+	require.Equal(t, []byte{0x12, 0x34}, transfers[0].Input)
+	require.Equal(t, uint(1), transfers[0].Index)
+	require.Equal(t, big.NewInt(5), transfers[1].Value)
+	require.Equal(t, uint(5), transfers[1].Index)
+	require.Equal(t, txHash, transfers[1].TxHash)
+
+	// a top level transfer is not an internal transfer
+	root = callFrame{Type: "CALL", To: &traceTssAddress, Value: root.Calls[0].Value}
+	require.Empty(t, internalTransfersOfCallFrame(txHash, root, traceTssAddress))
+}
+
+func TestInternalTransfersOfParityTraces(t *testing.T) {
+	traces := `[
+		{"type": "call", "action": {"callType": "call", "from": "0x0000000000000000000000000000000000000001", "to": "0x0000000000000000000000000000000000000002", "value": "0x0"},
+			"traceAddress": [], "transactionHash": "0x00000000000000000000000000000000000000000000000000000000000000aa"},
+		{"type": "call", "action": {"callType": "call", "from": "0x0000000000000000000000000000000000000002", "to": "0x70e967acFcC17c3941E87562161406d41676FD83", "value": "0x1"},
+			"traceAddress": [0], "transactionHash": "0x00000000000000000000000000000000000000000000000000000000000000aa"},
+		{"type": "call", "action": {"callType": "call", "from": "0x0000000000000000000000000000000000000002", "to": "0x0000000000000000000000000000000000000003", "value": "0x0"},
+			"error": "Reverted", "traceAddress": [1], "transactionHash": "0x00000000000000000000000000000000000000000000000000000000000000aa"},
+		{"type": "call", "action": {"callType": "call", "from": "0x0000000000000000000000000000000000000003", "to": "0x70e967acFcC17c3941E87562161406d41676FD83", "value": "0x1"},
+			"traceAddress": [1, 0], "transactionHash": "0x00000000000000000000000000000000000000000000000000000000000000aa"},
+		{"type": "call", "action": {"callType": "call", "from": "0x0000000000000000000000000000000000000005", "to": "0x70e967acFcC17c3941E87562161406d41676FD83", "value": "0x2"},
+			"traceAddress": [], "transactionHash": "0x00000000000000000000000000000000000000000000000000000000000000bb"},
+		{"type": "suicide", "action": {"address": "0x0000000000000000000000000000000000000006", "refundAddress": "0x70e967acFcC17c3941E87562161406d41676FD83", "balance": "0x7"},
+			"traceAddress": [0], "transactionHash": "0x00000000000000000000000000000000000000000000000000000000000000bb"},
+		{"type": "reward", "action": {"author": "0x70e967acFcC17c3941E87562161406d41676FD83", "value": "0x9"}, "traceAddress": []}
+	]`
+	var parsed []parityTrace
+	require.NoError(t, json.Unmarshal([]byte(traces), &parsed))
+
+	transfers := internalTransfersOfParityTraces(parsed, traceTssAddress)
+	require.Len(t, transfers, 2)
+	require.Equal(t, ethcommon.HexToHash("0xaa"), transfers[0].TxHash)
+	require.Equal(t, ethcommon.HexToAddress("0x02"), transfers[0].From)
+	require.Equal(t, uint(1), transfers[0].Index)
+	require.Equal(t, ethcommon.HexToHash("0xbb"), transfers[1].TxHash)
+	require.Equal(t, ethcommon.HexToAddress("0x06"), transfers[1].From)
+	require.Equal(t, big.NewInt(7), transfers[1].Value)
+	require.Equal(t, uint(1), transfers[1].Index)
+}
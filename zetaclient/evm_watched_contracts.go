@@ -0,0 +1,249 @@
+package zetaclient
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/zeta-chain/protocol-contracts/pkg/contracts/evm/erc20custody.sol"
+	"github.com/zeta-chain/protocol-contracts/pkg/contracts/evm/zetaconnector.non-eth.sol"
+	connectorzevm "github.com/zeta-chain/protocol-contracts/pkg/contracts/zevm/connectorzevm.sol"
+	zrc20 "github.com/zeta-chain/protocol-contracts/pkg/contracts/zevm/zrc20.sol"
+	"github.com/zeta-chain/zetacore/x/crosschain/types"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
+)
+
+// watchedContract is a contract observed for inbound events, with the ABI its events are decoded with
+type watchedContract struct {
+	kind    string
+	address ethcommon.Address
+	event   abi.Event
+	bound   *bind.BoundContract
+}
+
+func newWatchedContract(kind string, address ethcommon.Address, abiJSON string) (*watchedContract, error) {
+	eventName, found := config.InboundABIEvents[kind]
+	if !found {
+		return nil, fmt.Errorf("unknown contract kind %s", kind)
+	}
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, err
+	}
+	event, found := parsed.Events[eventName]
+	if !found {
+		return nil, fmt.Errorf("event %s not found in abi of %s contract %s", eventName, kind, address.Hex())
+	}
+	return &watchedContract{
+		kind:    kind,
+		address: address,
+		event:   event,
+		bound:   bind.NewBoundContract(address, parsed, nil, nil, nil),
+	}, nil
+}
+
+// WatchedContracts is the decoder table of the contracts of a chain observed for inbound events.
+// It is built once and reused on every tick until the contracts change
+type WatchedContracts struct {
+	byAddress map[ethcommon.Address]*watchedContract
+	addresses []ethcommon.Address
+	eventIDs  []ethcommon.Hash
+}
+
+func newWatchedContracts(contracts []*watchedContract) WatchedContracts {
+	w := WatchedContracts{byAddress: make(map[ethcommon.Address]*watchedContract, len(contracts))}
+	seen := make(map[ethcommon.Hash]bool)
+	for _, contract := range contracts {
+		if _, found := w.byAddress[contract.address]; !found {
+			w.addresses = append(w.addresses, contract.address)
+		}
+		w.byAddress[contract.address] = contract
+		if !seen[contract.event.ID] {
+			seen[contract.event.ID] = true
+			w.eventIDs = append(w.eventIDs, contract.event.ID)
+		}
+	}
+	return w
+}
+
+// Len returns the number of contracts
+func (w WatchedContracts) Len() int {
+	return len(w.byAddress)
+}
+
+// Addresses returns the addresses of the contracts
+func (w WatchedContracts) Addresses() []ethcommon.Address {
+	return w.addresses
+}
+
+// EventIDs returns the distinct topic hashes of the inbound events of the contracts
+func (w WatchedContracts) EventIDs() []ethcommon.Hash {
+	return w.eventIDs
+}
+
+// Topics returns the topic hashes the logs of the contracts are filtered with: those of the inbound events and of the
+// admin events
+func (w WatchedContracts) Topics() []ethcommon.Hash {
+	adminEventIDs := AdminEventIDs()
+	topics := make([]ethcommon.Hash, 0, len(w.eventIDs)+len(adminEventIDs))
+	topics = append(topics, w.eventIDs...)
+	return append(topics, adminEventIDs...)
+}
+
+// Match returns the contract that emitted vLog if vLog is its inbound event
+func (w WatchedContracts) Match(vLog ethtypes.Log) (*watchedContract, bool) {
+	contract, found := w.byAddress[vLog.Address]
+	if !found || !contract.emitted(vLog) {
+		return nil, false
+	}
+	return contract, true
+}
+
+// emitted returns true if vLog is the inbound event of the contract
+func (c *watchedContract) emitted(vLog ethtypes.Log) bool {
+	return vLog.Address == c.address && len(vLog.Topics) > 0 && vLog.Topics[0] == c.event.ID
+}
+
+// watchedContractsCache holds the decoder table of the watched contracts along with the contracts it was built for
+type watchedContractsCache struct {
+	mu        sync.Mutex
+	built     bool
+	watched   []config.WatchedContract
+	contracts WatchedContracts
+}
+
+// getWatchedContracts returns the decoder table of the connector and ERC20 custody set in core params, or of the zEVM
+// contracts on the zeta chain, followed by the contracts set in config. The table is rebuilt only if the contracts
+// changed, e.g. with new core params
+func (ob *EVMChainClient) getWatchedContracts() (WatchedContracts, error) {
+	params := ob.GetCoreParams()
+	evmCfg, _ := ob.cfg.GetEVMConfig(ob.chain.ChainId)
+	watched := []config.WatchedContract{
+		{Address: params.ConnectorContractAddress, Kind: config.ContractKindConnector},
+		{Address: params.Erc20CustodyContractAddress, Kind: config.ContractKindERC20Custody},
+	}
+	if ob.chain.IsZetaChain() {
+		zevmContracts, err := ob.getZEVMContracts()
+		if err != nil {
+			return WatchedContracts{}, err
+		}
+		watched = zevmContracts
+	}
+	watched = append(watched, evmCfg.WatchedContracts...)
+
+	ob.watched.mu.Lock()
+	defer ob.watched.mu.Unlock()
+	if ob.watched.built && reflect.DeepEqual(ob.watched.watched, watched) {
+		return ob.watched.contracts, nil
+	}
+	contracts, err := ob.buildWatchedContracts(watched)
+	if err != nil {
+		return WatchedContracts{}, err
+	}
+	ob.watched.built = true
+	ob.watched.watched = watched
+	ob.watched.contracts = contracts
+	return contracts, nil
+}
+
+// buildWatchedContracts parses the ABI of every contract; contracts with no address are left out
+func (ob *EVMChainClient) buildWatchedContracts(watched []config.WatchedContract) (WatchedContracts, error) {
+	contracts := make([]*watchedContract, 0, len(watched))
+	for _, contract := range watched {
+		address := ethcommon.HexToAddress(contract.Address)
+		if address == (ethcommon.Address{}) {
+			continue
+		}
+		abiJSON := contract.GetABI()
+		if abiJSON == "" {
+			switch contract.Kind {
+			case config.ContractKindConnector:
+				abiJSON = ob.cfg.GetChainConnectorABI(ob.chain.ChainId)
+			case config.ContractKindERC20Custody:
+				abiJSON = ob.cfg.GetChainERC20CustodyABI(ob.chain.ChainId)
+			case config.ContractKindZRC20:
+				abiJSON = config.GetZRC20ABI()
+			case config.ContractKindConnectorZEVM:
+				abiJSON = config.GetConnectorZEVMABI()
+			}
+		}
+		c, err := newWatchedContract(contract.Kind, address, abiJSON)
+		if err != nil {
+			return WatchedContracts{}, err
+		}
+		contracts = append(contracts, c)
+	}
+	return newWatchedContracts(contracts), nil
+}
+
+// getCoreContract returns the decoder of the connector or the ERC20 custody set in core params, with the ABI of the
+// chain loaded from config
+func (ob *EVMChainClient) getCoreContract(kind string) (*watchedContract, error) {
+	params := ob.GetCoreParams()
+	switch kind {
+	case config.ContractKindConnector:
+		address := ethcommon.HexToAddress(params.ConnectorContractAddress)
+		return newWatchedContract(kind, address, ob.cfg.GetChainConnectorABI(ob.chain.ChainId))
+	case config.ContractKindERC20Custody:
+		address := ethcommon.HexToAddress(params.Erc20CustodyContractAddress)
+		return newWatchedContract(kind, address, ob.cfg.GetChainERC20CustodyABI(ob.chain.ChainId))
+	}
+	return nil, fmt.Errorf("unknown core contract kind %s", kind)
+}
+
+// getInboundVoteMsgForLog decodes the inbound event of a watched contract with the ABI of the contract
+// and returns the vote msg along with the gas limit of the vote
+func (ob *EVMChainClient) getInboundVoteMsgForLog(contract *watchedContract, vLog ethtypes.Log) (*types.MsgVoteOnObservedInboundTx, uint64, error) {
+	switch contract.kind {
+	case config.ContractKindConnector:
+		event := new(zetaconnector.ZetaConnectorNonEthZetaSent)
+		if err := contract.bound.UnpackLog(event, contract.event.Name, vLog); err != nil {
+			return nil, 0, err
+		}
+		event.Raw = vLog
+		msg, err := ob.GetInboundVoteMsgForZetaSentEvent(event)
+		if err != nil {
+			return nil, 0, err
+		}
+		return &msg, PostSendNonEVMGasLimit, nil
+	case config.ContractKindERC20Custody:
+		event := new(erc20custody.ERC20CustodyDeposited)
+		if err := contract.bound.UnpackLog(event, contract.event.Name, vLog); err != nil {
+			return nil, 0, err
+		}
+		event.Raw = vLog
+		msg, err := ob.GetInboundVoteMsgForDepositedEvent(event)
+		if err != nil {
+			return nil, 0, err
+		}
+		return &msg, PostSendEVMGasLimit, nil
+	case config.ContractKindZRC20:
+		event := new(zrc20.ZRC20Withdrawal)
+		if err := contract.bound.UnpackLog(event, contract.event.Name, vLog); err != nil {
+			return nil, 0, err
+		}
+		event.Raw = vLog
+		msg, err := ob.GetInboundVoteMsgForZRC20Withdrawal(event)
+		if err != nil {
+			return nil, 0, err
+		}
+		return &msg, PostSendEVMGasLimit, nil
+	case config.ContractKindConnectorZEVM:
+		event := new(connectorzevm.ZetaConnectorZEVMZetaSent)
+		if err := contract.bound.UnpackLog(event, contract.event.Name, vLog); err != nil {
+			return nil, 0, err
+		}
+		event.Raw = vLog
+		msg, err := ob.GetInboundVoteMsgForZEVMZetaSent(event)
+		if err != nil {
+			return nil, 0, err
+		}
+		return &msg, PostSendNonEVMGasLimit, nil
+	}
+	return nil, 0, fmt.Errorf("unknown contract kind %s", contract.kind)
+}
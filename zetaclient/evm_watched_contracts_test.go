@@ -0,0 +1,162 @@
+package zetaclient
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/zeta-chain/protocol-contracts/pkg/contracts/evm/zetaconnector.non-eth.sol"
+	"github.com/zeta-chain/zetacore/common"
+	observertypes "github.com/zeta-chain/zetacore/x/observer/types"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
+)
+
+func TestEVMChainClient_GetWatchedContracts(t *testing.T) {
+	chain := common.EthChain()
+	connector := ethcommon.HexToAddress("0x01")
+	custody := ethcommon.HexToAddress("0x02")
+	router := ethcommon.HexToAddress("0x03")
+	cfg := config.NewConfig()
+	cfg.EVMChainConfigs = map[int64]*config.EVMConfig{chain.ChainId: {
+		Chain:            chain,
+		WatchedContracts: []config.WatchedContract{{Address: router.Hex(), Kind: config.ContractKindConnector}},
+	}}
+	ob := &EVMChainClient{
+		Mu:    &sync.Mutex{},
+		chain: chain,
+		cfg:   cfg,
+		params: observertypes.CoreParams{
+			ConnectorContractAddress:    connector.Hex(),
+			Erc20CustodyContractAddress: custody.Hex(),
+		},
+	}
+
+	contracts, err := ob.getWatchedContracts()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []ethcommon.Address{connector, custody, router}, contracts.Addresses())
+	zetaSentID, depositedID, err := InboundEventIDs()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []ethcommon.Hash{zetaSentID, depositedID}, contracts.EventIDs())
+
+	// the ZetaSent events of the legacy router are handled like those of the connector
+	connectorABI, err := zetaconnector.ZetaConnectorNonEthMetaData.GetAbi()
+	require.NoError(t, err)
+	zetaSentLog := makeTestLog(t, connectorABI.Events["ZetaSent"], router, map[string]interface{}{
+		"DestinationChainId": big.NewInt(7001),
+	})
+	contract, found := contracts.Match(zetaSentLog)
+	require.True(t, found)
+	require.Equal(t, config.ContractKindConnector, contract.kind)
+	event := new(zetaconnector.ZetaConnectorNonEthZetaSent)
+	require.NoError(t, contract.bound.UnpackLog(event, contract.event.Name, zetaSentLog))
+	require.Equal(t, big.NewInt(7001), event.DestinationChainId)
+
+	// events are matched to the kind of their contract
+	zetaSentLog.Address = custody
+	_, found = contracts.Match(zetaSentLog)
+	require.False(t, found)
+	zetaSentLog.Address = ethcommon.HexToAddress("0x04")
+	_, found = contracts.Match(zetaSentLog)
+	require.False(t, found)
+
+	// unset contracts are not watched
+	ob.params.Erc20CustodyContractAddress = ""
+	contracts, err = ob.getWatchedContracts()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []ethcommon.Address{connector, router}, contracts.Addresses())
+	require.Equal(t, []ethcommon.Hash{zetaSentID}, contracts.EventIDs())
+}
+
+func TestEVMChainClient_GetWatchedContractsCached(t *testing.T) {
+	chain := common.EthChain()
+	cfg := config.NewConfig()
+	cfg.EVMChainConfigs = map[int64]*config.EVMConfig{chain.ChainId: {Chain: chain}}
+	ob := &EVMChainClient{
+		Mu:     &sync.Mutex{},
+		chain:  chain,
+		cfg:    cfg,
+		params: observertypes.CoreParams{ConnectorContractAddress: ethcommon.HexToAddress("0x01").Hex()},
+	}
+	contracts, err := ob.getWatchedContracts()
+	require.NoError(t, err)
+	again, err := ob.getWatchedContracts()
+	require.NoError(t, err)
+	// the decoder table is reused while the contracts don't change
+	require.Equal(t, contracts.Len(), again.Len())
+	require.True(t, contracts.byAddress[ethcommon.HexToAddress("0x01")] == again.byAddress[ethcommon.HexToAddress("0x01")])
+
+	// and rebuilt with new core params
+	ob.SetCoreParams(observertypes.CoreParams{
+		ConnectorContractAddress:    ethcommon.HexToAddress("0x01").Hex(),
+		Erc20CustodyContractAddress: ethcommon.HexToAddress("0x02").Hex(),
+	})
+	contracts, err = ob.getWatchedContracts()
+	require.NoError(t, err)
+	require.Equal(t, 2, contracts.Len())
+}
+
+func TestEVMChainClient_GetCoreContract(t *testing.T) {
+	// a connector ABI where the destination chain of ZetaSent isn't indexed
+	var entries []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(config.GetConnectorABI()), &entries))
+	for _, entry := range entries {
+		if entry["type"] != "event" || entry["name"] != "ZetaSent" {
+			continue
+		}
+		for _, input := range entry["inputs"].([]interface{}) {
+			if input.(map[string]interface{})["name"] == "destinationChainId" {
+				input.(map[string]interface{})["indexed"] = false
+			}
+		}
+	}
+	customABI, err := json.Marshal(entries)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "connector.json")
+	require.NoError(t, os.WriteFile(path, customABI, 0600))
+
+	chain := common.EthChain()
+	connector := ethcommon.HexToAddress("0x01")
+	cfg := config.NewConfig()
+	cfg.EVMChainConfigs = map[int64]*config.EVMConfig{chain.ChainId: {Chain: chain, ConnectorABIPath: path}}
+	require.NoError(t, cfg.LoadContractABIs())
+	ob := &EVMChainClient{
+		Mu:     &sync.Mutex{},
+		chain:  chain,
+		cfg:    cfg,
+		params: observertypes.CoreParams{ConnectorContractAddress: connector.Hex()},
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(string(customABI)))
+	require.NoError(t, err)
+	zetaSentLog := makeTestLog(t, parsed.Events["ZetaSent"], connector, map[string]interface{}{
+		"DestinationChainId": big.NewInt(7001),
+	})
+
+	// the inbound events are decoded with the ABI loaded from config
+	contract, err := ob.getCoreContract(config.ContractKindConnector)
+	require.NoError(t, err)
+	require.True(t, contract.emitted(zetaSentLog))
+	event := new(zetaconnector.ZetaConnectorNonEthZetaSent)
+	require.NoError(t, contract.bound.UnpackLog(event, contract.event.Name, zetaSentLog))
+	require.Equal(t, big.NewInt(7001), event.DestinationChainId)
+
+	// which the compiled-in bindings can't do
+	bindings, err := zetaconnector.NewZetaConnectorNonEthFilterer(connector, nil)
+	require.NoError(t, err)
+	_, err = bindings.ParseZetaSent(zetaSentLog)
+	require.Error(t, err)
+
+	// events of other contracts aren't the connector's
+	zetaSentLog.Address = ethcommon.HexToAddress("0x02")
+	require.False(t, contract.emitted(zetaSentLog))
+
+	_, err = ob.getCoreContract(config.ContractKindZRC20)
+	require.Error(t, err)
+}
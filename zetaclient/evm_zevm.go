@@ -0,0 +1,194 @@
+package zetaclient
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	connectorzevm "github.com/zeta-chain/protocol-contracts/pkg/contracts/zevm/connectorzevm.sol"
+	zrc20 "github.com/zeta-chain/protocol-contracts/pkg/contracts/zevm/zrc20.sol"
+	"github.com/zeta-chain/zetacore/common"
+	"github.com/zeta-chain/zetacore/x/crosschain/types"
+	fungibletypes "github.com/zeta-chain/zetacore/x/fungible/types"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
+)
+
+// ZEVMContractsTTL is how long the zEVM connector and ZRC20 contracts read from zetacore are used before being read again
+const ZEVMContractsTTL = ERC20WhitelistTTL
+
+// zevmZetaSentGasLimit is the gas limit of the cctxs zetacore creates for the ZETA sent from zEVM, see the evm hooks
+// of the crosschain module
+const zevmZetaSentGasLimit = 90_000
+
+// zevmContractsCache holds the zEVM connector and the ZRC20 contracts of the foreign coins
+type zevmContractsCache struct {
+	mu        sync.Mutex
+	updated   time.Time
+	connector ethcommon.Address
+	coins     map[ethcommon.Address]fungibletypes.ForeignCoins
+}
+
+// getZEVMContracts returns the zEVM contracts observed on the zeta chain: the connector, whose ZetaSent events send
+// ZETA to external chains, and the ZRC20 contracts, whose Withdrawal events withdraw to their foreign chain
+func (ob *EVMChainClient) getZEVMContracts() ([]config.WatchedContract, error) {
+	ob.zevm.mu.Lock()
+	defer ob.zevm.mu.Unlock()
+	if ob.zevm.coins == nil || time.Since(ob.zevm.updated) >= ZEVMContractsTTL {
+		system, err := ob.zetaClient.GetSystemContract()
+		if err != nil {
+			return nil, err
+		}
+		coins, err := ob.zetaClient.GetForeignCoins()
+		if err != nil {
+			return nil, err
+		}
+		ob.zevm.connector = ethcommon.HexToAddress(system.ConnectorZevm)
+		ob.zevm.coins = make(map[ethcommon.Address]fungibletypes.ForeignCoins, len(coins))
+		for _, coin := range coins {
+			if ethcommon.IsHexAddress(coin.Zrc20ContractAddress) {
+				ob.zevm.coins[ethcommon.HexToAddress(coin.Zrc20ContractAddress)] = coin
+			}
+		}
+		ob.zevm.updated = time.Now()
+	}
+
+	contracts := []config.WatchedContract{{Address: ob.zevm.connector.Hex(), Kind: config.ContractKindConnectorZEVM}}
+	for address := range ob.zevm.coins {
+		contracts = append(contracts, config.WatchedContract{Address: address.Hex(), Kind: config.ContractKindZRC20})
+	}
+	// the watched contracts are compared between ticks; keep them in a stable order
+	sort.Slice(contracts, func(i, j int) bool {
+		return contracts[i].Kind < contracts[j].Kind || contracts[i].Kind == contracts[j].Kind && contracts[i].Address < contracts[j].Address
+	})
+	return contracts, nil
+}
+
+// getZRC20Coin returns the foreign coin of a ZRC20 contract
+func (ob *EVMChainClient) getZRC20Coin(address ethcommon.Address) (fungibletypes.ForeignCoins, bool) {
+	ob.zevm.mu.Lock()
+	defer ob.zevm.mu.Unlock()
+	coin, found := ob.zevm.coins[address]
+	return coin, found
+}
+
+// getZEVMTxOrigin returns the sender and the contract called by a zEVM tx, the tx origin and the sender of the cctxs
+// zetacore creates for its events
+func (ob *EVMChainClient) getZEVMTxOrigin(txHash ethcommon.Hash) (origin ethcommon.Address, called ethcommon.Address, err error) {
+	tx, _, err := ob.evmClient.TransactionByHash(context.Background(), txHash)
+	if err != nil {
+		return origin, called, fmt.Errorf("failed to get transaction by hash %s: %w", txHash.Hex(), err)
+	}
+	origin, err = ethtypes.NewLondonSigner(big.NewInt(ob.chain.ChainId)).Sender(tx)
+	if err != nil {
+		return origin, called, fmt.Errorf("can't recover the sender of tx %s: %w", txHash.Hex(), err)
+	}
+	if tx.To() != nil {
+		called = *tx.To()
+	}
+	return origin, called, nil
+}
+
+// GetInboundVoteMsgForZRC20Withdrawal builds the msg zetacore builds for a ZRC20 withdrawal, whose digest is the index
+// of the cctx it creates
+func (ob *EVMChainClient) GetInboundVoteMsgForZRC20Withdrawal(event *zrc20.ZRC20Withdrawal) (types.MsgVoteOnObservedInboundTx, error) {
+	coin, found := ob.getZRC20Coin(event.Raw.Address)
+	if !found {
+		return types.MsgVoteOnObservedInboundTx{}, fmt.Errorf("no foreign coin for ZRC20 contract %s", event.Raw.Address.Hex())
+	}
+	receiverChain := common.GetChainFromChainID(coin.ForeignChainId)
+	if receiverChain == nil {
+		return types.MsgVoteOnObservedInboundTx{}, fmt.Errorf("unknown foreign chain %d of ZRC20 contract %s", coin.ForeignChainId, event.Raw.Address.Hex())
+	}
+	receiver, err := encodeReceiver(*receiverChain, event.To)
+	if err != nil {
+		return types.MsgVoteOnObservedInboundTx{}, ob.rejectInbound(WebhookEvent{
+			TxHash:          event.Raw.TxHash.Hex(),
+			BlockNumber:     event.Raw.BlockNumber,
+			Sender:          event.From.Hex(),
+			Receiver:        hex.EncodeToString(event.To),
+			ReceiverChainID: receiverChain.ChainId,
+			CoinType:        coin.CoinType.String(),
+			Asset:           event.Raw.Address.Hex(),
+			Amount:          event.Value.String(),
+		}, err.Error())
+	}
+	// the gas limit of the ZRC20 when the withdrawal was processed
+	caller, err := zrc20.NewZRC20Caller(event.Raw.Address, ob.evmClient)
+	if err != nil {
+		return types.MsgVoteOnObservedInboundTx{}, err
+	}
+	gasLimit, err := caller.GASLIMIT(&bind.CallOpts{BlockNumber: new(big.Int).SetUint64(event.Raw.BlockNumber)})
+	if err != nil {
+		return types.MsgVoteOnObservedInboundTx{}, fmt.Errorf("cannot query gas limit of ZRC20 contract %s: %w", event.Raw.Address.Hex(), err)
+	}
+	origin, called, err := ob.getZEVMTxOrigin(event.Raw.TxHash)
+	if err != nil {
+		return types.MsgVoteOnObservedInboundTx{}, err
+	}
+	msg, err := InboundEvent{
+		Sender:        called.Hex(),
+		SenderChain:   ob.chain,
+		TxOrigin:      origin.Hex(),
+		Receiver:      receiver,
+		ReceiverChain: *receiverChain,
+		Amount:        event.Value,
+		InTxHash:      event.Raw.TxHash.String(),
+		InBlockHeight: event.Raw.BlockNumber,
+		GasLimit:      gasLimit.Uint64(),
+		CoinType:      coin.CoinType,
+		Asset:         coin.Asset,
+		EventIndex:    event.Raw.Index,
+	}.VoteMessage(ob.zetaClient.GetKeys().GetOperatorAddress().String())
+	if err != nil {
+		return types.MsgVoteOnObservedInboundTx{}, err
+	}
+	return *msg, nil
+}
+
+// GetInboundVoteMsgForZEVMZetaSent builds the msg zetacore builds for ZETA sent from zEVM to an external chain
+func (ob *EVMChainClient) GetInboundVoteMsgForZEVMZetaSent(event *connectorzevm.ZetaConnectorZEVMZetaSent) (types.MsgVoteOnObservedInboundTx, error) {
+	destChain, err := ob.checkDestinationChain(event.DestinationChainId, event.ZetaTxSenderAddress, event.ZetaValueAndGas, event.Raw)
+	if err != nil {
+		return types.MsgVoteOnObservedInboundTx{}, err
+	}
+	receiver, err := encodeReceiver(*destChain, event.DestinationAddress)
+	if err != nil {
+		return types.MsgVoteOnObservedInboundTx{}, ob.rejectInbound(WebhookEvent{
+			TxHash:          event.Raw.TxHash.Hex(),
+			BlockNumber:     event.Raw.BlockNumber,
+			Sender:          event.ZetaTxSenderAddress.Hex(),
+			Receiver:        hex.EncodeToString(event.DestinationAddress),
+			ReceiverChainID: destChain.ChainId,
+			CoinType:        common.CoinType_Zeta.String(),
+			Amount:          event.ZetaValueAndGas.String(),
+		}, err.Error())
+	}
+	origin, called, err := ob.getZEVMTxOrigin(event.Raw.TxHash)
+	if err != nil {
+		return types.MsgVoteOnObservedInboundTx{}, err
+	}
+	msg, err := InboundEvent{
+		Sender:        called.Hex(),
+		SenderChain:   ob.chain,
+		TxOrigin:      origin.Hex(),
+		Receiver:      receiver,
+		ReceiverChain: *destChain,
+		Amount:        event.ZetaValueAndGas,
+		InTxHash:      event.Raw.TxHash.String(),
+		InBlockHeight: event.Raw.BlockNumber,
+		GasLimit:      zevmZetaSentGasLimit,
+		CoinType:      common.CoinType_Zeta,
+		EventIndex:    event.Raw.Index,
+	}.VoteMessage(ob.zetaClient.GetKeys().GetOperatorAddress().String())
+	if err != nil {
+		return types.MsgVoteOnObservedInboundTx{}, err
+	}
+	return *msg, nil
+}
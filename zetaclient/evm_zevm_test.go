@@ -0,0 +1,74 @@
+package zetaclient
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	zrc20 "github.com/zeta-chain/protocol-contracts/pkg/contracts/zevm/zrc20.sol"
+	"github.com/zeta-chain/zetacore/common"
+	fungibletypes "github.com/zeta-chain/zetacore/x/fungible/types"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
+)
+
+// zevmBridge serves the system contracts and the foreign coins of zEVM
+type zevmBridge struct {
+	ZetaCoreBridger
+	system fungibletypes.SystemContract
+	coins  []fungibletypes.ForeignCoins
+}
+
+func (b *zevmBridge) GetSystemContract() (fungibletypes.SystemContract, error) {
+	return b.system, nil
+}
+
+func (b *zevmBridge) GetForeignCoins() ([]fungibletypes.ForeignCoins, error) {
+	return b.coins, nil
+}
+
+func TestEVMChainClient_GetWatchedContractsZEVM(t *testing.T) {
+	chain := common.ZetaChain()
+	connector := ethcommon.HexToAddress("0x01")
+	ethZRC20 := ethcommon.HexToAddress("0x02")
+	usdtZRC20 := ethcommon.HexToAddress("0x03")
+	cfg := config.NewConfig()
+	cfg.EVMChainConfigs = map[int64]*config.EVMConfig{chain.ChainId: {Chain: chain}}
+	ob := &EVMChainClient{
+		Mu:    &sync.Mutex{},
+		chain: chain,
+		cfg:   cfg,
+		zetaClient: &zevmBridge{
+			system: fungibletypes.SystemContract{ConnectorZevm: connector.Hex()},
+			coins: []fungibletypes.ForeignCoins{
+				{Zrc20ContractAddress: usdtZRC20.Hex(), ForeignChainId: common.EthChain().ChainId, CoinType: common.CoinType_ERC20},
+				{Zrc20ContractAddress: ethZRC20.Hex(), ForeignChainId: common.EthChain().ChainId, CoinType: common.CoinType_Gas},
+			},
+		},
+	}
+
+	// the zEVM connector and the ZRC20 contracts are watched instead of the contracts of core params
+	contracts, err := ob.getWatchedContracts()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []ethcommon.Address{connector, ethZRC20, usdtZRC20}, contracts.Addresses())
+	require.Len(t, contracts.EventIDs(), 2)
+
+	// the withdrawals of the ZRC20 contracts are matched
+	zrc20ABI, err := zrc20.ZRC20MetaData.GetAbi()
+	require.NoError(t, err)
+	withdrawalLog := makeTestLog(t, zrc20ABI.Events["Withdrawal"], usdtZRC20, map[string]interface{}{
+		"Value": big.NewInt(100),
+	})
+	contract, found := contracts.Match(withdrawalLog)
+	require.True(t, found)
+	require.Equal(t, config.ContractKindZRC20, contract.kind)
+	coin, found := ob.getZRC20Coin(usdtZRC20)
+	require.True(t, found)
+	require.Equal(t, common.CoinType_ERC20, coin.CoinType)
+
+	// but not by the connector
+	withdrawalLog.Address = connector
+	_, found = contracts.Match(withdrawalLog)
+	require.False(t, found)
+}
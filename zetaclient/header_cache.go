@@ -0,0 +1,100 @@
+package zetaclient
+
+import (
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+const (
+	// HeaderCacheSize is the max number of headers cached per chain
+	HeaderCacheSize = 1000
+	// HeaderCacheTTL is how long a header fetched by number is reused; short enough for a reorg to be noticed soon
+	HeaderCacheTTL = time.Minute
+	// HeaderCacheTagTTL is how long the header of the latest, safe or finalized block is reused, shared by the
+	// components of the observer within the same tick
+	HeaderCacheTagTTL = 2 * time.Second
+)
+
+type cachedHeader struct {
+	header  *ethtypes.Header
+	expires time.Time
+}
+
+// HeaderCache shares the headers fetched by the components of an observer, keyed by block number or tag and by hash.
+// A nil cache caches nothing
+type HeaderCache struct {
+	byNumber *lru.Cache
+	byHash   *lru.Cache
+}
+
+func NewHeaderCache(size int) (*HeaderCache, error) {
+	byNumber, err := lru.New(size)
+	if err != nil {
+		return nil, err
+	}
+	byHash, err := lru.New(size)
+	if err != nil {
+		return nil, err
+	}
+	return &HeaderCache{
+		byNumber: byNumber,
+		byHash:   byHash,
+	}, nil
+}
+
+// Add caches the header of a block number, or of a block tag of rpc.BlockNumber if number is negative
+func (c *HeaderCache) Add(number int64, header *ethtypes.Header, now time.Time) {
+	if c == nil || header == nil {
+		return
+	}
+	ttl := HeaderCacheTTL
+	if number < 0 {
+		ttl = HeaderCacheTagTTL
+	}
+	c.byNumber.Add(number, cachedHeader{header: header, expires: now.Add(ttl)})
+	c.byHash.Add(header.Hash(), header)
+}
+
+// Get returns the cached header of a block number or tag unless it expired
+func (c *HeaderCache) Get(number int64, now time.Time) (*ethtypes.Header, bool) {
+	if c == nil {
+		return nil, false
+	}
+	value, found := c.byNumber.Get(number)
+	if !found {
+		return nil, false
+	}
+	cached := value.(cachedHeader)
+	if now.After(cached.expires) {
+		c.byNumber.Remove(number)
+		return nil, false
+	}
+	return cached.header, true
+}
+
+// GetByHash returns the cached header of a block hash; headers never expire by hash since a hash names a single block
+func (c *HeaderCache) GetByHash(hash ethcommon.Hash) (*ethtypes.Header, bool) {
+	if c == nil {
+		return nil, false
+	}
+	value, found := c.byHash.Get(hash)
+	if !found {
+		return nil, false
+	}
+	return value.(*ethtypes.Header), true
+}
+
+// RemoveFrom forgets the headers cached by number from block number on, e.g. reverted by a reorg
+func (c *HeaderCache) RemoveFrom(number int64) {
+	if c == nil {
+		return
+	}
+	for _, key := range c.byNumber.Keys() {
+		if n := key.(int64); n >= number || n < 0 {
+			c.byNumber.Remove(key)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package zetaclient
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeaderCache(t *testing.T) {
+	cache, err := NewHeaderCache(10)
+	require.NoError(t, err)
+	now := time.Now()
+
+	header := &ethtypes.Header{Number: big.NewInt(100)}
+	latest := &ethtypes.Header{Number: big.NewInt(110)}
+	cache.Add(100, header, now)
+	cache.Add(int64(rpc.LatestBlockNumber), latest, now)
+
+	cached, found := cache.Get(100, now)
+	require.True(t, found)
+	require.Equal(t, header, cached)
+	cached, found = cache.GetByHash(header.Hash())
+	require.True(t, found)
+	require.Equal(t, header, cached)
+
+	// the latest header expires first
+	_, found = cache.Get(int64(rpc.LatestBlockNumber), now.Add(HeaderCacheTagTTL+time.Second))
+	require.False(t, found)
+	_, found = cache.Get(100, now.Add(HeaderCacheTagTTL+time.Second))
+	require.True(t, found)
+	_, found = cache.Get(100, now.Add(HeaderCacheTTL+time.Second))
+	require.False(t, found)
+
+	// reorged blocks are forgotten
+	cache.Add(100, header, now)
+	cache.Add(101, &ethtypes.Header{Number: big.NewInt(101)}, now)
+	cache.RemoveFrom(101)
+	_, found = cache.Get(100, now)
+	require.True(t, found)
+	_, found = cache.Get(101, now)
+	require.False(t, found)
+
+	// a nil cache caches nothing
+	var none *HeaderCache
+	none.Add(100, header, now)
+	_, found = none.Get(100, now)
+	require.False(t, found)
+}
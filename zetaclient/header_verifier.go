@@ -0,0 +1,72 @@
+package zetaclient
+
+import (
+	"fmt"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// VerifyHeader checks that header is a valid child of parent: block number, parent hash, timestamp and gas,
+// plus the fields fixed by proof-of-stake once the difficulty is zero. Proof-of-work seals are not checked
+func VerifyHeader(parent, header *ethtypes.Header) error {
+	if header.Number == nil || parent.Number == nil {
+		return fmt.Errorf("header without block number")
+	}
+	if header.Number.Uint64() != parent.Number.Uint64()+1 {
+		return fmt.Errorf("block %d does not follow block %d", header.Number, parent.Number)
+	}
+	if header.ParentHash != parent.Hash() {
+		return fmt.Errorf("parent hash %s of block %d does not match block %d hash %s", header.ParentHash.Hex(), header.Number, parent.Number, parent.Hash().Hex())
+	}
+	if header.Time < parent.Time {
+		return fmt.Errorf("block %d time %d is before its parent time %d", header.Number, header.Time, parent.Time)
+	}
+	if header.GasUsed > header.GasLimit {
+		return fmt.Errorf("block %d gas used %d exceeds gas limit %d", header.Number, header.GasUsed, header.GasLimit)
+	}
+	if parent.BaseFee != nil && header.BaseFee == nil {
+		return fmt.Errorf("block %d has no base fee", header.Number)
+	}
+	if header.Difficulty != nil && header.Difficulty.Sign() == 0 {
+		if header.Nonce != (ethtypes.BlockNonce{}) {
+			return fmt.Errorf("proof-of-stake block %d has a nonce", header.Number)
+		}
+		if header.UncleHash != ethtypes.EmptyUncleHash {
+			return fmt.Errorf("proof-of-stake block %d has uncles", header.Number)
+		}
+	}
+	if parent.Difficulty != nil && parent.Difficulty.Sign() == 0 && (header.Difficulty == nil || header.Difficulty.Sign() != 0) {
+		return fmt.Errorf("block %d has a difficulty after proof-of-stake", header.Number)
+	}
+	return nil
+}
+
+// verifyHeaderRange checks that the headers of [startBlock, toBlock] form a valid chain linked to the last scanned block
+// and returns their hashes by block number
+func (ob *EVMChainClient) verifyHeaderRange(startBlock, toBlock int64) (map[uint64]ethcommon.Hash, error) {
+	if startBlock < 1 {
+		return nil, fmt.Errorf("the genesis block can't be verified")
+	}
+	parent, err := ob.headerByNumber(startBlock - 1)
+	if err != nil {
+		return nil, err
+	}
+	// the range must extend the chain verified so far
+	if hash, found := ob.blockHashes.Get(startBlock - 1); found && hash != parent.Hash() {
+		return nil, fmt.Errorf("block %d hash %s does not match the scanned block hash %s", startBlock-1, parent.Hash().Hex(), hash.Hex())
+	}
+	verified := make(map[uint64]ethcommon.Hash, toBlock-startBlock+1)
+	for bn := startBlock; bn <= toBlock; bn++ {
+		header, err := ob.headerByNumber(bn)
+		if err != nil {
+			return nil, err
+		}
+		if err := VerifyHeader(parent, header); err != nil {
+			return nil, err
+		}
+		verified[header.Number.Uint64()] = header.Hash()
+		parent = header
+	}
+	return verified, nil
+}
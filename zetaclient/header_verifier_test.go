@@ -0,0 +1,59 @@
+package zetaclient
+
+import (
+	"math/big"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyHeader(t *testing.T) {
+	// proof-of-stake headers
+	parent := &ethtypes.Header{
+		Number:     big.NewInt(100),
+		Time:       1000,
+		Difficulty: big.NewInt(0),
+		UncleHash:  ethtypes.EmptyUncleHash,
+		GasLimit:   30_000_000,
+		BaseFee:    big.NewInt(10),
+	}
+	newChild := func() *ethtypes.Header {
+		return &ethtypes.Header{
+			ParentHash: parent.Hash(),
+			Number:     big.NewInt(101),
+			Time:       1012,
+			Difficulty: big.NewInt(0),
+			UncleHash:  ethtypes.EmptyUncleHash,
+			GasLimit:   30_000_000,
+			GasUsed:    15_000_000,
+			BaseFee:    big.NewInt(10),
+		}
+	}
+	require.NoError(t, VerifyHeader(parent, newChild()))
+
+	for name, corrupt := range map[string]func(*ethtypes.Header){
+		"wrong parent hash":  func(h *ethtypes.Header) { h.ParentHash = ethcommon.HexToHash("0x01") },
+		"not the next block": func(h *ethtypes.Header) { h.Number = big.NewInt(102) },
+		"time before parent": func(h *ethtypes.Header) { h.Time = 999 },
+		"gas above limit":    func(h *ethtypes.Header) { h.GasUsed = 31_000_000 },
+		"no base fee":        func(h *ethtypes.Header) { h.BaseFee = nil },
+		"nonce after merge":  func(h *ethtypes.Header) { h.Nonce = ethtypes.EncodeNonce(1) },
+		"uncles after merge": func(h *ethtypes.Header) { h.UncleHash = ethcommon.HexToHash("0x02") },
+		"difficulty after merge": func(h *ethtypes.Header) {
+			h.Difficulty = big.NewInt(1)
+		},
+	} {
+		child := newChild()
+		corrupt(child)
+		require.Error(t, VerifyHeader(parent, child), name)
+	}
+
+	// proof-of-work and proof-of-authority headers keep their difficulty and nonce
+	parent.Difficulty = big.NewInt(2)
+	child := newChild()
+	child.Difficulty = big.NewInt(2)
+	child.Nonce = ethtypes.EncodeNonce(7)
+	require.NoError(t, VerifyHeader(parent, child))
+}
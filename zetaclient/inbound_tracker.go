@@ -10,13 +10,14 @@ import (
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/zeta-chain/zetacore/common"
 	"github.com/zeta-chain/zetacore/x/crosschain/types"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
 	"golang.org/x/net/context"
 )
 
 // ExternalChainWatcherForNewInboundTrackerSuggestions At each tick, gets a list of Inbound tracker suggestions from zeta-core and tries to check if the in-tx was confirmed.
 // If it was, it tries to broadcast the confirmation vote. If this zeta client has previously broadcast the vote, the tx would be rejected
 func (ob *EVMChainClient) ExternalChainWatcherForNewInboundTrackerSuggestions() {
-	ticker := NewDynamicTicker(fmt.Sprintf("EVM_ExternalChainWatcher_InboundTrackerSuggestions_%d", ob.chain.ChainId), ob.GetCoreParams().InTxTicker)
+	ticker := NewDynamicTicker(fmt.Sprintf("EVM_ExternalChainWatcher_InboundTrackerSuggestions_%d", ob.chain.ChainId), ob.GetInTxTicker())
 	defer ticker.Stop()
 	ob.logger.ExternalChainWatcher.Info().Msg("ExternalChainWatcher for inboundTrackerSuggestions started")
 	for {
@@ -26,7 +27,7 @@ func (ob *EVMChainClient) ExternalChainWatcherForNewInboundTrackerSuggestions()
 			if err != nil {
 				ob.logger.ExternalChainWatcher.Err(err).Msg("ObserveTrackerSuggestions error")
 			}
-			ticker.UpdateInterval(ob.GetCoreParams().InTxTicker, ob.logger.ExternalChainWatcher)
+			ticker.UpdateInterval(ob.GetInTxTicker(), ob.logger.ExternalChainWatcher)
 		case <-ob.stop:
 			ob.logger.ExternalChainWatcher.Info().Msg("ExternalChainWatcher for inboundTrackerSuggestions stopped")
 			return
@@ -97,7 +98,10 @@ func (ob *BitcoinChainClient) CheckReceiptForBtcTxHash(txHash string, vote bool)
 	if event == nil {
 		return "", errors.New("no btc deposit event found")
 	}
-	msg := ob.GetInboundVoteMessageFromBtcEvent(event)
+	msg, err := ob.GetInboundVoteMessageFromBtcEvent(event)
+	if err != nil {
+		return "", err
+	}
 	if !vote {
 		return msg.Digest(), nil
 	}
@@ -142,7 +146,7 @@ func (ob *EVMChainClient) ObserveTrackerSuggestions() error {
 }
 
 func (ob *EVMChainClient) CheckReceiptForCoinTypeZeta(txHash string, vote bool) (string, error) {
-	connector, err := ob.GetConnectorContract()
+	connector, err := ob.getCoreContract(config.ContractKindConnector)
 	if err != nil {
 		return "", err
 	}
@@ -152,19 +156,30 @@ func (ob *EVMChainClient) CheckReceiptForCoinTypeZeta(txHash string, vote bool)
 		return "", err
 	}
 
+	if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+		return "", fmt.Errorf("inbound tx %s failed", txHash)
+	}
+
 	var msg types.MsgVoteOnObservedInboundTx
 	for _, log := range receipt.Logs {
-		event, err := connector.ParseZetaSent(*log)
-		if err == nil && event != nil {
-			msg, err = ob.GetInboundVoteMsgForZetaSentEvent(event)
-			if err == nil {
-				break
-			}
+		if !connector.emitted(*log) {
+			continue
+		}
+		vote, _, err := ob.getInboundVoteMsgForLog(connector, *log)
+		if err == nil {
+			msg = *vote
+			break
 		}
 	}
+	if msg.InTxHash == "" {
+		return "", fmt.Errorf("no valid ZetaSent event found in inbound tx %s", txHash)
+	}
 	if !vote {
 		return msg.Digest(), nil
 	}
+	if !ob.isInTxConfirmed(receipt.BlockNumber.Uint64()) {
+		return "", fmt.Errorf("inbound tx %s included in block %d is not confirmed yet", txHash, receipt.BlockNumber.Uint64())
+	}
 
 	zetaHash, err := ob.zetaClient.PostSend(PostSendNonEVMGasLimit, &msg)
 	if err != nil {
@@ -177,7 +192,7 @@ func (ob *EVMChainClient) CheckReceiptForCoinTypeZeta(txHash string, vote bool)
 }
 
 func (ob *EVMChainClient) CheckReceiptForCoinTypeERC20(txHash string, vote bool) (string, error) {
-	custody, err := ob.GetERC20CustodyContract()
+	custody, err := ob.getCoreContract(config.ContractKindERC20Custody)
 	if err != nil {
 		return "", err
 	}
@@ -186,19 +201,30 @@ func (ob *EVMChainClient) CheckReceiptForCoinTypeERC20(txHash string, vote bool)
 	if err != nil {
 		return "", err
 	}
+	if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+		return "", fmt.Errorf("inbound tx %s failed", txHash)
+	}
+
 	var msg types.MsgVoteOnObservedInboundTx
 	for _, log := range receipt.Logs {
-		zetaDeposited, err := custody.ParseDeposited(*log)
-		if err == nil && zetaDeposited != nil {
-			msg, err = ob.GetInboundVoteMsgForDepositedEvent(zetaDeposited)
-			if err == nil {
-				break
-			}
+		if !custody.emitted(*log) {
+			continue
+		}
+		vote, _, err := ob.getInboundVoteMsgForLog(custody, *log)
+		if err == nil {
+			msg = *vote
+			break
 		}
 	}
+	if msg.InTxHash == "" {
+		return "", fmt.Errorf("no valid Deposited event found in inbound tx %s", txHash)
+	}
 	if !vote {
 		return msg.Digest(), nil
 	}
+	if !ob.isInTxConfirmed(receipt.BlockNumber.Uint64()) {
+		return "", fmt.Errorf("inbound tx %s included in block %d is not confirmed yet", txHash, receipt.BlockNumber.Uint64())
+	}
 
 	zetaHash, err := ob.zetaClient.PostSend(PostSendEVMGasLimit, &msg)
 	if err != nil {
@@ -244,10 +270,16 @@ func (ob *EVMChainClient) CheckReceiptForCoinTypeGas(txHash string, vote bool) (
 			return "", err
 		}
 	}
-	msg := ob.GetInboundVoteMsgForTokenSentToTSS(tx.Hash(), tx.Value(), receipt, from, tx.Data())
+	msg, err := ob.GetInboundVoteMsgForTokenSentToTSS(tx.Hash(), tx.Value(), receipt, from, tx.Data(), 0)
+	if err != nil {
+		return "", err
+	}
 	if !vote {
 		return msg.Digest(), nil
 	}
+	if !ob.isInTxConfirmed(receipt.BlockNumber.Uint64()) {
+		return "", fmt.Errorf("inbound tx %s included in block %d is not confirmed yet", txHash, receipt.BlockNumber.Uint64())
+	}
 
 	zetaHash, err := ob.zetaClient.PostSend(PostSendEVMGasLimit, msg)
 	if err != nil {
@@ -16,7 +16,9 @@ import (
 	"github.com/zeta-chain/go-tss/blame"
 	"github.com/zeta-chain/zetacore/common"
 	crosschaintypes "github.com/zeta-chain/zetacore/x/crosschain/types"
+	fungibletypes "github.com/zeta-chain/zetacore/x/fungible/types"
 	observertypes "github.com/zeta-chain/zetacore/x/observer/types"
+	clienttypes "github.com/zeta-chain/zetacore/zetaclient/types"
 )
 
 // ChainClient is the interface for chain clients
@@ -30,6 +32,7 @@ type ChainClient interface {
 	GetPromCounter(name string) (prometheus.Counter, error)
 	GetTxID(nonce uint64) string
 	ExternalChainWatcherForNewInboundTrackerSuggestions()
+	GetWatcherStatus() []clienttypes.WatcherStatus
 }
 
 // ChainSigner is the interface to sign transactions for a chain
@@ -47,22 +50,11 @@ type ChainSigner interface {
 // ZetaCoreBridger is the interface to interact with ZetaCore
 type ZetaCoreBridger interface {
 	PostSend(zetaGasLimit uint64, msg *crosschaintypes.MsgVoteOnObservedInboundTx) (string, error)
-	PostReceiveConfirmation(
-		sendHash string,
-		outTxHash string,
-		outBlockHeight uint64,
-		outTxGasUsed uint64,
-		outTxEffectiveGasPrice *big.Int,
-		outTxEffectiveGasLimit uint64,
-		amount *big.Int,
-		status common.ReceiveStatus,
-		chain common.Chain,
-		nonce uint64,
-		coinType common.CoinType,
-	) (string, error)
+	PostReceiveConfirmation(confirmation OutboundConfirmation) (string, error)
 	PostGasPrice(chain common.Chain, gasPrice uint64, supply string, blockNum uint64) (string, error)
 	PostAddBlockHeader(chainID int64, txhash []byte, height int64, header common.HeaderData) (string, error)
 	GetBlockHeaderStateByChain(chainID int64) (observertypes.QueryGetBlockHeaderStateResponse, error)
+	Prove(blockHash string, txHash string, txIndex int64, proof *common.Proof, chainID int64) (bool, error)
 
 	PostBlameData(blame *blame.Blame, chainID int64, index string) (string, error)
 	AddTxHashToOutTxTracker(
@@ -80,13 +72,19 @@ type ZetaCoreBridger interface {
 	GetAllPendingCctx(chainID int64) ([]*crosschaintypes.CrossChainTx, error)
 	GetPendingNoncesByChain(chainID int64) (crosschaintypes.PendingNonces, error)
 	GetCctxByNonce(chainID int64, nonce uint64) (*crosschaintypes.CrossChainTx, error)
+	GetCctxByHash(sendHash string) (*crosschaintypes.CrossChainTx, error)
+	GetBallot(ballotIdentifier string) (*observertypes.QueryBallotByIdentifierResponse, error)
 	GetAllOutTxTrackerByChain(chain common.Chain, order Order) ([]crosschaintypes.OutTxTracker, error)
 	GetCrosschainFlags() (observertypes.CrosschainFlags, error)
 	GetObserverList(chain common.Chain) ([]string, error)
 	GetKeyGen() (*observertypes.Keygen, error)
 	GetBtcTssAddress() (string, error)
 	GetInboundTrackersForChain(chainID int64) ([]crosschaintypes.InTxTracker, error)
+	GetForeignCoins() ([]fungibletypes.ForeignCoins, error)
+	GetSystemContract() (fungibletypes.SystemContract, error)
 	GetLogger() *zerolog.Logger
+	CoreAvailable() bool
+	Congested() bool
 	Pause()
 	Unpause()
 }
@@ -117,9 +115,16 @@ type EVMRPCClient interface {
 	TransactionByHash(ctx context.Context, hash ethcommon.Hash) (tx *ethtypes.Transaction, isPending bool, err error)
 	TransactionReceipt(ctx context.Context, txHash ethcommon.Hash) (*ethtypes.Receipt, error)
 	TransactionSender(ctx context.Context, tx *ethtypes.Transaction, block ethcommon.Hash, index uint) (ethcommon.Address, error)
+	NonceAt(ctx context.Context, account ethcommon.Address, blockNumber *big.Int) (uint64, error)
+	BalanceAt(ctx context.Context, account ethcommon.Address, blockNumber *big.Int) (*big.Int, error)
 }
 
 // KlaytnRPCClient is the interface for Klaytn RPC client
 type KlaytnRPCClient interface {
 	BlockByNumber(ctx context.Context, number *big.Int) (*RPCBlock, error)
 }
+
+// RollupRPCClient is the interface for the RPC client reading rollup blocks as raw json
+type RollupRPCClient interface {
+	BlockByNumber(ctx context.Context, number *big.Int) (*RPCBlock, error)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
@@ -28,6 +29,9 @@ type Keys struct {
 	signerName      string
 	kb              ckeys.Keyring
 	OperatorAddress sdk.AccAddress
+
+	// passwordFile holds the password of the hotkey with the file backend, HOTKEY_PASSWORD is read if empty
+	passwordFile string
 }
 
 // NewKeysWithKeybase create a new instance of Keys
@@ -39,6 +43,11 @@ func NewKeysWithKeybase(kb ckeys.Keyring, granterAddress sdk.AccAddress, grantee
 	}
 }
 
+// SetHotkeyPasswordFile sets the file the password of the hotkey is read from with the file backend
+func (k *Keys) SetHotkeyPasswordFile(passwordFile string) {
+	k.passwordFile = passwordFile
+}
+
 func GetGranteeKeyName(signerName string) string {
 	return fmt.Sprintf("%s", signerName)
 }
@@ -52,10 +61,10 @@ func GetKeyringKeybase(cfg *config.Config) (ckeys.Keyring, string, error) {
 		return nil, "", fmt.Errorf("signer name is empty")
 	}
 
-	// read password from env if using keyring backend file
+	// read password from the password file or env if using keyring backend file
 	buf := bytes.NewBufferString("")
 	if cfg.KeyringBackend == config.KeyringBackendFile {
-		password, err := getHotkeyPassword()
+		password, err := getHotkeyPassword(cfg.HotkeyPasswordFile)
 		if err != nil {
 			return nil, "", err
 		}
@@ -102,8 +111,13 @@ func getKeybase(zetaCoreHome string, reader io.Reader, keyringBackend config.Key
 
 	// create a new keybase based on the selected backend
 	backend := ckeys.BackendTest
-	if keyringBackend == config.KeyringBackendFile {
+	switch keyringBackend {
+	case config.KeyringBackendFile:
 		backend = ckeys.BackendFile
+	case config.KeyringBackendOS:
+		// the os backend stores the keys in the keyring of the os, e.g. the secret service or the keychain, which
+		// handles their encryption itself
+		backend = ckeys.BackendOS
 	}
 
 	return ckeys.New(sdk.KeyringServiceName(), backend, cliDir, reader, cdc)
@@ -187,14 +201,26 @@ func (k *Keys) GetPubKeySet() (common.PubKeySet, error) {
 // returns empty if no password is needed
 func (k *Keys) GetHotkeyPassword() (string, error) {
 	if k.GetKeybase().Backend() == ckeys.BackendFile {
-		return getHotkeyPassword()
+		return getHotkeyPassword(k.passwordFile)
 	}
 	return "", nil
 }
 
-// getHotkeyPassword retrieves the HOTKEY_PASSWORD environment variable
-// and returns an error if it's not defined or shorter than 8 characters.
-func getHotkeyPassword() (string, error) {
+// getHotkeyPassword retrieves the password from the password file if set, the HOTKEY_PASSWORD environment variable
+// otherwise, and returns an error if it's not defined or shorter than 8 characters.
+func getHotkeyPassword(passwordFile string) (string, error) {
+	if passwordFile != "" {
+		content, err := os.ReadFile(passwordFile)
+		if err != nil {
+			return "", fmt.Errorf("fail to read hotkey password file %s: %w", passwordFile, err)
+		}
+		password := strings.TrimRight(string(content), "\r\n")
+		if len(password) < 8 {
+			return "", fmt.Errorf("hotkey password in %s should be at least 8 characters long", passwordFile)
+		}
+		return password, nil
+	}
+
 	password := os.Getenv(HotkeyPasswordEnvVar)
 
 	if password == "" {
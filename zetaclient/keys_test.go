@@ -123,3 +123,31 @@ func (ks *KeysSuite) TestNewKeys(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(pubKey.VerifySignature([]byte(msg), signedMsg), Equals, true)
 }
+
+func (ks *KeysSuite) TestGetHotkeyPassword(c *C) {
+	oldPassword, set := os.LookupEnv(HotkeyPasswordEnvVar)
+	defer func() {
+		if set {
+			os.Setenv(HotkeyPasswordEnvVar, oldPassword)
+		} else {
+			os.Unsetenv(HotkeyPasswordEnvVar)
+		}
+	}()
+	c.Assert(os.Setenv(HotkeyPasswordEnvVar, "envpassword"), IsNil)
+	password, err := getHotkeyPassword("")
+	c.Assert(err, IsNil)
+	c.Assert(password, Equals, "envpassword")
+
+	// the password file takes precedence over the environment, without its trailing new line
+	passwordFile := filepath.Join(c.MkDir(), "hotkey-password")
+	c.Assert(os.WriteFile(passwordFile, []byte("filepassword\n"), 0600), IsNil)
+	password, err = getHotkeyPassword(passwordFile)
+	c.Assert(err, IsNil)
+	c.Assert(password, Equals, "filepassword")
+
+	c.Assert(os.WriteFile(passwordFile, []byte("short\n"), 0600), IsNil)
+	_, err = getHotkeyPassword(passwordFile)
+	c.Assert(err, NotNil)
+	_, err = getHotkeyPassword(filepath.Join(c.MkDir(), "missing"))
+	c.Assert(err, NotNil)
+}
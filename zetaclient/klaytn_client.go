@@ -43,12 +43,13 @@ func Dial(url string) (*KlaytnClient, error) {
 }
 
 func (ec *KlaytnClient) BlockByNumber(ctx context.Context, number *big.Int) (*RPCBlock, error) {
-	return ec.getBlock(ctx, "klay_getBlockByNumber", toBlockNumArg(number), true)
+	return getRPCBlock(ctx, ec.c, "klay_getBlockByNumber", toBlockNumArg(number), true)
 }
 
-func (ec *KlaytnClient) getBlock(ctx context.Context, method string, args ...interface{}) (*RPCBlock, error) {
+// getRPCBlock fetches a block with its txs as raw json, without decoding the txs into go-ethereum types
+func getRPCBlock(ctx context.Context, c *rpc.Client, method string, args ...interface{}) (*RPCBlock, error) {
 	var raw json.RawMessage
-	err := ec.c.CallContext(ctx, &raw, method, args...)
+	err := c.CallContext(ctx, &raw, method, args...)
 	if err != nil {
 		return nil, err
 	} else if len(raw) == 0 {
@@ -11,6 +11,9 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+// DefaultPort is the port the metrics are served on if not set in config
+const DefaultPort = 8886
+
 type Metrics struct {
 	s *http.Server
 }
@@ -21,16 +24,394 @@ const (
 	//GAUGE_PENDING_TX MetricName = iota
 	//
 	//COUNTER_NUM_RPCS
-	PendingTxs = "pending_txs"
+	PendingTxs            = "pending_txs"
+	UnconfirmedInboundTxs = "unconfirmed_inbound_txs"
 )
 
 var (
 	Counters = map[string]prometheus.Counter{}
 
 	Gauges = map[string]prometheus.Gauge{}
+
+	// RetryCount counts the retries of rpc calls and broadcasts, labeled by call name
+	RetryCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zetaclient_retry_count",
+		Help: "Number of retries of rpc calls and broadcasts",
+	}, []string{"call"})
+
+	// RetryExhaustedCount counts the calls that still failed after using up their retry budget
+	RetryExhaustedCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zetaclient_retry_exhausted_count",
+		Help: "Number of rpc calls and broadcasts that failed after all retries",
+	}, []string{"call"})
+
+	// InboundEventsProcessed counts the inbound events observed on external chains, labeled by chain and event type
+	InboundEventsProcessed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zetaclient_inbound_events_processed_count",
+		Help: "Number of inbound events processed",
+	}, []string{"chain", "event"})
+
+	// PostSendCount counts the inbound votes posted to zetacore, labeled by chain and status (success or failure)
+	PostSendCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zetaclient_post_send_count",
+		Help: "Number of inbound votes posted to zetacore",
+	}, []string{"chain", "status"})
+
+	// EventCount counts the inbound and outbound events of external chains by event type and outcome: decoded into a
+	// vote or confirmation, posted to zetacore, failed, rejected or withheld, labeled by chain, event and outcome
+	EventCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zetaclient_event_count",
+		Help: "Number of inbound and outbound events of external chains by event type and outcome",
+	}, []string{"chain", "event", "outcome"})
+
+	// EventDuration is the time spent decoding the events of external chains and posting them to zetacore, labeled
+	// by chain, event and stage (decode or post)
+	EventDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "zetaclient_event_duration_seconds",
+		Help:    "Time spent decoding the events of external chains and posting them to zetacore",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"chain", "event", "stage"})
+
+	// InboundLatency is the time between the block of an inbound event and its vote posted to zetacore, labeled by chain
+	InboundLatency = prometheus.NewSummaryVec(prometheus.SummaryOpts{
+		Name:       "zetaclient_inbound_latency_seconds",
+		Help:       "Time between the block of an inbound event and its vote posted to zetacore",
+		Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		MaxAge:     time.Hour,
+	}, []string{"chain"})
+
+	// InboundLatencySLOBreaches counts the inbound votes posted later than the latency SLO of the chain, labeled by chain
+	InboundLatencySLOBreaches = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zetaclient_inbound_latency_slo_breaches",
+		Help: "Number of inbound votes posted later than the latency SLO after the block of their event",
+	}, []string{"chain"})
+
+	// LastScannedBlock is the last block scanned for inbound txs, labeled by chain
+	LastScannedBlock = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "zetaclient_last_scanned_block",
+		Help: "Last block scanned for inbound txs",
+	}, []string{"chain"})
+
+	// ChainHeadBlock is the latest block of the chain seen by the observer, labeled by chain
+	ChainHeadBlock = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "zetaclient_chain_head_block",
+		Help: "Latest block of the external chain",
+	}, []string{"chain"})
+
+	// ChainStalled is 1 while no new block of the chain was seen for longer than expected, labeled by chain
+	ChainStalled = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "zetaclient_chain_stalled",
+		Help: "Whether the external chain or its rpc endpoints stopped producing new blocks",
+	}, []string{"chain"})
+
+	// RPCLatency is the latency of the rpc calls to external chains, labeled by chain and method
+	RPCLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "zetaclient_rpc_latency_seconds",
+		Help:    "Latency of rpc calls to external chains",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"chain", "method"})
+
+	// RPCEndpointLatency is the latency of the last health probe of each rpc endpoint, labeled by chain and endpoint index
+	RPCEndpointLatency = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "zetaclient_rpc_endpoint_latency_seconds",
+		Help: "Latency of the last health probe of the rpc endpoints of external chains",
+	}, []string{"chain", "endpoint"})
+
+	// RPCErrorCount counts the failed rpc calls to external chains, labeled by chain and method
+	RPCErrorCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zetaclient_rpc_error_count",
+		Help: "Number of failed rpc calls to external chains",
+	}, []string{"chain", "method"})
+
+	// CoreCalls counts the calls of the bridge to zetacore, labeled by method and result code: the grpc status code of
+	// the queries, or the ABCI codespace and code of the broadcast txs
+	CoreCalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zetaclient_core_calls",
+		Help: "Number of calls to zetacore by method and result code",
+	}, []string{"method", "code"})
+
+	// SequenceMismatches counts the txs rejected by zetacore for their account sequence, signed again and retried
+	SequenceMismatches = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "zetaclient_sequence_mismatches",
+		Help: "Number of txs rejected by zetacore for their account sequence",
+	})
+
+	// CoreCircuitOpen is 1 while the posts to zetacore are paused by the circuit breaker, 0 otherwise
+	CoreCircuitOpen = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "zetaclient_core_circuit_open",
+		Help: "Whether the posts to zetacore are paused by the circuit breaker",
+	})
+
+	// CoreCallLatency is the latency of the calls of the bridge to zetacore, labeled by method and result code
+	CoreCallLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "zetaclient_core_call_latency_seconds",
+		Help:    "Latency of the calls to zetacore",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	// ShadowVotes counts the inbound votes of the chains in shadow mode by outcome: observed, matched by the ballots of
+	// the other observers, or unmatched, labeled by chain
+	ShadowVotes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zetaclient_shadow_votes",
+		Help: "Number of inbound votes withheld in shadow mode by outcome",
+	}, []string{"chain", "outcome"})
+
+	// ContractAdminEvents counts the admin events of the watched contracts: proxy upgrades, admin and ownership
+	// transfers, pauses and role changes, labeled by chain, contract and event
+	ContractAdminEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zetaclient_contract_admin_events",
+		Help: "Number of admin events of the watched contracts of external chains",
+	}, []string{"chain", "contract", "event"})
+
+	// QuarantinedEvents counts the inbound event logs quarantined because they could not be decoded into a vote,
+	// labeled by chain and event
+	QuarantinedEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zetaclient_quarantined_events",
+		Help: "Number of inbound event logs quarantined because they could not be decoded into a vote",
+	}, []string{"chain", "event"})
+
+	// InboundAboveCap counts the inbound transfers above the amount cap of their asset, labeled by chain and asset
+	InboundAboveCap = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zetaclient_inbound_above_cap",
+		Help: "Number of inbound transfers above the amount cap of their asset",
+	}, []string{"chain", "asset"})
+
+	// InboundDust counts the inbound transfers below the min amount of their asset, skipped rather than voted on,
+	// labeled by chain and asset
+	InboundDust = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zetaclient_inbound_dust",
+		Help: "Number of inbound transfers below the min amount of their asset skipped as dust",
+	}, []string{"chain", "asset"})
+
+	// ThrottledInbounds counts the inbound events throttled because their sender is above its rate limit, labeled by chain
+	ThrottledInbounds = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zetaclient_throttled_inbounds",
+		Help: "Number of inbound events throttled because their sender is above its rate limit",
+	}, []string{"chain"})
+
+	// NonWhitelistedDeposits counts the deposits rejected because their asset is not whitelisted, labeled by chain and
+	// asset
+	NonWhitelistedDeposits = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zetaclient_non_whitelisted_deposits",
+		Help: "Number of deposits rejected because their asset is not whitelisted",
+	}, []string{"chain", "asset"})
+
+	// SanctionedInbounds counts the inbound transfers not voted on because an address is sanctioned, labeled by chain
+	SanctionedInbounds = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zetaclient_sanctioned_inbounds",
+		Help: "Number of inbound transfers not voted on because their sender or receiver is sanctioned",
+	}, []string{"chain"})
+
+	// WatchedBalance is the balance of the TSS address and of the custody contract by asset, labeled by chain, address
+	// and asset; the gas token is the "gas" asset
+	WatchedBalance = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "zetaclient_watched_balance",
+		Help: "Balance of the TSS address and of the custody contract of external chains in the smallest unit of the asset",
+	}, []string{"chain", "address", "asset"})
+
+	// SignerBalance is the gas token balance of the TSS address broadcasting the outbound txs, labeled by chain
+	SignerBalance = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "zetaclient_signer_balance",
+		Help: "Gas token balance of the TSS address broadcasting the outbound txs in the smallest unit",
+	}, []string{"chain"})
+
+	// SignerBalanceLow is 1 while the balance of the TSS address is below the min signer balance, labeled by chain
+	SignerBalanceLow = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "zetaclient_signer_balance_low",
+		Help: "Whether the balance of the TSS address broadcasting the outbound txs is below the configured minimum",
+	}, []string{"chain"})
+
+	// BalanceDrops counts the unexpected drops of the watched balances, labeled by chain, address and asset
+	BalanceDrops = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zetaclient_balance_drops",
+		Help: "Number of unexpected drops of the balances of the TSS address and of the custody contract",
+	}, []string{"chain", "address", "asset"})
+
+	// RPCQuorumFailures counts the block ranges whose votes were withheld because the rpc endpoints disagreed, labeled by chain
+	RPCQuorumFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zetaclient_rpc_quorum_failures",
+		Help: "Number of block ranges of external chains on which too few rpc endpoints agreed",
+	}, []string{"chain"})
+
+	// ZetaCoreConnected is 1 while the grpc connection to zetacore is ready
+	ZetaCoreConnected = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "zetaclient_zetacore_connected",
+		Help: "Whether the grpc connection to zetacore is ready",
+	})
+
+	// PendingVotes counts the inbound votes whose post to zetacore failed, labeled by chain and status: enqueued for
+	// retry, posted on a retry or dead-lettered once the retries are exhausted
+	PendingVotes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zetaclient_pending_votes",
+		Help: "Number of inbound votes enqueued for retry after a failed post to zetacore, posted on a retry or dead-lettered",
+	}, []string{"chain", "status"})
+
+	// QueuedVotes is the number of inbound votes queued for a retry by chain, growing while zetacore is unavailable and
+	// drained once it is back
+	QueuedVotes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "zetaclient_queued_votes",
+		Help: "Number of inbound votes queued for a retry, pending zetacore",
+	}, []string{"chain"})
+
+	// RateLimitedPosts counts the inbound votes refused by the post rate limit of the bridge by chain, queued for a retry
+	RateLimitedPosts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zetaclient_rate_limited_posts",
+		Help: "Number of inbound votes of a chain above the post rate limit, queued for a retry",
+	}, []string{"chain"})
+
+	// InFlightPosts is the number of txs being broadcast to zetacore at once
+	InFlightPosts = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "zetaclient_in_flight_posts",
+		Help: "Number of txs being broadcast to zetacore",
+	})
+
+	// BackpressuredScans counts the inbound scans of a chain skipped while the bridge to zetacore is congested
+	BackpressuredScans = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zetaclient_backpressured_scans",
+		Help: "Number of inbound scans skipped while the bridge to zetacore is congested",
+	}, []string{"chain"})
+
+	// SkippedVotes counts the votes not posted since this observer has already voted on their ballot, by kind of vote
+	SkippedVotes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zetaclient_skipped_votes",
+		Help: "Number of votes not posted to zetacore since the observer has already voted on the ballot",
+	}, []string{"vote"})
+
+	// VotesBroadcast counts the votes broadcast to zetacore, by kind of vote; VotesIncluded counts those found counted
+	// in their ballot and VotesDropped those whose ballot never counted them
+	VotesBroadcast = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zetaclient_votes_broadcast",
+		Help: "Number of votes broadcast to zetacore",
+	}, []string{"vote"})
+	VotesIncluded = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zetaclient_votes_included",
+		Help: "Number of votes broadcast to zetacore found counted in their ballot",
+	}, []string{"vote"})
+	VotesDropped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zetaclient_votes_dropped",
+		Help: "Number of votes broadcast to zetacore never counted in their ballot",
+	}, []string{"vote"})
+
+	// VotedBallots counts the ballots this observer voted on by kind of vote and result: won if finalized as voted,
+	// lost if finalized otherwise, unfinalized if still in progress once no longer tracked
+	VotedBallots = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zetaclient_voted_ballots",
+		Help: "Number of ballots voted on by the observer, by whether they finalized as voted",
+	}, []string{"vote", "result"})
+
+	// OutboxEntries counts the txs of the outbox by outcome: replayed after a failed or lost broadcast, completed once
+	// included or dropped once the replays are exhausted
+	OutboxEntries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zetaclient_outbox_entries",
+		Help: "Number of txs of the outbox replayed, completed once included by zetacore or dropped",
+	}, []string{"status"})
+
+	// CoreEvents counts the events of zetacore pushed over the websocket subscription, by type
+	CoreEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "zetaclient_core_events",
+		Help: "Number of events of zetacore received over the websocket subscription",
+	}, []string{"type"})
+)
+
+const (
+	PostSendSuccess = "success"
+	PostSendFailure = "failure"
+)
+
+// outcomes and stages of the events of external chains
+const (
+	EventDecoded  = "decoded"
+	EventPosted   = "posted"
+	EventFailed   = "failed"
+	EventRejected = "rejected"
+	EventWithheld = "withheld"
+
+	EventStageDecode = "decode"
+	EventStagePost   = "post"
 )
 
-func NewMetrics() (*Metrics, error) {
+// statuses of the pending inbound votes
+const (
+	PendingVoteEnqueued     = "enqueued"
+	PendingVotePosted       = "posted"
+	PendingVoteDeadLettered = "dead_lettered"
+)
+
+// kinds of the votes
+const (
+	VoteInbound  = "inbound"
+	VoteOutbound = "outbound"
+)
+
+// results of the ballots voted on
+const (
+	BallotWon         = "won"
+	BallotLost        = "lost"
+	BallotUnfinalized = "unfinalized"
+)
+
+// outcomes of the txs of the outbox
+const (
+	OutboxReplayed  = "replayed"
+	OutboxCompleted = "completed"
+	OutboxDropped   = "dropped"
+)
+
+func init() {
+	prometheus.MustRegister(
+		RetryCount,
+		RetryExhaustedCount,
+		InboundEventsProcessed,
+		PostSendCount,
+		EventCount,
+		EventDuration,
+		InboundLatency,
+		InboundLatencySLOBreaches,
+		LastScannedBlock,
+		ChainHeadBlock,
+		ChainStalled,
+		RPCLatency,
+		RPCEndpointLatency,
+		RPCErrorCount,
+		CoreCalls,
+		CoreCallLatency,
+		CoreCircuitOpen,
+		SequenceMismatches,
+		RPCQuorumFailures,
+		QuarantinedEvents,
+		PendingVotes,
+		QueuedVotes,
+		RateLimitedPosts,
+		InFlightPosts,
+		BackpressuredScans,
+		OutboxEntries,
+		CoreEvents,
+		SkippedVotes,
+		VotesBroadcast,
+		VotesIncluded,
+		VotesDropped,
+		VotedBallots,
+		ZetaCoreConnected,
+		InboundAboveCap,
+		InboundDust,
+		ThrottledInbounds,
+		SanctionedInbounds,
+		NonWhitelistedDeposits,
+		WatchedBalance,
+		BalanceDrops,
+		SignerBalance,
+		SignerBalanceLow,
+		ContractAdminEvents,
+		ShadowVotes,
+	)
+}
+
+// NewMetrics creates the server of the /metrics endpoint listening on the given port
+func NewMetrics(port uint16) (*Metrics, error) {
+	if port == 0 {
+		port = DefaultPort
+	}
+
 	server := http.NewServeMux()
 
 	server.Handle("/metrics",
@@ -38,12 +419,14 @@ func NewMetrics() (*Metrics, error) {
 			prometheus.DefaultRegisterer,
 			promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{
 				Timeout: 30 * time.Second,
+				// the exemplars, e.g. the correlation ids of the event counts, are only served in the OpenMetrics format
+				EnableOpenMetrics: true,
 			}),
 		),
 	)
 
 	s := &http.Server{
-		Addr:              fmt.Sprintf(":8886"),
+		Addr:              fmt.Sprintf(":%d", port),
 		Handler:           server,
 		ReadTimeout:       5 * time.Second,
 		ReadHeaderTimeout: 5 * time.Second,
@@ -1,7 +1,10 @@
 package metrics
 
 import (
+	"fmt"
+	"io"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -17,7 +20,7 @@ func Test(t *testing.T) { TestingT(t) }
 var _ = Suite(&MetricsSuite{})
 
 func (ms *MetricsSuite) SetUpSuite(c *C) {
-	m, err := NewMetrics()
+	m, err := NewMetrics(DefaultPort)
 	c.Assert(err, IsNil)
 	m.Start()
 	ms.m = m
@@ -34,3 +37,31 @@ func (ms *MetricsSuite) TestMetrics(c *C) {
 	//out, err := ioutil.ReadAll(res.Body)
 	//fmt.Println(string(out))
 }
+
+func (ms *MetricsSuite) TestChainMetrics(c *C) {
+	ChainHeadBlock.WithLabelValues("eth_mainnet").Set(1000)
+	LastScannedBlock.WithLabelValues("eth_mainnet").Set(990)
+	PostSendCount.WithLabelValues("eth_mainnet", PostSendSuccess).Inc()
+	res, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/metrics", DefaultPort))
+	c.Assert(err, IsNil)
+	defer res.Body.Close()
+	out, err := io.ReadAll(res.Body)
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(string(out), `zetaclient_chain_head_block{chain="eth_mainnet"} 1000`), Equals, true)
+	c.Assert(strings.Contains(string(out), `zetaclient_last_scanned_block{chain="eth_mainnet"} 990`), Equals, true)
+	c.Assert(strings.Contains(string(out), `zetaclient_post_send_count{chain="eth_mainnet",status="success"} 1`), Equals, true)
+}
+
+func (ms *MetricsSuite) TestEventMetrics(c *C) {
+	EventCount.WithLabelValues("eth_mainnet", "Deposited", EventDecoded).Inc()
+	EventCount.WithLabelValues("eth_mainnet", "ZetaSent", EventFailed).Inc()
+	EventDuration.WithLabelValues("eth_mainnet", "Deposited", EventStagePost).Observe(0.2)
+	res, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/metrics", DefaultPort))
+	c.Assert(err, IsNil)
+	defer res.Body.Close()
+	out, err := io.ReadAll(res.Body)
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(string(out), `zetaclient_event_count{chain="eth_mainnet",event="Deposited",outcome="decoded"} 1`), Equals, true)
+	c.Assert(strings.Contains(string(out), `zetaclient_event_count{chain="eth_mainnet",event="ZetaSent",outcome="failed"} 1`), Equals, true)
+	c.Assert(strings.Contains(string(out), `zetaclient_event_duration_seconds_count{chain="eth_mainnet",event="Deposited",stage="post"} 1`), Equals, true)
+}
@@ -0,0 +1,222 @@
+package zetaclient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/zeta-chain/zetacore/common"
+	metricsPkg "github.com/zeta-chain/zetacore/zetaclient/metrics"
+	clienttypes "github.com/zeta-chain/zetacore/zetaclient/types"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+const (
+	// OutboxCheckInterval is the interval the inclusion of the txs of the outbox is checked at
+	OutboxCheckInterval = 30 * time.Second
+
+	// OutboxReplayAfter is how long a tx of the outbox is given to be included before it is broadcast again
+	OutboxReplayAfter = 2 * time.Minute
+
+	// OutboxMaxAttempts is the number of broadcasts of a tx after which it is dropped from the outbox
+	OutboxMaxAttempts = 10
+)
+
+// Outbox is a write-ahead log of the txs broadcast to zetacore: a tx is appended before its broadcast and completed
+// only once zetacore included it, so that the votes broadcast before a crash or lost on the way are replayed
+type Outbox struct {
+	db    *gorm.DB
+	codec codec.Codec
+}
+
+// OpenOutbox opens the outbox stored in the data directory dbPath, creating it if needed
+func OpenOutbox(dbPath string, cdc codec.Codec) (*Outbox, error) {
+	if err := os.MkdirAll(dbPath, os.ModePerm); err != nil {
+		return nil, err
+	}
+	db, err := gorm.Open(sqlite.Open(fmt.Sprintf("%s/outbox", dbPath)), &gorm.Config{})
+	if err != nil {
+		return nil, err
+	}
+	if err := db.AutoMigrate(&clienttypes.OutboxEntrySQLType{}); err != nil {
+		return nil, err
+	}
+	return &Outbox{db: db, codec: cdc}, nil
+}
+
+// Append writes the tx ahead of its broadcast and returns its entry; appending a tx already in the outbox, e.g. on a
+// retry, returns the existing entry
+func (o *Outbox) Append(gasLimit uint64, msg sdktypes.Msg, keyType common.KeyType) (*clienttypes.OutboxEntrySQLType, error) {
+	data, err := o.codec.MarshalInterface(msg)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(data)
+	entry := &clienttypes.OutboxEntrySQLType{
+		Key:      hex.EncodeToString(hash[:]),
+		KeyType:  string(keyType),
+		GasLimit: gasLimit,
+		Msg:      data,
+	}
+	if err := o.db.Where(&clienttypes.OutboxEntrySQLType{Key: entry.Key}).FirstOrCreate(entry).Error; err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// Record records a broadcast of the tx of the entry: the entry is completed if the tx was included, otherwise the hash
+// of the tx, if any, is kept for the inclusion to be checked later
+func (o *Outbox) Record(entry *clienttypes.OutboxEntrySQLType, txHash string, included bool) error {
+	if included {
+		return o.Complete(entry)
+	}
+	entry.Attempts++
+	if txHash != "" {
+		entry.TxHash = txHash
+	}
+	return o.db.Save(entry).Error
+}
+
+// Complete removes the entry of a tx included by zetacore
+func (o *Outbox) Complete(entry *clienttypes.OutboxEntrySQLType) error {
+	// delete permanently so that the unique key can be appended again
+	return o.db.Unscoped().Delete(entry).Error
+}
+
+// Due returns the entries last broadcast, or appended, before cutoff, oldest first
+func (o *Outbox) Due(cutoff time.Time) ([]clienttypes.OutboxEntrySQLType, error) {
+	var entries []clienttypes.OutboxEntrySQLType
+	if err := o.db.Where("updated_at <= ?", cutoff).Order("id").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Len returns the number of entries of the outbox
+func (o *Outbox) Len() (int64, error) {
+	var count int64
+	err := o.db.Model(&clienttypes.OutboxEntrySQLType{}).Count(&count).Error
+	return count, err
+}
+
+// Msg decodes the tx of the entry
+func (o *Outbox) Msg(entry *clienttypes.OutboxEntrySQLType) (sdktypes.Msg, error) {
+	var msg sdktypes.Msg
+	if err := o.codec.UnmarshalInterface(entry.Msg, &msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// EnableOutbox writes the txs broadcast by the bridge ahead to the outbox stored in dbPath. The incomplete txs of the
+// previous run are replayed right away, then the inclusion of the txs is checked until the bridge is stopped
+func (b *ZetaCoreBridge) EnableOutbox(dbPath string) error {
+	outbox, err := OpenOutbox(dbPath, b.encodingCfg.Codec)
+	if err != nil {
+		return err
+	}
+	b.outbox = outbox
+	go func() {
+		b.checkOutbox(time.Now())
+		ticker := time.NewTicker(OutboxCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-b.ctx.Done():
+				return
+			case <-ticker.C:
+				b.checkOutbox(time.Now().Add(-OutboxReplayAfter))
+			}
+		}
+	}()
+	return nil
+}
+
+// checkOutbox completes the entries of the outbox due by cutoff whose tx was included and broadcast the others again
+func (b *ZetaCoreBridge) checkOutbox(cutoff time.Time) {
+	// the replays are held while zetacore is unavailable rather than spending their attempts
+	if b.breaker.Allow() != nil {
+		return
+	}
+	entries, err := b.outbox.Due(cutoff)
+	if err != nil {
+		b.logger.Error().Err(err).Msg("checkOutbox: error reading outbox")
+		return
+	}
+	if len(entries) == 0 {
+		return
+	}
+	ctx, err := b.GetContext()
+	if err != nil {
+		b.logger.Error().Err(err).Msg("checkOutbox: fail to get context")
+		return
+	}
+	for i := range entries {
+		if b.ctx.Err() != nil {
+			return
+		}
+		b.checkOutboxEntry(ctx, &entries[i])
+	}
+}
+
+// checkOutboxEntry completes the entry if its tx was included, drops it once its broadcasts are exhausted and
+// broadcasts its tx again otherwise
+func (b *ZetaCoreBridge) checkOutboxEntry(ctx client.Context, entry *clienttypes.OutboxEntrySQLType) {
+	if entry.TxHash != "" && b.isIncluded(ctx, entry) {
+		if err := b.outbox.Complete(entry); err != nil {
+			b.logger.Error().Err(err).Msgf("checkOutbox: error completing outbox entry %s", entry.Key)
+			return
+		}
+		metricsPkg.OutboxEntries.WithLabelValues(metricsPkg.OutboxCompleted).Inc()
+		return
+	}
+	msg, err := b.outbox.Msg(entry)
+	if err != nil {
+		b.logger.Error().Err(err).Msgf("checkOutbox: dropping outbox entry %s, its msg can't be decoded", entry.Key)
+	} else if entry.Attempts >= OutboxMaxAttempts {
+		b.logger.Error().Msgf("checkOutbox: dropping outbox entry %s after %d attempts, last zeta tx %s",
+			entry.Key, entry.Attempts, entry.TxHash)
+	}
+	if err != nil || entry.Attempts >= OutboxMaxAttempts {
+		if err := b.outbox.Complete(entry); err != nil {
+			b.logger.Error().Err(err).Msgf("checkOutbox: error dropping outbox entry %s", entry.Key)
+			return
+		}
+		metricsPkg.OutboxEntries.WithLabelValues(metricsPkg.OutboxDropped).Inc()
+		return
+	}
+	metricsPkg.OutboxEntries.WithLabelValues(metricsPkg.OutboxReplayed).Inc()
+	txHash, included, err := b.broadcast(entry.GasLimit, msg, AuthZSigner{KeyType: common.KeyType(entry.KeyType)})
+	if err != nil {
+		b.logger.Warn().Err(err).Msgf("checkOutbox: replay %d of outbox entry %s failed", entry.Attempts+1, entry.Key)
+	} else {
+		b.logger.Info().Msgf("checkOutbox: outbox entry %s replayed: zeta tx %s", entry.Key, txHash)
+	}
+	if err := b.outbox.Record(entry, txHash, included); err != nil {
+		b.logger.Error().Err(err).Msgf("checkOutbox: error writing outbox entry %s", entry.Key)
+	}
+}
+
+// isIncluded returns true if the tx of the entry was included in a block. A tx failed by zetacore was included all
+// the same: replaying it would fail again, e.g. for a vote already cast, so its failure is only logged
+func (b *ZetaCoreBridge) isIncluded(ctx client.Context, entry *clienttypes.OutboxEntrySQLType) bool {
+	hash, err := hex.DecodeString(entry.TxHash)
+	if err != nil {
+		return false
+	}
+	res, err := ctx.Client.Tx(b.ctx, hash, false)
+	if err != nil {
+		return false
+	}
+	if res.TxResult.Code > 0 {
+		b.logger.Warn().Msgf("checkOutbox: zeta tx %s of outbox entry %s failed,code:%d, log:%s",
+			entry.TxHash, entry.Key, res.TxResult.Code, res.TxResult.Log)
+	}
+	return true
+}
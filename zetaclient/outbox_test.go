@@ -0,0 +1,80 @@
+package zetaclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/zeta-chain/zetacore/app"
+	"github.com/zeta-chain/zetacore/common"
+	crosschaintypes "github.com/zeta-chain/zetacore/x/crosschain/types"
+)
+
+func TestOutbox(t *testing.T) {
+	outbox, err := OpenOutbox(t.TempDir(), app.MakeEncodingConfig().Codec)
+	require.NoError(t, err)
+	msg := &crosschaintypes.MsgGasPriceVoter{Creator: "creator", ChainId: 1, Price: 10, BlockNumber: 100, Supply: "100"}
+
+	entry, err := outbox.Append(PostGasPriceGasLimit, msg, common.ZetaClientGranteeKey)
+	require.NoError(t, err)
+	decoded, err := outbox.Msg(entry)
+	require.NoError(t, err)
+	require.Equal(t, msg, decoded)
+
+	// a failed broadcast keeps the entry, appending the tx again returns it
+	require.NoError(t, outbox.Record(entry, "AA", false))
+	again, err := outbox.Append(PostGasPriceGasLimit, msg, common.ZetaClientGranteeKey)
+	require.NoError(t, err)
+	require.Equal(t, entry.ID, again.ID)
+	require.Equal(t, "AA", again.TxHash)
+	require.Equal(t, 1, again.Attempts)
+
+	entries, err := outbox.Due(time.Now())
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	entries, err = outbox.Due(time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	// the entry is completed once included
+	require.NoError(t, outbox.Record(again, "BB", true))
+	entries, err = outbox.Due(time.Now())
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestZetaCoreBridge_CheckOutboxEntry(t *testing.T) {
+	outbox, err := OpenOutbox(t.TempDir(), app.MakeEncodingConfig().Codec)
+	require.NoError(t, err)
+	bridge := &ZetaCoreBridge{ctx: context.Background(), logger: zerolog.Nop(), outbox: outbox}
+	ctx := client.Context{}.WithClient(&txClient{included: map[string]abci.ResponseDeliverTx{
+		"AA": {Code: 0},
+		"BB": {Code: 5, Log: "voter has already voted"},
+	}})
+
+	// an entry whose tx was included is completed
+	included, err := outbox.Append(DefaultGasLimit, &crosschaintypes.MsgGasPriceVoter{Creator: "creator", ChainId: 1, Price: 10}, common.ZetaClientGranteeKey)
+	require.NoError(t, err)
+	require.NoError(t, outbox.Record(included, "AA", false))
+	bridge.checkOutboxEntry(ctx, included)
+	// so is an entry whose tx was included but failed, replaying it would fail again
+	failed, err := outbox.Append(DefaultGasLimit, &crosschaintypes.MsgGasPriceVoter{Creator: "creator", ChainId: 1, Price: 15}, common.ZetaClientGranteeKey)
+	require.NoError(t, err)
+	require.NoError(t, outbox.Record(failed, "BB", false))
+	bridge.checkOutboxEntry(ctx, failed)
+
+	// an entry whose broadcasts are exhausted is dropped
+	exhausted, err := outbox.Append(DefaultGasLimit, &crosschaintypes.MsgGasPriceVoter{Creator: "creator", ChainId: 1, Price: 20}, common.ZetaClientGranteeKey)
+	require.NoError(t, err)
+	exhausted.Attempts = OutboxMaxAttempts - 1
+	require.NoError(t, outbox.Record(exhausted, "CC", false))
+	bridge.checkOutboxEntry(ctx, exhausted)
+
+	entries, err := outbox.Due(time.Now())
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
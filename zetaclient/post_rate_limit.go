@@ -0,0 +1,43 @@
+package zetaclient
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/zeta-chain/zetacore/common"
+	metricsPkg "github.com/zeta-chain/zetacore/zetaclient/metrics"
+)
+
+// SetPostRateLimit limits the inbound votes posted per chain to perMinute votes per minute after a burst of up to
+// burst votes, perMinute if zero, so that a spam burst on one chain can't take over the sequence and the gas budget
+// of the hotkey. The votes above are refused with ErrPostRateLimited, for the chain clients to queue for a retry.
+// The outbound confirmations are never limited. Rate limiting is disabled if perMinute is zero
+func (b *ZetaCoreBridge) SetPostRateLimit(perMinute uint64, burst uint64) {
+	if perMinute == 0 {
+		b.postLimiter = nil
+		return
+	}
+	b.postLimiter = NewSenderRateLimiter()
+	b.postRate = perMinute
+	b.postBurst = burst
+}
+
+// checkPostRateLimit takes a token from the bucket of the chain of an inbound vote and returns ErrPostRateLimited if
+// the chain is above its rate
+func (b *ZetaCoreBridge) checkPostRateLimit(chainID int64) error {
+	// the token buckets of the sender rate limiter are keyed by chain here
+	allowed, burstStarted := b.postLimiter.Allow(strconv.FormatInt(chainID, 10), b.postRate, b.postBurst, time.Now())
+	if allowed {
+		return nil
+	}
+	chainName := strconv.FormatInt(chainID, 10)
+	if chain := common.GetChainFromChainID(chainID); chain != nil {
+		chainName = chain.Name()
+	}
+	metricsPkg.RateLimitedPosts.WithLabelValues(chainName).Inc()
+	if burstStarted {
+		b.logger.Warn().Msgf("checkPostRateLimit: chain %s is above %d inbound votes per minute; queueing its votes", chainName, b.postRate)
+	}
+	return fmt.Errorf("%w: chain %s above %d votes per minute", ErrPostRateLimited, chainName, b.postRate)
+}
@@ -0,0 +1,29 @@
+package zetaclient
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/zeta-chain/zetacore/common"
+	metricsPkg "github.com/zeta-chain/zetacore/zetaclient/metrics"
+)
+
+func TestZetaCoreBridge_PostRateLimit(t *testing.T) {
+	bridge := &ZetaCoreBridge{logger: zerolog.Nop()}
+	bridge.SetPostRateLimit(60, 2)
+	limited := testutil.ToFloat64(metricsPkg.RateLimitedPosts.WithLabelValues(common.EthChain().Name()))
+
+	// the burst of a chain is allowed, the votes above are refused
+	require.NoError(t, bridge.checkPostRateLimit(common.EthChain().ChainId))
+	require.NoError(t, bridge.checkPostRateLimit(common.EthChain().ChainId))
+	require.ErrorIs(t, bridge.checkPostRateLimit(common.EthChain().ChainId), ErrPostRateLimited)
+	require.Equal(t, limited+1, testutil.ToFloat64(metricsPkg.RateLimitedPosts.WithLabelValues(common.EthChain().Name())))
+
+	// without holding back the votes of the other chains
+	require.NoError(t, bridge.checkPostRateLimit(common.BscMainnetChain().ChainId))
+
+	bridge.SetPostRateLimit(0, 0)
+	require.Nil(t, bridge.postLimiter)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/zeta-chain/zetacore/cmd/zetacored/config"
 	"github.com/zeta-chain/zetacore/common"
 	"github.com/zeta-chain/zetacore/x/crosschain/types"
+	fungibletypes "github.com/zeta-chain/zetacore/x/fungible/types"
 	observertypes "github.com/zeta-chain/zetacore/x/observer/types"
 	"google.golang.org/grpc"
 )
@@ -31,7 +32,7 @@ const (
 )
 
 func (b *ZetaCoreBridge) GetCrosschainFlags() (observertypes.CrosschainFlags, error) {
-	client := observertypes.NewQueryClient(b.grpcConn)
+	client := observertypes.NewQueryClient(b.conn())
 	resp, err := client.CrosschainFlags(context.Background(), &observertypes.QueryGetCrosschainFlagsRequest{})
 	if err != nil {
 		return observertypes.CrosschainFlags{}, err
@@ -40,7 +41,7 @@ func (b *ZetaCoreBridge) GetCrosschainFlags() (observertypes.CrosschainFlags, er
 }
 
 func (b *ZetaCoreBridge) GetCoreParamsForChainID(externalChainID int64) (*observertypes.CoreParams, error) {
-	client := observertypes.NewQueryClient(b.grpcConn)
+	client := observertypes.NewQueryClient(b.conn())
 	resp, err := client.GetCoreParamsForChain(context.Background(), &observertypes.QueryGetCoreParamsForChainRequest{ChainId: externalChainID})
 	if err != nil {
 		return &observertypes.CoreParams{}, err
@@ -49,7 +50,7 @@ func (b *ZetaCoreBridge) GetCoreParamsForChainID(externalChainID int64) (*observ
 }
 
 func (b *ZetaCoreBridge) GetCoreParams() ([]*observertypes.CoreParams, error) {
-	client := observertypes.NewQueryClient(b.grpcConn)
+	client := observertypes.NewQueryClient(b.conn())
 	var err error
 
 	resp := &observertypes.QueryGetCoreParamsResponse{}
@@ -64,7 +65,7 @@ func (b *ZetaCoreBridge) GetCoreParams() ([]*observertypes.CoreParams, error) {
 }
 
 func (b *ZetaCoreBridge) GetObserverParams() (observertypes.Params, error) {
-	client := observertypes.NewQueryClient(b.grpcConn)
+	client := observertypes.NewQueryClient(b.conn())
 	resp, err := client.Params(context.Background(), &observertypes.QueryParamsRequest{})
 	if err != nil {
 		return observertypes.Params{}, err
@@ -73,7 +74,7 @@ func (b *ZetaCoreBridge) GetObserverParams() (observertypes.Params, error) {
 }
 
 func (b *ZetaCoreBridge) GetUpgradePlan() (*upgradetypes.Plan, error) {
-	client := upgradetypes.NewQueryClient(b.grpcConn)
+	client := upgradetypes.NewQueryClient(b.conn())
 
 	resp, err := client.CurrentPlan(context.Background(), &upgradetypes.QueryCurrentPlanRequest{})
 	if err != nil {
@@ -83,7 +84,7 @@ func (b *ZetaCoreBridge) GetUpgradePlan() (*upgradetypes.Plan, error) {
 }
 
 func (b *ZetaCoreBridge) GetAllCctx() ([]*types.CrossChainTx, error) {
-	client := types.NewQueryClient(b.grpcConn)
+	client := types.NewQueryClient(b.conn())
 	resp, err := client.CctxAll(context.Background(), &types.QueryAllCctxRequest{})
 	if err != nil {
 		return nil, err
@@ -92,7 +93,7 @@ func (b *ZetaCoreBridge) GetAllCctx() ([]*types.CrossChainTx, error) {
 }
 
 func (b *ZetaCoreBridge) GetCctxByHash(sendHash string) (*types.CrossChainTx, error) {
-	client := types.NewQueryClient(b.grpcConn)
+	client := types.NewQueryClient(b.conn())
 	resp, err := client.Cctx(context.Background(), &types.QueryGetCctxRequest{Index: sendHash})
 	if err != nil {
 		return nil, err
@@ -101,7 +102,7 @@ func (b *ZetaCoreBridge) GetCctxByHash(sendHash string) (*types.CrossChainTx, er
 }
 
 func (b *ZetaCoreBridge) GetCctxByNonce(chainID int64, nonce uint64) (*types.CrossChainTx, error) {
-	client := types.NewQueryClient(b.grpcConn)
+	client := types.NewQueryClient(b.conn())
 	resp, err := client.CctxByNonce(context.Background(), &types.QueryGetCctxByNonceRequest{
 		ChainID: chainID,
 		Nonce:   nonce,
@@ -114,7 +115,7 @@ func (b *ZetaCoreBridge) GetCctxByNonce(chainID int64, nonce uint64) (*types.Cro
 
 func (b *ZetaCoreBridge) GetObserverList(chain common.Chain) ([]string, error) {
 	var err error
-	client := observertypes.NewQueryClient(b.grpcConn)
+	client := observertypes.NewQueryClient(b.conn())
 
 	for i := 0; i <= DefaultRetryCount; i++ {
 		resp, err := client.ObserversByChain(context.Background(), &observertypes.QueryObserversByChainRequest{ObservationChain: chain.ChainName.String()})
@@ -127,7 +128,7 @@ func (b *ZetaCoreBridge) GetObserverList(chain common.Chain) ([]string, error) {
 }
 
 func (b *ZetaCoreBridge) GetAllPendingCctx(chainID int64) ([]*types.CrossChainTx, error) {
-	client := types.NewQueryClient(b.grpcConn)
+	client := types.NewQueryClient(b.conn())
 	maxSizeOption := grpc.MaxCallRecvMsgSize(32 * 1024 * 1024)
 	resp, err := client.CctxAllPending(context.Background(), &types.QueryAllCctxPendingRequest{ChainId: chainID}, maxSizeOption)
 	if err != nil {
@@ -137,7 +138,7 @@ func (b *ZetaCoreBridge) GetAllPendingCctx(chainID int64) ([]*types.CrossChainTx
 }
 
 func (b *ZetaCoreBridge) GetCctxByStatus(status types.CctxStatus) ([]types.CrossChainTx, error) {
-	client := types.NewQueryClient(b.grpcConn)
+	client := types.NewQueryClient(b.conn())
 	resp, err := client.CctxByStatus(context.Background(), &types.QueryCctxByStatusRequest{Status: status})
 	if err != nil {
 		return nil, err
@@ -146,7 +147,7 @@ func (b *ZetaCoreBridge) GetCctxByStatus(status types.CctxStatus) ([]types.Cross
 }
 
 func (b *ZetaCoreBridge) GetGenesisSupply() (sdkmath.Int, error) {
-	tmURL := fmt.Sprintf("http://%s", b.cfg.ChainRPC)
+	tmURL := fmt.Sprintf("http://%s", b.chainRPC())
 	s, err := tmhttp.New(tmURL, "/websocket")
 	if err != nil {
 		return sdkmath.ZeroInt(), err
@@ -164,7 +165,7 @@ func (b *ZetaCoreBridge) GetGenesisSupply() (sdkmath.Int, error) {
 }
 
 func (b *ZetaCoreBridge) GetZetaTokenSupplyOnNode() (sdkmath.Int, error) {
-	client := banktypes.NewQueryClient(b.grpcConn)
+	client := banktypes.NewQueryClient(b.conn())
 	resp, err := client.SupplyOf(context.Background(), &banktypes.QuerySupplyOfRequest{Denom: config.BaseDenom})
 	if err != nil {
 		return sdkmath.ZeroInt(), err
@@ -173,7 +174,7 @@ func (b *ZetaCoreBridge) GetZetaTokenSupplyOnNode() (sdkmath.Int, error) {
 }
 
 func (b *ZetaCoreBridge) GetLastBlockHeight() ([]*types.LastBlockHeight, error) {
-	client := types.NewQueryClient(b.grpcConn)
+	client := types.NewQueryClient(b.conn())
 	resp, err := client.LastBlockHeightAll(context.Background(), &types.QueryAllLastBlockHeightRequest{})
 	if err != nil {
 		b.logger.Error().Err(err).Msg("query GetBlockHeight error")
@@ -183,7 +184,7 @@ func (b *ZetaCoreBridge) GetLastBlockHeight() ([]*types.LastBlockHeight, error)
 }
 
 func (b *ZetaCoreBridge) GetLatestZetaBlock() (*tmtypes.Block, error) {
-	client := tmservice.NewServiceClient(b.grpcConn)
+	client := tmservice.NewServiceClient(b.conn())
 	res, err := client.GetLatestBlock(context.Background(), &tmservice.GetLatestBlockRequest{})
 	if err != nil {
 		return nil, err
@@ -194,7 +195,7 @@ func (b *ZetaCoreBridge) GetLatestZetaBlock() (*tmtypes.Block, error) {
 func (b *ZetaCoreBridge) GetNodeInfo() (*tmservice.GetNodeInfoResponse, error) {
 	var err error
 
-	client := tmservice.NewServiceClient(b.grpcConn)
+	client := tmservice.NewServiceClient(b.conn())
 	for i := 0; i <= DefaultRetryCount; i++ {
 		res, err := client.GetNodeInfo(context.Background(), &tmservice.GetNodeInfoRequest{})
 		if err == nil {
@@ -206,7 +207,7 @@ func (b *ZetaCoreBridge) GetNodeInfo() (*tmservice.GetNodeInfoResponse, error) {
 }
 
 func (b *ZetaCoreBridge) GetLastBlockHeightByChain(chain common.Chain) (*types.LastBlockHeight, error) {
-	client := types.NewQueryClient(b.grpcConn)
+	client := types.NewQueryClient(b.conn())
 	resp, err := client.LastBlockHeight(context.Background(), &types.QueryGetLastBlockHeightRequest{Index: chain.ChainName.String()})
 	if err != nil {
 		return nil, err
@@ -215,7 +216,7 @@ func (b *ZetaCoreBridge) GetLastBlockHeightByChain(chain common.Chain) (*types.L
 }
 
 func (b *ZetaCoreBridge) GetZetaBlockHeight() (int64, error) {
-	client := types.NewQueryClient(b.grpcConn)
+	client := types.NewQueryClient(b.conn())
 	resp, err := client.LastZetaHeight(context.Background(), &types.QueryLastZetaHeightRequest{})
 	if err != nil {
 		return 0, err
@@ -224,7 +225,7 @@ func (b *ZetaCoreBridge) GetZetaBlockHeight() (int64, error) {
 }
 
 func (b *ZetaCoreBridge) GetNonceByChain(chain common.Chain) (*types.ChainNonces, error) {
-	client := types.NewQueryClient(b.grpcConn)
+	client := types.NewQueryClient(b.conn())
 	resp, err := client.ChainNonces(context.Background(), &types.QueryGetChainNoncesRequest{Index: chain.ChainName.String()})
 	if err != nil {
 		return nil, err
@@ -233,7 +234,7 @@ func (b *ZetaCoreBridge) GetNonceByChain(chain common.Chain) (*types.ChainNonces
 }
 
 func (b *ZetaCoreBridge) GetAllNodeAccounts() ([]*observertypes.NodeAccount, error) {
-	client := observertypes.NewQueryClient(b.grpcConn)
+	client := observertypes.NewQueryClient(b.conn())
 	resp, err := client.NodeAccountAll(context.Background(), &observertypes.QueryAllNodeAccountRequest{})
 	if err != nil {
 		return nil, err
@@ -244,7 +245,7 @@ func (b *ZetaCoreBridge) GetAllNodeAccounts() ([]*observertypes.NodeAccount, err
 
 func (b *ZetaCoreBridge) GetKeyGen() (*observertypes.Keygen, error) {
 	var err error
-	client := observertypes.NewQueryClient(b.grpcConn)
+	client := observertypes.NewQueryClient(b.conn())
 
 	for i := 0; i <= ExtendedRetryCount; i++ {
 		resp, err := client.Keygen(context.Background(), &observertypes.QueryGetKeygenRequest{})
@@ -258,7 +259,7 @@ func (b *ZetaCoreBridge) GetKeyGen() (*observertypes.Keygen, error) {
 }
 
 func (b *ZetaCoreBridge) GetBallot(ballotIdentifier string) (*observertypes.QueryBallotByIdentifierResponse, error) {
-	client := observertypes.NewQueryClient(b.grpcConn)
+	client := observertypes.NewQueryClient(b.conn())
 	resp, err := client.BallotByIdentifier(context.Background(), &observertypes.QueryBallotByIdentifierRequest{
 		BallotIdentifier: ballotIdentifier,
 	})
@@ -269,7 +270,7 @@ func (b *ZetaCoreBridge) GetBallot(ballotIdentifier string) (*observertypes.Quer
 }
 
 func (b *ZetaCoreBridge) GetInboundTrackersForChain(chainID int64) ([]types.InTxTracker, error) {
-	client := types.NewQueryClient(b.grpcConn)
+	client := types.NewQueryClient(b.conn())
 	resp, err := client.InTxTrackerAllByChain(context.Background(), &types.QueryAllInTxTrackerByChainRequest{ChainId: chainID})
 	if err != nil {
 		return nil, err
@@ -278,7 +279,7 @@ func (b *ZetaCoreBridge) GetInboundTrackersForChain(chainID int64) ([]types.InTx
 }
 
 func (b *ZetaCoreBridge) GetCurrentTss() (*types.TSS, error) {
-	client := types.NewQueryClient(b.grpcConn)
+	client := types.NewQueryClient(b.conn())
 	resp, err := client.TSS(context.Background(), &types.QueryGetTSSRequest{})
 	if err != nil {
 		return nil, err
@@ -287,7 +288,7 @@ func (b *ZetaCoreBridge) GetCurrentTss() (*types.TSS, error) {
 }
 
 func (b *ZetaCoreBridge) GetEthTssAddress() (string, error) {
-	client := types.NewQueryClient(b.grpcConn)
+	client := types.NewQueryClient(b.conn())
 	resp, err := client.GetTssAddress(context.Background(), &types.QueryGetTssAddressRequest{})
 	if err != nil {
 		return "", err
@@ -296,7 +297,7 @@ func (b *ZetaCoreBridge) GetEthTssAddress() (string, error) {
 }
 
 func (b *ZetaCoreBridge) GetBtcTssAddress() (string, error) {
-	client := types.NewQueryClient(b.grpcConn)
+	client := types.NewQueryClient(b.conn())
 	resp, err := client.GetTssAddress(context.Background(), &types.QueryGetTssAddressRequest{})
 	if err != nil {
 		return "", err
@@ -305,7 +306,7 @@ func (b *ZetaCoreBridge) GetBtcTssAddress() (string, error) {
 }
 
 func (b *ZetaCoreBridge) GetTssHistory() ([]types.TSS, error) {
-	client := types.NewQueryClient(b.grpcConn)
+	client := types.NewQueryClient(b.conn())
 	resp, err := client.TssHistory(context.Background(), &types.QueryTssHistoryRequest{})
 	if err != nil {
 		return nil, err
@@ -314,7 +315,7 @@ func (b *ZetaCoreBridge) GetTssHistory() ([]types.TSS, error) {
 }
 
 func (b *ZetaCoreBridge) GetOutTxTracker(chain common.Chain, nonce uint64) (*types.OutTxTracker, error) {
-	client := types.NewQueryClient(b.grpcConn)
+	client := types.NewQueryClient(b.conn())
 	resp, err := client.OutTxTracker(context.Background(), &types.QueryGetOutTxTrackerRequest{
 		ChainID: chain.ChainId,
 		Nonce:   nonce,
@@ -326,7 +327,7 @@ func (b *ZetaCoreBridge) GetOutTxTracker(chain common.Chain, nonce uint64) (*typ
 }
 
 func (b *ZetaCoreBridge) GetAllOutTxTrackerByChain(chain common.Chain, order Order) ([]types.OutTxTracker, error) {
-	client := types.NewQueryClient(b.grpcConn)
+	client := types.NewQueryClient(b.conn())
 	resp, err := client.OutTxTrackerAllByChain(context.Background(), &types.QueryAllOutTxTrackerByChainRequest{
 		Chain: chain.ChainId,
 		Pagination: &query.PageRequest{
@@ -353,8 +354,38 @@ func (b *ZetaCoreBridge) GetAllOutTxTrackerByChain(chain common.Chain, order Ord
 	return resp.OutTxTracker, nil
 }
 
+// GetForeignCoins returns the foreign coins of all chains, i.e. the gas assets and the whitelisted ERC20s
+func (b *ZetaCoreBridge) GetForeignCoins() ([]fungibletypes.ForeignCoins, error) {
+	client := fungibletypes.NewQueryClient(b.conn())
+	var coins []fungibletypes.ForeignCoins
+	var key []byte
+	for {
+		resp, err := client.ForeignCoinsAll(context.Background(), &fungibletypes.QueryAllForeignCoinsRequest{
+			Pagination: &query.PageRequest{Key: key, Limit: 1000},
+		})
+		if err != nil {
+			return nil, err
+		}
+		coins = append(coins, resp.ForeignCoins...)
+		if resp.Pagination == nil || len(resp.Pagination.NextKey) == 0 {
+			return coins, nil
+		}
+		key = resp.Pagination.NextKey
+	}
+}
+
+// GetSystemContract returns the system contracts of zEVM, i.e. the system contract and the zEVM connector
+func (b *ZetaCoreBridge) GetSystemContract() (fungibletypes.SystemContract, error) {
+	client := fungibletypes.NewQueryClient(b.conn())
+	resp, err := client.SystemContract(context.Background(), &fungibletypes.QueryGetSystemContractRequest{})
+	if err != nil {
+		return fungibletypes.SystemContract{}, err
+	}
+	return resp.SystemContract, nil
+}
+
 func (b *ZetaCoreBridge) GetClientParams(chainID int64) (observertypes.QueryGetCoreParamsForChainResponse, error) {
-	client := observertypes.NewQueryClient(b.grpcConn)
+	client := observertypes.NewQueryClient(b.conn())
 	resp, err := client.GetCoreParamsForChain(context.Background(), &observertypes.QueryGetCoreParamsForChainRequest{ChainId: chainID})
 	if err != nil {
 		return observertypes.QueryGetCoreParamsForChainResponse{}, err
@@ -363,7 +394,7 @@ func (b *ZetaCoreBridge) GetClientParams(chainID int64) (observertypes.QueryGetC
 }
 
 func (b *ZetaCoreBridge) GetPendingNoncesByChain(chainID int64) (types.PendingNonces, error) {
-	client := types.NewQueryClient(b.grpcConn)
+	client := types.NewQueryClient(b.conn())
 	resp, err := client.PendingNoncesByChain(context.Background(), &types.QueryPendingNoncesByChainRequest{ChainId: chainID})
 	if err != nil {
 		return types.PendingNonces{}, err
@@ -372,7 +403,7 @@ func (b *ZetaCoreBridge) GetPendingNoncesByChain(chainID int64) (types.PendingNo
 }
 
 func (b *ZetaCoreBridge) GetBlockHeaderStateByChain(chainID int64) (observertypes.QueryGetBlockHeaderStateResponse, error) {
-	client := observertypes.NewQueryClient(b.grpcConn)
+	client := observertypes.NewQueryClient(b.conn())
 	resp, err := client.GetBlockHeaderStateByChain(context.Background(), &observertypes.QueryGetBlockHeaderStateRequest{ChainId: chainID})
 	if err != nil {
 		return observertypes.QueryGetBlockHeaderStateResponse{}, err
@@ -381,7 +412,7 @@ func (b *ZetaCoreBridge) GetBlockHeaderStateByChain(chainID int64) (observertype
 }
 
 func (b *ZetaCoreBridge) GetSupportedChains() ([]*common.Chain, error) {
-	client := observertypes.NewQueryClient(b.grpcConn)
+	client := observertypes.NewQueryClient(b.conn())
 	resp, err := client.SupportedChains(context.Background(), &observertypes.QuerySupportedChains{})
 	if err != nil {
 		return nil, err
@@ -390,7 +421,7 @@ func (b *ZetaCoreBridge) GetSupportedChains() ([]*common.Chain, error) {
 }
 
 func (b *ZetaCoreBridge) GetPendingNonces() (*types.QueryAllPendingNoncesResponse, error) {
-	client := types.NewQueryClient(b.grpcConn)
+	client := types.NewQueryClient(b.conn())
 	resp, err := client.PendingNoncesAll(context.Background(), &types.QueryAllPendingNoncesRequest{})
 	if err != nil {
 		return nil, err
@@ -399,7 +430,7 @@ func (b *ZetaCoreBridge) GetPendingNonces() (*types.QueryAllPendingNoncesRespons
 }
 
 func (b *ZetaCoreBridge) Prove(blockHash string, txHash string, txIndex int64, proof *common.Proof, chainID int64) (bool, error) {
-	client := observertypes.NewQueryClient(b.grpcConn)
+	client := observertypes.NewQueryClient(b.conn())
 	resp, err := client.Prove(context.Background(), &observertypes.QueryProveRequest{
 		BlockHash: blockHash,
 		TxIndex:   txIndex,
@@ -412,3 +443,77 @@ func (b *ZetaCoreBridge) Prove(blockHash string, txHash string, txIndex int64, p
 	}
 	return resp.Valid, nil
 }
+
+// GetCctxByInboundHash returns the cctxs created by the inbound tx of the hash
+func (b *ZetaCoreBridge) GetCctxByInboundHash(inTxHash string) ([]types.CrossChainTx, error) {
+	client := types.NewQueryClient(b.conn())
+	resp, err := client.InTxHashToCctxData(context.Background(), &types.QueryInTxHashToCctxDataRequest{InTxHash: inTxHash})
+	if err != nil {
+		return nil, err
+	}
+	return resp.CrossChainTxs, nil
+}
+
+// GetCctxStatus returns the status of the cctx of the send hash
+func (b *ZetaCoreBridge) GetCctxStatus(sendHash string) (*types.Status, error) {
+	cctx, err := b.GetCctxByHash(sendHash)
+	if err != nil {
+		return nil, err
+	}
+	if cctx.CctxStatus == nil {
+		return nil, fmt.Errorf("cctx %s has no status", sendHash)
+	}
+	return cctx.CctxStatus, nil
+}
+
+// PendingOutbounds is the queue of the outbound txs of a chain pending on zetacore
+type PendingOutbounds struct {
+	ChainID int64                 `json:"chain_id"`
+	Nonces  types.PendingNonces   `json:"pending_nonces"`
+	Cctxs   []*types.CrossChainTx `json:"cctxs"`
+	Tracked []types.OutTxTracker  `json:"out_tx_trackers"`
+}
+
+// GetPendingOutbounds returns the range of the nonces pending on the chain, their cctxs and the outbound txs reported
+// for them
+func (b *ZetaCoreBridge) GetPendingOutbounds(chain common.Chain) (PendingOutbounds, error) {
+	nonces, err := b.GetPendingNoncesByChain(chain.ChainId)
+	if err != nil {
+		return PendingOutbounds{}, err
+	}
+	cctxs, err := b.GetAllPendingCctx(chain.ChainId)
+	if err != nil {
+		return PendingOutbounds{}, err
+	}
+	trackers, err := b.GetAllOutTxTrackerByChain(chain, Ascending)
+	if err != nil {
+		return PendingOutbounds{}, err
+	}
+	return PendingOutbounds{ChainID: chain.ChainId, Nonces: nonces, Cctxs: cctxs, Tracked: trackers}, nil
+}
+
+// KeygenState is the state of the keygen and of the TSS on zetacore
+type KeygenState struct {
+	Keygen     *observertypes.Keygen `json:"keygen"`
+	CurrentTss *types.TSS            `json:"current_tss,omitempty"`
+	TssHistory []types.TSS           `json:"tss_history"`
+}
+
+// GetKeygenState returns the state of the keygen, the current TSS if any keygen succeeded and the previous ones
+func (b *ZetaCoreBridge) GetKeygenState() (KeygenState, error) {
+	client := observertypes.NewQueryClient(b.conn())
+	resp, err := client.Keygen(context.Background(), &observertypes.QueryGetKeygenRequest{})
+	if err != nil {
+		return KeygenState{}, err
+	}
+	state := KeygenState{Keygen: resp.Keygen}
+	if state.Keygen != nil && state.Keygen.Status == observertypes.KeygenStatus_KeyGenSuccess {
+		if state.CurrentTss, err = b.GetCurrentTss(); err != nil {
+			return KeygenState{}, err
+		}
+	}
+	if state.TssHistory, err = b.GetTssHistory(); err != nil {
+		return KeygenState{}, err
+	}
+	return state, nil
+}
@@ -0,0 +1,74 @@
+package zetaclient
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/zeta-chain/zetacore/x/crosschain/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// cctxQueryServer serves the cctxs of zetacore by send hash and by inbound hash
+type cctxQueryServer struct {
+	types.UnimplementedQueryServer
+	cctxs map[string]*types.CrossChainTx
+}
+
+func (s *cctxQueryServer) Cctx(_ context.Context, req *types.QueryGetCctxRequest) (*types.QueryGetCctxResponse, error) {
+	cctx, found := s.cctxs[req.Index]
+	if !found {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	return &types.QueryGetCctxResponse{CrossChainTx: cctx}, nil
+}
+
+func (s *cctxQueryServer) InTxHashToCctxData(_ context.Context, req *types.QueryInTxHashToCctxDataRequest) (*types.QueryInTxHashToCctxDataResponse, error) {
+	var cctxs []types.CrossChainTx
+	for _, cctx := range s.cctxs {
+		if cctx.InboundTxParams != nil && cctx.InboundTxParams.InboundTxObservedHash == req.InTxHash {
+			cctxs = append(cctxs, *cctx)
+		}
+	}
+	return &types.QueryInTxHashToCctxDataResponse{CrossChainTxs: cctxs}, nil
+}
+
+func TestZetaCoreBridge_CctxQueries(t *testing.T) {
+	queryServer := &cctxQueryServer{cctxs: map[string]*types.CrossChainTx{
+		"0xsend": {
+			Index:           "0xsend",
+			CctxStatus:      &types.Status{Status: types.CctxStatus_PendingOutbound},
+			InboundTxParams: &types.InboundTxParams{InboundTxObservedHash: "0xinbound"},
+		},
+		"0xnostatus": {Index: "0xnostatus"},
+	}}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	types.RegisterQueryServer(server, queryServer)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+	conn, err := dialZetaCore(listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+	bridge := &ZetaCoreBridge{ctx: context.Background(), logger: zerolog.Nop(), endpoints: []*coreEndpoint{{host: "127.0.0.1", grpcConn: conn}}}
+
+	cctxStatus, err := bridge.GetCctxStatus("0xsend")
+	require.NoError(t, err)
+	require.Equal(t, types.CctxStatus_PendingOutbound, cctxStatus.Status)
+	_, err = bridge.GetCctxStatus("0xnostatus")
+	require.Error(t, err)
+	_, err = bridge.GetCctxStatus("0xunknown")
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	cctxs, err := bridge.GetCctxByInboundHash("0xinbound")
+	require.NoError(t, err)
+	require.Len(t, cctxs, 1)
+	require.Equal(t, "0xsend", cctxs[0].Index)
+}
@@ -0,0 +1,114 @@
+package zetaclient
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
+	"github.com/zeta-chain/zetacore/zetaclient/metrics"
+)
+
+// Backoff is an exponential backoff schedule with jitter and a bounded number of retries
+type Backoff struct {
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	Multiplier      float64
+	Jitter          float64 // randomization factor in [0, 1); 0.2 means +/- 20%
+	MaxRetries      int     // retry budget of a single call, not counting the first attempt
+}
+
+var (
+	// RPCBackoff is used around rpc calls to external chains
+	RPCBackoff = Backoff{
+		InitialInterval: 500 * time.Millisecond,
+		MaxInterval:     8 * time.Second,
+		Multiplier:      2,
+		Jitter:          0.2,
+		MaxRetries:      3,
+	}
+
+	// BroadcastBackoff is used around broadcasts to zetacore
+	BroadcastBackoff = Backoff{
+		InitialInterval: 2 * time.Second,
+		MaxInterval:     DefaultRetryInterval * 2 * time.Second,
+		Multiplier:      2,
+		Jitter:          0.2,
+		MaxRetries:      DefaultRetryCount - 1,
+	}
+
+	// SetTSSBackoff is used around the broadcasts of the keygen votes, attempted DefaultRetryCount+1 times since a
+	// keygen lost for a missing vote has to be run again by every observer
+	SetTSSBackoff = Backoff{
+		InitialInterval: 2 * time.Second,
+		MaxInterval:     DefaultRetryInterval * 2 * time.Second,
+		Multiplier:      2,
+		Jitter:          0.2,
+		MaxRetries:      DefaultRetryCount,
+	}
+
+	// ConfirmationBackoff is used around the broadcasts of the outbound confirmations, less time-critical than the
+	// inbound votes but never to be lost: they are retried at a slower pace for longer
+	ConfirmationBackoff = Backoff{
+		InitialInterval: 5 * time.Second,
+		MaxInterval:     2 * time.Minute,
+		Multiplier:      2,
+		Jitter:          0.2,
+		MaxRetries:      2 * DefaultRetryCount,
+	}
+)
+
+// BackoffFromPolicy returns the backoff of the retry policy, the fields of the policy unset keeping those of defaults
+func BackoffFromPolicy(policy config.VoteRetryPolicy, defaults Backoff) Backoff {
+	backoff := defaults
+	if policy.InitialIntervalMs > 0 {
+		// #nosec G701 always in range
+		backoff.InitialInterval = time.Duration(policy.InitialIntervalMs) * time.Millisecond
+	}
+	if policy.MaxIntervalMs > 0 {
+		// #nosec G701 always in range
+		backoff.MaxInterval = time.Duration(policy.MaxIntervalMs) * time.Millisecond
+	}
+	if policy.MaxRetries > 0 {
+		backoff.MaxRetries = policy.MaxRetries
+	}
+	return backoff
+}
+
+// Interval returns the time to wait before the n-th retry (starting from 0)
+func (b Backoff) Interval(n int) time.Duration {
+	interval := float64(b.InitialInterval) * math.Pow(b.Multiplier, float64(n))
+	if interval > float64(b.MaxInterval) {
+		interval = float64(b.MaxInterval)
+	}
+	if b.Jitter > 0 {
+		// #nosec G404 jitter does not need a secure random source
+		interval += interval * b.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(interval)
+}
+
+// Retry calls f until it succeeds, the retry budget is exhausted or ctx is done
+// name identifies the call in logs and metrics. The calls to zetacore are not retried while it is unavailable
+func Retry(ctx context.Context, name string, b Backoff, f func() error) error {
+	err := f()
+	for n := 0; err != nil && n < b.MaxRetries; n++ {
+		if errors.Is(err, ErrCoreUnavailable) {
+			return errors.Wrapf(err, "%s: aborted after %d retries", name, n)
+		}
+		metrics.RetryCount.WithLabelValues(name).Inc()
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(err, "%s: aborted after %d retries", name, n)
+		case <-time.After(b.Interval(n)):
+		}
+		err = f()
+	}
+	if err != nil {
+		metrics.RetryExhaustedCount.WithLabelValues(name).Inc()
+		return errors.Wrapf(err, "%s: failed after %d retries", name, b.MaxRetries)
+	}
+	return nil
+}
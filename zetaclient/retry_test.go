@@ -0,0 +1,99 @@
+package zetaclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
+)
+
+func TestBackoff_Interval(t *testing.T) {
+	b := Backoff{
+		InitialInterval: 100 * time.Millisecond,
+		MaxInterval:     time.Second,
+		Multiplier:      2,
+	}
+	require.Equal(t, 100*time.Millisecond, b.Interval(0))
+	require.Equal(t, 400*time.Millisecond, b.Interval(2))
+	require.Equal(t, time.Second, b.Interval(10))
+
+	// jitter stays within the randomization factor
+	b.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		interval := b.Interval(1)
+		require.GreaterOrEqual(t, interval, 100*time.Millisecond)
+		require.LessOrEqual(t, interval, 300*time.Millisecond)
+	}
+}
+
+func TestBackoffFromPolicy(t *testing.T) {
+	require.Equal(t, ConfirmationBackoff, BackoffFromPolicy(config.VoteRetryPolicy{}, ConfirmationBackoff))
+
+	// the fields set override those of the default backoff
+	backoff := BackoffFromPolicy(config.VoteRetryPolicy{MaxIntervalMs: 500, MaxRetries: 50}, ConfirmationBackoff)
+	require.Equal(t, ConfirmationBackoff.InitialInterval, backoff.InitialInterval)
+	require.Equal(t, 500*time.Millisecond, backoff.MaxInterval)
+	require.Equal(t, 50, backoff.MaxRetries)
+}
+
+func TestRetry(t *testing.T) {
+	b := Backoff{
+		InitialInterval: time.Millisecond,
+		MaxInterval:     time.Millisecond,
+		Multiplier:      2,
+		MaxRetries:      3,
+	}
+
+	// succeeds after two failures
+	calls := 0
+	err := Retry(context.Background(), "test", b, func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("rpc error")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, calls)
+
+	// gives up once the retry budget is used
+	calls = 0
+	err = Retry(context.Background(), "test", b, func() error {
+		calls++
+		return errors.New("rpc error")
+	})
+	require.ErrorContains(t, err, "failed after 3 retries")
+	require.Equal(t, 4, calls)
+
+	// stops retrying once the context is cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls = 0
+	err = Retry(ctx, "test", b, func() error {
+		calls++
+		return errors.New("rpc error")
+	})
+	require.Error(t, err)
+	require.Equal(t, 1, calls)
+}
+
+func TestRetry_CoreUnavailable(t *testing.T) {
+	b := Backoff{
+		InitialInterval: time.Millisecond,
+		MaxInterval:     time.Millisecond,
+		Multiplier:      2,
+		MaxRetries:      3,
+	}
+
+	// the posts held by the circuit breaker are not retried
+	calls := 0
+	err := Retry(context.Background(), "test", b, func() error {
+		calls++
+		return ErrCoreUnavailable
+	})
+	require.ErrorIs(t, err, ErrCoreUnavailable)
+	require.Equal(t, 1, calls)
+}
@@ -0,0 +1,145 @@
+package zetaclient
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"sync"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/rs/zerolog"
+	"github.com/zeta-chain/zetacore/common"
+	metricsPkg "github.com/zeta-chain/zetacore/zetaclient/metrics"
+)
+
+// SequenceMismatchRetries is the number of times a tx rejected for its account sequence is signed again with the
+// corrected sequence and broadcast right away
+var SequenceMismatchRetries = 3
+
+// sequenceMismatchRegex matches the log of a tx rejected by zetacore for its account sequence
+var sequenceMismatchRegex = regexp.MustCompile(`account sequence mismatch, expected ([0-9]*), got ([0-9]*)`)
+
+// ExpectedSequence returns the sequence zetacore expected from the error of a tx rejected for its account sequence
+func ExpectedSequence(errMsg string) (uint64, bool) {
+	matches := sequenceMismatchRegex.FindStringSubmatch(errMsg)
+	if len(matches) != 3 {
+		return 0, false
+	}
+	expected, err := strconv.ParseUint(matches[1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return expected, true
+}
+
+// IsSequenceMismatch returns true if the tx was rejected by zetacore for its account sequence
+func IsSequenceMismatch(err error) bool {
+	var txErr *txResultError
+	if errors.As(err, &txErr) && txErr.codespace == sdkerrors.ErrWrongSequence.Codespace() &&
+		txErr.code == sdkerrors.ErrWrongSequence.ABCICode() {
+		return true
+	}
+	_, ok := ExpectedSequence(err.Error())
+	return ok
+}
+
+// SequenceManager hands out the account number and sequence the txs broadcast to zetacore are signed with. The txs
+// are signed and broadcast one at a time so that concurrent votes never share a sequence
+type SequenceManager struct {
+	mu     sync.Mutex
+	fetch  func(keyType common.KeyType) (uint64, uint64, error)
+	logger zerolog.Logger
+
+	accountNumber map[common.KeyType]uint64
+	sequence      map[common.KeyType]uint64
+	synced        map[common.KeyType]bool
+}
+
+// NewSequenceManager creates a SequenceManager fetching the account number and sequence of a key type with fetch
+func NewSequenceManager(fetch func(keyType common.KeyType) (uint64, uint64, error), logger zerolog.Logger) *SequenceManager {
+	return &SequenceManager{
+		fetch:         fetch,
+		logger:        logger,
+		accountNumber: make(map[common.KeyType]uint64),
+		sequence:      make(map[common.KeyType]uint64),
+		synced:        make(map[common.KeyType]bool),
+	}
+}
+
+// Prefetch fetches the account number and sequence of the key type, e.g. on startup
+func (m *SequenceManager) Prefetch(keyType common.KeyType) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.sync(keyType)
+}
+
+// sync fetches the account number and sequence of the key type; the caller holds the lock
+func (m *SequenceManager) sync(keyType common.KeyType) error {
+	accountNumber, sequence, err := m.fetch(keyType)
+	if err != nil {
+		return err
+	}
+	m.accountNumber[keyType] = accountNumber
+	m.sequence[keyType] = sequence
+	m.synced[keyType] = true
+	return nil
+}
+
+// Use calls broadcast with the account number and sequence of the key type, no other tx being broadcast meanwhile.
+// The sequence moves on once broadcast succeeds. On an account sequence mismatch the account is queried again and
+// broadcast, which signs the tx, is called again with the corrected sequence, up to SequenceMismatchRetries times;
+// on another error the sequence is fetched again before the next tx, since the tx may or may not have been accepted
+func (m *SequenceManager) Use(keyType common.KeyType, broadcast func(accountNumber, sequence uint64) error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.synced[keyType] {
+		if err := m.sync(keyType); err != nil {
+			return err
+		}
+	}
+	for retry := 0; ; retry++ {
+		sequence := m.sequence[keyType]
+		err := broadcast(m.accountNumber[keyType], sequence)
+		if err == nil {
+			m.sequence[keyType] = sequence + 1
+			return nil
+		}
+		if !IsSequenceMismatch(err) {
+			m.synced[keyType] = false
+			return err
+		}
+		metricsPkg.SequenceMismatches.Inc()
+		if resyncErr := m.resync(keyType, err); resyncErr != nil {
+			m.logger.Error().Err(resyncErr).Msgf("fail to query the account after a sequence mismatch on seq number %d", sequence)
+			m.synced[keyType] = false
+			return err
+		}
+		if retry >= SequenceMismatchRetries {
+			return err
+		}
+		m.logger.Warn().Msgf("Account sequence mismatch on seq number %d, signing again with seq number %d", sequence, m.sequence[keyType])
+	}
+}
+
+// Peek returns the account number and the sequence the next tx of the key type is signed with, without using it
+func (m *SequenceManager) Peek(keyType common.KeyType) (uint64, uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.synced[keyType] {
+		if err := m.sync(keyType); err != nil {
+			return 0, 0, err
+		}
+	}
+	return m.accountNumber[keyType], m.sequence[keyType], nil
+}
+
+// resync queries the account after a sequence mismatch; the caller holds the lock. The sequence zetacore expects, if
+// given in the error, wins over the queried one since it counts the txs still in the mempool
+func (m *SequenceManager) resync(keyType common.KeyType, mismatch error) error {
+	err := m.sync(keyType)
+	if expected, ok := ExpectedSequence(mismatch.Error()); ok {
+		m.sequence[keyType] = expected
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,111 @@
+package zetaclient
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/zeta-chain/zetacore/common"
+)
+
+func TestExpectedSequence(t *testing.T) {
+	expected, ok := ExpectedSequence("fail to broadcast to zetachain,code:32, log:account sequence mismatch, expected 386232, got 386230: incorrect account sequence")
+	require.True(t, ok)
+	require.EqualValues(t, 386232, expected)
+	_, ok = ExpectedSequence("fail to broadcast to zetachain,code:13, log:insufficient fee")
+	require.False(t, ok)
+}
+
+func TestSequenceManager(t *testing.T) {
+	fetches := 0
+	chainSequence := uint64(10)
+	sequences := NewSequenceManager(func(common.KeyType) (uint64, uint64, error) {
+		fetches++
+		return 7, chainSequence, nil
+	}, zerolog.Nop())
+	keyType := common.ZetaClientGranteeKey
+	require.NoError(t, sequences.Prefetch(keyType))
+
+	// concurrent txs get consecutive sequences
+	var mu sync.Mutex
+	used := map[uint64]bool{}
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.NoError(t, sequences.Use(keyType, func(accountNumber, sequence uint64) error {
+				require.EqualValues(t, 7, accountNumber)
+				mu.Lock()
+				defer mu.Unlock()
+				require.False(t, used[sequence])
+				used[sequence] = true
+				return nil
+			}))
+		}()
+	}
+	wg.Wait()
+	for sequence := uint64(10); sequence < 30; sequence++ {
+		require.True(t, used[sequence])
+	}
+	require.Equal(t, 1, fetches)
+
+	// the tx is signed again with the expected sequence on a mismatch
+	var signed []uint64
+	require.NoError(t, sequences.Use(keyType, func(_, sequence uint64) error {
+		signed = append(signed, sequence)
+		if sequence == 30 {
+			return errors.New("fail to broadcast to zetachain,code:32, log:account sequence mismatch, expected 25, got 30: incorrect account sequence")
+		}
+		return nil
+	}))
+	require.Equal(t, []uint64{30, 25}, signed)
+	require.Equal(t, 2, fetches)
+	require.NoError(t, sequences.Use(keyType, func(_, sequence uint64) error {
+		require.EqualValues(t, 26, sequence)
+		return nil
+	}))
+
+	// and fetched again after another error
+	require.Error(t, sequences.Use(keyType, func(_, _ uint64) error {
+		return errors.New("connection refused")
+	}))
+	chainSequence = 40
+	require.NoError(t, sequences.Use(keyType, func(_, sequence uint64) error {
+		require.EqualValues(t, 40, sequence)
+		return nil
+	}))
+	require.Equal(t, 3, fetches)
+}
+
+func TestSequenceManager_MismatchRetries(t *testing.T) {
+	chainSequence := uint64(10)
+	sequences := NewSequenceManager(func(common.KeyType) (uint64, uint64, error) {
+		return 7, chainSequence, nil
+	}, zerolog.Nop())
+	keyType := common.ZetaClientGranteeKey
+
+	// the queried sequence is used when the error doesn't tell the expected one
+	var signed []uint64
+	require.NoError(t, sequences.Use(keyType, func(_, sequence uint64) error {
+		signed = append(signed, sequence)
+		if len(signed) == 1 {
+			chainSequence = 12
+			return &txResultError{codespace: "sdk", code: 32, msg: "incorrect account sequence"}
+		}
+		return nil
+	}))
+	require.Equal(t, []uint64{10, 12}, signed)
+
+	// the tx is given up once the retries are exhausted
+	attempts := 0
+	err := sequences.Use(keyType, func(_, _ uint64) error {
+		attempts++
+		return &txResultError{codespace: "sdk", code: 32, msg: "incorrect account sequence"}
+	})
+	require.True(t, IsSequenceMismatch(err))
+	require.Equal(t, SequenceMismatchRetries+1, attempts)
+	require.False(t, IsSequenceMismatch(errors.New("connection refused")))
+}
@@ -0,0 +1,126 @@
+package zetaclient
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/zeta-chain/zetacore/common"
+	crosschaintypes "github.com/zeta-chain/zetacore/x/crosschain/types"
+	"github.com/zeta-chain/zetacore/zetaclient/metrics"
+)
+
+// ShadowMatchTimeout is how long the other observers have to vote on an inbound tx observed in shadow mode before the
+// observation is counted as unmatched
+const ShadowMatchTimeout = 10 * time.Minute
+
+// Outcomes of the inbound votes withheld in shadow mode
+const (
+	ShadowVoteObserved  = "observed"
+	ShadowVoteMatched   = "matched"
+	ShadowVoteUnmatched = "unmatched"
+)
+
+var _ ZetaCoreBridger = (*ShadowBridge)(nil)
+
+// ShadowBridge is the bridge of an observer in shadow mode: it reads from zetacore but never votes, signs nor posts.
+// The inbound votes it withholds are matched against the ballots of the other observers to tell whether the
+// observer would have voted like them
+type ShadowBridge struct {
+	ZetaCoreBridger
+	chain  common.Chain
+	logger zerolog.Logger
+
+	mu      sync.Mutex
+	pending map[string]time.Time // ballot identifiers of the withheld votes not matched yet, by observation time
+}
+
+func NewShadowBridge(bridge ZetaCoreBridger, chain common.Chain, logger zerolog.Logger) *ShadowBridge {
+	return &ShadowBridge{
+		ZetaCoreBridger: bridge,
+		chain:           chain,
+		logger:          logger,
+		pending:         make(map[string]time.Time),
+	}
+}
+
+// PostSend withholds the inbound vote and keeps its ballot identifier to be matched
+func (b *ShadowBridge) PostSend(_ uint64, msg *crosschaintypes.MsgVoteOnObservedInboundTx) (string, error) {
+	ballot := msg.Digest()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, found := b.pending[ballot]; !found {
+		b.pending[ballot] = time.Now()
+		metrics.ShadowVotes.WithLabelValues(b.chain.Name(), ShadowVoteObserved).Inc()
+		b.logger.Info().Msgf("shadow mode: withholding vote on inbound tx %s, ballot %s", msg.InTxHash, ballot)
+	}
+	return "", nil
+}
+
+func (b *ShadowBridge) PostReceiveConfirmation(confirmation OutboundConfirmation) (string, error) {
+	b.logger.Info().Msgf("shadow mode: withholding vote on outbound tx %s of cctx %s nonce %d",
+		confirmation.OutTxHash, confirmation.SendHash, confirmation.Nonce)
+	return "", nil
+}
+
+func (b *ShadowBridge) PostGasPrice(_ common.Chain, _ uint64, _ string, _ uint64) (string, error) {
+	return "", nil
+}
+
+func (b *ShadowBridge) PostAddBlockHeader(_ int64, _ []byte, _ int64, _ common.HeaderData) (string, error) {
+	return "", nil
+}
+
+func (b *ShadowBridge) AddTxHashToOutTxTracker(_ int64, _ uint64, _ string, _ *common.Proof, _ string, _ int64) (string, error) {
+	return "", nil
+}
+
+// MatchPendingVotes looks up the ballots of the withheld votes: a vote is matched once the other observers voted the
+// same ballot, and unmatched if they didn't within ShadowMatchTimeout
+func (b *ShadowBridge) MatchPendingVotes(now time.Time) {
+	b.mu.Lock()
+	pending := make(map[string]time.Time, len(b.pending))
+	for ballot, observed := range b.pending {
+		pending[ballot] = observed
+	}
+	b.mu.Unlock()
+
+	for ballot, observed := range pending {
+		outcome := ""
+		if b.isBallotVoted(ballot) {
+			outcome = ShadowVoteMatched
+		} else if now.Sub(observed) > ShadowMatchTimeout {
+			outcome = ShadowVoteUnmatched
+			b.logger.Warn().Msgf("shadow mode: no observer voted ballot %s within %s", ballot, ShadowMatchTimeout)
+		}
+		if outcome == "" {
+			continue
+		}
+		metrics.ShadowVotes.WithLabelValues(b.chain.Name(), outcome).Inc()
+		b.mu.Lock()
+		delete(b.pending, ballot)
+		b.mu.Unlock()
+	}
+}
+
+// PendingVotes returns the number of withheld votes not matched yet
+func (b *ShadowBridge) PendingVotes() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.pending)
+}
+
+// isBallotVoted returns true if the ballot exists, or was finalized into a cctx and pruned
+func (b *ShadowBridge) isBallotVoted(ballot string) bool {
+	if _, err := b.GetBallot(ballot); err == nil {
+		return true
+	}
+	_, err := b.GetCctxByHash(ballot)
+	return err == nil
+}
+
+// IsShadow returns true if the chain runs in shadow mode
+func (ob *EVMChainClient) IsShadow() bool {
+	_, ok := ob.zetaClient.(*ShadowBridge)
+	return ok
+}
@@ -0,0 +1,53 @@
+package zetaclient
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/zeta-chain/zetacore/common"
+	crosschaintypes "github.com/zeta-chain/zetacore/x/crosschain/types"
+	observertypes "github.com/zeta-chain/zetacore/x/observer/types"
+)
+
+// ballotBridge serves the ballots voted by the other observers
+type ballotBridge struct {
+	ZetaCoreBridger
+	ballots map[string]bool
+}
+
+func (b *ballotBridge) GetBallot(ballotIdentifier string) (*observertypes.QueryBallotByIdentifierResponse, error) {
+	if !b.ballots[ballotIdentifier] {
+		return nil, errors.New("ballot not found")
+	}
+	return &observertypes.QueryBallotByIdentifierResponse{BallotIdentifier: ballotIdentifier}, nil
+}
+
+func (b *ballotBridge) GetCctxByHash(_ string) (*crosschaintypes.CrossChainTx, error) {
+	return nil, errors.New("cctx not found")
+}
+
+func TestShadowBridge(t *testing.T) {
+	bridge := &ballotBridge{ballots: make(map[string]bool)}
+	shadow := NewShadowBridge(bridge, common.EthChain(), zerolog.Nop())
+
+	voted := &crosschaintypes.MsgVoteOnObservedInboundTx{InTxHash: "0x01"}
+	missed := &crosschaintypes.MsgVoteOnObservedInboundTx{InTxHash: "0x02"}
+	for _, msg := range []*crosschaintypes.MsgVoteOnObservedInboundTx{voted, missed, voted} {
+		zetaHash, err := shadow.PostSend(PostSendEVMGasLimit, msg)
+		require.NoError(t, err)
+		require.Empty(t, zetaHash)
+	}
+	require.Equal(t, 2, shadow.PendingVotes())
+
+	// the other observers voted on the first inbound tx only
+	bridge.ballots[voted.Digest()] = true
+	shadow.MatchPendingVotes(time.Now())
+	require.Equal(t, 1, shadow.PendingVotes())
+
+	// the second is unmatched once the other observers had time to vote
+	shadow.MatchPendingVotes(time.Now().Add(ShadowMatchTimeout + time.Minute))
+	require.Equal(t, 0, shadow.PendingVotes())
+}
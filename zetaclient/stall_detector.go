@@ -0,0 +1,52 @@
+package zetaclient
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// StallBlockTimeMultiple is the number of expected block times without a new block after which a chain is stalled
+	StallBlockTimeMultiple = 10
+	// MinStallTimeout is the shortest time without a new block after which a chain is stalled, for chains with fast blocks
+	MinStallTimeout = 2 * time.Minute
+)
+
+// StallDetector tracks when the latest block of a chain last moved forward
+type StallDetector struct {
+	mu           sync.Mutex
+	lastBlock    uint64
+	lastProgress time.Time
+}
+
+// NewStallDetector returns a detector counting from now, so that a chain never seen producing a block becomes stalled too
+func NewStallDetector() *StallDetector {
+	return &StallDetector{lastProgress: time.Now()}
+}
+
+// Observe records the latest block seen at time now
+func (d *StallDetector) Observe(block uint64, now time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if block > d.lastBlock {
+		d.lastBlock = block
+		d.lastProgress = now
+	}
+}
+
+// Stalled returns true if no new block was seen within timeout, along with the last block seen and the time since it was seen
+func (d *StallDetector) Stalled(now time.Time, timeout time.Duration) (bool, uint64, time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	since := now.Sub(d.lastProgress)
+	return since > timeout, d.lastBlock, since
+}
+
+// StallTimeout returns the time without a new block after which a chain with the given block time is stalled
+func StallTimeout(blockTime float64) time.Duration {
+	timeout := time.Duration(blockTime * StallBlockTimeMultiple * float64(time.Second))
+	if timeout < MinStallTimeout {
+		return MinStallTimeout
+	}
+	return timeout
+}
@@ -0,0 +1,34 @@
+package zetaclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStallDetector(t *testing.T) {
+	now := time.Now()
+	d := NewStallDetector()
+	d.Observe(100, now)
+
+	stalled, _, _ := d.Stalled(now.Add(time.Minute), 2*time.Minute)
+	require.False(t, stalled)
+
+	// the same block seen again is no progress
+	d.Observe(100, now.Add(time.Minute))
+	stalled, lastBlock, since := d.Stalled(now.Add(3*time.Minute), 2*time.Minute)
+	require.True(t, stalled)
+	require.Equal(t, uint64(100), lastBlock)
+	require.Equal(t, 3*time.Minute, since)
+
+	d.Observe(101, now.Add(3*time.Minute))
+	stalled, _, _ = d.Stalled(now.Add(3*time.Minute), 2*time.Minute)
+	require.False(t, stalled)
+}
+
+func TestStallTimeout(t *testing.T) {
+	require.Equal(t, MinStallTimeout, StallTimeout(2))
+	require.Equal(t, 120*time.Second, StallTimeout(12))
+	require.Equal(t, 600*time.Second, StallTimeout(60))
+}
@@ -0,0 +1,320 @@
+package zetaclient
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/zeta-chain/zetacore/common"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
+	"github.com/zeta-chain/zetacore/zetaclient/types"
+)
+
+const (
+	// WatcherRestartDelay is the delay before restarting a crashed watcher, multiplied by the number of restarts
+	WatcherRestartDelay = 5 * time.Second
+	// MaxWatcherRestartDelay caps the delay before restarting a crashed watcher
+	MaxWatcherRestartDelay = 2 * time.Minute
+	// SupervisorStatusInterval is the interval in seconds between two status reports to telemetry
+	SupervisorStatusInterval = 10
+)
+
+// WatcherGroup runs the goroutines of a chain client, restarts them if they panic and keeps track of their status
+type WatcherGroup struct {
+	chain    string
+	stop     <-chan struct{}
+	logger   zerolog.Logger
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+	watchers map[string]*types.WatcherStatus
+}
+
+func NewWatcherGroup(chain string, stop <-chan struct{}, logger zerolog.Logger) *WatcherGroup {
+	return &WatcherGroup{
+		chain:    chain,
+		stop:     stop,
+		logger:   logger,
+		watchers: make(map[string]*types.WatcherStatus),
+	}
+}
+
+// Go runs the watcher in a goroutine; the watcher is restarted if it panics until the group is stopped
+func (g *WatcherGroup) Go(name string, watcher func()) {
+	g.mu.Lock()
+	g.watchers[name] = &types.WatcherStatus{Chain: g.chain, Watcher: name, Running: true}
+	g.mu.Unlock()
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		for {
+			err := g.run(watcher)
+			if err == nil { // watcher returned because it was stopped
+				g.setStopped(name)
+				return
+			}
+			restarts := g.setCrashed(name, err)
+			delay := WatcherRestartDelay * time.Duration(restarts)
+			if delay > MaxWatcherRestartDelay {
+				delay = MaxWatcherRestartDelay
+			}
+			g.logger.Error().Err(err).Msgf("watcher %s crashed; restarting in %s", name, delay)
+			select {
+			case <-g.stop:
+				g.setStopped(name)
+				return
+			case <-time.After(delay):
+			}
+			g.setRestarted(name)
+		}
+	}()
+}
+
+// run calls the watcher and turns a panic into an error
+func (g *WatcherGroup) run(watcher func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			g.logger.Error().Msgf("watcher panic: %v\n%s", r, debug.Stack())
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	watcher()
+	return nil
+}
+
+// Wait waits for all watchers to return
+func (g *WatcherGroup) Wait() {
+	g.wg.Wait()
+}
+
+// Status returns the status of all watchers sorted by name
+func (g *WatcherGroup) Status() []types.WatcherStatus {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	status := make([]types.WatcherStatus, 0, len(g.watchers))
+	for _, s := range g.watchers {
+		status = append(status, *s)
+	}
+	sort.Slice(status, func(i, j int) bool {
+		return status[i].Watcher < status[j].Watcher
+	})
+	return status
+}
+
+func (g *WatcherGroup) setStopped(name string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.watchers[name].Running = false
+}
+
+func (g *WatcherGroup) setCrashed(name string, err error) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	s := g.watchers[name]
+	s.Running = false
+	s.Restarts++
+	s.LastError = err.Error()
+	return s.Restarts
+}
+
+func (g *WatcherGroup) setRestarted(name string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	s := g.watchers[name]
+	s.Running = true
+	s.LastRestart = time.Now()
+}
+
+// ChainFactory creates the chain client and signer of a chain enabled in zetacore at runtime
+type ChainFactory func(chain common.Chain) (ChainClient, ChainSigner, error)
+
+// ChainClientSupervisor runs the chain clients of all chains enabled in zetacore and reports their aggregate status.
+// Chains added or removed in zetacore are started or stopped at runtime
+type ChainClientSupervisor struct {
+	mu       sync.RWMutex
+	clients  map[common.Chain]ChainClient
+	signers  map[common.Chain]ChainSigner
+	factory  ChainFactory
+	cfg      *config.Config
+	ts       *TelemetryServer
+	logger   zerolog.Logger
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+func NewChainClientSupervisor(
+	clients map[common.Chain]ChainClient,
+	signers map[common.Chain]ChainSigner,
+	factory ChainFactory,
+	cfg *config.Config,
+	ts *TelemetryServer,
+	logger zerolog.Logger,
+) *ChainClientSupervisor {
+	return &ChainClientSupervisor{
+		clients: clients,
+		signers: signers,
+		factory: factory,
+		cfg:     cfg,
+		ts:      ts,
+		logger:  logger.With().Str("module", "ChainClientSupervisor").Logger(),
+		stop:    make(chan struct{}),
+	}
+}
+
+// Start starts all chain clients, the chain list watcher and the status reporter
+func (s *ChainClientSupervisor) Start() {
+	s.mu.RLock()
+	for chain, client := range s.clients {
+		s.logger.Info().Msgf("starting chain client %s", chain.Name())
+		client.Start()
+	}
+	s.mu.RUnlock()
+	s.SyncChains()
+	go s.watchChains()
+	go s.reportStatus()
+}
+
+// Stop stops all chain clients; it is safe to call Stop() more than once
+func (s *ChainClientSupervisor) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		for chain, client := range s.clients {
+			s.logger.Info().Msgf("stopping chain client %s", chain.Name())
+			client.Stop()
+		}
+	})
+}
+
+// GetClient returns the chain client of a chain
+func (s *ChainClientSupervisor) GetClient(chain common.Chain) (ChainClient, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	client, found := s.clients[chain]
+	return client, found
+}
+
+// GetSigner returns the signer of a chain
+func (s *ChainClientSupervisor) GetSigner(chain common.Chain) (ChainSigner, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	signer, found := s.signers[chain]
+	return signer, found
+}
+
+// SyncChains starts the chain clients of newly enabled chains and stops the ones of chains no longer enabled in zetacore
+func (s *ChainClientSupervisor) SyncChains() {
+	enabled := s.cfg.GetEnabledChains()
+	if len(enabled) == 0 { // most likely zetacore has not been queried yet; keep running chains
+		s.logger.Warn().Msg("SyncChains: no chains enabled")
+		return
+	}
+	enabledSet := make(map[common.Chain]bool, len(enabled))
+	for _, chain := range enabled {
+		// the zeta chain is observed only if its zEVM has an endpoint configured
+		if chain.IsZetaChain() && !s.cfg.IsZEVMObserved() {
+			continue
+		}
+		enabledSet[chain] = true
+	}
+
+	// the chain clients are stopped once the lock is released since stopping waits for their goroutines, which would
+	// block GetClient and GetSigner meanwhile
+	stopped := make(map[common.Chain]ChainClient)
+	defer func() {
+		for chain, client := range stopped {
+			s.logger.Info().Msgf("SyncChains: chain %s is no longer enabled; stopping chain client", chain.Name())
+			client.Stop()
+		}
+	}()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case <-s.stop: // do not start new chain clients after Stop()
+		return
+	default:
+	}
+	for chain, client := range s.clients {
+		if enabledSet[chain] {
+			continue
+		}
+		stopped[chain] = client
+		delete(s.clients, chain)
+		delete(s.signers, chain)
+	}
+	for chain := range enabledSet {
+		if _, found := s.clients[chain]; found {
+			continue
+		}
+		if s.factory == nil {
+			continue
+		}
+		client, signer, err := s.factory(chain)
+		if err != nil {
+			s.logger.Error().Err(err).Msgf("SyncChains: cannot create chain client for chain %s", chain.Name())
+			continue
+		}
+		s.logger.Info().Msgf("SyncChains: chain %s is enabled; starting chain client", chain.Name())
+		client.Start()
+		s.clients[chain] = client
+		if signer != nil { // chains observed for inbound txs only have no signer
+			s.signers[chain] = signer
+		}
+	}
+}
+
+// Status returns the status of the goroutines of all chain clients
+func (s *ChainClientSupervisor) Status() []types.WatcherStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var status []types.WatcherStatus
+	for _, client := range s.clients {
+		status = append(status, client.GetWatcherStatus()...)
+	}
+	sort.SliceStable(status, func(i, j int) bool {
+		return status[i].Chain < status[j].Chain
+	})
+	return status
+}
+
+func (s *ChainClientSupervisor) watchChains() {
+	interval := s.cfg.ConfigUpdateTicker
+	if interval == 0 {
+		interval = SupervisorStatusInterval
+	}
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.SyncChains()
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+func (s *ChainClientSupervisor) reportStatus() {
+	ticker := time.NewTicker(SupervisorStatusInterval * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			status := s.Status()
+			for _, w := range status {
+				if !w.Running {
+					s.logger.Warn().Msgf("chain %s watcher %s is not running; restarts %d, last error: %s", w.Chain, w.Watcher, w.Restarts, w.LastError)
+				}
+			}
+			if s.ts != nil {
+				s.ts.SetWatcherStatus(status)
+			}
+		case <-s.stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package zetaclient
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/zeta-chain/zetacore/common"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
+	clienttypes "github.com/zeta-chain/zetacore/zetaclient/types"
+)
+
+// stubChainClient records Start() and Stop() calls
+type stubChainClient struct {
+	ChainClient
+	started bool
+	stopped bool
+	onStop  func()
+}
+
+func (c *stubChainClient) Start() { c.started = true }
+
+func (c *stubChainClient) Stop() {
+	c.stopped = true
+	if c.onStop != nil {
+		c.onStop()
+	}
+}
+
+func (c *stubChainClient) GetWatcherStatus() []clienttypes.WatcherStatus { return nil }
+
+func TestWatcherGroup(t *testing.T) {
+	stop := make(chan struct{})
+	group := NewWatcherGroup("test", stop, zerolog.Nop())
+
+	// the watcher panics on the first run and blocks until stopped on the second run
+	var runs int32
+	group.Go("crashy", func() {
+		if atomic.AddInt32(&runs, 1) == 1 {
+			panic("boom")
+		}
+		<-stop
+	})
+
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&runs) == 2
+	}, 2*WatcherRestartDelay, 10*time.Millisecond)
+
+	status := group.Status()
+	require.Len(t, status, 1)
+	require.Equal(t, "test", status[0].Chain)
+	require.Equal(t, "crashy", status[0].Watcher)
+	require.True(t, status[0].Running)
+	require.Equal(t, 1, status[0].Restarts)
+	require.Equal(t, "panic: boom", status[0].LastError)
+
+	close(stop)
+	group.Wait()
+	require.False(t, group.Status()[0].Running)
+}
+
+func TestChainClientSupervisor_SyncChains(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.ChainsEnabled = []common.Chain{common.ZetaChain(), common.EthChain()}
+
+	removed := &stubChainClient{}
+	created := &stubChainClient{}
+	factory := func(chain common.Chain) (ChainClient, ChainSigner, error) {
+		require.Equal(t, common.EthChain(), chain)
+		return created, nil, nil
+	}
+	supervisor := NewChainClientSupervisor(
+		map[common.Chain]ChainClient{common.BscMainnetChain(): removed},
+		map[common.Chain]ChainSigner{},
+		factory,
+		cfg,
+		nil,
+		zerolog.Nop(),
+	)
+	// the clients are looked up while a chain client stops, which waits for its goroutines
+	removed.onStop = func() {
+		_, found := supervisor.GetClient(common.BscMainnetChain())
+		require.False(t, found)
+	}
+	supervisor.SyncChains()
+
+	// bsc is no longer enabled and eth was added at runtime
+	require.True(t, removed.stopped)
+	_, found := supervisor.GetClient(common.BscMainnetChain())
+	require.False(t, found)
+	client, found := supervisor.GetClient(common.EthChain())
+	require.True(t, found)
+	require.Equal(t, created, client)
+	require.True(t, created.started)
+
+	// zeta chain is never observed
+	_, found = supervisor.GetClient(common.ZetaChain())
+	require.False(t, found)
+}
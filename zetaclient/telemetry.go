@@ -27,6 +27,7 @@ type TelemetryServer struct {
 	lastStartTimestamp     time.Time
 	status                 types.Status
 	ipAddress              string
+	unconfirmedInbound     map[int64][]types.UnconfirmedInbound // chainid => pending inbound txs
 }
 
 // NewTelemetryServer should only listen to the loopback
@@ -35,6 +36,7 @@ func NewTelemetryServer() *TelemetryServer {
 		logger:                 log.With().Str("module", "http").Logger(),
 		lastScannedBlockNumber: make(map[int64]int64),
 		lastStartTimestamp:     time.Now(),
+		unconfirmedInbound:     make(map[int64][]types.UnconfirmedInbound),
 	}
 	s := &http.Server{
 		Addr:              ":8123",
@@ -109,6 +111,19 @@ func (t *TelemetryServer) SetNumberOfUTXOs(numberOfUTXOs int) {
 	t.mu.Unlock()
 }
 
+func (t *TelemetryServer) SetWatcherStatus(watchers []types.WatcherStatus) {
+	t.mu.Lock()
+	t.status.Watchers = watchers
+	t.mu.Unlock()
+}
+
+// SetUnconfirmedInbound sets the inbound txs of a chain seen in its mempool and not yet mined
+func (t *TelemetryServer) SetUnconfirmedInbound(chainID int64, inbound []types.UnconfirmedInbound) {
+	t.mu.Lock()
+	t.unconfirmedInbound[chainID] = inbound
+	t.mu.Unlock()
+}
+
 // NewHandler registers the API routes and returns a new HTTP handler
 func (t *TelemetryServer) Handlers() http.Handler {
 	router := mux.NewRouter()
@@ -120,6 +135,7 @@ func (t *TelemetryServer) Handlers() http.Handler {
 	router.Handle("/lastcoreblock", http.HandlerFunc(t.lastCoreBlockHandler)).Methods(http.MethodGet)
 	router.Handle("/status", http.HandlerFunc(t.statusHandler)).Methods(http.MethodGet)
 	router.Handle("/ip", http.HandlerFunc(t.ipHandler)).Methods(http.MethodGet)
+	router.Handle("/unconfirmedinbound", http.HandlerFunc(t.unconfirmedInboundHandler)).Methods(http.MethodGet)
 	// router.Handle("/debug/pprof/goroutine", pprof.Handler("goroutine"))
 	// router.Handle("/debug/pprof/heap", pprof.Handler("heap"))
 	// router.HandleFunc("/debug/pprof/", pprof.Index)
@@ -221,6 +237,22 @@ func (t *TelemetryServer) statusHandler(w http.ResponseWriter, _ *http.Request)
 	fmt.Fprintf(w, "%s", s)
 }
 
+func (t *TelemetryServer) unconfirmedInboundHandler(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	jsonBytes, err := json.Marshal(t.unconfirmedInbound)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, err = w.Write(jsonBytes)
+	if err != nil {
+		t.logger.Error().Err(err).Msg("Failed to write response")
+	}
+}
+
 func (t *TelemetryServer) versionHandler(w http.ResponseWriter, _ *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	fmt.Fprintf(w, "%s", common.Version)
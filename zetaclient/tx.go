@@ -2,10 +2,8 @@ package zetaclient
 
 import (
 	"fmt"
-	"math/big"
 	"time"
 
-	"cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/authz"
 
@@ -14,6 +12,9 @@ import (
 	"github.com/zeta-chain/zetacore/x/crosschain/types"
 	observerTypes "github.com/zeta-chain/zetacore/x/observer/types"
 	"github.com/zeta-chain/zetacore/zetaclient/config"
+	"github.com/zeta-chain/zetacore/zetaclient/metrics"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -31,42 +32,6 @@ const (
 	DefaultRetryInterval            = 5
 )
 
-// GetInBoundVoteMessage returns a new MsgVoteOnObservedInboundTx
-func GetInBoundVoteMessage(
-	sender string,
-	senderChain int64,
-	txOrigin string,
-	receiver string,
-	receiverChain int64,
-	amount math.Uint,
-	message string,
-	inTxHash string,
-	inBlockHeight uint64,
-	gasLimit uint64,
-	coinType common.CoinType,
-	asset string,
-	signerAddress string,
-	eventIndex uint,
-) *types.MsgVoteOnObservedInboundTx {
-	msg := types.NewMsgVoteOnObservedInboundTx(
-		signerAddress,
-		sender,
-		senderChain,
-		txOrigin,
-		receiver,
-		receiverChain,
-		amount,
-		message,
-		inTxHash,
-		inBlockHeight,
-		gasLimit,
-		coinType,
-		asset,
-		eventIndex,
-	)
-	return msg
-}
-
 func (b *ZetaCoreBridge) WrapMessageWithAuthz(msg sdk.Msg) (sdk.Msg, AuthZSigner, error) {
 	msgURL := sdk.MsgTypeURL(msg)
 
@@ -89,16 +54,18 @@ func (b *ZetaCoreBridge) PostGasPrice(chain common.Chain, gasPrice uint64, suppl
 		return "", err
 	}
 
-	for i := 0; i < DefaultRetryCount; i++ {
-		zetaTxHash, err := b.Broadcast(PostGasPriceGasLimit, authzMsg, authzSigner)
-		if err == nil {
-			return zetaTxHash, nil
+	zetaTxHash := ""
+	err = Retry(b.ctx, "PostGasPrice", BroadcastBackoff, func() error {
+		zetaTxHash, err = b.Broadcast(PostGasPriceGasLimit, authzMsg, authzSigner)
+		if err != nil {
+			b.logger.Debug().Err(err).Msg("PostGasPrice broadcast fail")
 		}
-		b.logger.Debug().Err(err).Msgf("PostGasPrice broadcast fail | Retry count : %d", i+1)
-		time.Sleep(DefaultRetryInterval * time.Second)
+		return err
+	})
+	if err != nil {
+		return "", err
 	}
-
-	return "", fmt.Errorf("post gasprice failed after %d retries", DefaultRetryInterval)
+	return zetaTxHash, nil
 }
 
 func (b *ZetaCoreBridge) AddTxHashToOutTxTracker(
@@ -125,35 +92,119 @@ func (b *ZetaCoreBridge) AddTxHashToOutTxTracker(
 }
 
 func (b *ZetaCoreBridge) PostSend(zetaGasLimit uint64, msg *types.MsgVoteOnObservedInboundTx) (string, error) {
+	if b.voteBatcher != nil {
+		zetaTxHashes, errs := b.PostSends([]uint64{zetaGasLimit}, []*types.MsgVoteOnObservedInboundTx{msg})
+		return zetaTxHashes[0], errs[0]
+	}
+	if post, err := b.checkSend(msg); !post {
+		return "", err
+	}
 	authzMsg, authzSigner, err := b.WrapMessageWithAuthz(msg)
 	if err != nil {
 		return "", err
 	}
 
-	for i := 0; i < DefaultRetryCount; i++ {
-		zetaTxHash, err := b.Broadcast(zetaGasLimit, authzMsg, authzSigner)
-		if err == nil {
-			return zetaTxHash, nil
+	logger := WithCorrelationID(b.logger, InboundCorrelationID(msg))
+	zetaTxHash := ""
+	err = Retry(b.ctx, "PostSend", b.sendBackoff, func() error {
+		zetaTxHash, err = b.Broadcast(zetaGasLimit, authzMsg, authzSigner)
+		if err != nil {
+			logger.Debug().Err(err).Msg("PostSend broadcast fail")
 		}
-		b.logger.Debug().Err(err).Msgf("PostSend broadcast fail | Retry count : %d", i+1)
-		time.Sleep(DefaultRetryInterval * time.Second)
+		return err
+	})
+	if err != nil {
+		return "", err
 	}
-	return "", fmt.Errorf("post send failed after %d retries", DefaultRetryInterval)
+	logger.Debug().Msgf("PostSend broadcast zeta tx %s", zetaTxHash)
+	b.trackVote(metrics.VoteInbound, msg.Digest(), msg.Creator, observerTypes.VoteType_SuccessObservation, zetaTxHash)
+	return zetaTxHash, nil
 }
 
-func (b *ZetaCoreBridge) PostReceiveConfirmation(
-	sendHash string,
-	outTxHash string,
-	outBlockHeight uint64,
-	outTxGasUsed uint64,
-	outTxEffectiveGasPrice *big.Int,
-	outTxEffectiveGasLimit uint64,
-	amount *big.Int,
-	status common.ReceiveStatus,
-	chain common.Chain,
-	nonce uint64,
-	coinType common.CoinType,
-) (string, error) {
+// PostSends posts the inbound votes like PostSend. With vote batching, the votes are handed to the batcher at once in
+// their order, so that the votes sharing a tx are in the order they are given; they are posted one by one otherwise
+func (b *ZetaCoreBridge) PostSends(zetaGasLimits []uint64, msgs []*types.MsgVoteOnObservedInboundTx) ([]string, []error) {
+	zetaTxHashes := make([]string, len(msgs))
+	errs := make([]error, len(msgs))
+	if b.voteBatcher == nil {
+		for i, msg := range msgs {
+			zetaTxHashes[i], errs[i] = b.PostSend(zetaGasLimits[i], msg)
+		}
+		return zetaTxHashes, errs
+	}
+
+	var (
+		batched   []int
+		gasLimits []uint64
+		votes     []sdk.Msg
+	)
+	for i, msg := range msgs {
+		post, err := b.checkSend(msg)
+		if post {
+			if err = msg.ValidateBasic(); err != nil {
+				post, err = false, fmt.Errorf("%s invalid msg | %s", sdk.MsgTypeURL(msg), err.Error())
+			}
+		}
+		if !post {
+			errs[i] = err
+			continue
+		}
+		batched = append(batched, i)
+		gasLimits = append(gasLimits, zetaGasLimits[i])
+		votes = append(votes, msg)
+	}
+	batchedHashes, batchedErrs := b.voteBatcher.PostAll(gasLimits, votes)
+	for j, i := range batched {
+		if batchedErrs[j] != nil {
+			errs[i] = batchedErrs[j]
+			continue
+		}
+		msg := msgs[i]
+		zetaTxHashes[i] = batchedHashes[j]
+		logger := WithCorrelationID(b.logger, InboundCorrelationID(msg))
+		logger.Debug().Msgf("PostSend broadcast zeta tx %s", zetaTxHashes[i])
+		b.trackVote(metrics.VoteInbound, msg.Digest(), msg.Creator, observerTypes.VoteType_SuccessObservation, zetaTxHashes[i])
+	}
+	return zetaTxHashes, errs
+}
+
+// checkSend returns whether the inbound vote is to be posted: it isn't if its message is invalid, with an error, if
+// the observer has already voted, or if it is above the post rate limit, with ErrPostRateLimited
+func (b *ZetaCoreBridge) checkSend(msg *types.MsgVoteOnObservedInboundTx) (bool, error) {
+	// a message zetacore can't decode would relay a corrupted payload
+	if err := msg.ValidateMessageEncoding(); err != nil {
+		return false, fmt.Errorf("invalid message of inbound tx %s: %w", msg.InTxHash, err)
+	}
+	if b.hasVoted(msg.Digest(), msg.Creator) {
+		logger := WithCorrelationID(b.logger, InboundCorrelationID(msg))
+		logger.Info().Msgf("PostSend: inbound tx %s already voted, ballot %s", msg.InTxHash, msg.Digest())
+		metrics.SkippedVotes.WithLabelValues(metrics.VoteInbound).Inc()
+		return false, nil
+	}
+	if b.postLimiter != nil {
+		if err := b.checkPostRateLimit(msg.SenderChainId); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// hasVoted returns true if the voter has already voted on the ballot, so that the vote is skipped rather than rejected
+// by zetacore for a fee. The vote is posted if zetacore can't be queried
+func (b *ZetaCoreBridge) hasVoted(ballotIdentifier string, voter string) bool {
+	ballot, err := b.GetBallot(ballotIdentifier)
+	if err != nil {
+		if status.Code(err) != codes.NotFound {
+			b.logger.Warn().Err(err).Msgf("hasVoted: fail to query ballot %s", ballotIdentifier)
+		}
+		return false
+	}
+	return hasVote(ballot, voter)
+}
+
+// PostReceiveConfirmation votes on the confirmation of an outbound tx
+func (b *ZetaCoreBridge) PostReceiveConfirmation(confirmation OutboundConfirmation) (string, error) {
+	sendHash, outTxHash := confirmation.SendHash, confirmation.OutTxHash
 	lastReport, found := b.lastOutTxReportTime[outTxHash]
 	if found && time.Since(lastReport) < 10*time.Minute {
 		return "", fmt.Errorf(
@@ -164,42 +215,58 @@ func (b *ZetaCoreBridge) PostReceiveConfirmation(
 	}
 
 	signerAddress := b.keys.GetOperatorAddress().String()
-	msg := types.NewMsgVoteOnObservedOutboundTx(
-		signerAddress,
-		sendHash,
-		outTxHash,
-		outBlockHeight,
-		outTxGasUsed,
-		math.NewIntFromBigInt(outTxEffectiveGasPrice),
-		outTxEffectiveGasLimit,
-		math.NewUintFromBigInt(amount),
-		status,
-		chain.ChainId,
-		nonce,
-		coinType,
-	)
-
-	authzMsg, authzSigner, err := b.WrapMessageWithAuthz(msg)
+	msg, err := confirmation.VoteMessage(signerAddress)
 	if err != nil {
 		return "", err
 	}
+	if b.hasVoted(msg.Digest(), signerAddress) {
+		logger := WithCorrelationID(b.logger, sendHash)
+		logger.Info().Msgf("PostReceiveConfirmation: outTxHash %s already voted, ballot %s", outTxHash, msg.Digest())
+		metrics.SkippedVotes.WithLabelValues(metrics.VoteOutbound).Inc()
+		b.lastOutTxReportTime[outTxHash] = time.Now()
+		return "", nil
+	}
 
 	// FIXME: remove this gas limit stuff; in the special ante handler with no gas limit, add
 	// NewMsgReceiveConfirmation to it.
 	var gasLimit uint64 = PostReceiveConfirmationGasLimit
-	if status == common.ReceiveStatus_Failed {
+	if confirmation.Status == common.ReceiveStatus_Failed {
 		gasLimit = PostSendEVMGasLimit
 	}
-	for i := 0; i < DefaultRetryCount; i++ {
-		zetaTxHash, err := b.Broadcast(gasLimit, authzMsg, authzSigner)
-		if err == nil {
-			b.lastOutTxReportTime[outTxHash] = time.Now() // update last report time when bcast succeeds
-			return zetaTxHash, nil
+	logger := WithCorrelationID(b.logger, sendHash)
+	if b.confirmationBatcher != nil {
+		if err := msg.ValidateBasic(); err != nil {
+			return "", fmt.Errorf("%s invalid msg | %s", sdk.MsgTypeURL(msg), err.Error())
+		}
+		zetaTxHash, err := b.confirmationBatcher.Post(gasLimit, msg)
+		if err != nil {
+			return "", err
+		}
+		logger.Debug().Msgf("PostReceiveConfirmation broadcast zeta tx %s", zetaTxHash)
+		b.trackVote(metrics.VoteOutbound, msg.Digest(), signerAddress, observerTypes.ConvertReceiveStatusToVoteType(msg.Status), zetaTxHash)
+		b.lastOutTxReportTime[outTxHash] = time.Now()
+		return zetaTxHash, nil
+	}
+
+	authzMsg, authzSigner, err := b.WrapMessageWithAuthz(msg)
+	if err != nil {
+		return "", err
+	}
+	zetaTxHash := ""
+	err = Retry(b.ctx, "PostReceiveConfirmation", b.confirmationBackoff, func() error {
+		zetaTxHash, err = b.Broadcast(gasLimit, authzMsg, authzSigner)
+		if err != nil {
+			logger.Debug().Err(err).Msg("PostReceive broadcast fail")
 		}
-		b.logger.Debug().Err(err).Msgf("PostReceive broadcast fail | Retry count : %d", i+1)
-		time.Sleep(DefaultRetryInterval * time.Second)
+		return err
+	})
+	if err != nil {
+		return "", err
 	}
-	return "", fmt.Errorf("post receive failed after %d retries", DefaultRetryCount)
+	logger.Debug().Msgf("PostReceiveConfirmation broadcast zeta tx %s", zetaTxHash)
+	b.trackVote(metrics.VoteOutbound, msg.Digest(), signerAddress, observerTypes.ConvertReceiveStatusToVoteType(msg.Status), zetaTxHash)
+	b.lastOutTxReportTime[outTxHash] = time.Now() // update last report time when bcast succeeds
+	return zetaTxHash, nil
 }
 
 func (b *ZetaCoreBridge) SetTSS(tssPubkey string, keyGenZetaHeight int64, status common.ReceiveStatus) (string, error) {
@@ -212,21 +279,27 @@ func (b *ZetaCoreBridge) SetTSS(tssPubkey string, keyGenZetaHeight int64, status
 	}
 
 	zetaTxHash := ""
-	for i := 0; i <= DefaultRetryCount; i++ {
+	err = Retry(b.ctx, "SetTSS", SetTSSBackoff, func() error {
 		zetaTxHash, err = b.Broadcast(DefaultGasLimit, authzMsg, authzSigner)
-		if err == nil {
-			return zetaTxHash, nil
+		if err != nil {
+			b.logger.Debug().Err(err).Msg("SetTSS broadcast fail")
 		}
-		b.logger.Debug().Err(err).Msgf("SetTSS broadcast fail | Retry count : %d", i+1)
-		time.Sleep(DefaultRetryInterval * time.Second)
+		return err
+	})
+	if err != nil {
+		return "", err
 	}
-
-	return "", fmt.Errorf("set tss failed | err %s", err.Error())
+	return zetaTxHash, nil
 }
 
 func (b *ZetaCoreBridge) ConfigUpdater(cfg *config.Config) {
 	b.logger.Info().Msg("ConfigUpdater started")
 	ticker := time.NewTicker(time.Duration(cfg.ConfigUpdateTicker) * time.Second)
+	// the keygen is updated as soon as zetacore changes it if the core events are enabled
+	var keygenEvents <-chan CoreEvent
+	if b.coreEvents != nil {
+		keygenEvents = b.coreEvents.Subscribe(CoreEventKeygen)
+	}
 	for {
 		select {
 		case <-ticker.C:
@@ -235,6 +308,12 @@ func (b *ZetaCoreBridge) ConfigUpdater(cfg *config.Config) {
 			if err != nil {
 				b.logger.Err(err).Msg("ConfigUpdater failed to update config")
 			}
+		case event := <-keygenEvents:
+			b.logger.Info().Msgf("ConfigUpdater: keygen updated at zetacore height %d", event.Height)
+			err := b.UpdateConfigFromCore(cfg, false)
+			if err != nil {
+				b.logger.Err(err).Msg("ConfigUpdater failed to update config")
+			}
 		case <-b.stop:
 			b.logger.Info().Msg("ConfigUpdater stopped")
 			return
@@ -258,15 +337,18 @@ func (b *ZetaCoreBridge) PostBlameData(blame *blame.Blame, chainID int64, index
 
 	var gasLimit uint64 = PostBlameDataGasLimit
 
-	for i := 0; i < DefaultRetryCount; i++ {
-		zetaTxHash, err := b.Broadcast(gasLimit, authzMsg, authzSigner)
-		if err == nil {
-			return zetaTxHash, nil
+	zetaTxHash := ""
+	err = Retry(b.ctx, "PostBlameData", BroadcastBackoff, func() error {
+		zetaTxHash, err = b.Broadcast(gasLimit, authzMsg, authzSigner)
+		if err != nil {
+			b.logger.Error().Err(err).Msg("PostBlame broadcast fail")
 		}
-		b.logger.Error().Err(err).Msgf("PostBlame broadcast fail | Retry count : %d", i+1)
-		time.Sleep(DefaultRetryInterval * time.Second)
+		return err
+	})
+	if err != nil {
+		return "", err
 	}
-	return "", fmt.Errorf("post blame data failed after %d retries", DefaultRetryCount)
+	return zetaTxHash, nil
 }
 
 func (b *ZetaCoreBridge) PostAddBlockHeader(chainID int64, blockHash []byte, height int64, header common.HeaderData) (string, error) {
@@ -280,13 +362,16 @@ func (b *ZetaCoreBridge) PostAddBlockHeader(chainID int64, blockHash []byte, hei
 	}
 
 	var gasLimit uint64 = DefaultGasLimit
-	for i := 0; i < DefaultRetryCount; i++ {
-		zetaTxHash, err := b.Broadcast(gasLimit, authzMsg, authzSigner)
-		if err == nil {
-			return zetaTxHash, nil
+	zetaTxHash := ""
+	err = Retry(b.ctx, "PostAddBlockHeader", BroadcastBackoff, func() error {
+		zetaTxHash, err = b.Broadcast(gasLimit, authzMsg, authzSigner)
+		if err != nil {
+			b.logger.Error().Err(err).Msg("PostAddBlockHeader broadcast fail")
 		}
-		b.logger.Error().Err(err).Msgf("PostAddBlockHeader broadcast fail | Retry count : %d", i+1)
-		time.Sleep(DefaultRetryInterval * time.Second)
+		return err
+	})
+	if err != nil {
+		return "", err
 	}
-	return "", fmt.Errorf("post add block header failed after %d retries", DefaultRetryCount)
+	return zetaTxHash, nil
 }
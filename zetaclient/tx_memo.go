@@ -0,0 +1,84 @@
+package zetaclient
+
+import (
+	"encoding/json"
+	"sort"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/zeta-chain/zetacore/common"
+	"github.com/zeta-chain/zetacore/x/crosschain/types"
+)
+
+const (
+	// TxMemoClient is the client named in the memo of the txs of zetaclient
+	TxMemoClient = "zetaclientd"
+
+	// MaxTxMemoLength is the max length of the memo of a tx, the default MaxMemoCharacters of the auth module
+	MaxTxMemoLength = 256
+)
+
+// TxMemo is the memo of the txs broadcast by zetaclient, so that the votes on zetacore are attributed to the version
+// of the client that broadcast them
+type TxMemo struct {
+	Client  string  `json:"client"`
+	Version string  `json:"version,omitempty"`
+	Commit  string  `json:"commit,omitempty"`
+	Chains  []int64 `json:"chains,omitempty"`
+}
+
+// NewTxMemo returns the memo of the tx of the msg, with the external chains its msgs vote on
+func NewTxMemo(msg sdktypes.Msg) TxMemo {
+	return TxMemo{
+		Client:  TxMemoClient,
+		Version: common.Version,
+		Commit:  common.CommitHash,
+		Chains:  msgChains(msg),
+	}
+}
+
+// String returns the memo in json; the chains are left out of a memo longer than MaxTxMemoLength, and the memo is
+// empty if still longer
+func (m TxMemo) String() string {
+	memo, err := json.Marshal(m)
+	if err == nil && len(memo) > MaxTxMemoLength {
+		m.Chains = nil
+		memo, err = json.Marshal(m)
+	}
+	if err != nil || len(memo) > MaxTxMemoLength {
+		return ""
+	}
+	return string(memo)
+}
+
+// msgChains returns the external chains the msg, or the msgs wrapped in an authz exec, vote on in ascending order
+func msgChains(msg sdktypes.Msg) []int64 {
+	msgs := []sdktypes.Msg{msg}
+	if exec, ok := msg.(*authz.MsgExec); ok {
+		var err error
+		if msgs, err = exec.GetMessages(); err != nil {
+			return nil
+		}
+	}
+	seen := make(map[int64]bool)
+	var chains []int64
+	for _, msg := range msgs {
+		var chainID int64
+		switch msg := msg.(type) {
+		case *types.MsgVoteOnObservedInboundTx:
+			chainID = msg.SenderChainId
+		case *types.MsgVoteOnObservedOutboundTx:
+			chainID = msg.OutTxChain
+		case interface{ GetChainId() int64 }:
+			chainID = msg.GetChainId()
+		default:
+			continue
+		}
+		if !seen[chainID] {
+			seen[chainID] = true
+			chains = append(chains, chainID)
+		}
+	}
+	sort.Slice(chains, func(i, j int) bool { return chains[i] < chains[j] })
+	return chains
+}
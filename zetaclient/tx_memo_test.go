@@ -0,0 +1,38 @@
+package zetaclient
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/stretchr/testify/require"
+	"github.com/zeta-chain/zetacore/common"
+	crosschaintypes "github.com/zeta-chain/zetacore/x/crosschain/types"
+)
+
+func TestNewTxMemo(t *testing.T) {
+	inbound := &crosschaintypes.MsgVoteOnObservedInboundTx{Creator: "creator", SenderChainId: 56}
+	outbound := &crosschaintypes.MsgVoteOnObservedOutboundTx{Creator: "creator", OutTxChain: 1}
+	gasPrice := &crosschaintypes.MsgGasPriceVoter{Creator: "creator", ChainId: 56}
+	exec := authz.NewMsgExec(sdktypes.AccAddress("grantee"), []sdktypes.Msg{inbound, outbound, gasPrice})
+
+	memo := NewTxMemo(&exec)
+	require.Equal(t, TxMemoClient, memo.Client)
+	require.Equal(t, common.Version, memo.Version)
+	require.Equal(t, []int64{1, 56}, memo.Chains)
+	require.Equal(t, []int64{56}, NewTxMemo(gasPrice).Chains)
+
+	var decoded TxMemo
+	require.NoError(t, json.Unmarshal([]byte(memo.String()), &decoded))
+	require.Equal(t, memo, decoded)
+
+	// the chains are left out of a memo too long
+	long := TxMemo{Client: TxMemoClient, Version: "v10.1.0", Commit: strings.Repeat("a", 40), Chains: make([]int64, 100)}
+	var decodedLong TxMemo
+	require.NoError(t, json.Unmarshal([]byte(long.String()), &decodedLong))
+	require.Empty(t, decodedLong.Chains)
+	require.Equal(t, "v10.1.0", decodedLong.Version)
+	require.LessOrEqual(t, len(long.String()), MaxTxMemoLength)
+}
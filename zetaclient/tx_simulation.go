@@ -0,0 +1,135 @@
+package zetaclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	clienttx "github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	flag "github.com/spf13/pflag"
+	"google.golang.org/grpc/status"
+)
+
+// errRejectedInSimulation is returned for the txs rejected in simulation by the execution of their msgs rather than
+// for the tx itself, e.g. a vote on a ballot already finalized
+var errRejectedInSimulation = errors.New("tx rejected in simulation")
+
+// simulationRejections are the errors zetacore rejects a tx with in simulation, the more specific first, with the
+// action fixing them
+var simulationRejections = []struct {
+	err  *sdkerrors.Error
+	hint string
+}{
+	{sdkerrors.ErrWrongSequence, "the account sequence is out of sync, resyncing it"},
+	{authz.ErrNoAuthorizationFound, "the hotkey is not granted the msg by the operator, check the authz grants"},
+	{feegrant.ErrNoAllowance, "the operator granted no fee allowance to the hotkey, check the fee grant or unset FeeGrant"},
+	{feegrant.ErrFeeLimitExceeded, "the fee allowance of the hotkey is exhausted, renew the fee grant"},
+	{sdkerrors.ErrInsufficientFee, "the fee is below the min gas price of zetacore, raise GasPrices or Fees"},
+	{sdkerrors.ErrInsufficientFunds, "the account paying the fees lacks funds, fund the hotkey"},
+	{sdkerrors.ErrInvalidChainID, "the chain id doesn't match zetacore, check ChainID"},
+	{sdkerrors.ErrOutOfGas, "the gas limit is too low, raise GasAdjustment"},
+	{sdkerrors.ErrUnauthorized, "the signer is not allowed the msg, check the hotkey is an observer"},
+}
+
+// EnableTxSimulation has the txs simulated by zetacore before they are broadcast, so that the txs zetacore would
+// reject fail locally with the reason and the way to fix it
+func (b *ZetaCoreBridge) EnableTxSimulation() {
+	b.simulate = true
+}
+
+// simulateTx simulates the unsigned tx of the builder signed with sequence, whose signature is left empty as it is not
+// verified in simulation
+func (b *ZetaCoreBridge) simulateTx(ctx client.Context, builder client.TxBuilder, sequence uint64) error {
+	record, err := ctx.Keyring.Key(ctx.GetFromName())
+	if err != nil {
+		return err
+	}
+	pubKey, err := record.GetPubKey()
+	if err != nil {
+		return err
+	}
+	err = builder.SetSignatures(signing.SignatureV2{
+		PubKey:   pubKey,
+		Data:     &signing.SingleSignatureData{SignMode: signing.SignMode_SIGN_MODE_DIRECT},
+		Sequence: sequence,
+	})
+	if err != nil {
+		return err
+	}
+	txBytes, err := ctx.TxConfig.TxEncoder()(builder.GetTx())
+	if err != nil {
+		return err
+	}
+	_, err = txtypes.NewServiceClient(ctx).Simulate(context.Background(), &txtypes.SimulateRequest{TxBytes: txBytes})
+	if err != nil {
+		if _, ok := status.FromError(err); !ok {
+			// zetacore didn't answer
+			b.breaker.Record(err)
+		}
+		return simulationError(err)
+	}
+	return nil
+}
+
+// simulationError returns the error of a tx rejected in simulation with the ABCI code it would be rejected with, and
+// the action fixing it when known. The errors of a zetacore not answering are returned as is
+func simulationError(err error) error {
+	s, ok := status.FromError(err)
+	if !ok {
+		return fmt.Errorf("fail to simulate tx: %w", err)
+	}
+	// the ABCI code of the simulation is lost through the query, the rejection is matched by its message
+	for _, rejection := range simulationRejections {
+		if strings.Contains(s.Message(), rejection.err.Error()) {
+			return &txResultError{
+				codespace: rejection.err.Codespace(),
+				code:      rejection.err.ABCICode(),
+				msg:       fmt.Sprintf("tx rejected in simulation, %s: %s", rejection.hint, s.Message()),
+			}
+		}
+	}
+	return fmt.Errorf("%w: %s", errRejectedInSimulation, s.Message())
+}
+
+// simulateVote simulates the vote alone in a tx and returns ErrVoteRejected if zetacore rejects the vote itself. The
+// errors of the tx, e.g. its fee, or of a zetacore not answering are returned as is since they don't tell a vote at
+// fault. The sequence of the hotkey is peeked, not used
+func (b *ZetaCoreBridge) simulateVote(gasLimit uint64, msg sdk.Msg) error {
+	authzMsg, authzSigner, err := b.WrapMessageWithAuthz(msg)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrVoteRejected, err)
+	}
+	ctx, err := b.GetContext()
+	if err != nil {
+		return err
+	}
+	accountNumber, sequence, err := b.sequences.Peek(authzSigner.KeyType)
+	if err != nil {
+		return err
+	}
+	factory := clienttx.NewFactoryCLI(ctx, flag.NewFlagSet("zetacore", 0))
+	factory = factory.WithAccountNumber(accountNumber).WithSequence(sequence).WithSignMode(signing.SignMode_SIGN_MODE_DIRECT)
+	builder, err := factory.BuildUnsignedTx(authzMsg)
+	if err != nil {
+		return err
+	}
+	gasLimit = b.fees.GasLimit(gasLimit)
+	builder.SetGasLimit(gasLimit)
+	builder.SetFeeAmount(b.fees.Fee(gasLimit))
+	if b.feeGranter != nil {
+		builder.SetFeeGranter(b.feeGranter)
+	}
+	err = b.simulateTx(ctx, builder, sequence)
+	if errors.Is(err, errRejectedInSimulation) {
+		return fmt.Errorf("%w: %s", ErrVoteRejected, err)
+	}
+	return err
+}
@@ -0,0 +1,33 @@
+package zetaclient
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSimulationError(t *testing.T) {
+	// the rejections keep their ABCI code, so that the sequence mismatches are still resynced
+	err := simulationError(status.Error(codes.Unknown, "account sequence mismatch, expected 5, got 4: incorrect account sequence With gas wanted: '0' and gas used: '1000' "))
+	require.True(t, IsSequenceMismatch(err))
+	require.Equal(t, "sdk_32", coreCallCode(err))
+
+	err = simulationError(status.Error(codes.Unknown, fmt.Sprintf("failed to execute message; message index: 0: %s", authz.ErrNoAuthorizationFound.Error())))
+	require.Equal(t, "authz_2", coreCallCode(err))
+	require.Contains(t, err.Error(), "check the authz grants")
+
+	err = simulationError(status.Error(codes.Unknown, "0azeta is smaller than 100azeta: insufficient funds"))
+	require.Equal(t, fmt.Sprintf("sdk_%d", sdkerrors.ErrInsufficientFunds.ABCICode()), coreCallCode(err))
+
+	// the unknown rejections are surfaced as is, the errors of zetacore not answering are wrapped
+	err = simulationError(status.Error(codes.Unknown, "ballot already finalized"))
+	require.EqualError(t, err, "tx rejected in simulation: ballot already finalized")
+	refused := errors.New("connection refused")
+	require.ErrorIs(t, simulationError(refused), refused)
+}
@@ -0,0 +1,66 @@
+package zetaclient
+
+import (
+	"context"
+	"math/big"
+	"net"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/zeta-chain/zetacore/common"
+	observertypes "github.com/zeta-chain/zetacore/x/observer/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ballotQueryServer serves the ballots of zetacore
+type ballotQueryServer struct {
+	observertypes.UnimplementedQueryServer
+	ballots map[string]*observertypes.QueryBallotByIdentifierResponse
+}
+
+func (s *ballotQueryServer) BallotByIdentifier(_ context.Context, req *observertypes.QueryBallotByIdentifierRequest) (*observertypes.QueryBallotByIdentifierResponse, error) {
+	ballot, found := s.ballots[req.BallotIdentifier]
+	if !found {
+		return nil, status.Error(codes.NotFound, "not found ballot")
+	}
+	return ballot, nil
+}
+
+func TestZetaCoreBridge_HasVoted(t *testing.T) {
+	msg, err := InboundEvent{Sender: "0x01", SenderChain: common.EthChain(), TxOrigin: "0x01", Receiver: "0x02", ReceiverChain: common.ZetaChain(),
+		Amount: big.NewInt(1000), InTxHash: "0xvoted", InBlockHeight: 500, GasLimit: 90_000, CoinType: common.CoinType_Gas}.VoteMessage("zeta1observer")
+	require.NoError(t, err)
+	queryServer := &ballotQueryServer{ballots: map[string]*observertypes.QueryBallotByIdentifierResponse{
+		msg.Digest(): {
+			BallotIdentifier: msg.Digest(),
+			Voters: []*observertypes.VoterList{
+				{VoterAddress: "zeta1observer", VoteType: observertypes.VoteType_SuccessObservation},
+				{VoterAddress: "zeta1other", VoteType: observertypes.VoteType_NotYetVoted},
+			},
+		},
+	}}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	observertypes.RegisterQueryServer(server, queryServer)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+	conn, err := dialZetaCore(listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+	bridge := &ZetaCoreBridge{ctx: context.Background(), logger: zerolog.Nop(), endpoints: []*coreEndpoint{{host: "127.0.0.1", grpcConn: conn}}}
+
+	require.True(t, bridge.hasVoted(msg.Digest(), "zeta1observer"))
+	require.False(t, bridge.hasVoted(msg.Digest(), "zeta1other"))
+	require.False(t, bridge.hasVoted("0xunknown", "zeta1observer"))
+
+	// the duplicate vote is skipped without broadcasting
+	zetaHash, err := bridge.PostSend(PostSendEVMGasLimit, msg)
+	require.NoError(t, err)
+	require.Empty(t, zetaHash)
+}
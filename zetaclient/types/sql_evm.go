@@ -2,9 +2,13 @@ package types
 
 import (
 	"encoding/json"
+	"fmt"
 	"math/big"
+	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"gorm.io/gorm"
 )
@@ -13,6 +17,12 @@ import (
 
 const LastBlockNumID = 0xBEEF
 
+const InboundCheckpointID = 0xCAFE
+
+// QuarantinedKindGasDeposit is the kind of the quarantined gas token deposits to the TSS address, which have no log:
+// their record holds the sender as address, the calldata as data and the index of the deposit in its tx as log index
+const QuarantinedKindGasDeposit = "gas-deposit"
+
 // ReceiptDB : A modified receipt struct that the relational mapping can translate
 type ReceiptDB struct {
 	// Consensus fields: These fields are defined by the Yellow Paper
@@ -69,6 +79,64 @@ type LastBlockSQLType struct {
 	Num int64
 }
 
+// InboundEventSQLType records an inbound event already posted to zetacore
+type InboundEventSQLType struct {
+	gorm.Model
+	Key         string `gorm:"uniqueIndex"`
+	BlockNumber uint64 `gorm:"index"`
+	ZetaHash    string
+}
+
+// InboundProofSQLType records the proof of the tx of an inbound event against its block header, verified by zetacore
+type InboundProofSQLType struct {
+	gorm.Model
+	Key       string `gorm:"uniqueIndex"` // key of the inbound event, see InboundEventKey
+	BlockHash string
+	TxIndex   int64
+	TxProof   []byte // common.Proof, protobuf encoded
+	Receipt   []byte // receipt of the tx, JSON encoded
+}
+
+// InboundCheckpointSQLType records the last inbound event log posted to zetacore in the block range being scanned
+type InboundCheckpointSQLType struct {
+	gorm.Model
+	BlockNumber uint64
+	BlockHash   string
+	LogIndex    uint
+}
+
+// QuarantinedEventSQLType records an inbound event log that could not be decoded into a vote, with its raw data so
+// that it can be processed again after the decoder is fixed
+type QuarantinedEventSQLType struct {
+	gorm.Model
+	Key         string `gorm:"uniqueIndex"`
+	Kind        string // the kind of the contract that emitted the log
+	Address     string
+	Topics      string // hex encoded topics, comma separated
+	Data        string // hex encoded data
+	BlockNumber uint64
+	BlockHash   string
+	TxHash      string
+	TxIndex     uint
+	LogIndex    uint
+	Value       string // amount of the gas token deposits
+	Error       string
+}
+
+// PendingVoteSQLType records an inbound vote whose post to zetacore failed, to be retried with backoff until it is
+// posted or dead-lettered
+type PendingVoteSQLType struct {
+	gorm.Model
+	Key          string `gorm:"uniqueIndex"`
+	BlockNumber  uint64
+	GasLimit     uint64
+	Msg          []byte // protobuf encoded vote
+	Attempts     int
+	NextAttempt  time.Time
+	Error        string
+	DeadLettered bool
+}
+
 // Type translation functions:
 
 func ToReceiptDBType(receipt *ethtypes.Receipt) (ReceiptDB, error) {
@@ -160,3 +228,85 @@ func ToLastBlockSQLType(lastBlock int64) *LastBlockSQLType {
 		Num:   lastBlock,
 	}
 }
+
+// InboundEventKey identifies an inbound event by the hash of its tx and its log index in the block
+func InboundEventKey(txHash common.Hash, logIndex uint) string {
+	return fmt.Sprintf("%s-%d", txHash.Hex(), logIndex)
+}
+
+// GasDepositKey identifies a gas token deposit to the TSS address by the hash of its tx and its index in the tx
+func GasDepositKey(txHash common.Hash, eventIndex uint) string {
+	return fmt.Sprintf("%s-gas-%d", txHash.Hex(), eventIndex)
+}
+
+func ToInboundEventSQLType(key string, blockNumber uint64, zetaHash string) *InboundEventSQLType {
+	return &InboundEventSQLType{
+		Key:         key,
+		BlockNumber: blockNumber,
+		ZetaHash:    zetaHash,
+	}
+}
+
+func ToInboundCheckpointSQLType(blockNumber uint64, blockHash common.Hash, logIndex uint) *InboundCheckpointSQLType {
+	return &InboundCheckpointSQLType{
+		Model:       gorm.Model{ID: InboundCheckpointID},
+		BlockNumber: blockNumber,
+		BlockHash:   blockHash.Hex(),
+		LogIndex:    logIndex,
+	}
+}
+
+func ToQuarantinedEventSQLType(key string, kind string, vLog ethtypes.Log, err error) *QuarantinedEventSQLType {
+	topics := make([]string, len(vLog.Topics))
+	for i, topic := range vLog.Topics {
+		topics[i] = topic.Hex()
+	}
+	return &QuarantinedEventSQLType{
+		Key:         key,
+		Kind:        kind,
+		Address:     vLog.Address.Hex(),
+		Topics:      strings.Join(topics, ","),
+		Data:        hexutil.Encode(vLog.Data),
+		BlockNumber: vLog.BlockNumber,
+		BlockHash:   vLog.BlockHash.Hex(),
+		TxHash:      vLog.TxHash.Hex(),
+		TxIndex:     vLog.TxIndex,
+		LogIndex:    vLog.Index,
+		Error:       err.Error(),
+	}
+}
+
+// FromQuarantinedEventSQLType rebuilds the raw log of a quarantined event
+func FromQuarantinedEventSQLType(event QuarantinedEventSQLType) (ethtypes.Log, error) {
+	data, err := hexutil.Decode(event.Data)
+	if err != nil {
+		return ethtypes.Log{}, err
+	}
+	var topics []common.Hash
+	if event.Topics != "" {
+		for _, topic := range strings.Split(event.Topics, ",") {
+			topics = append(topics, common.HexToHash(topic))
+		}
+	}
+	return ethtypes.Log{
+		Address:     common.HexToAddress(event.Address),
+		Topics:      topics,
+		Data:        data,
+		BlockNumber: event.BlockNumber,
+		BlockHash:   common.HexToHash(event.BlockHash),
+		TxHash:      common.HexToHash(event.TxHash),
+		TxIndex:     event.TxIndex,
+		Index:       event.LogIndex,
+	}, nil
+}
+
+func ToPendingVoteSQLType(key string, blockNumber uint64, gasLimit uint64, msg []byte, nextAttempt time.Time, err error) *PendingVoteSQLType {
+	return &PendingVoteSQLType{
+		Key:         key,
+		BlockNumber: blockNumber,
+		GasLimit:    gasLimit,
+		Msg:         msg,
+		NextAttempt: nextAttempt,
+		Error:       err.Error(),
+	}
+}
@@ -0,0 +1,14 @@
+package types
+
+import "gorm.io/gorm"
+
+// OutboxEntrySQLType records a tx written ahead of its broadcast to zetacore, kept until zetacore includes it
+type OutboxEntrySQLType struct {
+	gorm.Model
+	Key      string `gorm:"uniqueIndex"` // hash of Msg
+	KeyType  string
+	GasLimit uint64
+	Msg      []byte // protobuf encoded msg, packed in an Any
+	TxHash   string // hash of the last tx broadcast, if any
+	Attempts int
+}
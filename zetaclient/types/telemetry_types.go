@@ -1,6 +1,33 @@
 package types
 
+import "time"
+
 // Status type for telemetry. More fields can be added as needed
 type Status struct {
-	BTCNumberOfUTXOs int `json:"btc_number_of_utxos"`
+	BTCNumberOfUTXOs int             `json:"btc_number_of_utxos"`
+	Watchers         []WatcherStatus `json:"watchers"`
+}
+
+// UnconfirmedInbound is an inbound tx seen in the mempool of an external chain and not yet mined.
+// It is reported for information only; inbound txs are voted on once confirmed
+type UnconfirmedInbound struct {
+	ChainID         int64     `json:"chain_id"`
+	TxHash          string    `json:"tx_hash"`
+	Sender          string    `json:"sender"`
+	CoinType        string    `json:"coin_type"`
+	Asset           string    `json:"asset,omitempty"`
+	Amount          string    `json:"amount"`
+	Receiver        string    `json:"receiver"`
+	ReceiverChainID int64     `json:"receiver_chain_id,omitempty"`
+	FirstSeen       time.Time `json:"first_seen"`
+}
+
+// WatcherStatus is the status of a goroutine of a chain client
+type WatcherStatus struct {
+	Chain       string    `json:"chain"`
+	Watcher     string    `json:"watcher"`
+	Running     bool      `json:"running"`
+	Restarts    int       `json:"restarts"`
+	LastError   string    `json:"last_error,omitempty"`
+	LastRestart time.Time `json:"last_restart"`
 }
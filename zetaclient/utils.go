@@ -3,15 +3,12 @@ package zetaclient
 import (
 	"bytes"
 	"context"
-	"encoding/base64"
-	"encoding/hex"
 	"fmt"
 	"math"
 	"math/big"
 	"strings"
 	"time"
 
-	sdkmath "cosmossdk.io/math"
 	"github.com/btcsuite/btcd/txscript"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
@@ -21,6 +18,7 @@ import (
 	"github.com/zeta-chain/protocol-contracts/pkg/contracts/evm/zetaconnector.non-eth.sol"
 	"github.com/zeta-chain/zetacore/common"
 	"github.com/zeta-chain/zetacore/x/crosschain/types"
+	metricsPkg "github.com/zeta-chain/zetacore/zetaclient/metrics"
 	clienttypes "github.com/zeta-chain/zetacore/zetaclient/types"
 )
 
@@ -104,7 +102,29 @@ func (ob *EVMChainClient) GetInboundVoteMsgForDepositedEvent(event *erc20custody
 	ob.logger.ExternalChainWatcher.Info().Msgf("TxBlockNumber %d Transaction Hash: %s Message : %s", event.Raw.BlockNumber, event.Raw.TxHash, event.Message)
 	if bytes.Compare(event.Message, []byte(DonationMessage)) == 0 {
 		ob.logger.ExternalChainWatcher.Info().Msgf("thank you rich folk for your donation!: %s", event.Raw.TxHash.Hex())
-		return types.MsgVoteOnObservedInboundTx{}, fmt.Errorf("thank you rich folk for your donation!: %s", event.Raw.TxHash.Hex())
+		return types.MsgVoteOnObservedInboundTx{}, fmt.Errorf("%w: thank you rich folk for your donation!: %s", ErrInboundRejected, event.Raw.TxHash.Hex())
+	}
+	if err := ValidateDepositedEvent(event); err != nil {
+		return types.MsgVoteOnObservedInboundTx{}, errors.Wrap(err, fmt.Sprintf("invalid Deposited event in tx %s", event.Raw.TxHash.Hex()))
+	}
+	whitelisted, err := ob.whitelist.IsWhitelisted(event.Asset)
+	if err != nil {
+		// the custody contract only accepts whitelisted assets; don't hold the deposit back if zetacore can't be queried
+		ob.logger.ExternalChainWatcher.Warn().Err(err).Msgf("can't check the whitelist for asset %s in tx %s", event.Asset.Hex(), event.Raw.TxHash.Hex())
+	} else if !whitelisted {
+		metricsPkg.NonWhitelistedDeposits.WithLabelValues(ob.chain.Name(), event.Asset.Hex()).Inc()
+		return types.MsgVoteOnObservedInboundTx{}, ob.rejectInbound(WebhookEvent{
+			TxHash:          event.Raw.TxHash.Hex(),
+			BlockNumber:     event.Raw.BlockNumber,
+			Receiver:        clienttypes.BytesToEthHex(event.Recipient),
+			ReceiverChainID: common.ZetaChain().ChainId,
+			CoinType:        common.CoinType_ERC20.String(),
+			Asset:           event.Asset.Hex(),
+			Amount:          event.Amount.String(),
+		}, fmt.Sprintf("asset %s is not whitelisted", event.Asset.Hex()))
+	}
+	if err := ob.checkTokenDecimals(event.Asset); err != nil {
+		return types.MsgVoteOnObservedInboundTx{}, errors.Wrap(err, fmt.Sprintf("invalid Deposited event in tx %s", event.Raw.TxHash.Hex()))
 	}
 	// get the sender of the event's transaction
 	tx, _, err := ob.evmClient.TransactionByHash(context.Background(), event.Raw.TxHash)
@@ -119,32 +139,59 @@ func (ob *EVMChainClient) GetInboundVoteMsgForDepositedEvent(event *erc20custody
 		return types.MsgVoteOnObservedInboundTx{}, errors.Wrap(err, fmt.Sprintf("can't recover the sender from the tx hash: %s", event.Raw.TxHash.Hex()))
 
 	}
-	return *GetInBoundVoteMessage(
-		sender.Hex(),
-		ob.chain.ChainId,
-		"",
-		clienttypes.BytesToEthHex(event.Recipient),
-		common.ZetaChain().ChainId,
-		sdkmath.NewUintFromBigInt(event.Amount),
-		hex.EncodeToString(event.Message),
-		event.Raw.TxHash.Hex(),
-		event.Raw.BlockNumber,
-		1_500_000,
-		common.CoinType_ERC20,
-		event.Asset.String(),
-		ob.zetaClient.GetKeys().GetOperatorAddress().String(),
-		event.Raw.Index,
-	), nil
+	ob.logger.ExternalChainWatcher.Info().Msgf("deposit of %s %s (%s) in tx %s", event.Amount, event.Asset.Hex(),
+		ob.whitelist.Symbol(event.Asset), event.Raw.TxHash.Hex())
+	recipient, err := encodeReceiver(common.ZetaChain(), event.Recipient)
+	if err != nil {
+		return types.MsgVoteOnObservedInboundTx{}, ob.rejectInbound(WebhookEvent{
+			TxHash:          event.Raw.TxHash.Hex(),
+			BlockNumber:     event.Raw.BlockNumber,
+			Sender:          sender.Hex(),
+			Receiver:        clienttypes.BytesToEthHex(event.Recipient),
+			ReceiverChainID: common.ZetaChain().ChainId,
+			CoinType:        common.CoinType_ERC20.String(),
+			Asset:           event.Asset.Hex(),
+			Amount:          event.Amount.String(),
+		}, err.Error())
+	}
+	msg, err := InboundEvent{
+		Sender:        sender.Hex(),
+		SenderChain:   ob.chain,
+		Receiver:      recipient,
+		ReceiverChain: common.ZetaChain(),
+		Amount:        event.Amount,
+		Message:       types.EncodeDepositMessage(event.Message),
+		InTxHash:      event.Raw.TxHash.Hex(),
+		InBlockHeight: event.Raw.BlockNumber,
+		GasLimit:      1_500_000,
+		CoinType:      common.CoinType_ERC20,
+		Asset:         event.Asset.String(),
+		EventIndex:    event.Raw.Index,
+	}.VoteMessage(ob.zetaClient.GetKeys().GetOperatorAddress().String())
+	if err != nil {
+		return types.MsgVoteOnObservedInboundTx{}, err
+	}
+	return *msg, nil
 }
 
 func (ob *EVMChainClient) GetInboundVoteMsgForZetaSentEvent(event *zetaconnector.ZetaConnectorNonEthZetaSent) (types.MsgVoteOnObservedInboundTx, error) {
 	ob.logger.ExternalChainWatcher.Info().Msgf("TxBlockNumber %d Transaction Hash: %s Message : %s", event.Raw.BlockNumber, event.Raw.TxHash, event.Message)
-	destChain := common.GetChainFromChainID(event.DestinationChainId.Int64())
-	if destChain == nil {
-		ob.logger.ExternalChainWatcher.Warn().Msgf("chain id not supported  %d", event.DestinationChainId.Int64())
-		return types.MsgVoteOnObservedInboundTx{}, fmt.Errorf("chain id not supported  %d", event.DestinationChainId.Int64())
+	destChain, err := ob.checkDestinationChain(event.DestinationChainId, event.ZetaTxSenderAddress, event.ZetaValueAndGas, event.Raw)
+	if err != nil {
+		return types.MsgVoteOnObservedInboundTx{}, err
+	}
+	destAddr, err := encodeReceiver(*destChain, event.DestinationAddress)
+	if err != nil {
+		return types.MsgVoteOnObservedInboundTx{}, ob.rejectInbound(WebhookEvent{
+			TxHash:          event.Raw.TxHash.Hex(),
+			BlockNumber:     event.Raw.BlockNumber,
+			Sender:          event.ZetaTxSenderAddress.Hex(),
+			Receiver:        clienttypes.BytesToEthHex(event.DestinationAddress),
+			ReceiverChainID: destChain.ChainId,
+			CoinType:        common.CoinType_Zeta.String(),
+			Amount:          event.ZetaValueAndGas.String(),
+		}, err.Error())
 	}
-	destAddr := clienttypes.BytesToEthHex(event.DestinationAddress)
 	if *destChain != common.ZetaChain() {
 		cfgDest, found := ob.cfg.GetEVMConfig(destChain.ChainId)
 		if !found {
@@ -152,49 +199,132 @@ func (ob *EVMChainClient) GetInboundVoteMsgForZetaSentEvent(event *zetaconnector
 		}
 		if strings.EqualFold(destAddr, cfgDest.ZetaTokenContractAddress) {
 			ob.logger.ExternalChainWatcher.Warn().Msgf("potential attack attempt: %s destination address is ZETA token contract address %s", destChain, destAddr)
-			return types.MsgVoteOnObservedInboundTx{}, fmt.Errorf("potential attack attempt: %s destination address is ZETA token contract address %s", destChain, destAddr)
+			return types.MsgVoteOnObservedInboundTx{}, fmt.Errorf("%w: potential attack attempt: %s destination address is ZETA token contract address %s", ErrInboundRejected, destChain, destAddr)
 		}
 	}
-	return *GetInBoundVoteMessage(
-		event.ZetaTxSenderAddress.Hex(),
-		ob.chain.ChainId,
-		event.SourceTxOriginAddress.Hex(),
-		clienttypes.BytesToEthHex(event.DestinationAddress),
-		destChain.ChainId,
-		sdkmath.NewUintFromBigInt(event.ZetaValueAndGas),
-		base64.StdEncoding.EncodeToString(event.Message),
-		event.Raw.TxHash.Hex(),
-		event.Raw.BlockNumber,
-		event.DestinationGasLimit.Uint64(),
-		common.CoinType_Zeta,
-		"",
-		ob.zetaClient.GetKeys().GetOperatorAddress().String(),
-		event.Raw.Index,
-	), nil
-}
-
-func (ob *EVMChainClient) GetInboundVoteMsgForTokenSentToTSS(txhash ethcommon.Hash, value *big.Int, receipt *ethtypes.Receipt, from ethcommon.Address, data []byte) *types.MsgVoteOnObservedInboundTx {
+	msg, err := InboundEvent{
+		Sender:        event.ZetaTxSenderAddress.Hex(),
+		SenderChain:   ob.chain,
+		TxOrigin:      event.SourceTxOriginAddress.Hex(),
+		Receiver:      destAddr,
+		ReceiverChain: *destChain,
+		Amount:        event.ZetaValueAndGas,
+		Message:       types.EncodeRelayedMessage(event.Message),
+		InTxHash:      event.Raw.TxHash.Hex(),
+		InBlockHeight: event.Raw.BlockNumber,
+		GasLimit:      event.DestinationGasLimit.Uint64(),
+		CoinType:      common.CoinType_Zeta,
+		EventIndex:    event.Raw.Index,
+	}.VoteMessage(ob.zetaClient.GetKeys().GetOperatorAddress().String())
+	if err != nil {
+		return types.MsgVoteOnObservedInboundTx{}, err
+	}
+	return *msg, nil
+}
+
+// checkDestinationChain returns the destination chain of ZETA sent from the chain, or an ErrInboundRejected error if
+// zetacore can't route the send: an unknown chain, a chain zetacore doesn't support or the source chain itself
+func (ob *EVMChainClient) checkDestinationChain(destChainID *big.Int, sender ethcommon.Address, amount *big.Int, vLog ethtypes.Log) (*common.Chain, error) {
+	var destChain *common.Chain
+	reason := ""
+	if destChainID.IsInt64() {
+		destChain = common.GetChainFromChainID(destChainID.Int64())
+	}
+	switch {
+	case destChain == nil:
+		reason = fmt.Sprintf("unknown destination chain %s", destChainID)
+	case destChain.ChainId == ob.chain.ChainId:
+		reason = fmt.Sprintf("destination chain %s is the source chain", destChain)
+	case !ob.cfg.IsChainSupported(destChain.ChainId):
+		reason = fmt.Sprintf("destination chain %s is not supported by zetacore", destChain)
+	default:
+		return destChain, nil
+	}
+	rejection := WebhookEvent{
+		TxHash:      vLog.TxHash.Hex(),
+		BlockNumber: vLog.BlockNumber,
+		Sender:      sender.Hex(),
+		CoinType:    common.CoinType_Zeta.String(),
+		Amount:      amount.String(),
+	}
+	if destChainID.IsInt64() {
+		rejection.ReceiverChainID = destChainID.Int64()
+	}
+	return nil, ob.rejectInbound(rejection, reason)
+}
+
+// encodeReceiver returns the address of the receiver on its chain, a hex address on the EVM chains and the zeta chain
+// or the address string on bitcoin, and checks it is one: see common.ValidateAddress. The bytes of a bitcoin receiver
+// are set by the sender, they are checked to be printable ASCII before being decoded since the address decoders panic
+// on other bytes
+func encodeReceiver(receiverChain common.Chain, receiver []byte) (string, error) {
+	address := clienttypes.BytesToEthHex(receiver)
+	if common.IsBitcoinChain(receiverChain.ChainId) {
+		for _, b := range receiver {
+			if b < 0x21 || b > 0x7e {
+				return "", fmt.Errorf("invalid receiver on chain %d: non printable ASCII byte 0x%02x", receiverChain.ChainId, b)
+			}
+		}
+		address = string(receiver)
+	}
+	if err := common.ValidateAddress(receiverChain, address); err != nil {
+		return "", fmt.Errorf("invalid receiver on chain %d: %w", receiverChain.ChainId, err)
+	}
+	return address, nil
+}
+
+// rejectInbound returns the ErrInboundRejected error of an inbound event zetacore couldn't process, e.g. sent to an
+// invalid destination, and publishes the rejection to the webhooks so that the sender can be refunded rather than the
+// transfer failing later in the pipeline
+func (ob *EVMChainClient) rejectInbound(rejection WebhookEvent, reason string) error {
+	ob.logger.ExternalChainWatcher.Warn().Msgf("rejecting inbound event in tx %s: %s", rejection.TxHash, reason)
+	rejection.Type = WebhookEventInboundRejected
+	rejection.ChainID = ob.chain.ChainId
+	rejection.Chain = ob.chain.Name()
+	rejection.Message = reason
+	ob.webhooks.Publish(rejection)
+	return fmt.Errorf("%w: %s in tx %s", ErrInboundRejected, reason, rejection.TxHash)
+}
+
+// IsTssGasDeposit returns true if a tx sent to the given address transfers gas tokens to the TSS address.
+// Txs without value are not deposits, whatever their calldata
+func IsTssGasDeposit(to *ethcommon.Address, value *big.Int, tssAddress ethcommon.Address) bool {
+	return to != nil && *to == tssAddress && value != nil && value.Sign() > 0
+}
+
+// GetInboundVoteMsgForTokenSentToTSS returns the vote for a gas token deposit; eventIndex tells apart the deposits made
+// by the same tx, 0 being the top level call. Deposits with an invalid memo are rejected for their sender to be refunded
+func (ob *EVMChainClient) GetInboundVoteMsgForTokenSentToTSS(txhash ethcommon.Hash, value *big.Int, receipt *ethtypes.Receipt, from ethcommon.Address, data []byte, eventIndex uint) (*types.MsgVoteOnObservedInboundTx, error) {
 	ob.logger.ExternalChainWatcher.Info().Msgf("TSS inTx detected: %s, blocknum %d", txhash.Hex(), receipt.BlockNumber)
 	ob.logger.ExternalChainWatcher.Info().Msgf("TSS inTx value: %s", value.String())
 	ob.logger.ExternalChainWatcher.Info().Msgf("TSS inTx from: %s", from.Hex())
 	message := ""
 	if len(data) != 0 {
-		message = hex.EncodeToString(data)
-	}
-	return GetInBoundVoteMessage(
-		from.Hex(),
-		ob.chain.ChainId,
-		from.Hex(),
-		from.Hex(),
-		common.ZetaChain().ChainId,
-		sdkmath.NewUintFromBigInt(value),
-		message,
-		txhash.Hex(),
-		receipt.BlockNumber.Uint64(),
-		90_000,
-		common.CoinType_Gas,
-		"",
-		ob.zetaClient.GetKeys().GetOperatorAddress().String(),
-		0, // not a smart contract call
-	)
+		memo, err := common.ParseMemo(data)
+		if err != nil {
+			return nil, ob.rejectInbound(WebhookEvent{
+				TxHash:          txhash.Hex(),
+				BlockNumber:     receipt.BlockNumber.Uint64(),
+				Sender:          from.Hex(),
+				ReceiverChainID: common.ZetaChain().ChainId,
+				CoinType:        common.CoinType_Gas.String(),
+				Amount:          value.String(),
+			}, err.Error())
+		}
+		message = types.EncodeDepositMessage(memo.Bytes())
+	}
+	return InboundEvent{
+		Sender:        from.Hex(),
+		SenderChain:   ob.chain,
+		TxOrigin:      from.Hex(),
+		Receiver:      from.Hex(),
+		ReceiverChain: common.ZetaChain(),
+		Amount:        value,
+		Message:       message,
+		InTxHash:      txhash.Hex(),
+		InBlockHeight: receipt.BlockNumber.Uint64(),
+		GasLimit:      90_000,
+		CoinType:      common.CoinType_Gas,
+		EventIndex:    eventIndex,
+	}.VoteMessage(ob.zetaClient.GetKeys().GetOperatorAddress().String())
 }
@@ -0,0 +1,214 @@
+package zetaclient
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+)
+
+// VoteBatcher gathers the inbound votes posted within a time window into a single zetacore tx, so that the events of
+// a busy block are broadcast at once rather than in a tx, and with a sequence number, each
+type VoteBatcher struct {
+	maxSize   int
+	window    time.Duration
+	broadcast func(gasLimit uint64, msgs []sdk.Msg) (string, error)
+	simulate  func(gasLimit uint64, msg sdk.Msg) error
+
+	mu      sync.Mutex
+	pending *voteBatch
+}
+
+// voteBatch is a batch of votes broadcast in a single tx once full or once its window has elapsed
+type voteBatch struct {
+	msgs      []sdk.Msg
+	gasLimits []uint64
+	gasLimit  uint64
+	full      chan struct{}
+	done      chan struct{}
+
+	zetaHash string
+	err      error
+	// results are the outcomes of the votes, each its own, once the batch is split by isolate
+	results []voteResult
+}
+
+// voteResult is the outcome of a vote of a split batch
+type voteResult struct {
+	zetaHash string
+	err      error
+}
+
+// NewVoteBatcher creates a VoteBatcher broadcasting at most maxSize votes per tx with broadcast; the gas limit of a
+// batch is the sum of the gas limits of its votes
+func NewVoteBatcher(maxSize int, window time.Duration, broadcast func(gasLimit uint64, msgs []sdk.Msg) (string, error)) *VoteBatcher {
+	if maxSize < 1 {
+		maxSize = 1
+	}
+	return &VoteBatcher{
+		maxSize:   maxSize,
+		window:    window,
+		broadcast: broadcast,
+	}
+}
+
+// SetIsolation has the votes of a failed batch simulated one by one with simulate, which returns ErrVoteRejected for a
+// vote zetacore rejects on its own, so that the votes at fault are isolated from the others, see isolate
+func (v *VoteBatcher) SetIsolation(simulate func(gasLimit uint64, msg sdk.Msg) error) {
+	v.simulate = simulate
+}
+
+// Post adds the vote to the pending batch and waits for the batch to be broadcast; it returns the hash of the zetacore
+// tx of the batch, or of the tx the vote was broadcast again in once the batch is split. The first vote of a batch
+// starts its window
+func (v *VoteBatcher) Post(gasLimit uint64, msg sdk.Msg) (string, error) {
+	v.mu.Lock()
+	batch, index := v.add(gasLimit, msg)
+	v.mu.Unlock()
+	return batch.result(index)
+}
+
+// PostAll adds the votes to the pending batches in their order, so that the votes sharing a tx keep it, and waits for
+// the batches to be broadcast; it returns the outcome of each vote like Post
+func (v *VoteBatcher) PostAll(gasLimits []uint64, msgs []sdk.Msg) ([]string, []error) {
+	batches := make([]*voteBatch, len(msgs))
+	indexes := make([]int, len(msgs))
+	v.mu.Lock()
+	for i, msg := range msgs {
+		batches[i], indexes[i] = v.add(gasLimits[i], msg)
+	}
+	v.mu.Unlock()
+
+	zetaHashes := make([]string, len(msgs))
+	errs := make([]error, len(msgs))
+	for i, batch := range batches {
+		zetaHashes[i], errs[i] = batch.result(indexes[i])
+	}
+	return zetaHashes, errs
+}
+
+// add appends the vote to the pending batch, started if there is none, and returns the batch and the index of the vote
+// in it; v.mu must be held
+func (v *VoteBatcher) add(gasLimit uint64, msg sdk.Msg) (*voteBatch, int) {
+	batch := v.pending
+	if batch == nil {
+		batch = &voteBatch{full: make(chan struct{}), done: make(chan struct{})}
+		v.pending = batch
+		go v.flush(batch)
+	}
+	index := len(batch.msgs)
+	batch.msgs = append(batch.msgs, msg)
+	batch.gasLimits = append(batch.gasLimits, gasLimit)
+	batch.gasLimit += gasLimit
+	if len(batch.msgs) >= v.maxSize {
+		v.pending = nil
+		close(batch.full)
+	}
+	return batch, index
+}
+
+// result waits for the batch to be broadcast and returns the outcome of its vote at index
+func (batch *voteBatch) result(index int) (string, error) {
+	<-batch.done
+	if batch.results != nil {
+		return batch.results[index].zetaHash, batch.results[index].err
+	}
+	return batch.zetaHash, batch.err
+}
+
+// flush broadcasts the batch once it is full or its window has elapsed
+func (v *VoteBatcher) flush(batch *voteBatch) {
+	timer := time.NewTimer(v.window)
+	defer timer.Stop()
+	select {
+	case <-batch.full:
+	case <-timer.C:
+		v.mu.Lock()
+		if v.pending == batch {
+			v.pending = nil
+		}
+		v.mu.Unlock()
+	}
+	batch.zetaHash, batch.err = v.broadcast(batch.gasLimit, batch.msgs)
+	if batch.err != nil && len(batch.msgs) > 1 && v.simulate != nil && !errors.Is(batch.err, ErrCoreUnavailable) {
+		v.isolate(batch)
+	}
+	close(batch.done)
+}
+
+// isolate simulates the votes of a failed batch one by one: the votes zetacore rejects on their own get their
+// rejection, to be dead-lettered, and the others are broadcast again in a new tx. The batch error stands for every
+// vote if no vote is at fault, or if zetacore can't tell, e.g. it doesn't answer or rejects the tx itself
+func (v *VoteBatcher) isolate(batch *voteBatch) {
+	results := make([]voteResult, len(batch.msgs))
+	var passed []int
+	var msgs []sdk.Msg
+	var gasLimit uint64
+	for i, msg := range batch.msgs {
+		err := v.simulate(batch.gasLimits[i], msg)
+		if err == nil {
+			passed = append(passed, i)
+			msgs = append(msgs, msg)
+			gasLimit += batch.gasLimits[i]
+			continue
+		}
+		if !errors.Is(err, ErrVoteRejected) {
+			return
+		}
+		results[i].err = err
+	}
+	if len(passed) == len(batch.msgs) {
+		return
+	}
+	if len(passed) > 0 {
+		zetaHash, err := v.broadcast(gasLimit, msgs)
+		for _, i := range passed {
+			results[i] = voteResult{zetaHash: zetaHash, err: err}
+		}
+	}
+	batch.results = results
+}
+
+// EnableVoteBatching makes PostSend gather the inbound votes into txs of at most maxSize votes, broadcast once full
+// or once window has elapsed since their first vote. The votes of a failed batch zetacore rejects on their own get
+// ErrVoteRejected while the others are broadcast again
+func (b *ZetaCoreBridge) EnableVoteBatching(maxSize int, window time.Duration) {
+	b.voteBatcher = NewVoteBatcher(maxSize, window, b.voteBroadcaster("PostSend", &b.sendBackoff))
+	b.voteBatcher.SetIsolation(b.simulateVote)
+}
+
+// EnableConfirmationBatching makes PostReceiveConfirmation gather the outbound confirmations into txs of at most
+// maxSize votes, independently of the inbound votes
+func (b *ZetaCoreBridge) EnableConfirmationBatching(maxSize int, window time.Duration) {
+	b.confirmationBatcher = NewVoteBatcher(maxSize, window, b.voteBroadcaster("PostReceiveConfirmation", &b.confirmationBackoff))
+	b.confirmationBatcher.SetIsolation(b.simulateVote)
+}
+
+// SetVoteRetry sets the retry schedules of the inbound votes and of the outbound confirmations
+func (b *ZetaCoreBridge) SetVoteRetry(send Backoff, confirmation Backoff) {
+	b.sendBackoff = send
+	b.confirmationBackoff = confirmation
+}
+
+// voteBroadcaster returns the broadcast of the votes of a flow in a single authz exec, retried with its backoff
+func (b *ZetaCoreBridge) voteBroadcaster(name string, backoff *Backoff) func(gasLimit uint64, msgs []sdk.Msg) (string, error) {
+	return func(gasLimit uint64, msgs []sdk.Msg) (string, error) {
+		authzSigner := GetSigner(sdk.MsgTypeURL(msgs[0]))
+		authzMsg := authz.NewMsgExec(authzSigner.GranteeAddress, msgs)
+		zetaTxHash := ""
+		err := Retry(b.ctx, name, *backoff, func() (err error) {
+			zetaTxHash, err = b.Broadcast(gasLimit, &authzMsg, authzSigner)
+			if err != nil {
+				b.logger.Debug().Err(err).Msgf("%s broadcast of %d votes fail", name, len(msgs))
+			}
+			return err
+		})
+		if err != nil {
+			return "", err
+		}
+		b.logger.Debug().Msgf("%s broadcast %d votes in zeta tx %s", name, len(msgs), zetaTxHash)
+		return zetaTxHash, nil
+	}
+}
@@ -0,0 +1,152 @@
+package zetaclient
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"github.com/zeta-chain/zetacore/x/crosschain/types"
+)
+
+func TestVoteBatcher(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]sdk.Msg
+	var gasLimits []uint64
+	batcher := NewVoteBatcher(3, time.Hour, func(gasLimit uint64, msgs []sdk.Msg) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		batches = append(batches, msgs)
+		gasLimits = append(gasLimits, gasLimit)
+		return "zetahash", nil
+	})
+
+	// a full batch is broadcast without waiting for its window
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			zetaHash, err := batcher.Post(100, &types.MsgVoteOnObservedInboundTx{})
+			require.NoError(t, err)
+			require.Equal(t, "zetahash", zetaHash)
+		}()
+	}
+	wg.Wait()
+	require.Len(t, batches, 1)
+	require.Len(t, batches[0], 3)
+	require.Equal(t, []uint64{300}, gasLimits)
+}
+
+func TestVoteBatcher_Window(t *testing.T) {
+	broadcasts := 0
+	batcher := NewVoteBatcher(10, 10*time.Millisecond, func(_ uint64, msgs []sdk.Msg) (string, error) {
+		broadcasts++
+		require.Len(t, msgs, 1)
+		return "", errors.New("connection refused")
+	})
+
+	// a batch is broadcast once its window has elapsed and every vote gets its error
+	_, err := batcher.Post(100, &types.MsgVoteOnObservedInboundTx{})
+	require.Error(t, err)
+	_, err = batcher.Post(100, &types.MsgVoteOnObservedInboundTx{})
+	require.Error(t, err)
+	require.Equal(t, 2, broadcasts)
+}
+
+func TestVoteBatcher_Isolation(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]sdk.Msg
+	batcher := NewVoteBatcher(3, time.Hour, func(gasLimit uint64, msgs []sdk.Msg) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		batches = append(batches, msgs)
+		for _, msg := range msgs {
+			if msg.(*types.MsgVoteOnObservedInboundTx).InTxHash == "0xbad" {
+				return "", errors.New("failed to execute message; message index: 1: ballot already finalized")
+			}
+		}
+		return "rebatched", nil
+	})
+	batcher.SetIsolation(func(_ uint64, msg sdk.Msg) error {
+		if msg.(*types.MsgVoteOnObservedInboundTx).InTxHash == "0xbad" {
+			return fmt.Errorf("%w: ballot already finalized", ErrVoteRejected)
+		}
+		return nil
+	})
+
+	// the vote at fault gets its rejection, the others are broadcast again without it
+	results := make(map[string]error)
+	hashes := make(map[string]string)
+	var wg sync.WaitGroup
+	for _, inTxHash := range []string{"0xgood1", "0xbad", "0xgood2"} {
+		wg.Add(1)
+		go func(inTxHash string) {
+			defer wg.Done()
+			zetaHash, err := batcher.Post(100, &types.MsgVoteOnObservedInboundTx{InTxHash: inTxHash})
+			mu.Lock()
+			defer mu.Unlock()
+			results[inTxHash] = err
+			hashes[inTxHash] = zetaHash
+		}(inTxHash)
+	}
+	wg.Wait()
+	require.ErrorIs(t, results["0xbad"], ErrVoteRejected)
+	require.NoError(t, results["0xgood1"])
+	require.NoError(t, results["0xgood2"])
+	require.Equal(t, "rebatched", hashes["0xgood1"])
+	require.Len(t, batches, 2)
+	require.Len(t, batches[1], 2)
+}
+
+func TestVoteBatcher_IsolationNoFault(t *testing.T) {
+	broadcasts := 0
+	batcher := NewVoteBatcher(2, time.Hour, func(_ uint64, msgs []sdk.Msg) (string, error) {
+		broadcasts++
+		return "", errors.New("insufficient fee")
+	})
+	batcher.SetIsolation(func(uint64, sdk.Msg) error {
+		return nil
+	})
+
+	// the batch error stands for every vote when no vote is at fault, without broadcasting again
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := batcher.Post(100, &types.MsgVoteOnObservedInboundTx{})
+			require.EqualError(t, err, "insufficient fee")
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, 1, broadcasts)
+}
+
+func TestVoteBatcher_PostAll(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]string
+	batcher := NewVoteBatcher(2, 10*time.Millisecond, func(_ uint64, msgs []sdk.Msg) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		var inTxHashes []string
+		for _, msg := range msgs {
+			inTxHashes = append(inTxHashes, msg.(*types.MsgVoteOnObservedInboundTx).InTxHash)
+		}
+		batches = append(batches, inTxHashes)
+		return fmt.Sprintf("zetahash%d", len(batches)), nil
+	})
+
+	// the votes fill the batches in their order
+	var msgs []sdk.Msg
+	for _, inTxHash := range []string{"0x01", "0x02", "0x03"} {
+		msgs = append(msgs, &types.MsgVoteOnObservedInboundTx{InTxHash: inTxHash})
+	}
+	zetaHashes, errs := batcher.PostAll([]uint64{100, 100, 100}, msgs)
+	require.Equal(t, []error{nil, nil, nil}, errs)
+	require.Equal(t, []string{"zetahash1", "zetahash1", "zetahash2"}, zetaHashes)
+	require.Equal(t, [][]string{{"0x01", "0x02"}, {"0x03"}}, batches)
+}
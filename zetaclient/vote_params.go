@@ -0,0 +1,164 @@
+package zetaclient
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/zeta-chain/zetacore/common"
+	"github.com/zeta-chain/zetacore/x/crosschain/types"
+)
+
+// InboundEvent is an inbound tx observed on an external chain, voted on with PostSend
+type InboundEvent struct {
+	Sender        string // address on the sender chain
+	SenderChain   common.Chain
+	TxOrigin      string // address on the sender chain
+	Receiver      string // address on the receiver chain
+	ReceiverChain common.Chain
+	Amount        *big.Int
+	Message       string // encoded with types.EncodeDepositMessage or types.EncodeRelayedMessage
+	InTxHash      string
+	InBlockHeight uint64
+	GasLimit      uint64
+	CoinType      common.CoinType
+	Asset         string // address of the ERC20 on the sender chain, if any
+	EventIndex    uint   // tells apart the events of the same tx
+}
+
+// Validate checks the event can be voted on
+func (e InboundEvent) Validate() error {
+	if e.Sender == "" {
+		return errors.New("empty sender")
+	}
+	if e.InTxHash == "" {
+		return errors.New("empty inbound tx hash")
+	}
+	if err := validateChain(e.SenderChain); err != nil {
+		return fmt.Errorf("invalid sender chain: %w", err)
+	}
+	if err := validateChain(e.ReceiverChain); err != nil {
+		return fmt.Errorf("invalid receiver chain: %w", err)
+	}
+	if err := validateAmount(e.Amount); err != nil {
+		return err
+	}
+	if err := validateCoinType(e.CoinType); err != nil {
+		return err
+	}
+	if e.CoinType == common.CoinType_ERC20 && e.Asset == "" {
+		return errors.New("empty asset of ERC20 deposit")
+	}
+	return nil
+}
+
+// VoteMessage validates the event and returns the vote of the signer on it
+func (e InboundEvent) VoteMessage(signerAddress string) (*types.MsgVoteOnObservedInboundTx, error) {
+	if err := e.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid inbound event in tx %s: %w", e.InTxHash, err)
+	}
+	return types.NewMsgVoteOnObservedInboundTx(
+		signerAddress,
+		e.Sender,
+		e.SenderChain.ChainId,
+		e.TxOrigin,
+		e.Receiver,
+		e.ReceiverChain.ChainId,
+		sdkmath.NewUintFromBigInt(e.Amount),
+		e.Message,
+		e.InTxHash,
+		e.InBlockHeight,
+		e.GasLimit,
+		e.CoinType,
+		e.Asset,
+		e.EventIndex,
+	), nil
+}
+
+// OutboundConfirmation is an outbound tx of a cctx confirmed on an external chain, voted on with PostReceiveConfirmation
+type OutboundConfirmation struct {
+	SendHash          string // index of the cctx
+	OutTxHash         string
+	OutBlockHeight    uint64
+	GasUsed           uint64
+	EffectiveGasPrice *big.Int // nil if unused, e.g. on bitcoin
+	EffectiveGasLimit uint64
+	Amount            *big.Int
+	Status            common.ReceiveStatus
+	Chain             common.Chain
+	Nonce             uint64
+	CoinType          common.CoinType
+}
+
+// Validate checks the confirmation can be voted on
+func (c OutboundConfirmation) Validate() error {
+	if c.SendHash == "" {
+		return errors.New("empty cctx index")
+	}
+	if c.OutTxHash == "" {
+		return errors.New("empty outbound tx hash")
+	}
+	if err := validateChain(c.Chain); err != nil {
+		return err
+	}
+	if err := validateAmount(c.Amount); err != nil {
+		return err
+	}
+	if c.EffectiveGasPrice != nil && c.EffectiveGasPrice.Sign() < 0 {
+		return fmt.Errorf("negative gas price %s", c.EffectiveGasPrice)
+	}
+	if c.Status != common.ReceiveStatus_Success && c.Status != common.ReceiveStatus_Failed {
+		return fmt.Errorf("invalid status %s", c.Status)
+	}
+	return validateCoinType(c.CoinType)
+}
+
+// VoteMessage validates the confirmation and returns the vote of the signer on it
+func (c OutboundConfirmation) VoteMessage(signerAddress string) (*types.MsgVoteOnObservedOutboundTx, error) {
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid confirmation of outbound tx %s: %w", c.OutTxHash, err)
+	}
+	// a nil gas price is passed as is, so that the ballot is the one of the observers running older versions
+	return types.NewMsgVoteOnObservedOutboundTx(
+		signerAddress,
+		c.SendHash,
+		c.OutTxHash,
+		c.OutBlockHeight,
+		c.GasUsed,
+		sdkmath.NewIntFromBigInt(c.EffectiveGasPrice),
+		c.EffectiveGasLimit,
+		sdkmath.NewUintFromBigInt(c.Amount),
+		c.Status,
+		c.Chain.ChainId,
+		c.Nonce,
+		c.CoinType,
+	), nil
+}
+
+// validateChain checks the chain is set; the chains enabled at runtime are not all in the default chain list
+func validateChain(chain common.Chain) error {
+	if chain.ChainId == 0 {
+		return errors.New("unset chain")
+	}
+	return nil
+}
+
+// validateAmount checks the amount is set and not negative
+func validateAmount(amount *big.Int) error {
+	if amount == nil {
+		return errors.New("nil amount")
+	}
+	if amount.Sign() < 0 {
+		return fmt.Errorf("negative amount %s", amount)
+	}
+	return nil
+}
+
+// validateCoinType checks the coin type is known
+func validateCoinType(coinType common.CoinType) error {
+	if _, found := common.CoinType_name[int32(coinType)]; !found {
+		return fmt.Errorf("unknown coin type %d", coinType)
+	}
+	return nil
+}
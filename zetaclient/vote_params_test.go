@@ -0,0 +1,76 @@
+package zetaclient
+
+import (
+	"math/big"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/stretchr/testify/require"
+	"github.com/zeta-chain/zetacore/common"
+	"github.com/zeta-chain/zetacore/x/crosschain/types"
+)
+
+func TestInboundEvent_VoteMessage(t *testing.T) {
+	event := InboundEvent{
+		Sender:        "0x01",
+		SenderChain:   common.EthChain(),
+		TxOrigin:      "0x02",
+		Receiver:      "0x03",
+		ReceiverChain: common.ZetaChain(),
+		Amount:        big.NewInt(1000),
+		Message:       "cafe",
+		InTxHash:      "0xabc",
+		InBlockHeight: 100,
+		GasLimit:      90_000,
+		CoinType:      common.CoinType_ERC20,
+		Asset:         "0x04",
+		EventIndex:    2,
+	}
+	msg, err := event.VoteMessage("zeta1observer")
+	require.NoError(t, err)
+	require.Equal(t, types.NewMsgVoteOnObservedInboundTx("zeta1observer", "0x01", common.EthChain().ChainId, "0x02", "0x03",
+		common.ZetaChain().ChainId, sdkmath.NewUint(1000), "cafe", "0xabc", 100, 90_000, common.CoinType_ERC20, "0x04", 2), msg)
+
+	invalid := func(update func(e *InboundEvent)) error {
+		e := event
+		update(&e)
+		_, err := e.VoteMessage("zeta1observer")
+		return err
+	}
+	require.ErrorContains(t, invalid(func(e *InboundEvent) { e.Sender = "" }), "empty sender")
+	require.ErrorContains(t, invalid(func(e *InboundEvent) { e.InTxHash = "" }), "empty inbound tx hash")
+	require.ErrorContains(t, invalid(func(e *InboundEvent) { e.ReceiverChain = common.Chain{} }), "invalid receiver chain")
+	require.ErrorContains(t, invalid(func(e *InboundEvent) { e.Amount = nil }), "nil amount")
+	require.ErrorContains(t, invalid(func(e *InboundEvent) { e.Amount = big.NewInt(-1) }), "negative amount")
+	require.ErrorContains(t, invalid(func(e *InboundEvent) { e.CoinType = 42 }), "unknown coin type")
+	require.ErrorContains(t, invalid(func(e *InboundEvent) { e.Asset = "" }), "empty asset")
+}
+
+func TestOutboundConfirmation_VoteMessage(t *testing.T) {
+	confirmation := OutboundConfirmation{
+		SendHash:          "0xcctx",
+		OutTxHash:         "0xout",
+		OutBlockHeight:    100,
+		GasUsed:           21_000,
+		EffectiveGasPrice: big.NewInt(10),
+		EffectiveGasLimit: 50_000,
+		Amount:            big.NewInt(1000),
+		Status:            common.ReceiveStatus_Success,
+		Chain:             common.EthChain(),
+		Nonce:             7,
+		CoinType:          common.CoinType_Gas,
+	}
+	msg, err := confirmation.VoteMessage("zeta1observer")
+	require.NoError(t, err)
+	require.Equal(t, types.NewMsgVoteOnObservedOutboundTx("zeta1observer", "0xcctx", "0xout", 100, 21_000, sdkmath.NewInt(10),
+		50_000, sdkmath.NewUint(1000), common.ReceiveStatus_Success, common.EthChain().ChainId, 7, common.CoinType_Gas), msg)
+
+	// the gas price is optional, e.g. on bitcoin
+	confirmation.EffectiveGasPrice = nil
+	_, err = confirmation.VoteMessage("zeta1observer")
+	require.NoError(t, err)
+
+	confirmation.Status = common.ReceiveStatus_Created
+	_, err = confirmation.VoteMessage("zeta1observer")
+	require.ErrorContains(t, err, "invalid status")
+}
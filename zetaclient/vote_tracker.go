@@ -0,0 +1,172 @@
+package zetaclient
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	observertypes "github.com/zeta-chain/zetacore/x/observer/types"
+	metricsPkg "github.com/zeta-chain/zetacore/zetaclient/metrics"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// VoteTrackingInterval is the interval the ballots of the votes broadcast are checked at
+	VoteTrackingInterval = 30 * time.Second
+
+	// VoteTrackingTimeout is how long a vote broadcast is tracked until its ballot counts it and finalizes; it outlasts
+	// the replays of the outbox
+	VoteTrackingTimeout = 30 * time.Minute
+)
+
+// VoteTracker holds the votes broadcast to zetacore until their ballot finalized, so that the votes which never
+// counted and the ballots finalized against the vote of the observer are reported
+type VoteTracker struct {
+	mu    sync.Mutex
+	votes map[string]*trackedVote // by ballot identifier
+}
+
+// trackedVote is a vote broadcast on a ballot
+type trackedVote struct {
+	kind        string // metricsPkg.VoteInbound or metricsPkg.VoteOutbound
+	voter       string
+	voteType    observertypes.VoteType
+	zetaTxHash  string
+	broadcastAt time.Time
+	included    bool
+}
+
+// NewVoteTracker creates an empty VoteTracker
+func NewVoteTracker() *VoteTracker {
+	return &VoteTracker{votes: make(map[string]*trackedVote)}
+}
+
+// Track records the vote of voter on the ballot broadcast in the zeta tx; a vote broadcast again on the same ballot
+// replaces the former
+func (t *VoteTracker) Track(kind string, ballotIdentifier string, voter string, voteType observertypes.VoteType, zetaTxHash string, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, found := t.votes[ballotIdentifier]; !found {
+		metricsPkg.VotesBroadcast.WithLabelValues(kind).Inc()
+	}
+	t.votes[ballotIdentifier] = &trackedVote{
+		kind:        kind,
+		voter:       voter,
+		voteType:    voteType,
+		zetaTxHash:  zetaTxHash,
+		broadcastAt: now,
+	}
+}
+
+// pending returns the ballot identifiers of the votes tracked
+func (t *VoteTracker) pending() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ballots := make([]string, 0, len(t.votes))
+	for ballotIdentifier := range t.votes {
+		ballots = append(ballots, ballotIdentifier)
+	}
+	return ballots
+}
+
+// EnableVoteTracking has the inbound votes and outbound confirmations broadcast tracked until their ballot finalized,
+// counted by the VotesBroadcast, VotesIncluded, VotesDropped and VotedBallots metrics
+func (b *ZetaCoreBridge) EnableVoteTracking() {
+	b.voteTracker = NewVoteTracker()
+	go func() {
+		ticker := time.NewTicker(VoteTrackingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-b.ctx.Done():
+				return
+			case <-ticker.C:
+				b.checkVotes(time.Now())
+			}
+		}
+	}()
+}
+
+// trackVote tracks the vote broadcast in the zeta tx, if vote tracking is enabled
+func (b *ZetaCoreBridge) trackVote(kind string, ballotIdentifier string, voter string, voteType observertypes.VoteType, zetaTxHash string) {
+	if b.voteTracker == nil {
+		return
+	}
+	b.voteTracker.Track(kind, ballotIdentifier, voter, voteType, zetaTxHash, time.Now())
+}
+
+// checkVotes checks the ballots of the votes tracked against zetacore at now
+func (b *ZetaCoreBridge) checkVotes(now time.Time) {
+	// the ballots are left unchecked while zetacore is unavailable, their votes may still count
+	if b.breaker.Allow() != nil {
+		return
+	}
+	for _, ballotIdentifier := range b.voteTracker.pending() {
+		if b.ctx.Err() != nil {
+			return
+		}
+		ballot, err := b.GetBallot(ballotIdentifier)
+		if err != nil && status.Code(err) != codes.NotFound {
+			b.logger.Warn().Err(err).Msgf("checkVotes: fail to query ballot %s", ballotIdentifier)
+			return
+		}
+		b.voteTracker.check(b.logger, ballotIdentifier, ballot, now)
+	}
+}
+
+// check updates the vote on the ballot from its state on zetacore, nil if zetacore has no such ballot yet, and stops
+// tracking it once the ballot finalized or the vote timed out
+func (t *VoteTracker) check(logger zerolog.Logger, ballotIdentifier string, ballot *observertypes.QueryBallotByIdentifierResponse, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	vote, found := t.votes[ballotIdentifier]
+	if !found {
+		return
+	}
+	if ballot != nil && !vote.included && hasVote(ballot, vote.voter) {
+		vote.included = true
+		metricsPkg.VotesIncluded.WithLabelValues(vote.kind).Inc()
+	}
+	finalized := ballot != nil && ballot.BallotStatus != observertypes.BallotStatus_BallotInProgress
+	if !finalized && now.Sub(vote.broadcastAt) < VoteTrackingTimeout {
+		return
+	}
+	delete(t.votes, ballotIdentifier)
+
+	if !vote.included {
+		metricsPkg.VotesDropped.WithLabelValues(vote.kind).Inc()
+		logger.Warn().Msgf("checkVotes: %s vote of zeta tx %s not counted in ballot %s", vote.kind, vote.zetaTxHash, ballotIdentifier)
+	}
+	result := metricsPkg.BallotUnfinalized
+	switch {
+	case !finalized:
+		logger.Warn().Msgf("checkVotes: ballot %s not finalized %s after the %s vote of zeta tx %s",
+			ballotIdentifier, VoteTrackingTimeout, vote.kind, vote.zetaTxHash)
+	case ballotResult(ballot.BallotStatus) == vote.voteType:
+		result = metricsPkg.BallotWon
+	default:
+		result = metricsPkg.BallotLost
+		logger.Warn().Msgf("checkVotes: ballot %s finalized as %s against the %s vote of zeta tx %s",
+			ballotIdentifier, ballot.BallotStatus.String(), vote.kind, vote.zetaTxHash)
+	}
+	metricsPkg.VotedBallots.WithLabelValues(vote.kind, result).Inc()
+}
+
+// hasVote returns true if the ballot counts a vote of the voter
+func hasVote(ballot *observertypes.QueryBallotByIdentifierResponse, voter string) bool {
+	for _, v := range ballot.Voters {
+		if v.VoterAddress == voter && v.VoteType != observertypes.VoteType_NotYetVoted {
+			return true
+		}
+	}
+	return false
+}
+
+// ballotResult returns the vote a finalized ballot status agrees with
+func ballotResult(ballotStatus observertypes.BallotStatus) observertypes.VoteType {
+	if ballotStatus == observertypes.BallotStatus_BallotFinalized_FailureObservation {
+		return observertypes.VoteType_FailureObservation
+	}
+	return observertypes.VoteType_SuccessObservation
+}
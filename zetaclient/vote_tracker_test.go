@@ -0,0 +1,65 @@
+package zetaclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	observertypes "github.com/zeta-chain/zetacore/x/observer/types"
+	metricsPkg "github.com/zeta-chain/zetacore/zetaclient/metrics"
+)
+
+func TestVoteTracker(t *testing.T) {
+	now := time.Now()
+	inProgress := &observertypes.QueryBallotByIdentifierResponse{
+		BallotStatus: observertypes.BallotStatus_BallotInProgress,
+		Voters: []*observertypes.VoterList{
+			{VoterAddress: "zeta1observer", VoteType: observertypes.VoteType_SuccessObservation},
+			{VoterAddress: "zeta1other", VoteType: observertypes.VoteType_NotYetVoted},
+		},
+	}
+	finalized := func(ballotStatus observertypes.BallotStatus, voteType observertypes.VoteType) *observertypes.QueryBallotByIdentifierResponse {
+		return &observertypes.QueryBallotByIdentifierResponse{
+			BallotStatus: ballotStatus,
+			Voters:       []*observertypes.VoterList{{VoterAddress: "zeta1observer", VoteType: voteType}},
+		}
+	}
+
+	broadcast := testutil.ToFloat64(metricsPkg.VotesBroadcast.WithLabelValues(metricsPkg.VoteOutbound))
+	included := testutil.ToFloat64(metricsPkg.VotesIncluded.WithLabelValues(metricsPkg.VoteOutbound))
+	dropped := testutil.ToFloat64(metricsPkg.VotesDropped.WithLabelValues(metricsPkg.VoteOutbound))
+	won := testutil.ToFloat64(metricsPkg.VotedBallots.WithLabelValues(metricsPkg.VoteOutbound, metricsPkg.BallotWon))
+	lost := testutil.ToFloat64(metricsPkg.VotedBallots.WithLabelValues(metricsPkg.VoteOutbound, metricsPkg.BallotLost))
+	unfinalized := testutil.ToFloat64(metricsPkg.VotedBallots.WithLabelValues(metricsPkg.VoteOutbound, metricsPkg.BallotUnfinalized))
+
+	tracker := NewVoteTracker()
+	tracker.Track(metricsPkg.VoteOutbound, "won", "zeta1observer", observertypes.VoteType_SuccessObservation, "0x01", now)
+	tracker.Track(metricsPkg.VoteOutbound, "lost", "zeta1observer", observertypes.VoteType_SuccessObservation, "0x02", now)
+	tracker.Track(metricsPkg.VoteOutbound, "missing", "zeta1observer", observertypes.VoteType_FailureObservation, "0x03", now)
+	// a vote broadcast again on its ballot is counted once
+	tracker.Track(metricsPkg.VoteOutbound, "missing", "zeta1observer", observertypes.VoteType_FailureObservation, "0x04", now)
+	require.Equal(t, broadcast+3, testutil.ToFloat64(metricsPkg.VotesBroadcast.WithLabelValues(metricsPkg.VoteOutbound)))
+
+	// the vote is counted once, its ballot still in progress is tracked
+	tracker.check(zerolog.Nop(), "won", inProgress, now)
+	tracker.check(zerolog.Nop(), "won", inProgress, now)
+	require.Equal(t, included+1, testutil.ToFloat64(metricsPkg.VotesIncluded.WithLabelValues(metricsPkg.VoteOutbound)))
+	require.Len(t, tracker.pending(), 3)
+
+	tracker.check(zerolog.Nop(), "won", finalized(observertypes.BallotStatus_BallotFinalized_SuccessObservation, observertypes.VoteType_SuccessObservation), now)
+	tracker.check(zerolog.Nop(), "lost", finalized(observertypes.BallotStatus_BallotFinalized_FailureObservation, observertypes.VoteType_SuccessObservation), now)
+	require.Equal(t, included+2, testutil.ToFloat64(metricsPkg.VotesIncluded.WithLabelValues(metricsPkg.VoteOutbound)))
+	require.Equal(t, won+1, testutil.ToFloat64(metricsPkg.VotedBallots.WithLabelValues(metricsPkg.VoteOutbound, metricsPkg.BallotWon)))
+	require.Equal(t, lost+1, testutil.ToFloat64(metricsPkg.VotedBallots.WithLabelValues(metricsPkg.VoteOutbound, metricsPkg.BallotLost)))
+	require.ElementsMatch(t, []string{"missing"}, tracker.pending())
+
+	// a ballot zetacore doesn't know is awaited until the vote times out
+	tracker.check(zerolog.Nop(), "missing", nil, now.Add(time.Minute))
+	require.Len(t, tracker.pending(), 1)
+	tracker.check(zerolog.Nop(), "missing", nil, now.Add(VoteTrackingTimeout))
+	require.Empty(t, tracker.pending())
+	require.Equal(t, dropped+1, testutil.ToFloat64(metricsPkg.VotesDropped.WithLabelValues(metricsPkg.VoteOutbound)))
+	require.Equal(t, unfinalized+1, testutil.ToFloat64(metricsPkg.VotedBallots.WithLabelValues(metricsPkg.VoteOutbound, metricsPkg.BallotUnfinalized)))
+}
@@ -0,0 +1,260 @@
+package zetaclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/rs/zerolog"
+	"github.com/zeta-chain/zetacore/common"
+	"github.com/zeta-chain/zetacore/x/crosschain/types"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
+)
+
+const (
+	// WebhookSignatureHeader carries the hex encoded HMAC-SHA256 of the payload keyed by the secret of the webhook
+	WebhookSignatureHeader = "X-Zetaclient-Signature"
+	// WebhookEventHeader carries the type of the event
+	WebhookEventHeader = "X-Zetaclient-Event"
+	// WebhookQueueSize is the number of events buffered before new events are dropped
+	WebhookQueueSize   = 1000
+	webhookHTTPTimeout = 10 * time.Second
+)
+
+// event types published to webhooks
+const (
+	WebhookEventZetaSent     = "ZetaSent"
+	WebhookEventDeposited    = "Deposited"
+	WebhookEventGasDeposit   = "GasDeposit"
+	WebhookEventZetaReceived = "ZetaReceived"
+	WebhookEventZetaReverted = "ZetaReverted"
+	WebhookEventWithdrawn    = "Withdrawn"
+
+	// withdrawal of a ZRC20 from zEVM to its foreign chain
+	WebhookEventZRC20Withdrawal = "ZRC20Withdrawal"
+
+	// outbound tx reverted on the destination chain; its cctx is reverted and the sender refunded on the origin chain
+	WebhookEventOutboundFailed = "OutboundFailed"
+
+	// inbound event not voted on because zetacore couldn't route it or its asset isn't whitelisted; its sender is to be
+	// refunded
+	WebhookEventInboundRejected = "InboundRejected"
+
+	// inbound transfer above the amount cap of its asset
+	WebhookEventInboundAboveCap = "InboundAboveCap"
+
+	// sender bursting above its rate limit of inbound events
+	WebhookEventSenderThrottled = "SenderThrottled"
+
+	// inbound transfer not voted on because its sender or receiver is sanctioned
+	WebhookEventAddressSanctioned = "AddressSanctioned"
+
+	// admin events of the watched contracts
+	WebhookEventProxyUpgraded        = "ProxyUpgraded"
+	WebhookEventProxyAdminChanged    = "ProxyAdminChanged"
+	WebhookEventOwnershipTransferred = "OwnershipTransferred"
+	WebhookEventPaused               = "Paused"
+	WebhookEventUnpaused             = "Unpaused"
+	WebhookEventRoleGranted          = "RoleGranted"
+	WebhookEventRoleRevoked          = "RoleRevoked"
+
+	// unexpected drop of the balance of the TSS address or of the custody contract, and TSS balance below the minimum
+	WebhookEventBalanceDrop      = "BalanceDrop"
+	WebhookEventSignerBalanceLow = "SignerBalanceLow"
+)
+
+// WebhookBackoff is used around the delivery of an event to a webhook
+var WebhookBackoff = Backoff{
+	InitialInterval: time.Second,
+	MaxInterval:     30 * time.Second,
+	Multiplier:      2,
+	Jitter:          0.2,
+	MaxRetries:      5,
+}
+
+// WebhookEvent is the JSON payload posted to webhooks for every inbound or outbound event observed on an external chain
+type WebhookEvent struct {
+	Type            string    `json:"type"`
+	ChainID         int64     `json:"chain_id"`
+	Chain           string    `json:"chain"`
+	TxHash          string    `json:"tx_hash"`
+	BlockNumber     uint64    `json:"block_number"`
+	Sender          string    `json:"sender,omitempty"`
+	Receiver        string    `json:"receiver,omitempty"`
+	ReceiverChainID int64     `json:"receiver_chain_id,omitempty"`
+	CoinType        string    `json:"coin_type"`
+	Asset           string    `json:"asset,omitempty"`
+	Symbol          string    `json:"symbol,omitempty"`
+	Amount          string    `json:"amount"`
+	Message         string    `json:"message,omitempty"`
+	CctxIndex       string    `json:"cctx_index,omitempty"`
+	ZetaTxHash      string    `json:"zeta_tx_hash,omitempty"`
+	ObservedAt      time.Time `json:"observed_at"`
+
+	// NormalizedAmount is the amount in CanonicalDecimals, if the decimals of the asset are known
+	NormalizedAmount string `json:"normalized_amount,omitempty"`
+
+	// Proof is the proof of the inbound tx against its block header verified by zetacore, if the chain proves its
+	// inbounds, so that the peers can check the event themselves
+	Proof *InboundProof `json:"proof,omitempty"`
+}
+
+// SignWebhookPayload returns the hex encoded HMAC-SHA256 of the payload keyed by secret
+func SignWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookPublisher posts observed events to the webhooks set in config in the background.
+// Delivery is retried with backoff; events are dropped if the queue is full so that observation never blocks
+type WebhookPublisher struct {
+	webhooks []config.WebhookConfig
+	client   *http.Client
+	queue    chan WebhookEvent
+	logger   zerolog.Logger
+}
+
+func NewWebhookPublisher(webhooks []config.WebhookConfig, logger zerolog.Logger) *WebhookPublisher {
+	return &WebhookPublisher{
+		webhooks: webhooks,
+		client:   &http.Client{Timeout: webhookHTTPTimeout},
+		queue:    make(chan WebhookEvent, WebhookQueueSize),
+		logger:   logger,
+	}
+}
+
+// Publish queues an event for delivery; it is a no-op if no webhook is set
+func (p *WebhookPublisher) Publish(event WebhookEvent) {
+	if p == nil || len(p.webhooks) == 0 {
+		return
+	}
+	if event.ObservedAt.IsZero() {
+		event.ObservedAt = time.Now().UTC()
+	}
+	select {
+	case p.queue <- event:
+	default:
+		p.logger.Warn().Msgf("Publish: webhook queue full; dropping %s event of tx %s", event.Type, event.TxHash)
+	}
+}
+
+// Run delivers the queued events until stop is closed
+func (p *WebhookPublisher) Run(stop <-chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-stop
+		cancel()
+	}()
+	for {
+		select {
+		case event := <-p.queue:
+			payload, err := json.Marshal(event)
+			if err != nil {
+				p.logger.Error().Err(err).Msgf("Run: cannot marshal %s event of tx %s", event.Type, event.TxHash)
+				continue
+			}
+			for _, webhook := range p.webhooks {
+				err := Retry(ctx, "Webhook", WebhookBackoff, func() error {
+					return p.deliver(ctx, webhook, event.Type, payload)
+				})
+				if err != nil {
+					p.logger.Error().Err(err).Msgf("Run: cannot deliver %s event of tx %s to %s", event.Type, event.TxHash, webhook.URL)
+				}
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (p *WebhookPublisher) deliver(ctx context.Context, webhook config.WebhookConfig, eventType string, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, eventType)
+	if webhook.Secret != "" {
+		req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(webhook.Secret, payload))
+	}
+	res, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", res.StatusCode)
+	}
+	return nil
+}
+
+// publishInboundEvent publishes the inbound event voted on by msg
+func (ob *EVMChainClient) publishInboundEvent(msg *types.MsgVoteOnObservedInboundTx, zetaHash string) {
+	if ob.webhooks == nil {
+		return
+	}
+	eventType := WebhookEventGasDeposit
+	switch msg.CoinType {
+	case common.CoinType_Zeta:
+		eventType = WebhookEventZetaSent
+	case common.CoinType_ERC20:
+		eventType = WebhookEventDeposited
+	}
+	if ob.chain.IsZetaChain() && msg.CoinType != common.CoinType_Zeta {
+		eventType = WebhookEventZRC20Withdrawal
+	}
+	ob.webhooks.Publish(WebhookEvent{
+		Type:            eventType,
+		ChainID:         ob.chain.ChainId,
+		Chain:           ob.chain.Name(),
+		TxHash:          msg.InTxHash,
+		BlockNumber:     msg.InBlockHeight,
+		Sender:          msg.Sender,
+		Receiver:        msg.Receiver,
+		ReceiverChainID: msg.ReceiverChain,
+		CoinType:        msg.CoinType.String(),
+		Asset:           msg.Asset,
+		Symbol:          ob.assetSymbol(msg),
+		Amount:          msg.Amount.String(),
+		Message:         msg.Message,
+		CctxIndex:       InboundCorrelationID(msg),
+		ZetaTxHash:      zetaHash,
+
+		NormalizedAmount: ob.normalizedAmount(msg),
+		Proof:            ob.inboundProof(msg),
+	})
+}
+
+// assetSymbol returns the symbol of the ERC20 deposited by msg, empty for other assets or if unknown
+func (ob *EVMChainClient) assetSymbol(msg *types.MsgVoteOnObservedInboundTx) string {
+	if msg.CoinType != common.CoinType_ERC20 || ob.chain.IsZetaChain() || !ethcommon.IsHexAddress(msg.Asset) {
+		return ""
+	}
+	return ob.whitelist.Symbol(ethcommon.HexToAddress(msg.Asset))
+}
+
+// publishOutboundEvent publishes the event of a confirmed outbound tx of the cctx with index cctxIndex
+func (ob *EVMChainClient) publishOutboundEvent(eventType string, cctxIndex string, vLog *ethtypes.Log, coinType common.CoinType, amount *big.Int, zetaHash string) {
+	ob.webhooks.Publish(WebhookEvent{
+		Type:        eventType,
+		ChainID:     ob.chain.ChainId,
+		Chain:       ob.chain.Name(),
+		TxHash:      vLog.TxHash.Hex(),
+		BlockNumber: vLog.BlockNumber,
+		CoinType:    coinType.String(),
+		Amount:      amount.String(),
+		CctxIndex:   cctxIndex,
+		ZetaTxHash:  zetaHash,
+	})
+}
@@ -0,0 +1,60 @@
+package zetaclient
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
+)
+
+func TestWebhookPublisher(t *testing.T) {
+	backoff := WebhookBackoff
+	WebhookBackoff.InitialInterval = 10 * time.Millisecond
+	defer func() { WebhookBackoff = backoff }()
+
+	secret := "secret"
+	var attempts int32
+	received := make(chan WebhookEvent, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the first delivery fails and is retried
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, SignWebhookPayload(secret, body), r.Header.Get(WebhookSignatureHeader))
+		require.Equal(t, WebhookEventDeposited, r.Header.Get(WebhookEventHeader))
+		var event WebhookEvent
+		require.NoError(t, json.Unmarshal(body, &event))
+		received <- event
+	}))
+	defer server.Close()
+
+	publisher := NewWebhookPublisher([]config.WebhookConfig{{URL: server.URL, Secret: secret}}, zerolog.Nop())
+	stop := make(chan struct{})
+	defer close(stop)
+	go publisher.Run(stop)
+
+	publisher.Publish(WebhookEvent{Type: WebhookEventDeposited, ChainID: 1, TxHash: "0x01", Amount: "100"})
+	select {
+	case event := <-received:
+		require.Equal(t, "0x01", event.TxHash)
+		require.Equal(t, "100", event.Amount)
+		require.False(t, event.ObservedAt.IsZero())
+	case <-time.After(5 * time.Second):
+		t.Fatal("event not delivered")
+	}
+	require.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+
+	// publishing without webhooks is a no-op
+	var nilPublisher *WebhookPublisher
+	nilPublisher.Publish(WebhookEvent{})
+}
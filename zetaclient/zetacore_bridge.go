@@ -1,12 +1,14 @@
 package zetaclient
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/simapp/params"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/hashicorp/go-retryablehttp"
@@ -26,19 +28,66 @@ var _ ZetaCoreBridger = &ZetaCoreBridge{}
 // ZetaCoreBridge will be used to send tx to ZetaCore.
 type ZetaCoreBridge struct {
 	logger              zerolog.Logger
-	blockHeight         int64
-	accountNumber       map[common.KeyType]uint64
-	seqNumber           map[common.KeyType]uint64
-	grpcConn            *grpc.ClientConn
+	sequences           *SequenceManager
+	endpoints           []*coreEndpoint // the zetacore nodes in order of preference
+	active              int             // index of the node in use
+	endpointLock        sync.RWMutex
+	callOptions         []grpc.CallOption // added to the grpc calls to zetacore, see SetGRPCLimits
+	callOptionsLock     sync.RWMutex
 	httpClient          *retryablehttp.Client
 	cfg                 config.ClientConfiguration
 	encodingCfg         params.EncodingConfig
 	keys                *Keys
-	broadcastLock       *sync.RWMutex
 	zetaChainID         string
 	lastOutTxReportTime map[string]time.Time
 	stop                chan struct{}
 	pause               chan struct{}
+	ctx                 context.Context // cancelled on Stop() to abort pending retries
+	cancel              context.CancelFunc
+
+	// voteBatcher gathers the inbound votes into multi-vote txs, if enabled
+	voteBatcher *VoteBatcher
+
+	// confirmationBatcher gathers the outbound confirmations into multi-vote txs, if enabled
+	confirmationBatcher *VoteBatcher
+
+	// sendBackoff and confirmationBackoff are the retry schedules of the inbound votes and the outbound confirmations
+	sendBackoff         Backoff
+	confirmationBackoff Backoff
+
+	// broadcastMode and inclusionTimeout set how long a broadcast waits for its tx, see config.BroadcastMode
+	broadcastMode    string
+	inclusionTimeout time.Duration
+	fees             config.TxFees
+
+	// feeGranter pays the fees of the txs through a fee allowance to the hotkey, if set
+	feeGranter sdktypes.AccAddress
+
+	// simulate has the txs simulated before they are broadcast, if enabled
+	simulate bool
+
+	// postLimiter limits the inbound votes posted per chain to postRate per minute after postBurst, if enabled
+	postLimiter *SenderRateLimiter
+	postRate    uint64
+	postBurst   uint64
+
+	// outbox holds the txs broadcast until zetacore includes them, if enabled
+	outbox *Outbox
+
+	// inFlight is the number of txs being broadcast; the bridge is congested above maxInFlight of them or above
+	// maxOutboxBacklog txs of the outbox, see Congested
+	inFlight         int64
+	maxInFlight      int64
+	maxOutboxBacklog int64
+
+	// voteTracker follows the votes broadcast until their ballot finalized, if enabled
+	voteTracker *VoteTracker
+
+	// coreEvents pushes the events of zetacore to the client, if enabled
+	coreEvents *CoreEventListener
+
+	// breaker pauses the posts to zetacore while it is unavailable
+	breaker *CircuitBreaker
 }
 
 // NewZetaCoreBridge create a new instance of ZetaCoreBridge
@@ -55,36 +104,37 @@ func NewZetaCoreBridge(k *Keys, chainIP string, signerName string, chainID strin
 	httpClient := retryablehttp.NewClient()
 	httpClient.Logger = nil
 
-	grpcConn, err := grpc.Dial(
-		fmt.Sprintf("%s:9090", chainIP),
-		grpc.WithInsecure(),
-	)
-	if err != nil {
-		logger.Error().Err(err).Msg("grpc dial fail")
-		return nil, err
-	}
-	accountsMap := make(map[common.KeyType]uint64)
-	seqMap := make(map[common.KeyType]uint64)
-	for _, keyType := range common.GetAllKeyTypes() {
-		accountsMap[keyType] = 0
-		seqMap[keyType] = 0
-	}
-
-	return &ZetaCoreBridge{
+	ctx, cancel := context.WithCancel(context.Background())
+	bridge := &ZetaCoreBridge{
 		logger:              logger,
-		grpcConn:            grpcConn,
 		httpClient:          httpClient,
-		accountNumber:       accountsMap,
-		seqNumber:           seqMap,
 		cfg:                 cfg,
 		encodingCfg:         app.MakeEncodingConfig(),
 		keys:                k,
-		broadcastLock:       &sync.RWMutex{},
 		lastOutTxReportTime: map[string]time.Time{},
 		stop:                make(chan struct{}),
 		zetaChainID:         chainID,
 		pause:               make(chan struct{}),
-	}, nil
+		ctx:                 ctx,
+		cancel:              cancel,
+	}
+	endpoint, err := bridge.newCoreEndpoint(chainIP)
+	if err != nil {
+		cancel()
+		logger.Error().Err(err).Msg("grpc dial fail")
+		return nil, err
+	}
+	bridge.endpoints = []*coreEndpoint{endpoint}
+	bridge.fees = config.DefaultTxFees()
+	bridge.sendBackoff = BroadcastBackoff
+	bridge.confirmationBackoff = ConfirmationBackoff
+	bridge.SetBackpressure(DefaultMaxInFlightPosts, DefaultMaxOutboxBacklog)
+	bridge.sequences = NewSequenceManager(bridge.GetAccountNumberAndSequenceNumber, logger)
+	bridge.breaker = NewCircuitBreaker(CoreBreakerThreshold, CoreBreakerProbeInterval, bridge.probeCore,
+		logger.With().Str("module", "CircuitBreaker").Logger())
+	go bridge.breaker.Run(ctx)
+	go bridge.watchEndpoint(endpoint)
+	return bridge, nil
 }
 
 // MakeLegacyCodec creates codec
@@ -110,6 +160,7 @@ func (b *ZetaCoreBridge) UpdateChainID(chainID string) {
 func (b *ZetaCoreBridge) Stop() {
 	b.logger.Info().Msgf("ZetaBridge is stopping")
 	close(b.stop) // this notifies all configupdater to stop
+	b.cancel()
 }
 
 // GetAccountNumberAndSequenceNumber We do not use multiple KeyType for now , but this can be optionally used in the future to seprate TSS signer from Zetaclient GRantee
@@ -122,20 +173,12 @@ func (b *ZetaCoreBridge) GetAccountNumberAndSequenceNumber(_ common.KeyType) (ui
 	return ctx.AccountRetriever.GetAccountNumberSequence(ctx, address)
 }
 
+// SetAccountNumber prefetches the account number and sequence the txs of the key type are signed with; they are
+// fetched again before the next tx if this fails
 func (b *ZetaCoreBridge) SetAccountNumber(keyType common.KeyType) {
-	ctx, err := b.GetContext()
-	if err != nil {
-		b.logger.Error().Err(err).Msg("fail to get context")
-		return
-	}
-	address := b.keys.GetAddress()
-	accN, seq, err := ctx.AccountRetriever.GetAccountNumberSequence(ctx, address)
-	if err != nil {
+	if err := b.sequences.Prefetch(keyType); err != nil {
 		b.logger.Error().Err(err).Msg("fail to get account number and sequence number")
-		return
 	}
-	b.accountNumber[keyType] = accN
-	b.seqNumber[keyType] = seq
 }
 
 func (b *ZetaCoreBridge) WaitForCoreToCreateBlocks() {
@@ -186,7 +229,7 @@ func (b *ZetaCoreBridge) UpdateConfigFromCore(cfg *config.Config, init bool) err
 	for _, coreParam := range coreParams {
 		err := config.ValidateCoreParams(coreParam)
 		if err != nil {
-			b.logger.Debug().Err(err).Msgf("Invalid core params for chain %s", common.GetChainFromChainID(coreParam.ChainId).ChainName)
+			b.logger.Debug().Err(err).Msgf("Invalid core params for chain %d", coreParam.ChainId)
 		}
 		if common.IsBitcoinChain(coreParam.ChainId) {
 			newBTCParams = coreParam
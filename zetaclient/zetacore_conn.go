@@ -0,0 +1,191 @@
+package zetaclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/zeta-chain/zetacore/zetaclient/config"
+	"github.com/zeta-chain/zetacore/zetaclient/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/keepalive"
+)
+
+var (
+	// ZetaCoreKeepalive pings zetacore on the connections with pending calls so that a dead connection is detected
+	// rather than waited on. The grpc server of zetacore closes the connections pinged more than once every 5 minutes
+	// or without pending call, hence the interval
+	ZetaCoreKeepalive = keepalive.ClientParameters{
+		Time:                6 * time.Minute,
+		Timeout:             20 * time.Second,
+		PermitWithoutStream: false,
+	}
+
+	// ZetaCoreConnectBackoff schedules the reconnections to zetacore once the connection dropped
+	ZetaCoreConnectBackoff = backoff.Config{
+		BaseDelay:  time.Second,
+		Multiplier: 1.6,
+		Jitter:     0.2,
+		MaxDelay:   30 * time.Second,
+	}
+)
+
+// dialZetaCore dials the grpc endpoint of zetacore; the connection is re-established with backoff if it drops, e.g.
+// while zetacore restarts
+func dialZetaCore(target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	return grpc.Dial(
+		target,
+		append([]grpc.DialOption{
+			grpc.WithInsecure(),
+			grpc.WithKeepaliveParams(ZetaCoreKeepalive),
+			grpc.WithUnaryInterceptor(observeCoreQuery),
+			grpc.WithConnectParams(grpc.ConnectParams{
+				Backoff:           ZetaCoreConnectBackoff,
+				MinConnectTimeout: 10 * time.Second,
+			}),
+		}, opts...)...,
+	)
+}
+
+// coreEndpoint is a zetacore node, queried over grpc and broadcast to over the tendermint rpc
+type coreEndpoint struct {
+	host     string
+	grpcConn *grpc.ClientConn
+}
+
+// newCoreEndpoint dials the zetacore node at host, the grpc calls carrying the call options of the bridge
+func (b *ZetaCoreBridge) newCoreEndpoint(host string) (*coreEndpoint, error) {
+	grpcConn, err := dialZetaCore(fmt.Sprintf("%s:9090", host), grpc.WithChainUnaryInterceptor(b.withCallOptions))
+	if err != nil {
+		return nil, err
+	}
+	return &coreEndpoint{host: host, grpcConn: grpcConn}, nil
+}
+
+// chainRPC returns the address of the tendermint rpc of the node
+func (e *coreEndpoint) chainRPC() string {
+	return fmt.Sprintf("%s:26657", e.host)
+}
+
+// AddBackupEndpoints adds zetacore nodes to fail over to, in order of preference, once the connection to the
+// preferred node is lost; the node the bridge was created with, usually the local one, is preferred to all
+func (b *ZetaCoreBridge) AddBackupEndpoints(hosts []string) error {
+	for _, host := range hosts {
+		endpoint, err := b.newCoreEndpoint(host)
+		if err != nil {
+			return err
+		}
+		b.endpointLock.Lock()
+		b.endpoints = append(b.endpoints, endpoint)
+		b.endpointLock.Unlock()
+		go b.watchEndpoint(endpoint)
+	}
+	return nil
+}
+
+// SetGRPCLimits sets the max sizes in bytes of the grpc messages received from and sent to zetacore, the grpc defaults
+// if zero, and the compression of the grpc messages, config.GRPCCompressionGzip or none
+func (b *ZetaCoreBridge) SetGRPCLimits(maxRecvMsgSize int, maxSendMsgSize int, compression string) {
+	var callOptions []grpc.CallOption
+	if maxRecvMsgSize > 0 {
+		callOptions = append(callOptions, grpc.MaxCallRecvMsgSize(maxRecvMsgSize))
+	}
+	if maxSendMsgSize > 0 {
+		callOptions = append(callOptions, grpc.MaxCallSendMsgSize(maxSendMsgSize))
+	}
+	if compression == config.GRPCCompressionGzip {
+		callOptions = append(callOptions, grpc.UseCompressor(gzip.Name))
+	}
+	b.callOptionsLock.Lock()
+	defer b.callOptionsLock.Unlock()
+	b.callOptions = callOptions
+}
+
+// withCallOptions is the grpc interceptor adding the call options of the bridge to the calls to zetacore; the options
+// of the call take precedence
+func (b *ZetaCoreBridge) withCallOptions(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	b.callOptionsLock.RLock()
+	callOptions := append(append([]grpc.CallOption(nil), b.callOptions...), opts...)
+	b.callOptionsLock.RUnlock()
+	return invoker(ctx, method, req, reply, cc, callOptions...)
+}
+
+// activeEndpoint returns the zetacore node the queries and broadcasts go to
+func (b *ZetaCoreBridge) activeEndpoint() *coreEndpoint {
+	b.endpointLock.RLock()
+	defer b.endpointLock.RUnlock()
+	return b.endpoints[b.active]
+}
+
+// conn returns the grpc connection to the active zetacore node
+func (b *ZetaCoreBridge) conn() *grpc.ClientConn {
+	return b.activeEndpoint().grpcConn
+}
+
+// chainRPC returns the address of the tendermint rpc of the active zetacore node
+func (b *ZetaCoreBridge) chainRPC() string {
+	return b.activeEndpoint().chainRPC()
+}
+
+// ConnectionState returns the state of the grpc connection to the active zetacore node
+func (b *ZetaCoreBridge) ConnectionState() connectivity.State {
+	return b.conn().GetState()
+}
+
+// selectEndpoint makes the most preferred zetacore node whose connection is ready the active one; the active node
+// is kept if none is ready
+func (b *ZetaCoreBridge) selectEndpoint() {
+	b.endpointLock.Lock()
+	defer b.endpointLock.Unlock()
+	for i, endpoint := range b.endpoints {
+		if endpoint.grpcConn.GetState() != connectivity.Ready {
+			continue
+		}
+		if i != b.active {
+			b.logger.Warn().Msgf("selectEndpoint: failing over from zetacore node %s to %s", b.endpoints[b.active].host, endpoint.host)
+			b.active = i
+		}
+		break
+	}
+	if b.endpoints[b.active].grpcConn.GetState() == connectivity.Ready {
+		metrics.ZetaCoreConnected.Set(1)
+	} else {
+		metrics.ZetaCoreConnected.Set(0)
+	}
+}
+
+// watchEndpoint reports the state changes of the grpc connection to a zetacore node until the bridge is stopped and
+// selects the node to use on each change. An idle connection is reconnected right away rather than by the next call,
+// so that a vote doesn't wait for the handshake and a backup is ready to fail over to
+func (b *ZetaCoreBridge) watchEndpoint(endpoint *coreEndpoint) {
+	state := endpoint.grpcConn.GetState()
+	for {
+		b.selectEndpoint()
+		if state == connectivity.Idle {
+			endpoint.grpcConn.Connect()
+		}
+		if !endpoint.grpcConn.WaitForStateChange(b.ctx, state) {
+			return // bridge stopped
+		}
+		previous := state
+		state = endpoint.grpcConn.GetState()
+		switch {
+		case state == connectivity.Ready:
+			b.logger.Info().Msgf("watchEndpoint: connected to zetacore node %s", endpoint.host)
+		case previous == connectivity.Ready:
+			b.logger.Warn().Msgf("watchEndpoint: connection to zetacore node %s lost, state %s; reconnecting", endpoint.host, state)
+		case state == connectivity.TransientFailure:
+			b.logger.Warn().Msgf("watchEndpoint: reconnecting to zetacore node %s failed; retrying with backoff", endpoint.host)
+		}
+	}
+}
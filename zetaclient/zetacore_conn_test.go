@@ -0,0 +1,108 @@
+package zetaclient
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	observertypes "github.com/zeta-chain/zetacore/x/observer/types"
+	"github.com/zeta-chain/zetacore/zetaclient/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
+)
+
+// startCoreNode serves grpc on a local port and returns the server and an endpoint connected to it
+func startCoreNode(t *testing.T) (*grpc.Server, *coreEndpoint) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	conn, err := dialZetaCore(listener.Addr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return server, &coreEndpoint{host: "127.0.0.1", grpcConn: conn}
+}
+
+func TestZetaCoreBridge_WatchEndpoint(t *testing.T) {
+	server, endpoint := startCoreNode(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bridge := &ZetaCoreBridge{ctx: ctx, logger: zerolog.Nop(), endpoints: []*coreEndpoint{endpoint}}
+	go bridge.watchEndpoint(endpoint)
+
+	// the idle connection is established without waiting for a call
+	require.Eventually(t, func() bool { return bridge.ConnectionState() == connectivity.Ready }, 5*time.Second, 10*time.Millisecond)
+
+	// and reported as lost once zetacore stops
+	server.Stop()
+	require.Eventually(t, func() bool { return bridge.ConnectionState() != connectivity.Ready }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestZetaCoreBridge_Failover(t *testing.T) {
+	primaryServer, primary := startCoreNode(t)
+	_, backup := startCoreNode(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bridge := &ZetaCoreBridge{ctx: ctx, logger: zerolog.Nop(), endpoints: []*coreEndpoint{primary, backup}}
+	go bridge.watchEndpoint(primary)
+	go bridge.watchEndpoint(backup)
+
+	// the primary node is preferred while ready
+	require.Eventually(t, func() bool {
+		return primary.grpcConn.GetState() == connectivity.Ready && backup.grpcConn.GetState() == connectivity.Ready
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, primary.grpcConn, bridge.conn())
+
+	// the bridge fails over to the backup once the primary is lost
+	primaryServer.Stop()
+	require.Eventually(t, func() bool { return bridge.conn() == backup.grpcConn }, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, connectivity.Ready, bridge.ConnectionState())
+}
+
+func TestZetaCoreBridge_SetGRPCLimits(t *testing.T) {
+	voters := make([]*observertypes.VoterList, 100)
+	for i := range voters {
+		voters[i] = &observertypes.VoterList{VoterAddress: "zeta1observer", VoteType: observertypes.VoteType_SuccessObservation}
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	observertypes.RegisterQueryServer(server, &ballotQueryServer{ballots: map[string]*observertypes.QueryBallotByIdentifierResponse{
+		"0xlarge": {BallotIdentifier: "0xlarge", Voters: voters},
+	}})
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+	bridge := &ZetaCoreBridge{ctx: context.Background(), logger: zerolog.Nop()}
+	conn, err := dialZetaCore(listener.Addr().String(), grpc.WithChainUnaryInterceptor(bridge.withCallOptions))
+	require.NoError(t, err)
+	defer conn.Close()
+	bridge.endpoints = []*coreEndpoint{{host: "127.0.0.1", grpcConn: conn}}
+
+	// the results larger than the max size received are refused
+	bridge.SetGRPCLimits(1024, 0, config.GRPCCompressionNone)
+	_, err = bridge.GetBallot("0xlarge")
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	bridge.SetGRPCLimits(0, 0, config.GRPCCompressionNone)
+	ballot, err := bridge.GetBallot("0xlarge")
+	require.NoError(t, err)
+	require.Len(t, ballot.Voters, 100)
+
+	// and so are the requests larger than the max size sent, unless compressed below it
+	identifier := "0x" + strings.Repeat("0", 1000)
+	bridge.SetGRPCLimits(0, 256, config.GRPCCompressionNone)
+	_, err = bridge.GetBallot(identifier)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	bridge.SetGRPCLimits(0, 256, config.GRPCCompressionGzip)
+	_, err = bridge.GetBallot(identifier)
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -24,23 +24,24 @@ type ZetaCoreLog struct {
 	ZetaChainWatcher zerolog.Logger
 }
 
-// CoreObserver wraps the zetacore bridge and adds the client and signer maps to it . This is the high level object used for CCTX interactions
+// CoreObserver wraps the zetacore bridge and adds the chain clients and signers to it . This is the high level object used for CCTX interactions
 type CoreObserver struct {
-	bridge    ZetaCoreBridger
-	signerMap map[common.Chain]ChainSigner
-	clientMap map[common.Chain]ChainClient
-	metrics   *metrics.Metrics
-	logger    ZetaCoreLog
-	cfg       *config.Config
-	ts        *TelemetryServer
-	stop      chan struct{}
+	bridge     ZetaCoreBridger
+	supervisor *ChainClientSupervisor
+	metrics    *metrics.Metrics
+	logger     ZetaCoreLog
+	cfg        *config.Config
+	ts         *TelemetryServer
+	stop       chan struct{}
+
+	// coreEvents triggers the send scheduler on the new blocks and outbounds of zetacore, if subscribed
+	coreEvents <-chan CoreEvent
 }
 
 // NewCoreObserver creates a new CoreObserver
 func NewCoreObserver(
 	bridge ZetaCoreBridger,
-	signerMap map[common.Chain]ChainSigner,
-	clientMap map[common.Chain]ChainClient,
+	supervisor *ChainClientSupervisor,
 	metrics *metrics.Metrics,
 	logger zerolog.Logger,
 	cfg *config.Config,
@@ -60,9 +61,7 @@ func NewCoreObserver(
 	}
 
 	co.bridge = bridge
-	co.signerMap = signerMap
-
-	co.clientMap = clientMap
+	co.supervisor = supervisor
 	co.metrics = metrics
 	co.logger.ChainLogger.Info().Msg("starting core observer")
 	err := metrics.RegisterCounter(OutboundTxSignCount, "number of Outbound tx signed")
@@ -85,6 +84,12 @@ func (co *CoreObserver) GetPromCounter(name string) (prom.Counter, error) {
 	return cnt, nil
 }
 
+// SubscribeCoreEvents schedules the outbounds as soon as zetacore produces a block or schedules an outbound,
+// rather than on the next poll of the block height; it must be called before MonitorCore
+func (co *CoreObserver) SubscribeCoreEvents(listener *CoreEventListener) {
+	co.coreEvents = listener.Subscribe(CoreEventNewBlock, CoreEventOutboundScheduled)
+}
+
 func (co *CoreObserver) MonitorCore() {
 	myid := co.bridge.GetKeys().GetAddress()
 	co.logger.ZetaChainWatcher.Info().Msgf("Starting Send Scheduler for %s", myid)
@@ -95,10 +100,29 @@ func (co *CoreObserver) MonitorCore() {
 		co.bridge.Pause()
 		// now stop everything
 		close(co.stop) // this stops the startSendScheduler() loop
-		for _, c := range co.clientMap {
-			c.Stop()
+		co.supervisor.Stop()
+	}()
+}
+
+// sendSchedulerTrigger merges the ticks and the core events into one trigger of the send scheduler; the triggers
+// coming while the scheduler is busy are coalesced
+func (co *CoreObserver) sendSchedulerTrigger(ticks <-chan time.Time) <-chan struct{} {
+	trigger := make(chan struct{}, 1)
+	go func() {
+		for {
+			select {
+			case <-co.stop:
+				return
+			case <-ticks:
+			case <-co.coreEvents:
+			}
+			select {
+			case trigger <- struct{}{}:
+			default:
+			}
 		}
 	}()
+	return trigger
 }
 
 // ZetaCore block is heart beat; each block we schedule some send according to
@@ -106,13 +130,14 @@ func (co *CoreObserver) MonitorCore() {
 func (co *CoreObserver) startSendScheduler() {
 	outTxMan := NewOutTxProcessorManager(co.logger.ChainLogger)
 	observeTicker := time.NewTicker(3 * time.Second)
+	trigger := co.sendSchedulerTrigger(observeTicker.C)
 	var lastBlockNum int64
 	for {
 		select {
 		case <-co.stop:
 			co.logger.ZetaChainWatcher.Warn().Msg("stop sendScheduler")
 			return
-		case <-observeTicker.C:
+		case <-trigger:
 			{
 				bn, err := co.bridge.GetZetaBlockHeight()
 				if err != nil {
@@ -138,11 +163,15 @@ func (co *CoreObserver) startSendScheduler() {
 						if c.ChainId == common.ZetaChain().ChainId {
 							continue
 						}
-						signer := co.signerMap[c]
+						signer, found := co.supervisor.GetSigner(c)
+						if !found {
+							co.logger.ZetaChainWatcher.Error().Msgf("signer not found for chain %s", c.Name())
+							continue
+						}
 
 						cctxList, err := co.bridge.GetAllPendingCctx(c.ChainId)
 						if err != nil {
-							co.logger.ZetaChainWatcher.Error().Err(err).Msgf("failed to GetAllPendingCctx for chain %s", c.ChainName.String())
+							co.logger.ZetaChainWatcher.Error().Err(err).Msgf("failed to GetAllPendingCctx for chain %s", c.Name())
 							continue
 						}
 						ob, err := co.getUpdatedChainOb(c.ChainId)
@@ -150,6 +179,10 @@ func (co *CoreObserver) startSendScheduler() {
 							co.logger.ZetaChainWatcher.Error().Err(err).Msgf("getTargetChainOb fail, Chain ID: %s", c.ChainName)
 							continue
 						}
+						// chains in shadow mode are observed, never signed for
+						if evmOb, ok := ob.(*EVMChainClient); ok && evmOb.IsShadow() {
+							continue
+						}
 						chain, err := common.GetChainNameFromChainID(c.ChainId)
 						if err != nil {
 							co.logger.ZetaChainWatcher.Error().Err(err).Msgf("GetTargetChain fail, Chain ID: %s", c.ChainName)
@@ -157,7 +190,7 @@ func (co *CoreObserver) startSendScheduler() {
 						}
 						res, err := co.bridge.GetAllOutTxTrackerByChain(c, Ascending)
 						if err != nil {
-							co.logger.ZetaChainWatcher.Warn().Err(err).Msgf("failed to GetAllOutTxTrackerByChain for chain %s", c.ChainName.String())
+							co.logger.ZetaChainWatcher.Warn().Err(err).Msgf("failed to GetAllOutTxTrackerByChain for chain %s", c.Name())
 							continue
 						}
 						trackerMap := make(map[uint64]bool)
@@ -237,6 +270,10 @@ func (co *CoreObserver) startSendScheduler() {
 							if _, ok := trackerMap[nonce]; ok {
 								interval = nonCriticalInterval
 							}
+							// the outTx broadcast by this signer was dropped; sign it again at once
+							if evmOb, ok := ob.(*EVMChainClient); ok && evmOb.IsOutTxDropped(nonce) {
+								interval = 1
+							}
 
 							// otherwise, the normal interval is used
 							if nonce%interval == currentHeight%interval && !outTxMan.IsOutTxActive(outTxID) {
@@ -324,7 +361,7 @@ func (co *CoreObserver) getTargetChainOb(chainID int64) (ChainClient, error) {
 	if c == nil {
 		return nil, fmt.Errorf("chain not found for chainID %d", chainID)
 	}
-	chainOb, found := co.clientMap[*c]
+	chainOb, found := co.supervisor.GetClient(*c)
 	if !found {
 		return nil, fmt.Errorf("chain client not found for chainID %d", chainID)
 	}